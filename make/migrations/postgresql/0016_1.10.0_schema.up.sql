@@ -0,0 +1,223 @@
+/** Add table for the cache entries of proxy cache project **/
+CREATE TABLE proxy_cache_entry
+(
+  id            SERIAL PRIMARY KEY NOT NULL,
+  project_id    int NOT NULL,
+  repository    varchar(255) NOT NULL,
+  reference     varchar(255) NOT NULL,
+  digest        varchar(255) NOT NULL,
+  hits          bigint DEFAULT 0 NOT NULL,
+  misses        bigint DEFAULT 0 NOT NULL,
+  expire_time   timestamp,
+  creation_time timestamp default CURRENT_TIMESTAMP,
+  update_time   timestamp default CURRENT_TIMESTAMP,
+  UNIQUE (project_id, repository, reference)
+);
+
+/** Add secret to sign the payload of webhook **/
+ALTER TABLE notification_policy ADD COLUMN secret text;
+
+/** Add table for the delivery attempts of notification job **/
+CREATE TABLE notification_delivery
+(
+  id            SERIAL PRIMARY KEY NOT NULL,
+  job_id        int NOT NULL,
+  address       text,
+  status_code   int,
+  response_body text,
+  latency       bigint,
+  error         text,
+  creation_time timestamp default CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_notification_delivery_job_id ON notification_delivery (job_id);
+
+/** Add the project of the repository to the retention task for the system level retention policy **/
+ALTER TABLE retention_task ADD COLUMN project varchar(255);
+
+/** Add table to record how each candidate is handled in the retention execution **/
+CREATE TABLE retention_report_item
+(
+  id            SERIAL PRIMARY KEY NOT NULL,
+  execution_id  int NOT NULL,
+  task_id       int NOT NULL,
+  repository    varchar(255),
+  tag           varchar(255),
+  digest        varchar(255),
+  action        varchar(32),
+  rules         text,
+  reason        text,
+  creation_time timestamp default CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_retention_report_item_execution_id ON retention_report_item (execution_id);
+
+/** Add the repository count and chart storage resources to the quotas of projects, the usage of chart storage will be corrected by the sync quota **/
+UPDATE quota SET hard = '{"repository_count": -1, "chart_storage": -1}'::jsonb || hard WHERE reference = 'project';
+
+UPDATE quota_usage u SET used = jsonb_build_object(
+    'repository_count', (SELECT COUNT(DISTINCT a.repo) FROM artifact a WHERE CAST(a.project_id AS VARCHAR) = u.reference_id),
+    'chart_storage', 0
+  ) || used
+WHERE reference = 'project';
+
+/** Add the status of blob for the non-blocking GC, the version is used to update the status optimistically **/
+ALTER TABLE blob ADD COLUMN status varchar(255) DEFAULT 'none';
+ALTER TABLE blob ADD COLUMN version bigint DEFAULT 0;
+ALTER TABLE blob ADD COLUMN update_time timestamp default CURRENT_TIMESTAMP;
+CREATE INDEX idx_blob_status ON blob (status);
+
+/** Add table for the reports of GC, the projects and candidates are the JSON of the space reclaimed per project and the blobs would be deleted by dry run **/
+CREATE TABLE gc_report
+(
+  id                SERIAL PRIMARY KEY NOT NULL,
+  job_uuid          varchar(64) NOT NULL,
+  upstream_job_uuid varchar(64),
+  dry_run           boolean DEFAULT false,
+  non_blocking      boolean DEFAULT false,
+  blobs_deleted     bigint DEFAULT 0,
+  manifests_deleted bigint DEFAULT 0,
+  bytes_freed       bigint DEFAULT 0,
+  duration          bigint DEFAULT 0,
+  start_time        timestamp,
+  end_time          timestamp,
+  projects          text,
+  candidates        text,
+  creation_time     timestamp default CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_gc_report_job_uuid ON gc_report (job_uuid);
+CREATE INDEX idx_gc_report_upstream_job_uuid ON gc_report (upstream_job_uuid);
+
+/** Add tables for the PostgreSQL backend of jobservice, the job_queue keeps both the stats and the queue status of the jobs, the time columns are unix timestamps as the ones kept in redis **/
+CREATE TABLE job_queue
+(
+  id                SERIAL PRIMARY KEY NOT NULL,
+  job_id            varchar(128) NOT NULL,
+  job_name          varchar(128) NOT NULL,
+  kind              varchar(32) NOT NULL,
+  is_unique         boolean DEFAULT false,
+  unique_key        varchar(255),
+  status            varchar(32) NOT NULL,
+  ref_link          text,
+  parameters        text,
+  cron_spec         varchar(255),
+  web_hook_url      text,
+  upstream_job_id   varchar(128),
+  numeric_policy_id bigint DEFAULT 0,
+  check_in          text,
+  check_in_at       bigint DEFAULT 0,
+  die_at            bigint DEFAULT 0,
+  request_id        varchar(64),
+  execution_done    boolean DEFAULT false,
+  priority          int DEFAULT 1,
+  queued            boolean DEFAULT false,
+  fails             bigint DEFAULT 0,
+  failed_at         bigint DEFAULT 0,
+  last_err          text,
+  pool_id           varchar(255),
+  worker_id         varchar(255),
+  lease_expire_at   bigint DEFAULT 0,
+  run_at            bigint DEFAULT 0,
+  enqueue_time      bigint DEFAULT 0,
+  update_time       bigint DEFAULT 0,
+  start_time        bigint DEFAULT 0,
+  expire_at         bigint DEFAULT 0,
+  revision          bigint DEFAULT 0,
+  UNIQUE (job_id)
+);
+
+CREATE INDEX idx_job_queue_queued ON job_queue (queued, run_at);
+CREATE INDEX idx_job_queue_upstream_job_id ON job_queue (upstream_job_id);
+CREATE INDEX idx_job_queue_worker_id ON job_queue (worker_id);
+/** Only one unique job with the same name and parameters can be in the queue **/
+CREATE UNIQUE INDEX idx_job_queue_unique_key ON job_queue (unique_key) WHERE queued;
+
+CREATE TABLE job_latency
+(
+  id          SERIAL PRIMARY KEY NOT NULL,
+  job_id      varchar(128) NOT NULL,
+  job_name    varchar(128) NOT NULL,
+  revision    bigint DEFAULT 0,
+  status      varchar(32) NOT NULL,
+  latency     bigint DEFAULT 0,
+  finish_time bigint DEFAULT 0
+);
+
+CREATE INDEX idx_job_latency_finish_time ON job_latency (job_name, finish_time);
+
+CREATE TABLE job_hook_retry
+(
+  id          SERIAL PRIMARY KEY NOT NULL,
+  event       text NOT NULL,
+  create_time bigint DEFAULT 0
+);
+
+/*the system level robot account has no project and can access multiple projects*/
+ALTER TABLE robot ADD COLUMN IF NOT EXISTS level varchar(50) NOT NULL DEFAULT 'project';
+
+/*the secret of robot account can be regenerated, and the expiration is customized per robot*/
+ALTER TABLE robot ADD COLUMN IF NOT EXISTS duration bigint NOT NULL DEFAULT 0;
+ALTER TABLE robot ADD COLUMN IF NOT EXISTS secret varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE robot ADD COLUMN IF NOT EXISTS previous_secret varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE robot ADD COLUMN IF NOT EXISTS previous_secret_expiresat bigint NOT NULL DEFAULT 0;
+ALTER TABLE robot ADD COLUMN IF NOT EXISTS access text;
+ALTER TABLE robot ADD COLUMN IF NOT EXISTS last_used_at bigint NOT NULL DEFAULT 0;
+
+/*the personal access token of user, only the hash of the token is stored*/
+CREATE TABLE IF NOT EXISTS access_token (
+ id SERIAL PRIMARY KEY NOT NULL,
+ user_id int NOT NULL,
+ name varchar(255) NOT NULL,
+ hash varchar(64) NOT NULL,
+ read_only boolean DEFAULT false NOT NULL,
+ projects text,
+ expiresat bigint DEFAULT 0 NOT NULL,
+ last_used_at bigint DEFAULT 0 NOT NULL,
+ creation_time timestamp default CURRENT_TIMESTAMP,
+ CONSTRAINT unique_access_token_name UNIQUE (user_id, name),
+ CONSTRAINT unique_access_token_hash UNIQUE (hash)
+);
+
+/*the custom project roles are defined as sets of permissions by the system admin*/
+ALTER TABLE role ALTER COLUMN name TYPE varchar(255);
+CREATE TABLE IF NOT EXISTS role_permission (
+ id SERIAL PRIMARY KEY NOT NULL,
+ role_id int NOT NULL,
+ resource varchar(255) NOT NULL,
+ action varchar(255) NOT NULL,
+ CONSTRAINT unique_role_permission UNIQUE (role_id, resource, action)
+);
+
+/*the audit trail of the mutating API calls and the logins*/
+CREATE TABLE IF NOT EXISTS audit_log (
+ id SERIAL PRIMARY KEY NOT NULL,
+ username varchar(255) NOT NULL,
+ source_ip varchar(64),
+ operation varchar(32) NOT NULL,
+ resource_type varchar(64),
+ resource varchar(1024),
+ method varchar(16),
+ status_code int,
+ result varchar(16),
+ summary text,
+ op_time timestamp default CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_audit_log_op_time ON audit_log (op_time);
+CREATE INDEX IF NOT EXISTS idx_audit_log_username ON audit_log (username);
+
+/*the final status of the retention execution whose event is published, to avoid publishing the duplicate events*/
+ALTER TABLE retention_execution ADD COLUMN IF NOT EXISTS notified_status varchar(32);
+
+/*the repositories which the manifests are pushed to, they are kept after the manifests are untagged so that GC can delete the untagged manifests from their repositories*/
+CREATE TABLE IF NOT EXISTS repository_manifest (
+ id SERIAL PRIMARY KEY NOT NULL,
+ project_id int NOT NULL,
+ repository_name varchar(255) NOT NULL,
+ digest varchar(255) NOT NULL,
+ creation_time timestamp default CURRENT_TIMESTAMP,
+ CONSTRAINT unique_repository_manifest UNIQUE (repository_name, digest)
+);
+CREATE INDEX IF NOT EXISTS idx_repository_manifest_digest ON repository_manifest (digest);
+INSERT INTO repository_manifest (project_id, repository_name, digest)
+  SELECT DISTINCT project_id, repo, digest FROM artifact ON CONFLICT DO NOTHING;
@@ -201,6 +201,11 @@ def parse_yaml_config(config_file_path, with_notary, with_clair, with_chartmuseu
     js_config = configs.get('jobservice') or {}
     config_dict['max_job_workers'] = js_config["max_job_workers"]
     config_dict['jobservice_secret'] = generate_random_string(16)
+    allowed_backends = ['redis', 'postgresql']
+    job_backend = (js_config.get('backend') or 'redis').lower()
+    if job_backend not in allowed_backends:
+        raise Exception('jobservice backend must be one of redis, postgresql')
+    config_dict['jobservice_backend'] = job_backend
 
     # notification config
     notification_config = configs.get('notification') or {}
@@ -215,6 +220,12 @@ def parse_yaml_config(config_file_path, with_notary, with_clair, with_chartmuseu
         raise Exception('log level must be one of debug, info, warning, error, fatal')
     config_dict['log_level'] = log_level.lower()
 
+    allowed_formats = ['text', 'json']
+    log_format = (log_configs.get('format') or 'text').lower()
+    if log_format not in allowed_formats:
+        raise Exception('log format must be one of text, json')
+    config_dict['log_format'] = log_format
+
     # parse local log related configs
     local_logs = log_configs.get('local') or {}
     if local_logs:
@@ -31,5 +31,6 @@ def prepare_job_service(config_dict):
         uid=DEFAULT_UID,
         gid=DEFAULT_GID,
         max_job_workers=config_dict['max_job_workers'],
+        backend=config_dict['jobservice_backend'],
         redis_url=config_dict['redis_url_js'],
         level=log_level)
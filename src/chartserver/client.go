@@ -74,6 +74,28 @@ func (cc *ChartClient) GetContent(addr string) ([]byte, error) {
 	return content, nil
 }
 
+// GetContentLength gets the length of the content at the specified url by a HEAD request
+// without downloading the content
+func (cc *ChartClient) GetContentLength(addr string) (int64, error) {
+	response, err := cc.sendRequest(addr, http.MethodHead, nil)
+	if err != nil {
+		err = errors.Wrap(err, "get content length failed")
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return 0, &commonhttp.Error{
+			Code:    response.StatusCode,
+			Message: http.StatusText(response.StatusCode),
+		}
+	}
+	if response.ContentLength < 0 {
+		return 0, errors.Errorf("no content length returned from %s", addr)
+	}
+	return response.ContentLength, nil
+}
+
 // DeleteContent sends deleting request to the addr to delete content
 func (cc *ChartClient) DeleteContent(addr string) error {
 	response, err := cc.sendRequest(addr, http.MethodDelete, nil)
@@ -8,6 +8,7 @@ import (
 
 	"github.com/pkg/errors"
 	"k8s.io/helm/cmd/helm/search"
+	helm_repo "k8s.io/helm/pkg/repo"
 
 	hlog "github.com/goharbor/harbor/src/common/utils/log"
 	"github.com/goharbor/harbor/src/core/config"
@@ -181,6 +182,44 @@ func (c *Controller) GetChartVersionDetails(namespace, chartName, version string
 	return chartDetails, nil
 }
 
+// GetChartVersionSize returns the size of the package of the specified chart version
+// See @ServiceHandler.GetChartVersionSize
+func (c *Controller) GetChartVersionSize(namespace, chartName, version string) (int64, error) {
+	chartV, err := c.GetChartVersion(namespace, chartName, version)
+	if err != nil {
+		return 0, err
+	}
+
+	return c.getChartVersionSize(namespace, chartV)
+}
+
+// GetStorageOfCharts calculates and returns the total size of the chart packages under the namespace.
+// See @ServiceHandler.GetStorageOfCharts
+func (c *Controller) GetStorageOfCharts(namespace string) (int64, error) {
+	charts, err := c.ListCharts(namespace)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, chart := range charts {
+		versions, err := c.GetChart(namespace, chart.Name)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, version := range versions {
+			size, err := c.getChartVersionSize(namespace, &version.ChartVersion)
+			if err != nil {
+				return 0, err
+			}
+			total += size
+		}
+	}
+
+	return total, nil
+}
+
 // SearchChart search charts in the specified namespaces with the keyword q.
 // RegExp mode is enabled as default.
 // For each chart, only the latest version will shown in the result list if matched to avoid duplicated entries.
@@ -216,18 +255,53 @@ func (c *Controller) SearchChart(q string, namespaces []string) ([]*search.Resul
 	return results, nil
 }
 
+// Get the size of the package of the chart version, the size is read from the response of a HEAD request
+// to avoid downloading the package, and the package is only downloaded when the backend server doesn't
+// return the length
+func (c *Controller) getChartVersionSize(namespace string, chartV *helm_repo.ChartVersion) (int64, error) {
+	if len(chartV.URLs) == 0 {
+		return 0, errors.Errorf("no package found for chart %s:%s", chartV.Name, chartV.Version)
+	}
+
+	url, err := c.chartVersionURL(namespace, chartV.URLs[0])
+	if err != nil {
+		return 0, err
+	}
+
+	size, err := c.apiClient.GetContentLength(url)
+	if err == nil {
+		return size, nil
+	}
+	hlog.Debugf("failed to get the size of chart %s:%s by HEAD request, download it instead: %v", chartV.Name, chartV.Version, err)
+
+	content, err := c.apiClient.GetContent(url)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(content)), nil
+}
+
 // Get the content bytes of the chart version
 func (c *Controller) getChartVersionContent(namespace string, subPath string) ([]byte, error) {
+	url, err := c.chartVersionURL(namespace, subPath)
+	if err != nil {
+		return nil, err
+	}
+	return c.apiClient.GetContent(url)
+}
+
+// Get the url of the chart version package in the backend server
+func (c *Controller) chartVersionURL(namespace string, subPath string) (string, error) {
 	var url string
 	if strings.HasPrefix(subPath, "http") {
 		extEndpoint, err := config.ExtEndpoint()
 		if err != nil {
-			return nil, errors.Wrap(err, "can not get ext endpoint")
+			return "", errors.Wrap(err, "can not get ext endpoint")
 		}
 		url = strings.TrimPrefix(subPath, fmt.Sprintf("%s/%s", extEndpoint, "chartrepo/"))
 	} else {
 		url = path.Join(namespace, subPath)
 	}
-	url = fmt.Sprintf("%s/%s", c.backendServerAddress.String(), url)
-	return c.apiClient.GetContent(url)
+	return fmt.Sprintf("%s/%s", c.backendServerAddress.String(), url), nil
 }
@@ -2,6 +2,8 @@ package chartserver
 
 import (
 	"testing"
+
+	htesting "github.com/goharbor/harbor/src/testing"
 )
 
 // Test the function GetCountOfCharts
@@ -113,3 +115,20 @@ func TestChartSearching(t *testing.T) {
 		t.Fatalf("expect 2 results but got %d", len(results))
 	}
 }
+
+// Test the function GetChartVersionSize
+func TestGetChartVersionSize(t *testing.T) {
+	s, c, err := createMockObjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	size, err := c.GetChartVersionSize("repo1", "harbor", "0.2.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size != int64(len(htesting.HelmChartContent)) {
+		t.Fatalf("expect %d but got %d", len(htesting.HelmChartContent), size)
+	}
+}
@@ -53,6 +53,11 @@ func (b *BaseAPI) Render() error {
 	return nil
 }
 
+// Logger returns the logger of the request which attaches the request ID to the logs
+func (b *BaseAPI) Logger() *log.Logger {
+	return log.FromContext(b.Ctx.Request.Context())
+}
+
 // RenderError provides shortcut to render http error
 func (b *BaseAPI) RenderError(code int, text string) {
 	http.Error(b.Ctx.ResponseWriter, text, code)
@@ -65,7 +70,7 @@ func (b *BaseAPI) RenderFormattedError(errorCode int, errorMsg string) {
 		Message: errorMsg,
 	}
 	formattedErrMsg := error.String()
-	log.Errorf("%s %s failed with error: %s", b.Ctx.Request.Method, b.Ctx.Request.URL.String(), formattedErrMsg)
+	b.Logger().Errorf("%s %s failed with error: %s", b.Ctx.Request.Method, b.Ctx.Request.URL.String(), formattedErrMsg)
 	b.RenderError(error.Code, formattedErrMsg)
 }
 
@@ -192,7 +197,7 @@ func (b *BaseAPI) ParseAndHandleError(text string, err error) {
 	if err == nil {
 		return
 	}
-	log.Errorf("%s: %v", text, err)
+	b.Logger().WithField(log.FieldError, err).Errorf("%s: %v", text, err)
 	if e, ok := err.(*commonhttp.Error); ok {
 		b.RenderFormattedError(e.Code, e.Message)
 		return
@@ -225,7 +230,7 @@ func (b *BaseAPI) SendBadRequestError(err error) {
 // When you send an internal server error  to the client, you expect user to check the log
 // to find out the root cause.
 func (b *BaseAPI) SendInternalServerError(err error) {
-	log.Error(err.Error())
+	b.Logger().WithField(log.FieldError, err).Error(err.Error())
 	b.RenderFormattedError(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
 }
 
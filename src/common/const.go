@@ -138,6 +138,8 @@ const (
 	DefaultClairHealthCheckServerURL  = "http://clair:6061"
 	// Use this prefix to distinguish harbor user, the prefix contains a special character($), so it cannot be registered as a harbor user.
 	RobotPrefix = "robot$"
+	// Use this prefix to distinguish the personal access token from the password of user.
+	AccessTokenPrefix = "pat_"
 	// Use this prefix to index user who tries to login with web hook token.
 	AuthProxyUserNamePrefix = "tokenreview$"
 	CoreConfigPath          = "/api/internal/configurations"
@@ -153,9 +155,14 @@ const (
 	NotificationEnable = "notification_enable"
 
 	// Quota setting items for project
-	QuotaPerProjectEnable = "quota_per_project_enable"
-	CountPerProject       = "count_per_project"
-	StoragePerProject     = "storage_per_project"
+	QuotaPerProjectEnable     = "quota_per_project_enable"
+	CountPerProject           = "count_per_project"
+	StoragePerProject         = "storage_per_project"
+	RepositoryCountPerProject = "repository_count_per_project"
+	ChartStoragePerProject    = "chart_storage_per_project"
+
+	// the retention period of audit logs in days, the logs are kept forever if it's 0
+	AuditLogRetentionDays = "audit_log_retention_days"
 
 	// ForeignLayer
 	ForeignLayer = "application/vnd.docker.image.rootfs.foreign.diff.tar.gzip"
@@ -0,0 +1,118 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/astaxie/beego/orm"
+	"github.com/goharbor/harbor/src/common/models"
+)
+
+// AddAccessToken adds the personal access token, the hash of the token should be set
+func AddAccessToken(token *models.AccessToken) (int64, error) {
+	ids := make([]string, 0, len(token.ProjectIDs))
+	for _, id := range token.ProjectIDs {
+		ids = append(ids, strconv.FormatInt(id, 10))
+	}
+	token.Projects = strings.Join(ids, ",")
+	token.CreationTime = time.Now()
+
+	id, err := GetOrmer().Insert(token)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
+			return 0, ErrDupRows
+		}
+		return 0, err
+	}
+	return id, nil
+}
+
+// GetAccessToken gets the personal access token by ID
+func GetAccessToken(id int64) (*models.AccessToken, error) {
+	return getAccessToken(&models.AccessToken{ID: id}, "ID")
+}
+
+// GetAccessTokenByHash gets the personal access token by the hash of it
+func GetAccessTokenByHash(hash string) (*models.AccessToken, error) {
+	return getAccessToken(&models.AccessToken{Hash: hash}, "Hash")
+}
+
+func getAccessToken(token *models.AccessToken, col string) (*models.AccessToken, error) {
+	if err := GetOrmer().Read(token, col); err != nil {
+		if err == orm.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if err := parseProjectIDs(token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// ListAccessTokens lists the personal access tokens of the user
+func ListAccessTokens(userID int) ([]*models.AccessToken, error) {
+	tokens := []*models.AccessToken{}
+	if _, err := GetOrmer().QueryTable(&models.AccessToken{}).Filter("UserID", userID).
+		OrderBy("-CreationTime").All(&tokens); err != nil {
+		return nil, err
+	}
+
+	for _, token := range tokens {
+		if err := parseProjectIDs(token); err != nil {
+			return nil, err
+		}
+	}
+	return tokens, nil
+}
+
+// UpdateAccessTokenLastUsedAt updates the time when the personal access token is used last time
+func UpdateAccessTokenLastUsedAt(id int64, lastUsedAt int64) error {
+	_, err := GetOrmer().QueryTable(&models.AccessToken{}).Filter("ID", id).Update(orm.Params{
+		"LastUsedAt": lastUsedAt,
+	})
+	return err
+}
+
+// DeleteAccessToken deletes the personal access token by ID
+func DeleteAccessToken(id int64) error {
+	_, err := GetOrmer().QueryTable(&models.AccessToken{}).Filter("ID", id).Delete()
+	return err
+}
+
+// DeleteAccessTokensOfUser deletes all the personal access tokens of the user
+func DeleteAccessTokensOfUser(userID int) error {
+	_, err := GetOrmer().QueryTable(&models.AccessToken{}).Filter("UserID", userID).Delete()
+	return err
+}
+
+func parseProjectIDs(token *models.AccessToken) error {
+	token.ProjectIDs = []int64{}
+	if len(token.Projects) == 0 {
+		return nil
+	}
+	for _, str := range strings.Split(token.Projects, ",") {
+		id, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return err
+		}
+		token.ProjectIDs = append(token.ProjectIDs, id)
+	}
+	return nil
+}
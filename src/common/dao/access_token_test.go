@@ -0,0 +1,72 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessTokenDaoMethods(t *testing.T) {
+	token := &models.AccessToken{
+		UserID:     1,
+		Name:       "ci",
+		Hash:       models.HashAccessToken("pat_test"),
+		ReadOnly:   true,
+		ProjectIDs: []int64{1, 2},
+	}
+	id, err := AddAccessToken(token)
+	require.Nil(t, err)
+	defer DeleteAccessTokensOfUser(1)
+
+	// duplicated name
+	_, err = AddAccessToken(&models.AccessToken{
+		UserID: 1,
+		Name:   "ci",
+		Hash:   models.HashAccessToken("pat_test2"),
+	})
+	assert.Equal(t, ErrDupRows, err)
+
+	token, err = GetAccessTokenByHash(models.HashAccessToken("pat_test"))
+	require.Nil(t, err)
+	require.NotNil(t, token)
+	assert.Equal(t, id, token.ID)
+	assert.True(t, token.ReadOnly)
+	assert.Equal(t, []int64{1, 2}, token.ProjectIDs)
+
+	token, err = GetAccessTokenByHash(models.HashAccessToken("pat_non_exist"))
+	require.Nil(t, err)
+	assert.Nil(t, token)
+
+	now := time.Now().Unix()
+	require.Nil(t, UpdateAccessTokenLastUsedAt(id, now))
+	token, err = GetAccessToken(id)
+	require.Nil(t, err)
+	require.NotNil(t, token)
+	assert.Equal(t, now, token.LastUsedAt)
+
+	tokens, err := ListAccessTokens(1)
+	require.Nil(t, err)
+	assert.Equal(t, 1, len(tokens))
+
+	require.Nil(t, DeleteAccessToken(id))
+	token, err = GetAccessToken(id)
+	require.Nil(t, err)
+	assert.Nil(t, token)
+}
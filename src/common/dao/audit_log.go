@@ -0,0 +1,102 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	"github.com/astaxie/beego/orm"
+	"github.com/goharbor/harbor/src/common/models"
+)
+
+// AddAuditLog persists the audit log
+func AddAuditLog(auditLog *models.AuditLog) (int64, error) {
+	// the max length of username in database is 255, replace the last
+	// three charaters with "..." if the length is greater than 256
+	if len(auditLog.Username) > 255 {
+		auditLog.Username = auditLog.Username[:252] + "..."
+	}
+	if auditLog.OpTime.IsZero() {
+		auditLog.OpTime = time.Now()
+	}
+
+	return GetOrmer().Insert(auditLog)
+}
+
+// GetTotalOfAuditLogs returns the total count of the audit logs matching the query
+func GetTotalOfAuditLogs(query *models.AuditLogQuery) (int64, error) {
+	return auditLogQueryConditions(query).Count()
+}
+
+// ListAuditLogs lists the audit logs according to the query, the latest ones come first
+func ListAuditLogs(query *models.AuditLogQuery) ([]*models.AuditLog, error) {
+	qs := auditLogQueryConditions(query).OrderBy("-op_time", "-id")
+
+	if query != nil && query.Pagination != nil {
+		size := query.Pagination.Size
+		if size > 0 {
+			qs = qs.Limit(size)
+
+			page := query.Pagination.Page
+			if page > 0 {
+				qs = qs.Offset((page - 1) * size)
+			}
+		}
+	}
+
+	logs := []*models.AuditLog{}
+	_, err := qs.All(&logs)
+	return logs, err
+}
+
+// DeleteAuditLogsBefore deletes the audit logs recorded before the time and returns the count of deleted ones
+func DeleteAuditLogsBefore(t time.Time) (int64, error) {
+	return GetOrmer().QueryTable(&models.AuditLog{}).Filter("op_time__lt", t).Delete()
+}
+
+func auditLogQueryConditions(query *models.AuditLogQuery) orm.QuerySeter {
+	qs := GetOrmer().QueryTable(&models.AuditLog{})
+
+	if query == nil {
+		return qs
+	}
+
+	if len(query.Username) != 0 {
+		qs = qs.Filter("username__contains", query.Username)
+	}
+	if len(query.SourceIP) != 0 {
+		qs = qs.Filter("source_ip", query.SourceIP)
+	}
+	if len(query.Operation) != 0 {
+		qs = qs.Filter("operation", query.Operation)
+	}
+	if len(query.ResourceType) != 0 {
+		qs = qs.Filter("resource_type", query.ResourceType)
+	}
+	if len(query.Resource) != 0 {
+		qs = qs.Filter("resource__contains", query.Resource)
+	}
+	if len(query.Result) != 0 {
+		qs = qs.Filter("result", query.Result)
+	}
+	if query.BeginTime != nil {
+		qs = qs.Filter("op_time__gte", query.BeginTime)
+	}
+	if query.EndTime != nil {
+		qs = qs.Filter("op_time__lte", query.EndTime)
+	}
+
+	return qs
+}
@@ -0,0 +1,93 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLogDaoMethods(t *testing.T) {
+	now := time.Now()
+	old := now.AddDate(0, 0, -10)
+	_, err := AddAuditLog(&models.AuditLog{
+		Username:     "audit_user",
+		SourceIP:     "10.0.0.1",
+		Operation:    models.AuditLogOperationCreate,
+		ResourceType: "project",
+		Resource:     "/api/projects",
+		Method:       "POST",
+		StatusCode:   201,
+		Result:       models.AuditLogResultSuccess,
+		OpTime:       old,
+	})
+	require.Nil(t, err)
+	_, err = AddAuditLog(&models.AuditLog{
+		Username:     "audit_user",
+		SourceIP:     "10.0.0.2",
+		Operation:    models.AuditLogOperationLogin,
+		ResourceType: "user",
+		Resource:     "/c/login",
+		Method:       "POST",
+		StatusCode:   401,
+		Result:       models.AuditLogResultFailure,
+	})
+	require.Nil(t, err)
+	defer DeleteAuditLogsBefore(now.Add(time.Hour))
+
+	total, err := GetTotalOfAuditLogs(&models.AuditLogQuery{Username: "audit_user"})
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), total)
+
+	logs, err := ListAuditLogs(&models.AuditLogQuery{
+		Username: "audit_user",
+		Result:   models.AuditLogResultFailure,
+	})
+	require.Nil(t, err)
+	require.Equal(t, 1, len(logs))
+	assert.Equal(t, models.AuditLogOperationLogin, logs[0].Operation)
+
+	logs, err = ListAuditLogs(&models.AuditLogQuery{
+		Username:     "audit_user",
+		ResourceType: "project",
+		EndTime:      &now,
+	})
+	require.Nil(t, err)
+	require.Equal(t, 1, len(logs))
+	assert.Equal(t, "10.0.0.1", logs[0].SourceIP)
+
+	logs, err = ListAuditLogs(&models.AuditLogQuery{
+		Username: "audit_user",
+		Pagination: &models.Pagination{
+			Page: 1,
+			Size: 1,
+		},
+	})
+	require.Nil(t, err)
+	require.Equal(t, 1, len(logs))
+	// the latest one comes first
+	assert.Equal(t, models.AuditLogOperationLogin, logs[0].Operation)
+
+	count, err := DeleteAuditLogsBefore(now.AddDate(0, 0, -1))
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), count)
+	total, err = GetTotalOfAuditLogs(&models.AuditLogQuery{Username: "audit_user"})
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), total)
+}
@@ -14,6 +14,9 @@ import (
 func AddBlob(blob *models.Blob) (int64, error) {
 	now := time.Now()
 	blob.CreationTime = now
+	if blob.Status == "" {
+		blob.Status = models.BlobStatusNone
+	}
 	id, err := GetOrmer().Insert(blob)
 	if err != nil {
 		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
@@ -27,6 +30,9 @@ func AddBlob(blob *models.Blob) (int64, error) {
 // GetOrCreateBlob returns blob by digest, create it if not exists
 func GetOrCreateBlob(blob *models.Blob) (bool, *models.Blob, error) {
 	blob.CreationTime = time.Now()
+	if blob.Status == "" {
+		blob.Status = models.BlobStatusNone
+	}
 
 	created, id, err := GetOrmer().ReadOrCreate(blob, "digest")
 	if err != nil {
@@ -82,6 +88,10 @@ func ListBlobs(query *models.BlobQuery) ([]*models.Blob, error) {
 			qs = qs.Filter("Digest__in", query.Digests)
 		}
 
+		if query.Status != "" {
+			qs = qs.Filter("Status", query.Status)
+		}
+
 		if query.Size > 0 {
 			qs = qs.Limit(query.Size)
 			if query.Page > 0 {
@@ -130,6 +140,7 @@ func SyncBlobs(references []distribution.Descriptor) error {
 				ContentType:  reference.MediaType,
 				Size:         reference.Size,
 				CreationTime: time.Now(),
+				Status:       models.BlobStatusNone,
 			})
 		}
 	}
@@ -158,12 +169,19 @@ func SyncBlobs(references []distribution.Descriptor) error {
 	return nil
 }
 
-// GetBlobsByArtifact returns blobs of artifact
+// GetBlobsByArtifact returns blobs of artifact,
+// the blobs of the manifests referenced by the artifact are included when it's a manifest list or an image index
 func GetBlobsByArtifact(artifactDigest string) ([]*models.Blob, error) {
-	sql := `SELECT * FROM blob WHERE digest IN (SELECT digest_blob FROM artifact_blob WHERE digest_af = ?)`
+	sql := `SELECT * FROM blob WHERE digest IN (
+  SELECT digest_blob FROM artifact_blob WHERE digest_af = ?
+  UNION
+  SELECT digest_blob FROM artifact_blob WHERE digest_af IN (
+    SELECT digest_blob FROM artifact_blob WHERE digest_af = ? AND digest_blob != ?
+  )
+)`
 
 	var blobs []*models.Blob
-	if _, err := GetOrmer().Raw(sql, artifactDigest).QueryRows(&blobs); err != nil {
+	if _, err := GetOrmer().Raw(sql, artifactDigest, artifactDigest, artifactDigest).QueryRows(&blobs); err != nil {
 		return nil, err
 	}
 
@@ -182,6 +200,8 @@ func GetExclusiveBlobs(projectID int64, repository, digest string) ([]*models.Bl
 		return exclusive, nil
 	}
 
+	// the manifests referenced by the manifest lists or image indexes of other artifacts
+	// are treated as the artifacts as well
 	sql := fmt.Sprintf(`
 SELECT
   DISTINCT b.digest_blob AS digest
@@ -200,11 +220,30 @@ FROM
         project_id = ?
         AND digest != ?
       )
+    UNION
+    SELECT
+      ab.digest_blob AS digest
+    FROM
+      artifact af
+      JOIN artifact_blob ab ON af.digest = ab.digest_af
+    WHERE
+      af.kind = ?
+      AND (
+        (
+          af.project_id = ?
+          AND af.repo != ?
+        )
+        OR (
+          af.project_id = ?
+          AND af.digest != ?
+        )
+      )
   ) AS a
   LEFT JOIN artifact_blob b ON a.digest = b.digest_af
   AND b.digest_blob IN (%s)`, ParamPlaceholderForIn(len(blobs)))
 
-	params := []interface{}{projectID, repository, projectID, digest}
+	params := []interface{}{projectID, repository, projectID, digest,
+		models.ArtifactKindImageIndex, projectID, repository, projectID, digest}
 	for _, blob := range blobs {
 		params = append(params, blob.Digest)
 	}
@@ -230,3 +269,76 @@ FROM
 
 	return exclusive, nil
 }
+
+// referencedBlobsSQL is the sql to get the digests of the artifacts and the blobs referenced by them,
+// the blobs of the manifests referenced by the manifest lists or image indexes are included
+const referencedBlobsSQL = `
+WITH RECURSIVE referenced(digest) AS (
+  SELECT digest FROM artifact
+  UNION
+  SELECT ab.digest_blob FROM artifact_blob ab JOIN referenced r ON ab.digest_af = r.digest
+)`
+
+// ListUnreferencedBlobs returns the blobs which are not referenced by any artifact and not touched since the time,
+// the update time of the blobs is refreshed by the pulling and pushing which use them, see TouchBlobs
+func ListUnreferencedBlobs(before time.Time) ([]*models.Blob, error) {
+	sql := referencedBlobsSQL + `
+SELECT * FROM blob WHERE digest NOT IN (SELECT digest FROM referenced) AND update_time < ? ORDER BY id`
+
+	var blobs []*models.Blob
+	if _, err := GetOrmer().Raw(sql, before).QueryRows(&blobs); err != nil {
+		return nil, err
+	}
+
+	return blobs, nil
+}
+
+// IsBlobReferenced returns true when the blob is referenced by any artifact
+func IsBlobReferenced(digest string) (bool, error) {
+	sql := referencedBlobsSQL + `
+SELECT COUNT(*) FROM referenced WHERE digest = ?`
+
+	var count int64
+	if err := GetOrmer().Raw(sql, digest).QueryRow(&count); err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// UpdateBlobStatus updates the status of the blob only when the version of it is not changed,
+// returns the count of the updated rows, 0 means the blob is updated by others
+func UpdateBlobStatus(blob *models.Blob) (int64, error) {
+	sql := `UPDATE blob SET status = ?, version = version + 1, update_time = ? WHERE id = ? AND version = ?`
+
+	res, err := GetOrmer().Raw(sql, blob.Status, time.Now(), blob.ID, blob.Version).Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if n > 0 {
+		blob.Version++
+	}
+
+	return n, nil
+}
+
+// TouchBlobs refreshes the update time of the blobs used by the pulling or pushing and resets the status of
+// the ones marked as deletion candidates or failed to be deleted by GC, the version is increased so that the
+// blobs are skipped in the marking and sweeping of GC, the blobs being deleted are left untouched
+func TouchBlobs(digests ...string) error {
+	if len(digests) == 0 {
+		return nil
+	}
+
+	sql := fmt.Sprintf(`UPDATE blob SET status = CASE WHEN status IN (?, ?) THEN ? ELSE status END,
+version = version + 1, update_time = ? WHERE digest IN (%s) AND status != ?`, ParamPlaceholderForIn(len(digests)))
+
+	_, err := GetOrmer().Raw(sql, models.BlobStatusDelete, models.BlobStatusDeleteFailed, models.BlobStatusNone,
+		time.Now(), digests, models.BlobStatusDeleting).Exec()
+	return err
+}
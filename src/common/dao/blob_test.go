@@ -17,6 +17,7 @@ package dao
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/docker/distribution"
 	"github.com/docker/distribution/manifest/schema2"
@@ -152,6 +153,107 @@ func TestSyncBlobs(t *testing.T) {
 	assert.Len(blobs, 4)
 }
 
+func TestUpdateBlobStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	blob := &models.Blob{
+		Digest:      digest.FromString(utils.GenerateRandomString()).String(),
+		ContentType: schema2.MediaTypeLayer,
+		Size:        1,
+	}
+	id, err := AddBlob(blob)
+	require.Nil(t, err)
+	blob.ID = id
+	defer DeleteBlob(blob.Digest)
+	assert.Equal(models.BlobStatusNone, blob.Status)
+
+	blob.Status = models.BlobStatusDelete
+	n, err := UpdateBlobStatus(blob)
+	assert.Nil(err)
+	assert.Equal(int64(1), n)
+
+	// the blob is updated by others
+	stale := *blob
+	stale.Version--
+	stale.Status = models.BlobStatusDeleting
+	n, err = UpdateBlobStatus(&stale)
+	assert.Nil(err)
+	assert.Equal(int64(0), n)
+
+	// reset the blobs marked as deletion candidates
+	assert.Nil(TouchBlobs(blob.Digest))
+	blob2, err := GetBlob(blob.Digest)
+	assert.Nil(err)
+	assert.Equal(models.BlobStatusNone, blob2.Status)
+	assert.Equal(blob.Version+1, blob2.Version)
+
+	// the blob being touched is skipped by GC
+	blob.Status = models.BlobStatusDelete
+	n, err = UpdateBlobStatus(blob)
+	assert.Nil(err)
+	assert.Equal(int64(0), n)
+
+	// the deleting blobs are not reset
+	blob2.Status = models.BlobStatusDeleting
+	n, err = UpdateBlobStatus(blob2)
+	assert.Nil(err)
+	assert.Equal(int64(1), n)
+	assert.Nil(TouchBlobs(blob.Digest))
+	if blobs, err := ListBlobs(&models.BlobQuery{Digest: blob.Digest, Status: models.BlobStatusDeleting}); assert.Nil(err) {
+		assert.Len(blobs, 1)
+	}
+}
+
+func TestListUnreferencedBlobs(t *testing.T) {
+	withProject(func(projectID int64, projectName string) {
+		assert := assert.New(t)
+
+		digest1 := digest.FromString(utils.GenerateRandomString()).String()
+		digest2 := digest.FromString(utils.GenerateRandomString()).String()
+
+		manifest, err := prepareImage(projectID, projectName, "mysql", "latest", digest1)
+		require.Nil(t, err)
+		_, _, err = GetOrCreateBlob(&models.Blob{Digest: digest2, Size: 1})
+		require.Nil(t, err)
+		defer func() {
+			for _, d := range []string{manifest, digest1, digest2} {
+				DeleteBlob(d)
+			}
+		}()
+
+		isUnreferenced := func(before time.Time, d string) bool {
+			blobs, err := ListUnreferencedBlobs(before)
+			require.Nil(t, err)
+			for _, blob := range blobs {
+				if blob.Digest == d {
+					return true
+				}
+			}
+			return false
+		}
+
+		future := time.Now().Add(time.Minute)
+		assert.False(isUnreferenced(future, manifest))
+		assert.False(isUnreferenced(future, digest1))
+		assert.True(isUnreferenced(future, digest2))
+		// the blobs created in the time window are skipped
+		assert.False(isUnreferenced(time.Now().Add(-time.Hour), digest2))
+		// the blobs touched in the time window are skipped
+		before := time.Now()
+		time.Sleep(10 * time.Millisecond)
+		assert.True(isUnreferenced(before, digest2))
+		require.Nil(t, TouchBlobs(digest2))
+		assert.False(isUnreferenced(before, digest2))
+
+		referenced, err := IsBlobReferenced(digest1)
+		assert.Nil(err)
+		assert.True(referenced)
+		referenced, err = IsBlobReferenced(digest2)
+		assert.Nil(err)
+		assert.False(referenced)
+	})
+}
+
 func prepareImage(projectID int64, projectName, name, tag string, layerDigests ...string) (string, error) {
 	digest := digest.FromString(strings.Join(layerDigests, ":")).String()
 	artifact := &models.Artifact{PID: projectID, Repo: projectName + "/" + name, Digest: digest, Tag: tag}
@@ -301,6 +403,49 @@ func (suite *GetExclusiveBlobsSuite) TestInDifferentProjects() {
 	})
 }
 
+func (suite *GetExclusiveBlobsSuite) TestWithImageIndex() {
+	withProject(func(projectID int64, projectName string) {
+		digest1 := digest.FromString(utils.GenerateRandomString()).String()
+		digest2 := digest.FromString(utils.GenerateRandomString()).String()
+		digest3 := digest.FromString(utils.GenerateRandomString()).String()
+
+		// manifests pushed by digest are not artifacts, only their blobs are attached
+		child1 := digest.FromString(digest1 + digest2).String()
+		child2 := digest.FromString(digest1 + digest3).String()
+		for child, layers := range map[string][]string{child1: {digest1, digest2}, child2: {digest1, digest3}} {
+			var afnbs []*models.ArtifactAndBlob
+			for _, blobDigest := range append([]string{child}, layers...) {
+				_, _, err := GetOrCreateBlob(&models.Blob{Digest: blobDigest, Size: 1})
+				suite.Nil(err)
+				afnbs = append(afnbs, &models.ArtifactAndBlob{DigestAF: child, DigestBlob: blobDigest})
+			}
+			suite.Nil(AddArtifactNBlobs(afnbs))
+		}
+
+		index := suite.mustPrepareImage(projectID, projectName, "mysql", "latest", child1, child2)
+		_, err := GetOrmer().Raw(`UPDATE artifact SET kind = ? WHERE digest = ?`, models.ArtifactKindImageIndex, index).Exec()
+		suite.Nil(err)
+
+		if blobs, err := GetBlobsByArtifact(index); suite.Nil(err) {
+			// index + 2 manifests + 3 layers
+			suite.Len(blobs, 6)
+		}
+		if blobs, err := GetExclusiveBlobs(projectID, projectName+"/mysql", index); suite.Nil(err) {
+			suite.Len(blobs, 6)
+		}
+
+		manifest := suite.mustPrepareImage(projectID, projectName, "mariadb", "latest", digest1, digest2)
+		if blobs, err := GetExclusiveBlobs(projectID, projectName+"/mysql", index); suite.Nil(err) {
+			// digest1 and digest2 are shared with mariadb:latest
+			suite.Len(blobs, 4)
+		}
+		if blobs, err := GetExclusiveBlobs(projectID, projectName+"/mariadb", manifest); suite.Nil(err) {
+			// only the manifest itself is exclusive
+			suite.Len(blobs, 1)
+		}
+	})
+}
+
 func TestRunGetExclusiveBlobsSuite(t *testing.T) {
 	suite.Run(t, new(GetExclusiveBlobsSuite))
 }
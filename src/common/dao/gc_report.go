@@ -0,0 +1,70 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/astaxie/beego/orm"
+	"github.com/goharbor/harbor/src/common/models"
+)
+
+// AddGCReport ...
+func AddGCReport(report *models.GCReport) (int64, error) {
+	projects, err := json.Marshal(report.Projects)
+	if err != nil {
+		return 0, err
+	}
+	report.ProjectsJSON = string(projects)
+
+	if len(report.Candidates) > 0 {
+		candidates, err := json.Marshal(report.Candidates)
+		if err != nil {
+			return 0, err
+		}
+		report.CandidatesJSON = string(candidates)
+	}
+
+	report.CreationTime = time.Now()
+	return GetOrmer().Insert(report)
+}
+
+// GetLatestGCReport returns the report of the latest execution of the GC job,
+// the uuid is the job uuid of the manual GC or the upstream job uuid of the scheduled GC
+func GetLatestGCReport(uuid string) (*models.GCReport, error) {
+	report := &models.GCReport{}
+	cond := orm.NewCondition().Or("JobUUID", uuid).Or("UpstreamJobUUID", uuid)
+	err := GetOrmer().QueryTable(report).SetCond(cond).OrderBy("-ID").Limit(1).One(report)
+	if err == orm.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(report.ProjectsJSON) > 0 {
+		if err := json.Unmarshal([]byte(report.ProjectsJSON), &report.Projects); err != nil {
+			return nil, err
+		}
+	}
+	if len(report.CandidatesJSON) > 0 {
+		if err := json.Unmarshal([]byte(report.CandidatesJSON), &report.Candidates); err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,65 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGCReport(t *testing.T) {
+	assert := assert.New(t)
+
+	uuid := utils.GenerateRandomString()
+	report, err := GetLatestGCReport(uuid)
+	assert.Nil(err)
+	assert.Nil(report)
+
+	for i := 0; i < 2; i++ {
+		_, err := AddGCReport(&models.GCReport{
+			JobUUID:         utils.GenerateRandomString(),
+			UpstreamJobUUID: uuid,
+			DryRun:          true,
+			BlobsDeleted:    int64(i),
+			StartTime:       time.Now(),
+			EndTime:         time.Now(),
+			Projects: []*models.GCProjectSummary{
+				{ProjectID: 1, ProjectName: "library", BlobsDeleted: int64(i)},
+			},
+			Candidates: []*models.GCCandidate{
+				{Digest: "sha256:9e14cd7a6b2d4f1e0a2e1e8fdc79b51f5d0f0f0b3e3a4b8d9b7b0a4a9b1c2d3e", Size: 1},
+			},
+		})
+		require.Nil(t, err)
+	}
+
+	report, err = GetLatestGCReport(uuid)
+	require.Nil(t, err)
+	require.NotNil(t, report)
+	assert.Equal(int64(1), report.BlobsDeleted)
+	assert.True(report.DryRun)
+	require.Len(t, report.Projects, 1)
+	assert.Equal("library", report.Projects[0].ProjectName)
+	assert.Len(report.Candidates, 1)
+
+	report, err = GetLatestGCReport(report.JobUUID)
+	require.Nil(t, err)
+	assert.NotNil(report)
+}
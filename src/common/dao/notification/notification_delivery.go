@@ -0,0 +1,34 @@
+package notification
+
+import (
+	"errors"
+
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/common/models"
+)
+
+// AddNotificationDelivery insert the delivery record of notification job to DB
+func AddNotificationDelivery(delivery *models.NotificationDelivery) (int64, error) {
+	if delivery == nil {
+		return 0, errors.New("nil delivery")
+	}
+	if delivery.JobID == 0 {
+		return 0, errors.New("notification job ID is empty")
+	}
+	return dao.GetOrmer().Insert(delivery)
+}
+
+// GetNotificationDeliveries returns the delivery records of the notification job, the latest one is the first
+func GetNotificationDeliveries(jobID int64) ([]*models.NotificationDelivery, error) {
+	deliveries := []*models.NotificationDelivery{}
+	_, err := dao.GetOrmer().QueryTable(&models.NotificationDelivery{}).
+		Filter("JobID", jobID).
+		OrderBy("-ID").
+		All(&deliveries)
+	return deliveries, err
+}
+
+// DeleteNotificationDeliveries deletes the delivery records of the notification job
+func DeleteNotificationDeliveries(jobID int64) (int64, error) {
+	return dao.GetOrmer().Delete(&models.NotificationDelivery{JobID: jobID}, "job_id")
+}
@@ -0,0 +1,46 @@
+package notification
+
+import (
+	"testing"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationDelivery(t *testing.T) {
+	_, err := AddNotificationDelivery(nil)
+	assert.NotNil(t, err)
+	_, err = AddNotificationDelivery(&models.NotificationDelivery{})
+	assert.NotNil(t, err)
+
+	var jobID int64 = 2222
+	defer DeleteNotificationDeliveries(jobID)
+
+	_, err = AddNotificationDelivery(&models.NotificationDelivery{
+		JobID:      jobID,
+		Address:    "http://127.0.0.1:8080",
+		StatusCode: 500,
+		Latency:    10,
+	})
+	require.Nil(t, err)
+	id, err := AddNotificationDelivery(&models.NotificationDelivery{
+		JobID:        jobID,
+		Address:      "http://127.0.0.1:8080",
+		StatusCode:   200,
+		ResponseBody: "ok",
+		Latency:      20,
+	})
+	require.Nil(t, err)
+
+	deliveries, err := GetNotificationDeliveries(jobID)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(deliveries))
+	assert.Equal(t, id, deliveries[0].ID)
+	assert.Equal(t, 200, deliveries[0].StatusCode)
+	assert.Equal(t, "ok", deliveries[0].ResponseBody)
+
+	n, err := DeleteNotificationDeliveries(jobID)
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), n)
+}
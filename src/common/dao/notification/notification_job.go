@@ -46,6 +46,9 @@ func GetNotificationJob(id int64) (*models.NotificationJob, error) {
 	if err == orm.ErrNoRows {
 		return nil, nil
 	}
+	if err != nil {
+		return nil, err
+	}
 	return j, nil
 }
 
@@ -242,20 +242,10 @@ func projectQueryConditions(query *models.ProjectQueryParam) (string, []interfac
 		sql += ` and u2.username=?`
 		params = append(params, query.Member.Name)
 
+		// the role is the ID of role, including the custom ones
 		if query.Member.Role > 0 {
 			sql += ` and pm.role = ?`
-			roleID := 0
-			switch query.Member.Role {
-			case common.RoleProjectAdmin:
-				roleID = 1
-			case common.RoleDeveloper:
-				roleID = 2
-			case common.RoleGuest:
-				roleID = 3
-			case common.RoleMaster:
-				roleID = 4
-			}
-			params = append(params, roleID)
+			params = append(params, query.Member.Role)
 		}
 	}
 	if len(query.ProjectIDs) > 0 {
@@ -81,6 +81,27 @@ func RemoveBlobsFromProject(projectID int64, blobs ...*models.Blob) error {
 	return err
 }
 
+// RemoveBlobFromAllProjects removes the blob from all the projects
+func RemoveBlobFromAllProjects(blobID int64) error {
+	_, err := GetOrmer().Raw(`DELETE FROM project_blob WHERE blob_id = ?`, blobID).Exec()
+	return err
+}
+
+// GetProjectBlobsByBlobIDs returns the relationships between the blobs and the projects
+func GetProjectBlobsByBlobIDs(blobIDs ...int64) ([]*models.ProjectBlob, error) {
+	var projectBlobs []*models.ProjectBlob
+	if len(blobIDs) == 0 {
+		return projectBlobs, nil
+	}
+
+	sql := fmt.Sprintf(`SELECT * FROM project_blob WHERE blob_id IN (%s)`, ParamPlaceholderForIn(len(blobIDs)))
+	if _, err := GetOrmer().Raw(sql, blobIDs).QueryRows(&projectBlobs); err != nil {
+		return nil, err
+	}
+
+	return projectBlobs, nil
+}
+
 // HasBlobInProject ...
 func HasBlobInProject(projectID int64, digest string) (bool, error) {
 	sql := `SELECT COUNT(*) FROM project_blob JOIN blob ON project_blob.blob_id = blob.id AND project_id = ? AND digest = ?`
@@ -118,6 +118,15 @@ func Test_projectQueryConditions(t *testing.T) {
 	}
 }
 
+func TestProjectQueryConditionsOfCustomRole(t *testing.T) {
+	_, params := projectQueryConditions(&models.ProjectQueryParam{
+		Member: &models.MemberQuery{Name: "name", Role: 10},
+	})
+	if len(params) != 2 || params[1] != 10 {
+		t.Errorf("projectQueryConditions() got params = %v, want the ID of custom role 10", params)
+	}
+}
+
 func TestProjetExistsByName(t *testing.T) {
 	name := "project_exist_by_name_test"
 	exist := ProjectExistsByName(name)
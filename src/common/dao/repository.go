@@ -62,6 +62,9 @@ func GetRepositoryByName(name string) (*models.RepoRecord, error) {
 // DeleteRepository ...
 func DeleteRepository(name string) error {
 	o := GetOrmer()
+	if _, err := o.QueryTable("repository_manifest").Filter("repository_name", name).Delete(); err != nil {
+		return err
+	}
 	_, err := o.QueryTable("repository").Filter("name", name).Delete()
 	return err
 }
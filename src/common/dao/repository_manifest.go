@@ -0,0 +1,71 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/models"
+)
+
+// AddManifestToRepository records the manifest pushed to the repository
+func AddManifestToRepository(projectID int64, repository, digest string) (int64, error) {
+	rm := &models.RepositoryManifest{
+		ProjectID:      projectID,
+		RepositoryName: repository,
+		Digest:         digest,
+		CreationTime:   time.Now(),
+	}
+
+	_, id, err := GetOrmer().ReadOrCreate(rm, "repository_name", "digest")
+	return id, err
+}
+
+// GetRepositoriesOfManifests returns the records of the repositories which the manifests are pushed to
+func GetRepositoriesOfManifests(digests ...string) ([]*models.RepositoryManifest, error) {
+	var rms []*models.RepositoryManifest
+	if len(digests) == 0 {
+		return rms, nil
+	}
+
+	sql := fmt.Sprintf(`SELECT * FROM repository_manifest WHERE digest IN (%s) ORDER BY id`, ParamPlaceholderForIn(len(digests)))
+
+	_, err := GetOrmer().Raw(sql, digests).QueryRows(&rms)
+	return rms, err
+}
+
+// RemoveManifestFromRepository removes the record of the manifest deleted from the repository
+func RemoveManifestFromRepository(repository, digest string) error {
+	_, err := GetOrmer().Raw(`DELETE FROM repository_manifest WHERE repository_name = ? AND digest = ?`,
+		repository, digest).Exec()
+	return err
+}
+
+// ListUntaggedManifestsOfRepository returns the manifests pushed to the repository which are not tagged and
+// not referenced by any manifest list, the creation time of the returned manifests is the time pushed to the repository
+func ListUntaggedManifestsOfRepository(repository string) ([]*models.Blob, error) {
+	sql := referencedBlobsSQL + `
+SELECT b.id, b.digest, b.content_type, b.size, rm.creation_time, b.status, b.version, b.update_time
+FROM blob AS b JOIN repository_manifest AS rm ON b.digest = rm.digest
+WHERE rm.repository_name = ? AND b.digest NOT IN (SELECT digest FROM referenced) ORDER BY b.id`
+
+	var blobs []*models.Blob
+	if _, err := GetOrmer().Raw(sql, repository).QueryRows(&blobs); err != nil {
+		return nil, err
+	}
+
+	return blobs, nil
+}
@@ -0,0 +1,88 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"testing"
+
+	"github.com/docker/distribution/manifest/schema2"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepositoryManifest(t *testing.T) {
+	withProject(func(projectID int64, projectName string) {
+		manifest := digest.FromString(utils.GenerateRandomString()).String()
+		repo1 := projectName + "/photon"
+		repo2 := projectName + "/mysql"
+
+		for _, repo := range []string{repo1, repo2, repo1} {
+			_, err := AddManifestToRepository(projectID, repo, manifest)
+			require.Nil(t, err)
+		}
+		defer DeleteRepository(repo2)
+
+		rms, err := GetRepositoriesOfManifests(manifest)
+		require.Nil(t, err)
+		require.Len(t, rms, 2)
+		assert.Equal(t, repo1, rms[0].RepositoryName)
+		assert.Equal(t, repo2, rms[1].RepositoryName)
+
+		require.Nil(t, RemoveManifestFromRepository(repo1, manifest))
+		rms, err = GetRepositoriesOfManifests(manifest)
+		require.Nil(t, err)
+		require.Len(t, rms, 1)
+		assert.Equal(t, repo2, rms[0].RepositoryName)
+
+		require.Nil(t, DeleteRepository(repo2))
+		rms, err = GetRepositoriesOfManifests(manifest)
+		require.Nil(t, err)
+		assert.Len(t, rms, 0)
+	})
+}
+
+func TestListUntaggedManifestsOfRepository(t *testing.T) {
+	withProject(func(projectID int64, projectName string) {
+		repo := projectName + "/photon"
+		tagged, err := prepareImage(projectID, projectName, "photon", "latest", digest.FromString(utils.GenerateRandomString()).String())
+		require.Nil(t, err)
+		untagged := digest.FromString(utils.GenerateRandomString()).String()
+		_, _, err = GetOrCreateBlob(&models.Blob{Digest: untagged, ContentType: schema2.MediaTypeManifest, Size: 10})
+		require.Nil(t, err)
+		defer func() {
+			DeleteBlob(tagged)
+			DeleteBlob(untagged)
+			DeleteRepository(repo)
+		}()
+
+		for _, d := range []string{tagged, untagged} {
+			_, err := AddManifestToRepository(projectID, repo, d)
+			require.Nil(t, err)
+		}
+
+		manifests, err := ListUntaggedManifestsOfRepository(repo)
+		require.Nil(t, err)
+		require.Len(t, manifests, 1)
+		assert.Equal(t, untagged, manifests[0].Digest)
+		assert.Equal(t, int64(10), manifests[0].Size)
+
+		manifests, err = ListUntaggedManifestsOfRepository(projectName + "/mysql")
+		require.Nil(t, err)
+		assert.Len(t, manifests, 0)
+	})
+}
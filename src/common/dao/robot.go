@@ -84,6 +84,9 @@ func getRobotQuerySetter(query *models.RobotQuery) orm.QuerySeter {
 	if query.ProjectID != 0 {
 		qs = qs.Filter("ProjectID", query.ProjectID)
 	}
+	if len(query.Level) > 0 {
+		qs = qs.Filter("Level", query.Level)
+	}
 	return qs
 }
 
@@ -99,6 +102,14 @@ func UpdateRobot(robot *models.Robot) error {
 	return err
 }
 
+// UpdateRobotLastUsedAt updates the time when the token of robot is used last time
+func UpdateRobotLastUsedAt(id int64, lastUsedAt int64) error {
+	_, err := GetOrmer().QueryTable(&models.Robot{}).Filter("ID", id).Update(orm.Params{
+		"LastUsedAt": lastUsedAt,
+	})
+	return err
+}
+
 // DeleteRobot ...
 func DeleteRobot(id int64) error {
 	_, err := GetOrmer().QueryTable(&models.Robot{}).Filter("ID", id).Delete()
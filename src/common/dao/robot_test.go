@@ -16,6 +16,7 @@ package dao
 
 import (
 	"testing"
+	"time"
 
 	"github.com/goharbor/harbor/src/common/models"
 
@@ -157,3 +158,39 @@ func TestListAllRobot(t *testing.T) {
 	assert.Equal(t, 5, len(robots))
 
 }
+
+func TestListSystemRobots(t *testing.T) {
+	robot := &models.Robot{
+		Name:        "test-system",
+		Description: "test system description",
+		Level:       models.RobotLevelSystem,
+	}
+	id, err := AddRobot(robot)
+	require.Nil(t, err)
+	defer DeleteRobot(id)
+
+	robots, err := ListRobots(&models.RobotQuery{
+		Level: models.RobotLevelSystem,
+	})
+	require.Nil(t, err)
+	require.Equal(t, 1, len(robots))
+	assert.Equal(t, "test-system", robots[0].Name)
+	assert.Equal(t, int64(0), robots[0].ProjectID)
+}
+
+func TestUpdateRobotLastUsedAt(t *testing.T) {
+	robot := &models.Robot{
+		Name:      "test-last-used",
+		ProjectID: 1,
+	}
+	id, err := AddRobot(robot)
+	require.Nil(t, err)
+	defer DeleteRobot(id)
+
+	now := time.Now().Unix()
+	require.Nil(t, UpdateRobotLastUsedAt(id, now))
+
+	robot, err = GetRobotByID(id)
+	require.Nil(t, err)
+	assert.Equal(t, now, robot.LastUsedAt)
+}
@@ -15,12 +15,17 @@
 package dao
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/astaxie/beego/orm"
 	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/rbac"
 )
 
+// ErrRoleInUse is returned when deleting the custom role which is in use by the project members
+var ErrRoleInUse = errors.New("the role is in use by project members")
+
 // GetUserProjectRoles returns roles that the user has according to the project.
 func GetUserProjectRoles(userID int, projectID int64, entityType string) ([]models.Role, error) {
 
@@ -90,3 +95,111 @@ func GetRoleByID(id int) (*models.Role, error) {
 	}
 	return &role, nil
 }
+
+// ListRoles lists all the roles, including the predefined and custom ones
+func ListRoles() ([]*models.Role, error) {
+	roles := []*models.Role{}
+	if _, err := GetOrmer().QueryTable(&models.Role{}).OrderBy("RoleID").All(&roles); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// AddCustomRole adds the custom role with its permissions
+func AddCustomRole(role *models.Role) (int, error) {
+	role.RoleCode = models.CustomRoleCode
+	err := WithTransaction(func(o orm.Ormer) error {
+		id, err := o.Insert(role)
+		if err != nil {
+			return err
+		}
+		role.RoleID = int(id)
+		return addRolePermissions(o, role.RoleID, role.Permissions)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return role.RoleID, nil
+}
+
+// UpdateCustomRole updates the name and replaces the permissions of the custom role
+func UpdateCustomRole(role *models.Role) error {
+	return WithTransaction(func(o orm.Ormer) error {
+		if _, err := o.Update(role, "Name"); err != nil {
+			return err
+		}
+		if _, err := o.QueryTable(&models.RolePermission{}).Filter("RoleID", role.RoleID).Delete(); err != nil {
+			return err
+		}
+		return addRolePermissions(o, role.RoleID, role.Permissions)
+	})
+}
+
+// DeleteCustomRole deletes the custom role and its permissions, ErrRoleInUse is returned
+// if the role is in use by the project members
+func DeleteCustomRole(id int) error {
+	return WithTransaction(func(o orm.Ormer) error {
+		count, err := countRoleMembers(o, id)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrRoleInUse
+		}
+		if _, err := o.QueryTable(&models.RolePermission{}).Filter("RoleID", id).Delete(); err != nil {
+			return err
+		}
+		_, err = o.QueryTable(&models.Role{}).Filter("RoleID", id).
+			Filter("RoleCode", models.CustomRoleCode).Delete()
+		return err
+	})
+}
+
+// GetRolePermissions returns the permissions of the custom role, the resources are relative to the project
+func GetRolePermissions(roleID int) ([]*rbac.Policy, error) {
+	permissions := []*models.RolePermission{}
+	if _, err := GetOrmer().QueryTable(&models.RolePermission{}).Filter("RoleID", roleID).
+		OrderBy("ID").All(&permissions); err != nil {
+		return nil, err
+	}
+
+	policies := []*rbac.Policy{}
+	for _, permission := range permissions {
+		policies = append(policies, &rbac.Policy{
+			Resource: rbac.Resource(permission.Resource),
+			Action:   rbac.Action(permission.Action),
+		})
+	}
+	return policies, nil
+}
+
+// CountRoleMembers returns the count of the project members, including users and groups, with the role
+func CountRoleMembers(roleID int) (int64, error) {
+	return countRoleMembers(GetOrmer(), roleID)
+}
+
+func countRoleMembers(o orm.Ormer, roleID int) (int64, error) {
+	var count int64
+	if err := o.Raw(`select count(*) from project_member where role = ?`, roleID).
+		QueryRow(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func addRolePermissions(o orm.Ormer, roleID int, policies []*rbac.Policy) error {
+	if len(policies) == 0 {
+		return nil
+	}
+
+	permissions := []*models.RolePermission{}
+	for _, policy := range policies {
+		permissions = append(permissions, &models.RolePermission{
+			RoleID:   roleID,
+			Resource: policy.Resource.String(),
+			Action:   policy.Action.String(),
+		})
+	}
+	_, err := o.InsertMulti(len(permissions), permissions)
+	return err
+}
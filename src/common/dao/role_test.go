@@ -0,0 +1,76 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"testing"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomRoleDaoMethods(t *testing.T) {
+	id, err := AddCustomRole(&models.Role{
+		Name: "scanner",
+		Permissions: []*rbac.Policy{
+			{Resource: rbac.ResourceRepository, Action: rbac.ActionPush},
+			{Resource: rbac.ResourceRepositoryTagScanJob, Action: rbac.ActionCreate},
+		},
+	})
+	require.Nil(t, err)
+	defer DeleteCustomRole(id)
+
+	role, err := GetRoleByID(id)
+	require.Nil(t, err)
+	require.NotNil(t, role)
+	assert.True(t, role.IsCustom())
+
+	policies, err := GetRolePermissions(id)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(policies))
+	assert.Equal(t, rbac.ResourceRepository, policies[0].Resource)
+	assert.Equal(t, rbac.ActionPush, policies[0].Action)
+
+	role.Name = "webhook-manager"
+	role.Permissions = []*rbac.Policy{
+		{Resource: rbac.ResourceNotificationPolicy, Action: rbac.ActionCreate},
+	}
+	require.Nil(t, UpdateCustomRole(role))
+	role, err = GetRoleByID(id)
+	require.Nil(t, err)
+	require.NotNil(t, role)
+	assert.Equal(t, "webhook-manager", role.Name)
+	policies, err = GetRolePermissions(id)
+	require.Nil(t, err)
+	assert.Equal(t, 1, len(policies))
+
+	roles, err := ListRoles()
+	require.Nil(t, err)
+	assert.True(t, len(roles) > 1)
+
+	count, err := CountRoleMembers(id)
+	require.Nil(t, err)
+	assert.Equal(t, int64(0), count)
+
+	require.Nil(t, DeleteCustomRole(id))
+	role, err = GetRoleByID(id)
+	require.Nil(t, err)
+	assert.Nil(t, role)
+	policies, err = GetRolePermissions(id)
+	require.Nil(t, err)
+	assert.Equal(t, 0, len(policies))
+}
@@ -30,6 +30,9 @@ type Client interface {
 	GetJobLog(uuid string) ([]byte, error)
 	PostAction(uuid, action string) error
 	GetExecutions(uuid string) ([]job.Stats, error)
+	GetQueues() ([]*job.QueueStats, error)
+	GetRunningJobs() ([]*job.RunningJob, error)
+	GetLatency(window int64) ([]*job.LatencyStats, error)
 	// TODO Redirect joblog when we see there's memory issue.
 }
 
@@ -186,6 +189,36 @@ func (d *DefaultClient) PostAction(uuid, action string) error {
 	return nil
 }
 
+// GetQueues returns the queue status of all kinds of jobs
+func (d *DefaultClient) GetQueues() ([]*job.QueueStats, error) {
+	url := d.endpoint + "/api/v1/dashboard/queues"
+	queues := []*job.QueueStats{}
+	if err := d.client.Get(url, &queues); err != nil {
+		return nil, err
+	}
+	return queues, nil
+}
+
+// GetRunningJobs returns the jobs being executed by the workers of jobservice
+func (d *DefaultClient) GetRunningJobs() ([]*job.RunningJob, error) {
+	url := d.endpoint + "/api/v1/dashboard/running"
+	jobs := []*job.RunningJob{}
+	if err := d.client.Get(url, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// GetLatency returns the execution latency of all kinds of jobs finished in the window(seconds)
+func (d *DefaultClient) GetLatency(window int64) ([]*job.LatencyStats, error) {
+	url := fmt.Sprintf("%s/api/v1/dashboard/latency?window=%d", d.endpoint, window)
+	latency := []*job.LatencyStats{}
+	if err := d.client.Get(url, &latency); err != nil {
+		return nil, err
+	}
+	return latency, nil
+}
+
 func isStatusBehindError(err error) (string, bool) {
 	if err == nil {
 		return "", false
@@ -65,6 +65,33 @@ func TestPostAction(t *testing.T) {
 	assert.Nil(err2)
 }
 
+func TestGetQueues(t *testing.T) {
+	assert := assert.New(t)
+	queues, err := testClient.GetQueues()
+	assert.Nil(err)
+	assert.Len(queues, 1)
+	assert.Equal(int64(1), queues[0].Pending)
+}
+
+func TestGetRunningJobs(t *testing.T) {
+	assert := assert.New(t)
+	jobs, err := testClient.GetRunningJobs()
+	assert.Nil(err)
+	assert.Len(jobs, 1)
+	assert.Equal(ID, jobs[0].JobID)
+}
+
+func TestGetLatency(t *testing.T) {
+	assert := assert.New(t)
+	latency, err := testClient.GetLatency(3600)
+	assert.Nil(err)
+	assert.Len(latency, 1)
+	assert.Equal(int64(100), latency[0].P95)
+
+	_, err = testClient.GetLatency(60)
+	assert.NotNil(err)
+}
+
 func TestIsStatusBehindError(t *testing.T) {
 	// nil error
 	status, flag := isStatusBehindError(nil)
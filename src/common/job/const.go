@@ -7,6 +7,8 @@ const (
 	ImageScanAllJob = "IMAGE_SCAN_ALL"
 	// ImageGC the name of image garbage collection job in job service
 	ImageGC = "IMAGE_GC"
+	// AuditLogPurgeJob is the name of the job which purges the expired audit logs in job service
+	AuditLogPurgeJob = "AUDIT_LOG_PURGE"
 
 	// JobKindGeneric : Kind of generic job
 	JobKindGeneric = "Generic"
@@ -32,4 +34,6 @@ const (
 
 	// JobActionStop : the action to stop the job
 	JobActionStop = "stop"
+	// JobActionRetry : the action to retry the failed or stopped job
+	JobActionRetry = "retry"
 )
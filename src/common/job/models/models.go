@@ -24,6 +24,7 @@ type JobMetadata struct {
 	ScheduleDelay uint64 `json:"schedule_delay,omitempty"`
 	Cron          string `json:"cron_spec,omitempty"`
 	IsUnique      bool   `json:"unique"`
+	RequestID     string `json:"request_id,omitempty"` // The ID of the request which triggers the job
 }
 
 // JobStats keeps the result of job launching.
@@ -17,8 +17,9 @@ import (
 )
 
 const (
-	jobUUID    = "u-1234-5678-9012"
-	jobsPrefix = "/api/v1/jobs"
+	jobUUID         = "u-1234-5678-9012"
+	jobsPrefix      = "/api/v1/jobs"
+	dashboardPrefix = "/api/v1/dashboard"
 )
 
 func currPath() string {
@@ -119,5 +120,49 @@ func NewJobServiceServer() *httptest.Server {
 				}
 			}
 		})
+	mux.HandleFunc(fmt.Sprintf("%s/queues", dashboardPrefix),
+		func(rw http.ResponseWriter, req *http.Request) {
+			writeJSON(rw, []*job.QueueStats{
+				{
+					JobName: "REPLICATION",
+					Pending: 1,
+				},
+			})
+		})
+	mux.HandleFunc(fmt.Sprintf("%s/running", dashboardPrefix),
+		func(rw http.ResponseWriter, req *http.Request) {
+			writeJSON(rw, []*job.RunningJob{
+				{
+					WorkerID:  "worker-1",
+					JobID:     jobUUID,
+					JobName:   "REPLICATION",
+					StartedAt: time.Now().Unix(),
+				},
+			})
+		})
+	mux.HandleFunc(fmt.Sprintf("%s/latency", dashboardPrefix),
+		func(rw http.ResponseWriter, req *http.Request) {
+			if req.URL.Query().Get("window") != "3600" {
+				rw.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			writeJSON(rw, []*job.LatencyStats{
+				{
+					JobName: "REPLICATION",
+					Count:   1,
+					P50:     100,
+					P95:     100,
+					Max:     100,
+				},
+			})
+		})
 	return httptest.NewServer(mux)
 }
+
+func writeJSON(rw http.ResponseWriter, v interface{}) {
+	b, _ := json.Marshal(v)
+	rw.WriteHeader(http.StatusOK)
+	if _, err := rw.Write(b); err != nil {
+		panic(err)
+	}
+}
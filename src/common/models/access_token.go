@@ -0,0 +1,99 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/astaxie/beego/validation"
+	"github.com/goharbor/harbor/src/common/utils"
+)
+
+// AccessTokenTable is the name of table in DB that holds the personal access token
+const AccessTokenTable = "access_token"
+
+// AccessToken is the personal access token of user, which can be used instead of the password.
+// Only the hash of the token is stored.
+type AccessToken struct {
+	ID     int64  `orm:"pk;auto;column(id)" json:"id"`
+	UserID int    `orm:"column(user_id)" json:"user_id"`
+	Name   string `orm:"column(name)" json:"name"`
+	Hash   string `orm:"column(hash)" json:"-"`
+	// ReadOnly limits the token to pull the images and read the resources
+	ReadOnly bool `orm:"column(read_only)" json:"read_only"`
+	// Projects is the comma separated IDs of the projects which can be accessed by the token,
+	// it's empty if the token isn't limited to some projects
+	Projects     string    `orm:"column(projects)" json:"-"`
+	ProjectIDs   []int64   `orm:"-" json:"project_ids"`
+	ExpiresAt    int64     `orm:"column(expiresat)" json:"expires_at"`
+	LastUsedAt   int64     `orm:"column(last_used_at)" json:"last_used_at"`
+	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+}
+
+// TableName ...
+func (a *AccessToken) TableName() string {
+	return AccessTokenTable
+}
+
+// IsScoped returns whether the token has less permissions than its user
+func (a *AccessToken) IsScoped() bool {
+	return a.ReadOnly || len(a.ProjectIDs) > 0
+}
+
+// IsExpired returns whether the token is expired, the token never expires if the expiration is 0
+func (a *AccessToken) IsExpired() bool {
+	return a.ExpiresAt > 0 && a.ExpiresAt <= time.Now().Unix()
+}
+
+// HashAccessToken returns the hash of the personal access token
+func HashAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// AccessTokenReq is the request to create the personal access token
+type AccessTokenReq struct {
+	Name       string  `json:"name"`
+	ReadOnly   bool    `json:"read_only"`
+	ProjectIDs []int64 `json:"project_ids"`
+	// ExpiresAt is the expiration in seconds, the token never expires if it's 0
+	ExpiresAt int64 `json:"expires_at"`
+}
+
+// Valid ...
+func (ar *AccessTokenReq) Valid(v *validation.Validation) {
+	if utils.IsIllegalLength(ar.Name, 1, 255) {
+		v.SetError("name", "token name with illegal length")
+	}
+	if ar.ExpiresAt != 0 && ar.ExpiresAt <= time.Now().Unix() {
+		v.SetError("expires_at", "expiration of token must be in the future")
+	}
+	for _, id := range ar.ProjectIDs {
+		if id <= 0 {
+			v.SetError("project_ids", "invalid project ID")
+			break
+		}
+	}
+}
+
+// AccessTokenRep is the response of creating the personal access token,
+// the token is only returned once
+type AccessTokenRep struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Token string `json:"token"`
+}
@@ -4,6 +4,14 @@ import (
 	"time"
 )
 
+const (
+	// ArtifactKindImage is the kind of the artifact for image manifest
+	ArtifactKindImage = "Docker-Image"
+	// ArtifactKindImageIndex is the kind of the artifact for manifest list or OCI image index,
+	// the manifests referenced by it are recorded in the artifact_blob table
+	ArtifactKindImageIndex = "Docker-Image-Index"
+)
+
 // Artifact holds the details of a artifact.
 type Artifact struct {
 	ID           int64     `orm:"pk;auto;column(id)" json:"id"`
@@ -0,0 +1,72 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"time"
+)
+
+const (
+	// AuditLogTable is the name of table in DB that holds the audit logs
+	AuditLogTable = "audit_log"
+
+	// AuditLogOperationCreate ...
+	AuditLogOperationCreate = "create"
+	// AuditLogOperationUpdate ...
+	AuditLogOperationUpdate = "update"
+	// AuditLogOperationDelete ...
+	AuditLogOperationDelete = "delete"
+	// AuditLogOperationLogin ...
+	AuditLogOperationLogin = "login"
+
+	// AuditLogResultSuccess ...
+	AuditLogResultSuccess = "success"
+	// AuditLogResultFailure ...
+	AuditLogResultFailure = "failure"
+)
+
+// AuditLog records the mutating API call or the login, which is used to audit
+// the operations done by the users to the system
+type AuditLog struct {
+	ID           int64     `orm:"pk;auto;column(id)" json:"id"`
+	Username     string    `orm:"column(username)" json:"username"`
+	SourceIP     string    `orm:"column(source_ip)" json:"source_ip"`
+	Operation    string    `orm:"column(operation)" json:"operation"`
+	ResourceType string    `orm:"column(resource_type)" json:"resource_type"`
+	Resource     string    `orm:"column(resource)" json:"resource"`
+	Method       string    `orm:"column(method)" json:"method"`
+	StatusCode   int       `orm:"column(status_code)" json:"status_code"`
+	Result       string    `orm:"column(result)" json:"result"`
+	Summary      string    `orm:"column(summary)" json:"summary"`
+	OpTime       time.Time `orm:"column(op_time)" json:"op_time"`
+}
+
+// TableName ...
+func (a *AuditLog) TableName() string {
+	return AuditLogTable
+}
+
+// AuditLogQuery is used to set query conditions when listing audit logs
+type AuditLogQuery struct {
+	Username     string      // the operator's username of the log
+	SourceIP     string      // the IP from which the request is sent
+	Operation    string      // operation
+	ResourceType string      // the type of the resource, e.g. "project"
+	Resource     string      // the resource, fuzzy matching
+	Result       string      // success or failure
+	BeginTime    *time.Time  // the time after which the operation is done
+	EndTime      *time.Time  // the time before which the operation is done
+	Pagination   *Pagination // pagination information
+}
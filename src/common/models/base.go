@@ -23,7 +23,9 @@ func init() {
 		new(User),
 		new(Project),
 		new(Role),
+		new(RolePermission),
 		new(AccessLog),
+		new(AuditLog),
 		new(ScanJob),
 		new(RepoRecord),
 		new(ImgScanOverview),
@@ -34,13 +36,17 @@ func init() {
 		new(ResourceLabel),
 		new(UserGroup),
 		new(AdminJob),
+		new(GCReport),
 		new(JobLog),
 		new(Robot),
 		new(OIDCUser),
+		new(AccessToken),
 		new(NotificationPolicy),
 		new(NotificationJob),
+		new(NotificationDelivery),
 		new(Blob),
 		new(ProjectBlob),
+		new(RepositoryManifest),
 		new(Artifact),
 		new(ArtifactAndBlob),
 		new(CVEWhitelist),
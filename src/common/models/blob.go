@@ -3,7 +3,21 @@ package models
 import (
 	"time"
 
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/distribution/manifest/schema1"
 	"github.com/docker/distribution/manifest/schema2"
+	"github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// BlobStatusNone is the status of the blob in use or not handled by GC
+	BlobStatusNone = "none"
+	// BlobStatusDelete is the status of the blob marked as deletion candidate by GC
+	BlobStatusDelete = "delete"
+	// BlobStatusDeleting is the status of the blob being deleted by GC
+	BlobStatusDeleting = "deleting"
+	// BlobStatusDeleteFailed is the status of the blob failed to be deleted by GC
+	BlobStatusDeleteFailed = "deletefailed"
 )
 
 // Blob holds the details of a blob.
@@ -13,6 +27,9 @@ type Blob struct {
 	ContentType  string    `orm:"column(content_type)" json:"content_type"`
 	Size         int64     `orm:"column(size)" json:"size"`
 	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+	Status       string    `orm:"column(status)" json:"status"`
+	Version      int64     `orm:"column(version)" json:"version"`
+	UpdateTime   time.Time `orm:"column(update_time);auto_now" json:"update_time"`
 }
 
 // TableName ...
@@ -25,10 +42,21 @@ func (b *Blob) IsForeignLayer() bool {
 	return b.ContentType == schema2.MediaTypeForeignLayer
 }
 
+// IsManifest returns true if the blob is a manifest, manifest list or image index
+func (b *Blob) IsManifest() bool {
+	switch b.ContentType {
+	case schema1.MediaTypeManifest, schema1.MediaTypeSignedManifest, schema2.MediaTypeManifest,
+		manifestlist.MediaTypeManifestList, v1.MediaTypeImageManifest, v1.MediaTypeImageIndex:
+		return true
+	}
+	return false
+}
+
 // BlobQuery ...
 type BlobQuery struct {
 	Digest      string
 	ContentType string
 	Digests     []string
+	Status      string
 	Pagination
 }
@@ -89,8 +89,10 @@ type OIDCSetting struct {
 
 // QuotaSetting wraps the settings for Quota
 type QuotaSetting struct {
-	CountPerProject   int64 `json:"count_per_project"`
-	StoragePerProject int64 `json:"storage_per_project"`
+	CountPerProject           int64 `json:"count_per_project"`
+	StoragePerProject         int64 `json:"storage_per_project"`
+	RepositoryCountPerProject int64 `json:"repository_count_per_project"`
+	ChartStoragePerProject    int64 `json:"chart_storage_per_project"`
 }
 
 // ConfigEntry ...
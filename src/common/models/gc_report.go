@@ -0,0 +1,62 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"time"
+)
+
+// GCReport is the summary of a GC execution, for the dry run it's the estimation of the GC
+type GCReport struct {
+	ID               int64               `orm:"pk;auto;column(id)" json:"id"`
+	JobUUID          string              `orm:"column(job_uuid)" json:"-"`
+	UpstreamJobUUID  string              `orm:"column(upstream_job_uuid)" json:"-"`
+	DryRun           bool                `orm:"column(dry_run)" json:"dry_run"`
+	NonBlocking      bool                `orm:"column(non_blocking)" json:"non_blocking"`
+	BlobsDeleted     int64               `orm:"column(blobs_deleted)" json:"blobs_deleted"`
+	ManifestsDeleted int64               `orm:"column(manifests_deleted)" json:"manifests_deleted"`
+	BytesFreed       int64               `orm:"column(bytes_freed)" json:"bytes_freed"`
+	Duration         int64               `orm:"column(duration)" json:"duration"`
+	StartTime        time.Time           `orm:"column(start_time)" json:"start_time"`
+	EndTime          time.Time           `orm:"column(end_time)" json:"end_time"`
+	ProjectsJSON     string              `orm:"column(projects)" json:"-"`
+	CandidatesJSON   string              `orm:"column(candidates)" json:"-"`
+	CreationTime     time.Time           `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+	Projects         []*GCProjectSummary `orm:"-" json:"projects"`
+	Candidates       []*GCCandidate      `orm:"-" json:"candidates,omitempty"`
+}
+
+// TableName ...
+func (r *GCReport) TableName() string {
+	return "gc_report"
+}
+
+// GCProjectSummary is the space reclaimed from the project by GC,
+// the blob shared by several projects is counted in each of them
+type GCProjectSummary struct {
+	ProjectID        int64  `json:"project_id"`
+	ProjectName      string `json:"project_name"`
+	BlobsDeleted     int64  `json:"blobs_deleted"`
+	ManifestsDeleted int64  `json:"manifests_deleted"`
+	BytesFreed       int64  `json:"bytes_freed"`
+}
+
+// GCCandidate is the blob or manifest which would be deleted by GC in the dry run
+type GCCandidate struct {
+	Digest      string `json:"digest"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	Manifest    bool   `json:"manifest"`
+}
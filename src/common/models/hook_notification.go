@@ -10,9 +10,20 @@ const (
 	NotificationPolicyTable = "notification_policy"
 	// NotificationJobTable is table name for notification job
 	NotificationJobTable = "notification_job"
+	// NotificationDeliveryTable is table name for the deliveries of notification job
+	NotificationDeliveryTable = "notification_delivery"
+
+	// PayloadFormatNative is the default payload format of http target
+	PayloadFormatNative = "native"
+	// PayloadFormatCloudEvents is the payload format of CloudEvents 1.0 in structured mode
+	PayloadFormatCloudEvents = "cloudevents"
+	// PayloadFormatSlack is the payload format compatible with Slack incoming webhook
+	PayloadFormatSlack = "slack"
 )
 
 // NotificationPolicy is the model for a notification policy.
+// The secret is used to sign the payload sent to http targets, it's encrypted in database
+// and never returned by API.
 type NotificationPolicy struct {
 	ID           int64         `orm:"pk;auto;column(id)" json:"id"`
 	Name         string        `orm:"column(name)" json:"name"`
@@ -22,6 +33,8 @@ type NotificationPolicy struct {
 	Targets      []EventTarget `orm:"-" json:"targets"`
 	EventTypesDB string        `orm:"column(event_types)" json:"-"`
 	EventTypes   []string      `orm:"-" json:"event_types"`
+	SecretDB     string        `orm:"column(secret)" json:"-"`
+	Secret       string        `orm:"-" json:"secret,omitempty"`
 	Creator      string        `orm:"column(creator)" json:"creator"`
 	CreationTime time.Time     `orm:"column(creation_time);auto_now_add" json:"creation_time"`
 	UpdateTime   time.Time     `orm:"column(update_time);auto_now_add" json:"update_time"`
@@ -108,4 +121,27 @@ type EventTarget struct {
 	Address        string `json:"address"`
 	AuthHeader     string `json:"auth_header,omitempty"`
 	SkipCertVerify bool   `json:"skip_cert_verify"`
+	PayloadFormat  string `json:"payload_format,omitempty"`
+	// Subject and Template are used to render the event into email when the type of target is email,
+	// the address is a comma separated list of the recipients in this case.
+	// The default templates are used if they're not set
+	Subject  string `json:"subject,omitempty"`
+	Template string `json:"template,omitempty"`
+}
+
+// NotificationDelivery records one attempt of delivering the notification job
+type NotificationDelivery struct {
+	ID           int64     `orm:"pk;auto;column(id)" json:"id"`
+	JobID        int64     `orm:"column(job_id)" json:"job_id"`
+	Address      string    `orm:"column(address)" json:"address"`
+	StatusCode   int       `orm:"column(status_code)" json:"status_code"`
+	ResponseBody string    `orm:"column(response_body)" json:"response_body"`
+	Latency      int64     `orm:"column(latency)" json:"latency"`
+	Error        string    `orm:"column(error)" json:"error,omitempty"`
+	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+}
+
+// TableName set table name for ORM.
+func (n *NotificationDelivery) TableName() string {
+	return NotificationDeliveryTable
 }
@@ -26,6 +26,10 @@ const (
 	ProMetaSeverity             = "severity"
 	ProMetaAutoScan             = "auto_scan"
 	ProMetaReuseSysCVEWhitelist = "reuse_sys_cve_whitelist"
+	ProMetaProxyCacheRegistryID = "proxy_cache_registry_id" // the ID of the upstream registry the proxy cache project bound to
+	ProMetaProxyCacheExpiry     = "proxy_cache_expiry"      // the expiry of cached tags in hours
+	ProMetaRetentionID          = "retention_id"            // the ID of the retention policy of the project
+	ProMetaRetentionOptOut      = "retention_opt_out"       // opt out the system level retention policy
 	SeverityNone                = "negligible"
 	SeverityLow                 = "low"
 	SeverityMedium              = "medium"
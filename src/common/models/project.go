@@ -15,6 +15,7 @@
 package models
 
 import (
+	"strconv"
 	"strings"
 	"time"
 
@@ -28,6 +29,8 @@ const (
 	ProjectPublic = "public"
 	// ProjectPrivate means project is private
 	ProjectPrivate = "private"
+	// DefaultProxyCacheExpiry is the expiry of the tags cached in proxy cache project if not specified
+	DefaultProxyCacheExpiry = 24 * time.Hour
 )
 
 // Project holds the details of a project.
@@ -119,6 +122,47 @@ func (p *Project) AutoScan() bool {
 	return isTrue(auto)
 }
 
+// ProxyCacheRegistryID returns the ID of the upstream registry if the project is a proxy cache project,
+// otherwise 0 is returned
+func (p *Project) ProxyCacheRegistryID() int64 {
+	value, exist := p.GetMetadata(ProMetaProxyCacheRegistryID)
+	if !exist {
+		return 0
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// IsProxyCache returns true if the project is a proxy cache project
+func (p *Project) IsProxyCache() bool {
+	return p.ProxyCacheRegistryID() > 0
+}
+
+// ProxyCacheExpiry returns the expiry of the tags cached in the proxy cache project
+func (p *Project) ProxyCacheExpiry() time.Duration {
+	value, exist := p.GetMetadata(ProMetaProxyCacheExpiry)
+	if !exist {
+		return DefaultProxyCacheExpiry
+	}
+	hours, err := strconv.Atoi(value)
+	if err != nil || hours <= 0 {
+		return DefaultProxyCacheExpiry
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// RetentionOptOut returns true if the project opts out the system level retention policy
+func (p *Project) RetentionOptOut() bool {
+	value, exist := p.GetMetadata(ProMetaRetentionOptOut)
+	if !exist {
+		return false
+	}
+	return isTrue(value)
+}
+
 func isTrue(value string) bool {
 	return strings.ToLower(value) == "true" ||
 		strings.ToLower(value) == "1"
@@ -177,8 +221,10 @@ type ProjectRequest struct {
 	Metadata     map[string]string `json:"metadata"`
 	CVEWhitelist CVEWhitelist      `json:"cve_whitelist"`
 
-	CountLimit   *int64 `json:"count_limit,omitempty"`
-	StorageLimit *int64 `json:"storage_limit,omitempty"`
+	CountLimit           *int64 `json:"count_limit,omitempty"`
+	StorageLimit         *int64 `json:"storage_limit,omitempty"`
+	RepositoryCountLimit *int64 `json:"repository_count_limit,omitempty"`
+	ChartStorageLimit    *int64 `json:"chart_storage_limit,omitempty"`
 }
 
 // ProjectQueryResult ...
@@ -201,9 +247,21 @@ type ProjectSummary struct {
 	MasterCount       int64 `json:"master_count"`
 	DeveloperCount    int64 `json:"developer_count"`
 	GuestCount        int64 `json:"guest_count"`
+	// CustomRoleCount is the total of members of the custom roles, the key is the ID of role
+	CustomRoleCount map[int]int64 `json:"custom_role_count,omitempty"`
 
 	Quota struct {
 		Hard types.ResourceList `json:"hard"`
 		Used types.ResourceList `json:"used"`
 	} `json:"quota"`
+
+	ProxyCache *ProxyCacheSummary `json:"proxy_cache,omitempty"`
+}
+
+// ProxyCacheSummary holds the statistics of proxy cache project
+type ProxyCacheSummary struct {
+	RegistryID int64 `json:"registry_id"`
+	CachedTags int64 `json:"cached_tags"`
+	Hits       int64 `json:"hits"`
+	Misses     int64 `json:"misses"`
 }
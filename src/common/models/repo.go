@@ -73,6 +73,19 @@ type TagDetail struct {
 	Author        string    `json:"author"`
 	Created       time.Time `json:"created"`
 	Config        *TagCfg   `json:"config"`
+	// Platforms is only populated for manifest list and OCI image index
+	Platforms []*PlatformDetail `json:"platforms,omitempty"`
+}
+
+// PlatformDetail holds the information of the platform specific manifest referenced by manifest list or OCI image index
+type PlatformDetail struct {
+	Digest       string    `json:"digest"`
+	Size         int64     `json:"size"`
+	Architecture string    `json:"architecture"`
+	OS           string    `json:"os"`
+	OSVersion    string    `json:"os.version,omitempty"`
+	Variant      string    `json:"variant,omitempty"`
+	Created      time.Time `json:"created"`
 }
 
 // TagCfg ...
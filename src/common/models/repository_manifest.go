@@ -0,0 +1,34 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"time"
+)
+
+// RepositoryManifest holds the relationship between repository and the manifest pushed to it,
+// it's kept after the manifest is untagged so that the repositories of the untagged manifests can be found
+type RepositoryManifest struct {
+	ID             int64     `orm:"pk;auto;column(id)" json:"id"`
+	ProjectID      int64     `orm:"column(project_id)" json:"project_id"`
+	RepositoryName string    `orm:"column(repository_name)" json:"repository_name"`
+	Digest         string    `orm:"column(digest)" json:"digest"`
+	CreationTime   time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
+}
+
+// TableName ...
+func (*RepositoryManifest) TableName() string {
+	return "repository_manifest"
+}
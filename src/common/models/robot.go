@@ -21,25 +21,57 @@ import (
 	"time"
 )
 
-// RobotTable is the name of table in DB that holds the robot object
-const RobotTable = "robot"
+const (
+	// RobotTable is the name of table in DB that holds the robot object
+	RobotTable = "robot"
+	// RobotLevelProject is the level of the robot account which can only access the resources of its project
+	RobotLevelProject = "project"
+	// RobotLevelSystem is the level of the robot account created by system admin,
+	// which can access the resources of multiple projects
+	RobotLevelSystem = "system"
+	// RobotAllProjects is the namespace of the robot permission which matches all the projects
+	RobotAllProjects = "*"
+)
 
 // Robot holds the details of a robot.
 type Robot struct {
-	ID           int64     `orm:"pk;auto;column(id)" json:"id"`
-	Name         string    `orm:"column(name)" json:"name"`
-	Description  string    `orm:"column(description)" json:"description"`
-	ProjectID    int64     `orm:"column(project_id)" json:"project_id"`
-	ExpiresAt    int64     `orm:"column(expiresat)" json:"expires_at"`
-	Disabled     bool      `orm:"column(disabled)" json:"disabled"`
+	ID          int64  `orm:"pk;auto;column(id)" json:"id"`
+	Name        string `orm:"column(name)" json:"name"`
+	Description string `orm:"column(description)" json:"description"`
+	ProjectID   int64  `orm:"column(project_id)" json:"project_id"`
+	Level       string `orm:"column(level)" json:"level"`
+	ExpiresAt   int64  `orm:"column(expiresat)" json:"expires_at"`
+	// Duration is the duration of the token in minutes, the global robot token duration is used if it's 0,
+	// and the token never expires if it's -1
+	Duration int64 `orm:"column(duration)" json:"duration"`
+	Disabled bool  `orm:"column(disabled)" json:"disabled"`
+	// Secret is the ID of the current token, the tokens with other IDs are rejected
+	Secret string `orm:"column(secret)" json:"-"`
+	// PreviousSecret is the ID of the token before the secret is regenerated,
+	// it keeps valid until PreviousSecretExpiresAt
+	PreviousSecret          string `orm:"column(previous_secret)" json:"-"`
+	PreviousSecretExpiresAt int64  `orm:"column(previous_secret_expiresat)" json:"-"`
+	// Access is the JSON of the policies carried by the token, it's used to regenerate the token
+	Access       string    `orm:"column(access)" json:"-"`
+	LastUsedAt   int64     `orm:"column(last_used_at)" json:"last_used_at"`
 	CreationTime time.Time `orm:"column(creation_time);auto_now_add" json:"creation_time"`
 	UpdateTime   time.Time `orm:"column(update_time);auto_now" json:"update_time"`
 }
 
+// MatchSecret checks whether the secret is the current one of the robot,
+// or the previous one which is still in the overlap window after the secret is regenerated
+func (r *Robot) MatchSecret(secret string) bool {
+	if secret == r.Secret {
+		return true
+	}
+	return secret == r.PreviousSecret && time.Now().Unix() < r.PreviousSecretExpiresAt
+}
+
 // RobotQuery ...
 type RobotQuery struct {
 	Name           string
 	ProjectID      int64
+	Level          string
 	Disabled       bool
 	FuzzyMatchName bool
 	Pagination
@@ -50,7 +82,18 @@ type RobotReq struct {
 	Name        string         `json:"name"`
 	Description string         `json:"description"`
 	Disabled    bool           `json:"disabled"`
+	Duration    int64          `json:"duration"`
 	Access      []*rbac.Policy `json:"access"`
+	// Permissions is only for the system level robot
+	Permissions []*RobotPermission `json:"permissions"`
+}
+
+// RobotPermission holds the access of the system level robot to the project,
+// the resources of the access are relative to the project, e.g. "repository"
+type RobotPermission struct {
+	// Namespace is the name of the project, or "*" for all the projects
+	Namespace string         `json:"namespace"`
+	Access    []*rbac.Policy `json:"access"`
 }
 
 // Valid ...
@@ -61,6 +104,27 @@ func (rq *RobotReq) Valid(v *validation.Validation) {
 	if utils.IsContainIllegalChar(rq.Name, []string{",", "~", "#", "$", "%"}) {
 		v.SetError("name", "robot name contains illegal characters")
 	}
+	if rq.Duration < -1 {
+		v.SetError("duration", "robot token duration must be -1 or non-negative")
+	}
+}
+
+// RobotSecretReq is the request to regenerate the secret of robot
+type RobotSecretReq struct {
+	// Duration is the duration of the new token in minutes, the one of the robot is used if it's 0
+	Duration int64 `json:"duration"`
+	// Overlap is the minutes in which the previous token keeps valid
+	Overlap int64 `json:"overlap"`
+}
+
+// Valid ...
+func (rs *RobotSecretReq) Valid(v *validation.Validation) {
+	if rs.Duration < -1 {
+		v.SetError("duration", "robot token duration must be -1 or non-negative")
+	}
+	if rs.Overlap < 0 {
+		v.SetError("overlap", "overlap of the previous token must be non-negative")
+	}
 }
 
 // RobotRep ...
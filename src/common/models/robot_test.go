@@ -0,0 +1,43 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRobotMatchSecret(t *testing.T) {
+	now := time.Now().Unix()
+	cases := []struct {
+		robot  *Robot
+		secret string
+		match  bool
+	}{
+		// the robot created by the previous version has no secret
+		{&Robot{}, "", true},
+		{&Robot{Secret: "new"}, "new", true},
+		{&Robot{Secret: "new"}, "", false},
+		{&Robot{Secret: "new", PreviousSecret: "old", PreviousSecretExpiresAt: now + 60}, "old", true},
+		{&Robot{Secret: "new", PreviousSecret: "old", PreviousSecretExpiresAt: now - 60}, "old", false},
+		{&Robot{Secret: "new", PreviousSecret: "old"}, "other", false},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.match, c.robot.MatchSecret(c.secret))
+	}
+}
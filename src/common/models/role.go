@@ -14,6 +14,12 @@
 
 package models
 
+import (
+	"github.com/astaxie/beego/validation"
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/common/utils"
+)
+
 const (
 	// PROJECTADMIN project administrator
 	PROJECTADMIN = 1
@@ -21,6 +27,11 @@ const (
 	DEVELOPER = 2
 	// GUEST guest
 	GUEST = 3
+
+	// CustomRoleCode is the role code of the roles defined by the system admin
+	CustomRoleCode = "CUSTOM"
+	// RolePermissionTable is the name of table in DB that holds the permissions of custom roles
+	RolePermissionTable = "role_permission"
 )
 
 // Role holds the details of a role.
@@ -30,4 +41,41 @@ type Role struct {
 	Name     string `orm:"column(name)" json:"role_name"`
 
 	RoleMask int `orm:"column(role_mask)" json:"role_mask"`
+
+	// Permissions are only available for the custom roles, they are relative to the project
+	Permissions []*rbac.Policy `orm:"-" json:"permissions,omitempty"`
+}
+
+// IsCustom returns whether the role is defined by the system admin
+func (r *Role) IsCustom() bool {
+	return r.RoleCode == CustomRoleCode
+}
+
+// RolePermission holds one permission of the custom role
+type RolePermission struct {
+	ID       int64  `orm:"pk;auto;column(id)"`
+	RoleID   int    `orm:"column(role_id)"`
+	Resource string `orm:"column(resource)"`
+	Action   string `orm:"column(action)"`
+}
+
+// TableName ...
+func (rp *RolePermission) TableName() string {
+	return RolePermissionTable
+}
+
+// RoleReq is the request to create or update the custom role
+type RoleReq struct {
+	Name        string         `json:"role_name"`
+	Permissions []*rbac.Policy `json:"permissions"`
+}
+
+// Valid ...
+func (rr *RoleReq) Valid(v *validation.Validation) {
+	if utils.IsIllegalLength(rr.Name, 1, 255) {
+		v.SetError("role_name", "role name with illegal length")
+	}
+	if len(rr.Permissions) == 0 {
+		v.SetError("permissions", "permissions of the role can't be empty")
+	}
 }
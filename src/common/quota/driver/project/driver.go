@@ -101,8 +101,10 @@ type driver struct {
 
 func (d *driver) HardLimits() types.ResourceList {
 	return types.ResourceList{
-		types.ResourceCount:   d.cfg.Get(common.CountPerProject).GetInt64(),
-		types.ResourceStorage: d.cfg.Get(common.StoragePerProject).GetInt64(),
+		types.ResourceCount:           d.cfg.Get(common.CountPerProject).GetInt64(),
+		types.ResourceStorage:         d.cfg.Get(common.StoragePerProject).GetInt64(),
+		types.ResourceRepositoryCount: d.cfg.Get(common.RepositoryCountPerProject).GetInt64(),
+		types.ResourceChartStorage:    d.cfg.Get(common.ChartStoragePerProject).GetInt64(),
 	}
 }
 
@@ -128,8 +130,10 @@ func (d *driver) Load(key string) (dr.RefObject, error) {
 
 func (d *driver) Validate(hardLimits types.ResourceList) error {
 	resources := map[types.ResourceName]bool{
-		types.ResourceCount:   true,
-		types.ResourceStorage: true,
+		types.ResourceCount:           true,
+		types.ResourceStorage:         true,
+		types.ResourceRepositoryCount: true,
+		types.ResourceChartStorage:    true,
 	}
 
 	for resource, value := range hardLimits {
@@ -31,7 +31,12 @@ type DriverSuite struct {
 func (suite *DriverSuite) TestHardLimits() {
 	driver := newDriver()
 
-	suite.Equal(types.ResourceList{types.ResourceCount: -1, types.ResourceStorage: -1}, driver.HardLimits())
+	suite.Equal(types.ResourceList{
+		types.ResourceCount:           -1,
+		types.ResourceStorage:         -1,
+		types.ResourceRepositoryCount: -1,
+		types.ResourceChartStorage:    -1,
+	}, driver.HardLimits())
 }
 
 func (suite *DriverSuite) TestLoad() {
@@ -59,7 +64,13 @@ func (suite *DriverSuite) TestLoad() {
 func (suite *DriverSuite) TestValidate() {
 	driver := newDriver()
 
-	suite.Nil(driver.Validate(types.ResourceList{types.ResourceCount: 1, types.ResourceStorage: 1024}))
+	suite.Nil(driver.Validate(types.ResourceList{
+		types.ResourceCount:           1,
+		types.ResourceStorage:         1024,
+		types.ResourceRepositoryCount: 1,
+		types.ResourceChartStorage:    -1,
+	}))
+	suite.Error(driver.Validate(types.ResourceList{types.ResourceCount: 1, types.ResourceStorage: 1024}))
 	suite.Error(driver.Validate(types.ResourceList{}))
 	suite.Error(driver.Validate(types.ResourceList{types.ResourceCount: 1}))
 	suite.Error(driver.Validate(types.ResourceList{types.ResourceCount: 1, types.ResourceStorage: 0}))
@@ -253,6 +253,36 @@ func (m *Manager) EnsureQuota(usages types.ResourceList) error {
 	return nil
 }
 
+// Reference returns the reference and reference id of the quota
+func (m *Manager) Reference() (string, string) {
+	return m.reference, m.referenceID
+}
+
+// GetQuota returns the hard limits and the usage of the quota
+func (m *Manager) GetQuota() (types.ResourceList, types.ResourceList, error) {
+	o := dao.GetOrmer()
+
+	quota := &models.Quota{Reference: m.reference, ReferenceID: m.referenceID}
+	if err := o.Read(quota, "reference", "reference_id"); err != nil {
+		return nil, nil, err
+	}
+	hardLimits, err := types.NewResourceList(quota.Hard)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	usage := &models.QuotaUsage{Reference: m.reference, ReferenceID: m.referenceID}
+	if err := o.Read(usage, "reference", "reference_id"); err != nil {
+		return nil, nil, err
+	}
+	used, err := types.NewResourceList(usage.Used)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return hardLimits, used, nil
+}
+
 // AddResources add resources to usage
 func (m *Manager) AddResources(resources types.ResourceList) error {
 	return dao.WithTransaction(func(o orm.Ormer) error {
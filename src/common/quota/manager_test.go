@@ -150,6 +150,22 @@ func (suite *ManagerSuite) TestSetResourceUsage() {
 	}
 }
 
+func (suite *ManagerSuite) TestGetQuota() {
+	mgr := suite.quotaManager()
+
+	used := types.ResourceList{types.ResourceCount: 1, types.ResourceStorage: 100}
+	mgr.NewQuota(hardLimits, used)
+
+	hard, usage, err := mgr.GetQuota()
+	if suite.Nil(err) {
+		suite.Equal(hardLimits, hard)
+		suite.Equal(used, usage)
+	}
+
+	_, _, err = suite.quotaManager("2").GetQuota()
+	suite.Error(err)
+}
+
 func (suite *ManagerSuite) TestEnsureQuota() {
 	// non-existent
 	nonExistRefID := "3"
@@ -31,7 +31,8 @@ func TestValidate(t *testing.T) {
 		args    args
 		wantErr bool
 	}{
-		{"valid", args{"project", types.ResourceList{types.ResourceCount: 1, types.ResourceStorage: 1}}, false},
+		{"valid", args{"project", types.ResourceList{types.ResourceCount: 1, types.ResourceStorage: 1, types.ResourceRepositoryCount: 1, types.ResourceChartStorage: 1}}, false},
+		{"missing", args{"project", types.ResourceList{types.ResourceCount: 1, types.ResourceStorage: 1}}, true},
 		{"invalid", args{"project", types.ResourceList{types.ResourceCount: 1, types.ResourceStorage: 0}}, true},
 		{"not support", args{"not support", types.ResourceList{types.ResourceCount: 1}}, true},
 	}
@@ -23,6 +23,10 @@ var (
 	ResourceCount = types.ResourceCount
 	// ResourceStorage alias types.ResourceStorage
 	ResourceStorage = types.ResourceStorage
+	// ResourceRepositoryCount alias types.ResourceRepositoryCount
+	ResourceRepositoryCount = types.ResourceRepositoryCount
+	// ResourceChartStorage alias types.ResourceChartStorage
+	ResourceChartStorage = types.ResourceChartStorage
 )
 
 // ResourceName alias types.ResourceName
@@ -65,9 +65,14 @@ func (v *visitor) GetRoles() []rbac.Role {
 	}
 
 	roles := []rbac.Role{}
+	cache, _ := v.ctx.(customRolePoliciesCache)
 
 	for _, roleID := range v.projectRoles {
-		roles = append(roles, &visitorRole{roleID: roleID, namespace: v.namespace})
+		if IsPredefinedRole(roleID) {
+			roles = append(roles, &visitorRole{roleID: roleID, namespace: v.namespace})
+		} else {
+			roles = append(roles, &customRole{roleID: roleID, namespace: v.namespace, cache: cache})
+		}
 	}
 
 	return roles
@@ -15,11 +15,18 @@
 package project
 
 import (
+	"fmt"
+
 	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/dao"
 	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/common/utils/log"
 )
 
 var (
+	// getCustomRolePolicies returns the policies of the custom role, which are relative to the project
+	getCustomRolePolicies = dao.GetRolePermissions
+
 	rolePoliciesMap = map[string][]*rbac.Policy{
 		"projectAdmin": {
 			{Resource: rbac.ResourceSelf, Action: rbac.ActionRead},
@@ -334,3 +341,71 @@ func (role *visitorRole) GetPolicies() []*rbac.Policy {
 
 	return policies
 }
+
+// IsPredefinedRole returns whether the role is one of the roles predefined by the system
+func IsPredefinedRole(roleID int) bool {
+	switch roleID {
+	case common.RoleProjectAdmin, common.RoleMaster, common.RoleDeveloper, common.RoleGuest:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValidPolicy returns whether the policy, which is relative to the project, can be granted to the custom role
+func IsValidPolicy(policy *rbac.Policy) bool {
+	for _, p := range allPolicies {
+		if p.Resource == policy.Resource && p.Action == policy.Action {
+			return true
+		}
+	}
+	return false
+}
+
+// customRolePoliciesCache is implemented by the visitor context which caches the policies of the
+// custom roles during its lifetime, e.g. the security context of one request
+type customRolePoliciesCache interface {
+	GetCustomRolePolicies(roleID int) ([]*rbac.Policy, error)
+}
+
+// customRole implement the rbac.Role interface for the role defined by the system admin
+type customRole struct {
+	namespace rbac.Namespace
+	roleID    int
+	// cache can be nil, the policies are got from DB directly then
+	cache customRolePoliciesCache
+}
+
+// GetRoleName returns the name for the custom role, the role ID is used to make it unique
+func (role *customRole) GetRoleName() string {
+	return fmt.Sprintf("custom-%d", role.roleID)
+}
+
+// GetPolicies returns the policies of the custom role in the namespace
+func (role *customRole) GetPolicies() []*rbac.Policy {
+	policies := []*rbac.Policy{}
+
+	getter := getCustomRolePolicies
+	if role.cache != nil {
+		getter = role.cache.GetCustomRolePolicies
+	}
+	rolePolicies, err := getter(role.roleID)
+	if err != nil {
+		log.Errorf("failed to get the policies of role %d: %v", role.roleID, err)
+		return policies
+	}
+
+	for _, policy := range rolePolicies {
+		// ignore the policies out of the project scope in case the role is modified in DB
+		if !IsValidPolicy(policy) {
+			continue
+		}
+		policies = append(policies, &rbac.Policy{
+			Resource: role.namespace.Resource(policy.Resource),
+			Action:   policy.Action,
+			Effect:   policy.Effect,
+		})
+	}
+
+	return policies
+}
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/rbac"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -39,6 +40,55 @@ func (suite *VisitorRoleTestSuite) TestGetRoleName() {
 	suite.Equal(unknow.GetRoleName(), "")
 }
 
+func (suite *VisitorRoleTestSuite) TestCustomRole() {
+	getter := getCustomRolePolicies
+	defer func() { getCustomRolePolicies = getter }()
+	getCustomRolePolicies = func(roleID int) ([]*rbac.Policy, error) {
+		return []*rbac.Policy{
+			{Resource: rbac.ResourceRepository, Action: rbac.ActionPush},
+			{Resource: rbac.ResourceRepositoryTagScanJob, Action: rbac.ActionCreate},
+			{Resource: rbac.Resource("/system"), Action: rbac.ActionRead},
+		}, nil
+	}
+
+	namespace := rbac.NewProjectNamespace(1, false)
+	role := &customRole{roleID: 5, namespace: namespace}
+	suite.Equal("custom-5", role.GetRoleName())
+	suite.Len(role.GetPolicies(), 2)
+
+	user := NewUser(authenticatedCtx, namespace, 5)
+	suite.True(rbac.HasPermission(user, namespace.Resource(rbac.ResourceRepository), rbac.ActionPush))
+	suite.True(rbac.HasPermission(user, namespace.Resource(rbac.ResourceRepositoryTagScanJob), rbac.ActionCreate))
+	suite.False(rbac.HasPermission(user, namespace.Resource(rbac.ResourceRepositoryTag), rbac.ActionDelete))
+	suite.False(rbac.HasPermission(user, rbac.NewProjectNamespace(2, false).Resource(rbac.ResourceRepository), rbac.ActionPush))
+}
+
+type fakeCachingContext struct {
+	fakeVisitorContext
+	calls int
+}
+
+func (ctx *fakeCachingContext) GetCustomRolePolicies(roleID int) ([]*rbac.Policy, error) {
+	ctx.calls++
+	return []*rbac.Policy{{Resource: rbac.ResourceRepository, Action: rbac.ActionPush}}, nil
+}
+
+func (suite *VisitorRoleTestSuite) TestCustomRolePoliciesCache() {
+	getter := getCustomRolePolicies
+	defer func() { getCustomRolePolicies = getter }()
+	getCustomRolePolicies = func(roleID int) ([]*rbac.Policy, error) {
+		suite.Fail("the policies should be got from the cache of the context")
+		return nil, nil
+	}
+
+	namespace := rbac.NewProjectNamespace(1, false)
+	ctx := &fakeCachingContext{fakeVisitorContext: fakeVisitorContext{username: "user"}}
+	user := NewUser(ctx, namespace, 5)
+	suite.True(rbac.HasPermission(user, namespace.Resource(rbac.ResourceRepository), rbac.ActionPush))
+	suite.False(rbac.HasPermission(user, namespace.Resource(rbac.ResourceRepository), rbac.ActionDelete))
+	suite.True(ctx.calls > 0)
+}
+
 func TestVisitorRoleTestSuite(t *testing.T) {
 	suite.Run(t, new(VisitorRoleTestSuite))
 }
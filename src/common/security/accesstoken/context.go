@@ -0,0 +1,114 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesstoken
+
+import (
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/common/security/local"
+	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/goharbor/harbor/src/core/promgr"
+)
+
+// the actions allowed by the read only token
+var readOnlyActions = map[rbac.Action]bool{
+	rbac.ActionPull: true,
+	rbac.ActionRead: true,
+	rbac.ActionList: true,
+}
+
+// SecurityContext implements security.Context interface for the user authenticated by the personal access token,
+// the permissions of the user are limited by the scopes of the token
+type SecurityContext struct {
+	*local.SecurityContext
+	token *models.AccessToken
+	pm    promgr.ProjectManager
+}
+
+// NewSecurityContext ...
+func NewSecurityContext(user *models.User, token *models.AccessToken, pm promgr.ProjectManager) *SecurityContext {
+	return &SecurityContext{
+		SecurityContext: local.NewSecurityContext(user, pm),
+		token:           token,
+		pm:              pm,
+	}
+}
+
+// IsScoped returns whether the token is read only or limited to some projects
+func (s *SecurityContext) IsScoped() bool {
+	return s.token.IsScoped()
+}
+
+// IsSysAdmin returns false for the scoped token even the user is system admin
+func (s *SecurityContext) IsSysAdmin() bool {
+	if s.token.IsScoped() {
+		return false
+	}
+	return s.SecurityContext.IsSysAdmin()
+}
+
+// Can returns whether the user can do action on resource in the scopes of the token
+func (s *SecurityContext) Can(action rbac.Action, resource rbac.Resource) bool {
+	if s.token.ReadOnly && !readOnlyActions[action] {
+		return false
+	}
+	if len(s.token.ProjectIDs) > 0 {
+		ns, err := resource.GetNamespace()
+		if err != nil || ns.Kind() != "project" || !s.inScope(ns.Identity().(int64)) {
+			return false
+		}
+	}
+	return s.SecurityContext.Can(action, resource)
+}
+
+// GetMyProjects returns the projects of the user in the scopes of the token
+func (s *SecurityContext) GetMyProjects() ([]*models.Project, error) {
+	projects, err := s.SecurityContext.GetMyProjects()
+	if err != nil || len(s.token.ProjectIDs) == 0 {
+		return projects, err
+	}
+
+	result := []*models.Project{}
+	for _, project := range projects {
+		if s.inScope(project.ProjectID) {
+			result = append(result, project)
+		}
+	}
+	return result, nil
+}
+
+// GetProjectRoles returns the roles of the user to the project, it's empty if the project isn't in the scopes of the token
+func (s *SecurityContext) GetProjectRoles(projectIDOrName interface{}) []int {
+	if len(s.token.ProjectIDs) > 0 {
+		project, err := s.pm.Get(projectIDOrName)
+		if err != nil {
+			log.Errorf("failed to get project %v: %v", projectIDOrName, err)
+			return []int{}
+		}
+		if project == nil || !s.inScope(project.ProjectID) {
+			return []int{}
+		}
+	}
+	return s.SecurityContext.GetProjectRoles(projectIDOrName)
+}
+
+func (s *SecurityContext) inScope(projectID int64) bool {
+	for _, id := range s.token.ProjectIDs {
+		if id == projectID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesstoken
+
+import (
+	"testing"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSysAdmin(t *testing.T) {
+	admin := &models.User{
+		Username:     "admin",
+		HasAdminRole: true,
+	}
+
+	ctx := NewSecurityContext(admin, &models.AccessToken{}, nil)
+	assert.True(t, ctx.IsSysAdmin())
+
+	ctx = NewSecurityContext(admin, &models.AccessToken{ReadOnly: true}, nil)
+	assert.False(t, ctx.IsSysAdmin())
+
+	ctx = NewSecurityContext(admin, &models.AccessToken{ProjectIDs: []int64{1}}, nil)
+	assert.False(t, ctx.IsSysAdmin())
+}
+
+func TestCanOutOfScopes(t *testing.T) {
+	user := &models.User{
+		Username: "test",
+	}
+	repository := rbac.NewProjectNamespace(2).Resource(rbac.ResourceRepository)
+
+	ctx := NewSecurityContext(user, &models.AccessToken{ReadOnly: true}, nil)
+	assert.False(t, ctx.Can(rbac.ActionPush, repository))
+
+	ctx = NewSecurityContext(user, &models.AccessToken{ProjectIDs: []int64{1}}, nil)
+	assert.False(t, ctx.Can(rbac.ActionPull, repository))
+	assert.False(t, ctx.Can(rbac.ActionRead, rbac.Resource("/system")))
+}
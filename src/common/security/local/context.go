@@ -15,6 +15,8 @@
 package local
 
 import (
+	"sync"
+
 	"github.com/goharbor/harbor/src/common"
 	"github.com/goharbor/harbor/src/common/dao"
 	"github.com/goharbor/harbor/src/common/models"
@@ -28,6 +30,10 @@ import (
 type SecurityContext struct {
 	user *models.User
 	pm   promgr.ProjectManager
+
+	lock sync.Mutex
+	// the policies of the custom roles, indexed by the role ID
+	rolePolicies map[int][]*rbac.Policy
 }
 
 // NewSecurityContext ...
@@ -83,6 +89,27 @@ func (s *SecurityContext) Can(action rbac.Action, resource rbac.Resource) bool {
 	return false
 }
 
+// GetCustomRolePolicies returns the policies of the custom role, they're cached in the security
+// context to avoid querying them from DB on every permission checking of the request
+func (s *SecurityContext) GetCustomRolePolicies(roleID int) ([]*rbac.Policy, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if policies, ok := s.rolePolicies[roleID]; ok {
+		return policies, nil
+	}
+
+	policies, err := dao.GetRolePermissions(roleID)
+	if err != nil {
+		return nil, err
+	}
+	if s.rolePolicies == nil {
+		s.rolePolicies = make(map[int][]*rbac.Policy)
+	}
+	s.rolePolicies[roleID] = policies
+	return policies, nil
+}
+
 // GetProjectRoles ...
 func (s *SecurityContext) GetProjectRoles(projectIDOrName interface{}) []int {
 	if !s.IsAuthenticated() || projectIDOrName == nil {
@@ -125,6 +152,8 @@ func (s *SecurityContext) GetProjectRoles(projectIDOrName interface{}) []int {
 			roles = append(roles, common.RoleDeveloper)
 		case "RS":
 			roles = append(roles, common.RoleGuest)
+		case models.CustomRoleCode:
+			roles = append(roles, role.RoleID)
 		}
 	}
 	return mergeRoles(roles, s.GetRolesByGroup(projectIDOrName))
@@ -309,6 +309,28 @@ func TestGetProjectRoles(t *testing.T) {
 	assert.Equal(t, 1, len(roles))
 	assert.Equal(t, common.RoleProjectAdmin, roles[0])
 }
+func TestGetCustomRolePolicies(t *testing.T) {
+	role := &models.Role{
+		Name:        "cached-role",
+		Permissions: []*rbac.Policy{{Resource: rbac.ResourceRepository, Action: rbac.ActionPush}},
+	}
+	id, err := dao.AddCustomRole(role)
+	require.Nil(t, err)
+	defer dao.DeleteCustomRole(id)
+
+	ctx := NewSecurityContext(&models.User{Username: "test"}, nil)
+	policies, err := ctx.GetCustomRolePolicies(id)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(policies))
+
+	// the policies are cached in the security context
+	role.Permissions = nil
+	require.Nil(t, dao.UpdateCustomRole(role))
+	policies, err = ctx.GetCustomRolePolicies(id)
+	require.Nil(t, err)
+	assert.Equal(t, 1, len(policies))
+}
+
 func PrepareGroupTest() {
 	initSqls := []string{
 		`insert into user_group (group_name, group_type, ldap_group_dn) values ('harbor_group_01', 1, 'cn=harbor_user,dc=example,dc=com')`,
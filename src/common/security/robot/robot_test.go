@@ -40,3 +40,24 @@ func TestGetPolicies(t *testing.T) {
 	assert.NotNil(t, robot.GetPolicies())
 	assert.Nil(t, robot.GetRoles())
 }
+
+func TestSystemRobotPermission(t *testing.T) {
+	policies := []*rbac.Policy{
+		{
+			Resource: "/project/1/repository",
+			Action:   rbac.ActionPush,
+		},
+		{
+			Resource: "/project/*/repository",
+			Action:   rbac.ActionPull,
+		},
+	}
+
+	for _, projectID := range []int64{1, 2} {
+		ns := rbac.NewProjectNamespace(projectID, false)
+		r := NewRobot("test", ns, policies)
+		assert.True(t, rbac.HasPermission(r, ns.Resource(rbac.ResourceRepository), rbac.ActionPull))
+		assert.Equal(t, projectID == 1, rbac.HasPermission(r, ns.Resource(rbac.ResourceRepository), rbac.ActionPush))
+		assert.False(t, rbac.HasPermission(r, ns.Resource(rbac.ResourceHelmChart), rbac.ActionRead))
+	}
+}
@@ -18,13 +18,14 @@ type HToken struct {
 	jwt.Token
 }
 
-// New ...
-func New(tokenID, projectID, expiresAt int64, access []*rbac.Policy) (*HToken, error) {
+// New creates the token of the robot, the secret is the ID of the token which is checked against the robot
+func New(tokenID, projectID, expiresAt int64, secret string, access []*rbac.Policy) (*HToken, error) {
 	rClaims := &RobotClaims{
 		TokenID:   tokenID,
 		ProjectID: projectID,
 		Access:    access,
 		StandardClaims: jwt.StandardClaims{
+			Id:        secret,
 			IssuedAt:  time.Now().UTC().Unix(),
 			ExpiresAt: expiresAt,
 			Issuer:    DefaultOptions().Issuer,
@@ -33,11 +33,12 @@ func TestNew(t *testing.T) {
 	projectID := int64(321)
 	tokenExpiration := time.Duration(10) * 24 * time.Hour
 	expiresAt := time.Now().UTC().Add(tokenExpiration).Unix()
-	token, err := New(tokenID, projectID, expiresAt, policies)
+	token, err := New(tokenID, projectID, expiresAt, "secret", policies)
 
 	assert.Nil(t, err)
 	assert.Equal(t, token.Header["alg"], "RS256")
 	assert.Equal(t, token.Header["typ"], "JWT")
+	assert.Equal(t, "secret", token.Claims.(*RobotClaims).Id)
 
 }
 
@@ -54,7 +55,7 @@ func TestRaw(t *testing.T) {
 
 	tokenExpiration := time.Duration(10) * 24 * time.Hour
 	expiresAt := time.Now().UTC().Add(tokenExpiration).Unix()
-	token, err := New(tokenID, projectID, expiresAt, policies)
+	token, err := New(tokenID, projectID, expiresAt, "secret", policies)
 	assert.Nil(t, err)
 
 	rawTk, err := token.Raw()
@@ -0,0 +1,58 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWithFields(t *testing.T) {
+	buf := enter()
+	defer exit()
+
+	l := WithFields(Fields{FieldRequestID: "id"})
+	l.SetFormatter(NewJSONFormatter())
+	l.Info(message)
+
+	entry := map[string]interface{}{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to unmarshal the log %s: %v", buf.String(), err)
+	}
+	if entry[FieldRequestID] != "id" {
+		t.Errorf("unexpected request ID: %v != %v", entry[FieldRequestID], "id")
+	}
+	if line, _ := entry["line"].(string); !strings.Contains(line, "context_test.go") {
+		t.Errorf("unexpected line: %s, expected the line of context_test.go", line)
+	}
+
+	if len(logger.fields) != 0 {
+		t.Errorf("unexpected fields of the default logger: %v", logger.fields)
+	}
+}
+
+func TestContext(t *testing.T) {
+	l := WithField(FieldRequestID, "id")
+	ctx := NewContext(context.Background(), l)
+	if FromContext(ctx) != l {
+		t.Errorf("unexpected logger from the context")
+	}
+
+	if FromContext(context.Background()) == nil {
+		t.Errorf("expected the copy of the default logger but got nil")
+	}
+}
@@ -14,7 +14,26 @@
 
 package log
 
+import (
+	"strings"
+)
+
 // Formatter formats records in different ways: text, json, etc.
 type Formatter interface {
 	Format(*Record) ([]byte, error)
 }
+
+const (
+	// FormatText formats the logs as plain text
+	FormatText = "text"
+	// FormatJSON formats the logs as JSON
+	FormatJSON = "json"
+)
+
+// NewFormatter returns the formatter of the format, the text formatter is returned for the unknown format
+func NewFormatter(format string) Formatter {
+	if strings.ToLower(format) == FormatJSON {
+		return NewJSONFormatter()
+	}
+	return NewTextFormatter()
+}
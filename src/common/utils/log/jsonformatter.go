@@ -0,0 +1,67 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// JSONFormatter represents a kind of formatter that formats the logs as one JSON object per line
+type JSONFormatter struct {
+	timeFormat string
+}
+
+// NewJSONFormatter returns a JSONFormatter, the format of time is time.RFC3339Nano
+func NewJSONFormatter() *JSONFormatter {
+	return &JSONFormatter{
+		timeFormat: time.RFC3339Nano,
+	}
+}
+
+// Format formats the logs as {"timestamp":...,"level":...,"line":...,"message":...} with the fields
+// attached by the logger, e.g. "component", "request_id", "user", "project" and "error"
+func (j *JSONFormatter) Format(r *Record) ([]byte, error) {
+	entry := make(map[string]interface{}, len(r.Fields)+4)
+	for k, v := range r.Fields {
+		// The error is marshaled as an empty object, use its message instead
+		if err, ok := v.(error); ok {
+			v = err.Error()
+		}
+		entry[k] = v
+	}
+
+	entry["timestamp"] = r.Time.Format(j.timeFormat)
+	entry["level"] = r.Lvl.string()
+	if len(r.Line) != 0 {
+		entry["line"] = r.Line
+	}
+	entry["message"] = strings.TrimRight(r.Msg, "\n")
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, '\n'), nil
+}
+
+// SetTimeFormat sets time format of JSONFormatter if the parameter fmt is not null
+func (j *JSONFormatter) SetTimeFormat(fmt string) {
+	if len(fmt) != 0 {
+		j.timeFormat = fmt
+	}
+}
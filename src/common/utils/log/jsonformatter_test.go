@@ -0,0 +1,58 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONFormat(t *testing.T) {
+	now := time.Date(2019, 10, 1, 8, 0, 0, 0, time.UTC)
+	r := NewRecord(now, "message\n", "[file.go:10]:", ErrorLevel)
+	r.Fields = Fields{
+		FieldComponent: "core",
+		FieldRequestID: "id",
+		FieldError:     errors.New("failure"),
+	}
+
+	b, err := NewJSONFormatter().Format(r)
+	require.Nil(t, err)
+	assert.Equal(t, byte('\n'), b[len(b)-1])
+
+	entry := map[string]interface{}{}
+	require.Nil(t, json.Unmarshal(b, &entry))
+	assert.Equal(t, "2019-10-01T08:00:00Z", entry["timestamp"])
+	assert.Equal(t, "ERROR", entry["level"])
+	assert.Equal(t, "[file.go:10]:", entry["line"])
+	assert.Equal(t, "message", entry["message"])
+	assert.Equal(t, "core", entry[FieldComponent])
+	assert.Equal(t, "id", entry[FieldRequestID])
+	assert.Equal(t, "failure", entry[FieldError])
+}
+
+func TestNewFormatter(t *testing.T) {
+	_, ok := NewFormatter("JSON").(*JSONFormatter)
+	assert.True(t, ok)
+	_, ok = NewFormatter("text").(*TextFormatter)
+	assert.True(t, ok)
+	_, ok = NewFormatter("").(*TextFormatter)
+	assert.True(t, ok)
+}
@@ -26,9 +26,23 @@ import (
 
 var logger = New(os.Stdout, NewTextFormatter(), WarningLevel, 4)
 
+// Fields are the extra key/value pairs attached to the logs
+type Fields map[string]interface{}
+
+// The keys of the common fields
+const (
+	FieldComponent = "component"
+	FieldRequestID = "request_id"
+	FieldUser      = "user"
+	FieldProject   = "project"
+	FieldError     = "error"
+)
+
 const srcSeparator = "harbor" + string(os.PathSeparator) + "src"
 
 func init() {
+	logger.SetFormatter(NewFormatter(os.Getenv("LOG_FORMAT")))
+
 	lvl := os.Getenv("LOG_LEVEL")
 	if len(lvl) == 0 {
 		logger.SetLevel(InfoLevel)
@@ -51,6 +65,7 @@ type Logger struct {
 	lvl       Level
 	callDepth int
 	skipLine  bool
+	fields    Fields
 	mu        sync.Mutex
 }
 
@@ -103,6 +118,35 @@ func (l *Logger) SetLevel(lvl Level) {
 	l.lvl = lvl
 }
 
+// WithFields returns a copy of Logger l with the fields attached to each log,
+// the fields of l are kept unless they are overridden
+func (l *Logger) WithFields(fields Fields) *Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	fs := make(Fields, len(l.fields)+len(fields))
+	for k, v := range l.fields {
+		fs[k] = v
+	}
+	for k, v := range fields {
+		fs[k] = v
+	}
+
+	return &Logger{
+		out:       l.out,
+		fmtter:    l.fmtter,
+		lvl:       l.lvl,
+		callDepth: l.callDepth,
+		skipLine:  l.skipLine,
+		fields:    fs,
+	}
+}
+
+// WithField returns a copy of Logger l with the field attached to each log
+func (l *Logger) WithField(key string, value interface{}) *Logger {
+	return l.WithFields(Fields{key: value})
+}
+
 // SetOutput sets the output of default Logger
 func SetOutput(out io.Writer) {
 	logger.SetOutput(out)
@@ -118,7 +162,34 @@ func SetLevel(lvl Level) {
 	logger.SetLevel(lvl)
 }
 
+// SetComponent sets the name of the service which is attached to the logs of default Logger
+func SetComponent(component string) {
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+
+	fs := make(Fields, len(logger.fields)+1)
+	for k, v := range logger.fields {
+		fs[k] = v
+	}
+	fs[FieldComponent] = component
+	logger.fields = fs
+}
+
+// WithFields returns a copy of default Logger with the fields attached to each log
+func WithFields(fields Fields) *Logger {
+	l := logger.WithFields(fields)
+	// The copy is called directly rather than by the functions of the package
+	l.callDepth--
+	return l
+}
+
+// WithField returns a copy of default Logger with the field attached to each log
+func WithField(key string, value interface{}) *Logger {
+	return WithFields(Fields{key: value})
+}
+
 func (l *Logger) output(record *Record) (err error) {
+	record.Fields = l.fields
 	b, err := l.fmtter.Format(record)
 	if err != nil {
 		return
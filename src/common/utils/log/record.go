@@ -24,6 +24,8 @@ type Record struct {
 	Msg  string    // content of the log
 	Line string    // in which file and line that the log produced
 	Lvl  Level     // level of the log
+	// extra fields attached by the logger, e.g. the component and the request ID
+	Fields Fields
 }
 
 // NewRecord creates a record according to the arguments provided and returns it
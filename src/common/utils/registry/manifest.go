@@ -16,6 +16,8 @@ package registry
 
 import (
 	"github.com/docker/distribution"
+	// register the unmarshal function of OCI image manifest
+	_ "github.com/docker/distribution/manifest/ocischema"
 )
 
 // UnMarshal converts []byte to be distribution.Manifest
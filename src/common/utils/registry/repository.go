@@ -26,10 +26,12 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/docker/distribution/manifest/manifestlist"
 	"github.com/docker/distribution/manifest/schema1"
 	"github.com/docker/distribution/manifest/schema2"
 	commonhttp "github.com/goharbor/harbor/src/common/http"
 	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 // Repository holds information of a repository entity
@@ -123,6 +125,9 @@ func (r *Repository) ManifestExist(reference string) (digest string, exist bool,
 
 	req.Header.Add(http.CanonicalHeaderKey("Accept"), schema1.MediaTypeManifest)
 	req.Header.Add(http.CanonicalHeaderKey("Accept"), schema2.MediaTypeManifest)
+	req.Header.Add(http.CanonicalHeaderKey("Accept"), v1.MediaTypeImageManifest)
+	req.Header.Add(http.CanonicalHeaderKey("Accept"), manifestlist.MediaTypeManifestList)
+	req.Header.Add(http.CanonicalHeaderKey("Accept"), v1.MediaTypeImageIndex)
 
 	resp, err := r.client.Do(req)
 	if err != nil {
@@ -58,5 +58,13 @@ func NewRegistryCtl(config map[string]interface{}) (*httptest.Server, error) {
 		Handler: Handler(resp),
 	})
 
+	m = append(m, &RequestHandlerMapping{
+		Method:  "DELETE",
+		Pattern: "/api/registry/blob/",
+		Handler: Handler(&Response{
+			StatusCode: http.StatusOK,
+		}),
+	})
+
 	return NewServer(m...), nil
 }
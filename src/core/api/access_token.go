@@ -0,0 +1,159 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/utils"
+)
+
+// AccessTokenAPI handles the requests of the personal access tokens of user,
+// the tokens can be managed by the user self or the system admin
+type AccessTokenAPI struct {
+	BaseController
+	user *models.User
+}
+
+// Prepare ...
+func (a *AccessTokenAPI) Prepare() {
+	a.BaseController.Prepare()
+	if !a.SecurityCtx.IsAuthenticated() {
+		a.SendUnAuthorizedError(errors.New("UnAuthorized"))
+		return
+	}
+	// the personal access token can't be used to create other tokens which may have more permissions
+	if !a.RequireNotAccessToken() {
+		return
+	}
+
+	query := models.User{}
+	id := a.GetStringFromPath(":id")
+	if id == "current" {
+		query.Username = a.SecurityCtx.GetUsername()
+	} else {
+		userID, err := strconv.Atoi(id)
+		if err != nil || userID <= 0 {
+			a.SendBadRequestError(fmt.Errorf("invalid user ID: %s", id))
+			return
+		}
+		query.UserID = userID
+	}
+
+	user, err := dao.GetUser(query)
+	if err != nil {
+		a.SendInternalServerError(fmt.Errorf("failed to get user %s: %v", id, err))
+		return
+	}
+	if user == nil {
+		a.SendNotFoundError(fmt.Errorf("user %s not found", id))
+		return
+	}
+	if user.Username != a.SecurityCtx.GetUsername() && !a.SecurityCtx.IsSysAdmin() {
+		a.SendForbiddenError(errors.New(a.SecurityCtx.GetUsername()))
+		return
+	}
+	a.user = user
+}
+
+// Post creates a personal access token for the user, the token is only returned in the response
+func (a *AccessTokenAPI) Post() {
+	req := &models.AccessTokenReq{}
+	isValid, err := a.DecodeJSONReqAndValidate(req)
+	if !isValid {
+		a.SendBadRequestError(err)
+		return
+	}
+	for _, pid := range req.ProjectIDs {
+		exist, err := a.ProjectMgr.Exists(pid)
+		if err != nil {
+			a.ParseAndHandleError(fmt.Sprintf("failed to check the existence of project %d", pid), err)
+			return
+		}
+		if !exist {
+			a.SendBadRequestError(fmt.Errorf("project %d not found", pid))
+			return
+		}
+	}
+
+	tk := common.AccessTokenPrefix + utils.GenerateRandomString()
+	token := &models.AccessToken{
+		UserID:     a.user.UserID,
+		Name:       req.Name,
+		Hash:       models.HashAccessToken(tk),
+		ReadOnly:   req.ReadOnly,
+		ProjectIDs: req.ProjectIDs,
+		ExpiresAt:  req.ExpiresAt,
+	}
+	id, err := dao.AddAccessToken(token)
+	if err != nil {
+		if err == dao.ErrDupRows {
+			a.SendConflictError(fmt.Errorf("token %s already exists", req.Name))
+			return
+		}
+		a.SendInternalServerError(fmt.Errorf("failed to create token: %v", err))
+		return
+	}
+
+	a.Redirect(http.StatusCreated, strconv.FormatInt(id, 10))
+	a.Data["json"] = models.AccessTokenRep{
+		ID:    id,
+		Name:  token.Name,
+		Token: tk,
+	}
+	a.ServeJSON()
+}
+
+// List lists the personal access tokens of the user
+func (a *AccessTokenAPI) List() {
+	tokens, err := dao.ListAccessTokens(a.user.UserID)
+	if err != nil {
+		a.SendInternalServerError(fmt.Errorf("failed to list tokens of user %d: %v", a.user.UserID, err))
+		return
+	}
+
+	a.Data["json"] = tokens
+	a.ServeJSON()
+}
+
+// Delete revokes the personal access token of the user
+func (a *AccessTokenAPI) Delete() {
+	id, err := a.GetInt64FromPath(":tid")
+	if err != nil || id <= 0 {
+		a.SendBadRequestError(fmt.Errorf("invalid token ID: %s", a.GetStringFromPath(":tid")))
+		return
+	}
+
+	token, err := dao.GetAccessToken(id)
+	if err != nil {
+		a.SendInternalServerError(fmt.Errorf("failed to get token %d: %v", id, err))
+		return
+	}
+	if token == nil || token.UserID != a.user.UserID {
+		a.SendNotFoundError(fmt.Errorf("token %d not found", id))
+		return
+	}
+
+	if err := dao.DeleteAccessToken(id); err != nil {
+		a.SendInternalServerError(fmt.Errorf("failed to delete token %d: %v", id, err))
+		return
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessTokenAPI(t *testing.T) {
+	tokenPath := "/api/users/current/tokens"
+
+	cases := []*codeCheckingCase{
+		// 401
+		{
+			request: &testingRequest{
+				method: http.MethodGet,
+				url:    tokenPath,
+			},
+			code: http.StatusUnauthorized,
+		},
+		// 403, the tokens of other user
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/users/1/tokens",
+				credential: nonSysAdmin,
+			},
+			code: http.StatusForbidden,
+		},
+		// 400, empty name
+		{
+			request: &testingRequest{
+				method:     http.MethodPost,
+				url:        tokenPath,
+				bodyJSON:   &models.AccessTokenReq{},
+				credential: nonSysAdmin,
+			},
+			code: http.StatusBadRequest,
+		},
+		// 400, expired
+		{
+			request: &testingRequest{
+				method: http.MethodPost,
+				url:    tokenPath,
+				bodyJSON: &models.AccessTokenReq{
+					Name:      "ci",
+					ExpiresAt: time.Now().Add(-time.Hour).Unix(),
+				},
+				credential: nonSysAdmin,
+			},
+			code: http.StatusBadRequest,
+		},
+		// 400, project not found
+		{
+			request: &testingRequest{
+				method: http.MethodPost,
+				url:    tokenPath,
+				bodyJSON: &models.AccessTokenReq{
+					Name:       "ci",
+					ProjectIDs: []int64{10000},
+				},
+				credential: nonSysAdmin,
+			},
+			code: http.StatusBadRequest,
+		},
+	}
+	runCodeCheckingCases(t, cases...)
+
+	rep := &models.AccessTokenRep{}
+	err := handleAndParse(&testingRequest{
+		method: http.MethodPost,
+		url:    tokenPath,
+		bodyJSON: &models.AccessTokenReq{
+			Name:       "ci",
+			ReadOnly:   true,
+			ProjectIDs: []int64{1},
+		},
+		credential: nonSysAdmin,
+	}, rep)
+	require.Nil(t, err)
+	assert.Equal(t, "ci", rep.Name)
+	require.NotEmpty(t, rep.Token)
+
+	tokenUser := &usrInfo{
+		Name:   nonSysAdmin.Name,
+		Passwd: rep.Token,
+	}
+	cases = []*codeCheckingCase{
+		// 409
+		{
+			request: &testingRequest{
+				method: http.MethodPost,
+				url:    tokenPath,
+				bodyJSON: &models.AccessTokenReq{
+					Name: "ci",
+				},
+				credential: nonSysAdmin,
+			},
+			code: http.StatusConflict,
+		},
+		// 200, authenticated by the token
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/users/current",
+				credential: tokenUser,
+			},
+			code: http.StatusOK,
+		},
+		// 403, the token can't manage the tokens
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        tokenPath,
+				credential: tokenUser,
+			},
+			code: http.StatusForbidden,
+		},
+		// 403, the token can't change the profile
+		{
+			request: &testingRequest{
+				method:     http.MethodPut,
+				url:        "/api/users/current",
+				bodyJSON:   &models.User{Email: "token@harbor.com", Realname: "token"},
+				credential: tokenUser,
+			},
+			code: http.StatusForbidden,
+		},
+		// 403, the token can't change the password
+		{
+			request: &testingRequest{
+				method: http.MethodPut,
+				url:    fmt.Sprintf("/api/users/%d/password", nonSysAdminID),
+				bodyJSON: &passwordReq{
+					OldPassword: nonSysAdmin.Passwd,
+					NewPassword: "Harbor123456",
+				},
+				credential: tokenUser,
+			},
+			code: http.StatusForbidden,
+		},
+		// 403, the token can't generate the CLI secret
+		{
+			request: &testingRequest{
+				method:     http.MethodPost,
+				url:        "/api/users/current/gen_cli_secret",
+				credential: tokenUser,
+			},
+			code: http.StatusForbidden,
+		},
+		// 403, the token can't create the robot account
+		{
+			request: &testingRequest{
+				method: http.MethodPost,
+				url:    "/api/projects/1/robots",
+				bodyJSON: &models.RobotReq{
+					Name: "token",
+				},
+				credential: tokenUser,
+			},
+			code: http.StatusForbidden,
+		},
+		// 403, the token can't create the system robot account
+		{
+			request: &testingRequest{
+				method: http.MethodPost,
+				url:    "/api/robots",
+				bodyJSON: &models.RobotReq{
+					Name: "token",
+				},
+				credential: tokenUser,
+			},
+			code: http.StatusForbidden,
+		},
+		// 403, the scoped token can't create the project
+		{
+			request: &testingRequest{
+				method: http.MethodPost,
+				url:    "/api/projects",
+				bodyJSON: &models.ProjectRequest{
+					Name: "token_project",
+				},
+				credential: tokenUser,
+			},
+			code: http.StatusForbidden,
+		},
+		// 401, the token of other user
+		{
+			request: &testingRequest{
+				method: http.MethodGet,
+				url:    "/api/users/current",
+				credential: &usrInfo{
+					Name:   sysAdmin.Name,
+					Passwd: rep.Token,
+				},
+			},
+			code: http.StatusUnauthorized,
+		},
+	}
+	runCodeCheckingCases(t, cases...)
+
+	tokens := []*models.AccessToken{}
+	err = handleAndParse(&testingRequest{
+		method:     http.MethodGet,
+		url:        tokenPath,
+		credential: nonSysAdmin,
+	}, &tokens)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(tokens))
+	assert.True(t, tokens[0].ReadOnly)
+	assert.Equal(t, []int64{1}, tokens[0].ProjectIDs)
+
+	cases = []*codeCheckingCase{
+		// 404
+		{
+			request: &testingRequest{
+				method:     http.MethodDelete,
+				url:        fmt.Sprintf("%s/%d", tokenPath, 10000),
+				credential: nonSysAdmin,
+			},
+			code: http.StatusNotFound,
+		},
+		// 200
+		{
+			request: &testingRequest{
+				method:     http.MethodDelete,
+				url:        fmt.Sprintf("%s/%d", tokenPath, rep.ID),
+				credential: nonSysAdmin,
+			},
+			code: http.StatusOK,
+		},
+		// 401, the token is revoked
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/users/current",
+				credential: tokenUser,
+			},
+			code: http.StatusUnauthorized,
+		},
+	}
+	runCodeCheckingCases(t, cases...)
+}
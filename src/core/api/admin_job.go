@@ -220,6 +220,55 @@ func (aj *AJAPI) getLog(id int64) {
 	}
 }
 
+// operate stops or retries the execution of admin job, the latest execution
+// which can be operated is chosen for the periodic one.
+func (aj *AJAPI) operate(id int64, action string) {
+	job, err := dao.GetAdminJob(id)
+	if err != nil {
+		aj.SendInternalServerError(fmt.Errorf("failed to get admin job %d: %v", id, err))
+		return
+	}
+	if job == nil {
+		aj.SendNotFoundError(fmt.Errorf("admin job %d not found", id))
+		return
+	}
+	if len(job.UUID) == 0 {
+		aj.SendBadRequestError(fmt.Errorf("admin job %d isn't submitted to jobservice yet", id))
+		return
+	}
+
+	jobID := job.UUID
+	if job.Kind == common_job.JobKindPeriodic {
+		exes, err := utils_core.GetJobServiceClient().GetExecutions(job.UUID)
+		if err != nil {
+			aj.SendInternalServerError(err)
+			return
+		}
+		jobID = ""
+		for _, exe := range exes {
+			switch exe.Info.Status {
+			case common_job.JobServiceStatusPending, common_job.JobServiceStatusRunning:
+				if action == models.JobActionStop {
+					jobID = exe.Info.JobID
+				}
+			case common_job.JobServiceStatusError, common_job.JobServiceStatusStopped:
+				if action == models.JobActionRetry {
+					jobID = exe.Info.JobID
+				}
+			}
+			if jobID != "" {
+				break
+			}
+		}
+		if jobID == "" {
+			aj.SendBadRequestError(fmt.Errorf("no execution of admin job %d to %s", id, action))
+			return
+		}
+	}
+
+	aj.operateJob(jobID, action)
+}
+
 // submit submits a job to job service per request
 func (aj *AJAPI) submit(ajr *models.AdminJobReq) {
 	// when the schedule is saved as None without any schedule, just return 200 and do nothing.
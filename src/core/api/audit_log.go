@@ -0,0 +1,224 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/dao"
+	common_job "github.com/goharbor/harbor/src/common/job"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/utils"
+	api_models "github.com/goharbor/harbor/src/core/api/models"
+)
+
+// the max count of the audit logs in one export
+const maxExportedAuditLogs = 10000
+
+// AuditLogAPI handles the requests to query and export the audit logs, only system admin has the permission
+type AuditLogAPI struct {
+	BaseController
+}
+
+// Prepare ...
+func (a *AuditLogAPI) Prepare() {
+	a.BaseController.Prepare()
+	if !a.SecurityCtx.IsAuthenticated() {
+		a.SendUnAuthorizedError(errors.New("UnAuthorized"))
+		return
+	}
+	if !a.SecurityCtx.IsSysAdmin() {
+		a.SendForbiddenError(errors.New(a.SecurityCtx.GetUsername()))
+		return
+	}
+}
+
+// List lists the audit logs according to the filters in the query string
+func (a *AuditLogAPI) List() {
+	page, size, err := a.GetPaginationParams()
+	if err != nil {
+		a.SendBadRequestError(err)
+		return
+	}
+	query, err := a.buildQuery()
+	if err != nil {
+		a.SendBadRequestError(err)
+		return
+	}
+	query.Pagination = &models.Pagination{
+		Page: page,
+		Size: size,
+	}
+
+	total, err := dao.GetTotalOfAuditLogs(query)
+	if err != nil {
+		a.SendInternalServerError(fmt.Errorf("failed to get total of audit logs: %v", err))
+		return
+	}
+	logs, err := dao.ListAuditLogs(query)
+	if err != nil {
+		a.SendInternalServerError(fmt.Errorf("failed to list audit logs: %v", err))
+		return
+	}
+
+	a.SetPaginationHeader(total, page, size)
+	a.Data["json"] = logs
+	a.ServeJSON()
+}
+
+// Export exports the audit logs matching the filters as a CSV file, at most 10000 latest logs are exported
+func (a *AuditLogAPI) Export() {
+	query, err := a.buildQuery()
+	if err != nil {
+		a.SendBadRequestError(err)
+		return
+	}
+	query.Pagination = &models.Pagination{
+		Page: 1,
+		Size: maxExportedAuditLogs,
+	}
+
+	logs, err := dao.ListAuditLogs(query)
+	if err != nil {
+		a.SendInternalServerError(fmt.Errorf("failed to list audit logs: %v", err))
+		return
+	}
+
+	w := a.Ctx.ResponseWriter
+	w.Header().Set(http.CanonicalHeaderKey("Content-Type"), "text/csv")
+	w.Header().Set(http.CanonicalHeaderKey("Content-Disposition"),
+		fmt.Sprintf("attachment; filename=audit_logs_%d.csv", time.Now().Unix()))
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	records := [][]string{{"id", "op_time", "username", "source_ip", "operation",
+		"resource_type", "resource", "method", "status_code", "result", "summary"}}
+	for _, auditLog := range logs {
+		records = append(records, []string{
+			strconv.FormatInt(auditLog.ID, 10),
+			auditLog.OpTime.UTC().Format(time.RFC3339),
+			escapeCSVCell(auditLog.Username),
+			escapeCSVCell(auditLog.SourceIP),
+			escapeCSVCell(auditLog.Operation),
+			escapeCSVCell(auditLog.ResourceType),
+			escapeCSVCell(auditLog.Resource),
+			escapeCSVCell(auditLog.Method),
+			strconv.Itoa(auditLog.StatusCode),
+			escapeCSVCell(auditLog.Result),
+			escapeCSVCell(auditLog.Summary),
+		})
+	}
+	if err = writer.WriteAll(records); err != nil {
+		a.Logger().Errorf("failed to export audit logs: %v", err)
+	}
+}
+
+// escapeCSVCell prefixes the cell with a single quote if it starts with the characters which make the
+// spreadsheet applications evaluate it as a formula, as the cells contain the values provided by the users
+func escapeCSVCell(cell string) string {
+	if len(cell) > 0 && strings.ContainsAny(cell[:1], "=+-@\t\r") {
+		return "'" + cell
+	}
+	return cell
+}
+
+func (a *AuditLogAPI) buildQuery() (*models.AuditLogQuery, error) {
+	query := &models.AuditLogQuery{
+		Username:     a.GetString("username"),
+		SourceIP:     a.GetString("source_ip"),
+		Operation:    a.GetString("operation"),
+		ResourceType: a.GetString("resource_type"),
+		Resource:     a.GetString("resource"),
+		Result:       a.GetString("result"),
+	}
+
+	if timestamp := a.GetString("begin_timestamp"); len(timestamp) > 0 {
+		t, err := utils.ParseTimeStamp(timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("invalid begin_timestamp: %s", timestamp)
+		}
+		query.BeginTime = t
+	}
+	if timestamp := a.GetString("end_timestamp"); len(timestamp) > 0 {
+		t, err := utils.ParseTimeStamp(timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end_timestamp: %s", timestamp)
+		}
+		query.EndTime = t
+	}
+	return query, nil
+}
+
+// AuditLogPurgeAPI handles the schedule of the job which purges the audit logs
+// older than the retention period in the configurations
+type AuditLogPurgeAPI struct {
+	AJAPI
+}
+
+// Prepare validates the user, it needs the system admin permission.
+func (ap *AuditLogPurgeAPI) Prepare() {
+	ap.BaseController.Prepare()
+	if !ap.SecurityCtx.IsAuthenticated() {
+		ap.SendUnAuthorizedError(errors.New("UnAuthorized"))
+		return
+	}
+	if !ap.SecurityCtx.IsSysAdmin() {
+		ap.SendForbiddenError(errors.New(ap.SecurityCtx.GetUsername()))
+		return
+	}
+}
+
+// Post creates a cron schedule or a manual trigger for purging the audit logs
+func (ap *AuditLogPurgeAPI) Post() {
+	ajr := api_models.AdminJobReq{}
+	isValid, err := ap.DecodeJSONReqAndValidate(&ajr)
+	if !isValid {
+		ap.SendBadRequestError(err)
+		return
+	}
+	ajr.Name = common_job.AuditLogPurgeJob
+	ajr.Parameters = nil
+	ap.submit(&ajr)
+	ap.Redirect(http.StatusCreated, strconv.FormatInt(ajr.ID, 10))
+}
+
+// Put updates or deletes the cron schedule of purging the audit logs
+func (ap *AuditLogPurgeAPI) Put() {
+	ajr := api_models.AdminJobReq{}
+	isValid, err := ap.DecodeJSONReqAndValidate(&ajr)
+	if !isValid {
+		ap.SendBadRequestError(err)
+		return
+	}
+	ajr.Name = common_job.AuditLogPurgeJob
+	ajr.Parameters = nil
+	ap.updateSchedule(ajr)
+}
+
+// Get gets the schedule of purging the audit logs
+func (ap *AuditLogPurgeAPI) Get() {
+	ap.getSchedule(common_job.AuditLogPurgeJob)
+}
+
+// List returns the top 10 executions of purging the audit logs which includes manual and cron
+func (ap *AuditLogPurgeAPI) List() {
+	ap.list(common_job.AuditLogPurgeJob)
+}
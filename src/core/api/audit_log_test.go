@@ -0,0 +1,114 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLogAPI(t *testing.T) {
+	_, err := dao.AddAuditLog(&models.AuditLog{
+		Username:     "audit_api_user",
+		SourceIP:     "10.0.0.1",
+		Operation:    models.AuditLogOperationDelete,
+		ResourceType: "label",
+		Resource:     "/api/labels/1",
+		Method:       http.MethodDelete,
+		StatusCode:   http.StatusOK,
+		Result:       models.AuditLogResultSuccess,
+	})
+	require.Nil(t, err)
+	defer dao.DeleteAuditLogsBefore(time.Now().Add(time.Hour))
+
+	cases := []*codeCheckingCase{
+		// 401
+		{
+			request: &testingRequest{
+				method: http.MethodGet,
+				url:    "/api/logs/audit",
+			},
+			code: http.StatusUnauthorized,
+		},
+		// 403
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/logs/audit",
+				credential: nonSysAdmin,
+			},
+			code: http.StatusForbidden,
+		},
+		// 400
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/logs/audit?begin_timestamp=invalid",
+				credential: sysAdmin,
+			},
+			code: http.StatusBadRequest,
+		},
+		// 403
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/system/auditlog/purge/schedule",
+				credential: nonSysAdmin,
+			},
+			code: http.StatusForbidden,
+		},
+	}
+	runCodeCheckingCases(t, cases...)
+
+	logs := []*models.AuditLog{}
+	err = handleAndParse(&testingRequest{
+		method:     http.MethodGet,
+		url:        "/api/logs/audit?username=audit_api_user&resource_type=label",
+		credential: sysAdmin,
+	}, &logs)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(logs))
+	assert.Equal(t, "10.0.0.1", logs[0].SourceIP)
+
+	resp, err := handle(&testingRequest{
+		method:     http.MethodGet,
+		url:        "/api/logs/audit/export?username=audit_api_user",
+		credential: sysAdmin,
+	})
+	require.Nil(t, err)
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "text/csv", resp.Header().Get("Content-Type"))
+	lines := strings.Split(strings.TrimSpace(resp.Body.String()), "\n")
+	require.Equal(t, 2, len(lines))
+	assert.True(t, strings.HasPrefix(lines[0], "id,op_time,username"))
+	assert.Contains(t, lines[1], "/api/labels/1")
+}
+
+func TestEscapeCSVCell(t *testing.T) {
+	assert.Equal(t, "", escapeCSVCell(""))
+	assert.Equal(t, "admin", escapeCSVCell("admin"))
+	assert.Equal(t, "'=HYPERLINK(\"http://example.com\")", escapeCSVCell("=HYPERLINK(\"http://example.com\")"))
+	assert.Equal(t, "'+1", escapeCSVCell("+1"))
+	assert.Equal(t, "'-1", escapeCSVCell("-1"))
+	assert.Equal(t, "'@SUM(A1)", escapeCSVCell("@SUM(A1)"))
+	assert.Equal(t, "'\tcmd", escapeCSVCell("\tcmd"))
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/goharbor/harbor/src/common/api"
 	"github.com/goharbor/harbor/src/common/rbac"
 	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/common/security/accesstoken"
 	"github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/common/utils/log"
 	"github.com/goharbor/harbor/src/core/config"
@@ -96,6 +97,30 @@ func (b *BaseController) RequireAuthenticated() bool {
 	return true
 }
 
+// RequireNotAccessToken returns true when the request isn't authenticated by the personal access token
+// otherwise send Forbidden response and returns false, it protects the requests which change or generate
+// the credentials, as they may escape the scopes of the token
+func (b *BaseController) RequireNotAccessToken() bool {
+	if _, ok := b.SecurityCtx.(*accesstoken.SecurityContext); ok {
+		b.SendForbiddenError(errors.New("personal access token can't be used to manage the credentials"))
+		return false
+	}
+
+	return true
+}
+
+// RequireNotScopedAccessToken returns true when the request isn't authenticated by the scoped personal access token
+// otherwise send Forbidden response and returns false, it protects the requests which aren't checked against the
+// permissions of projects and so can't be limited by the scopes of the token, e.g. creating projects
+func (b *BaseController) RequireNotScopedAccessToken() bool {
+	if sc, ok := b.SecurityCtx.(*accesstoken.SecurityContext); ok && sc.IsScoped() {
+		b.SendForbiddenError(errors.New("scoped personal access token can't be used for the request"))
+		return false
+	}
+
+	return true
+}
+
 // HasProjectPermission returns true when the request has action permission on project subresource
 func (b *BaseController) HasProjectPermission(projectIDOrName interface{}, action rbac.Action, subresource ...rbac.Resource) (bool, error) {
 	projectID, projectName, err := utils.ParseProjectIDOrName(projectIDOrName)
@@ -170,6 +195,12 @@ func (b *BaseController) WriteYamlData(object interface{}) {
 	_, _ = w.Write(yData)
 }
 
+// SetAuditLogBefore keeps the resource before the change, it's recorded as the summary in the audit log
+// of the request together with the request body
+func (b *BaseController) SetAuditLogBefore(before interface{}) {
+	b.Ctx.Input.SetData(filter.AuditLogBeforeKey, before)
+}
+
 // PopulateUserSession generates a new session ID and fill the user model in parm to the session
 func (b *BaseController) PopulateUserSession(u models.User) {
 	b.SessionRegenerateID()
@@ -16,6 +16,7 @@ package api
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"errors"
@@ -113,6 +114,7 @@ func (c *ConfigAPI) Put() {
 
 	}
 
+	c.SetAuditLogBefore(c.currentCfgs(m))
 	if err := c.cfgManager.UpdateConfig(m); err != nil {
 		log.Errorf("failed to upload configurations: %v", err)
 		c.SendInternalServerError(errors.New(""))
@@ -135,9 +137,32 @@ func (c *ConfigAPI) validateCfg(cfgs map[string]interface{}) (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	if value, ok := cfgs[common.AuditLogRetentionDays]; ok {
+		days, err := strconv.Atoi(fmt.Sprintf("%v", value))
+		if err != nil || days < 0 {
+			return false, fmt.Errorf("%s should be a non-negative integer", common.AuditLogRetentionDays)
+		}
+	}
 	return false, nil
 }
 
+// currentCfgs returns the current values of the configurations to be updated, the passwords are excluded
+func (c *ConfigAPI) currentCfgs(cfgs map[string]interface{}) map[string]interface{} {
+	current := map[string]interface{}{}
+	all := c.cfgManager.GetUserCfgs()
+	for key := range cfgs {
+		if value, ok := all[key]; ok {
+			current[key] = value
+		}
+	}
+	for _, item := range metadata.Instance().GetAll() {
+		if _, ok := item.ItemType.(*metadata.PasswordType); ok {
+			delete(current, item.Name)
+		}
+	}
+	return current
+}
+
 // delete sensitive attrs and add editable field to every attr
 func convertForGet(cfg map[string]interface{}) (map[string]*value, error) {
 	result := map[string]*value{}
@@ -104,6 +104,9 @@ func init() {
 	beego.Router("/api/users/:id([0-9]+)/password", &UserAPI{}, "put:ChangePassword")
 	beego.Router("/api/users/:id/permissions", &UserAPI{}, "get:ListUserPermissions")
 	beego.Router("/api/users/:id/sysadmin", &UserAPI{}, "put:ToggleUserAdminRole")
+	beego.Router("/api/users/:id/gen_cli_secret", &UserAPI{}, "post:GenCLISecret")
+	beego.Router("/api/users/:id/tokens", &AccessTokenAPI{}, "get:List;post:Post")
+	beego.Router("/api/users/:id/tokens/:tid([0-9]+)", &AccessTokenAPI{}, "delete:Delete")
 	beego.Router("/api/projects/:id([0-9]+)/logs", &ProjectAPI{}, "get:Logs")
 	beego.Router("/api/projects/:id([0-9]+)/summary", &ProjectAPI{}, "get:Summary")
 	beego.Router("/api/projects/:id([0-9]+)/_deletable", &ProjectAPI{}, "get:Deletable")
@@ -116,12 +119,16 @@ func init() {
 	beego.Router("/api/users/?:id", &UserAPI{})
 	beego.Router("/api/usergroups/?:ugid([0-9]+)", &UserGroupAPI{})
 	beego.Router("/api/logs", &LogAPI{})
+	beego.Router("/api/logs/audit", &AuditLogAPI{}, "get:List")
+	beego.Router("/api/logs/audit/export", &AuditLogAPI{}, "get:Export")
 	beego.Router("/api/repositories/*", &RepositoryAPI{}, "put:Put")
 	beego.Router("/api/repositories/*/labels", &RepositoryLabelAPI{}, "get:GetOfRepository;post:AddToRepository")
 	beego.Router("/api/repositories/*/labels/:id([0-9]+", &RepositoryLabelAPI{}, "delete:RemoveFromRepository")
 	beego.Router("/api/repositories/*/tags/:tag/labels", &RepositoryLabelAPI{}, "get:GetOfImage;post:AddToImage")
 	beego.Router("/api/repositories/*/tags/:tag/labels/:id([0-9]+", &RepositoryLabelAPI{}, "delete:RemoveFromImage")
 	beego.Router("/api/repositories/*/tags/:tag", &RepositoryAPI{}, "delete:Delete;get:GetTag")
+	beego.Router("/api/repositories/*/manifests", &RepositoryAPI{}, "get:GetUntaggedManifests")
+	beego.Router("/api/repositories/*/manifests/:digest", &RepositoryAPI{}, "delete:DeleteManifest")
 	beego.Router("/api/repositories/*/tags", &RepositoryAPI{}, "get:GetTags;post:Retag")
 	beego.Router("/api/repositories/*/tags/:tag/manifest", &RepositoryAPI{}, "get:GetManifests")
 	beego.Router("/api/repositories/*/signatures", &RepositoryAPI{}, "get:GetSignatures")
@@ -144,18 +151,31 @@ func init() {
 	beego.Router("/api/labels/:id([0-9]+)/resources", &LabelAPI{}, "get:ListResources")
 	beego.Router("/api/ping", &SystemInfoAPI{}, "get:Ping")
 	beego.Router("/api/system/gc/:id", &GCAPI{}, "get:GetGC")
+	beego.Router("/api/system/gc/:id([0-9]+)", &GCAPI{}, "put:Operate")
 	beego.Router("/api/system/gc/:id([0-9]+)/log", &GCAPI{}, "get:GetLog")
 	beego.Router("/api/system/gc/schedule", &GCAPI{}, "get:Get;put:Put;post:Post")
 	beego.Router("/api/system/scanAll/schedule", &ScanAllAPI{}, "get:Get;put:Put;post:Post")
+	beego.Router("/api/system/auditlog/purge", &AuditLogPurgeAPI{}, "get:List")
+	beego.Router("/api/system/auditlog/purge/schedule", &AuditLogPurgeAPI{}, "get:Get;put:Put;post:Post")
+	beego.Router("/api/jobs/:uuid", &JobAPI{}, "put:Operate")
+	beego.Router("/api/system/jobservice/queues", &JobServiceDashboardAPI{}, "get:GetQueues")
+	beego.Router("/api/system/jobservice/running", &JobServiceDashboardAPI{}, "get:GetRunningJobs")
+	beego.Router("/api/system/jobservice/latency", &JobServiceDashboardAPI{}, "get:GetLatency")
 	beego.Router("/api/system/CVEWhitelist", &SysCVEWhitelistAPI{}, "get:Get;put:Put")
 	beego.Router("/api/system/oidc/ping", &OIDCAPI{}, "post:Ping")
 
 	beego.Router("/api/projects/:pid([0-9]+)/robots/", &RobotAPI{}, "post:Post;get:List")
 	beego.Router("/api/projects/:pid([0-9]+)/robots/:id([0-9]+)", &RobotAPI{}, "get:Get;put:Put;delete:Delete")
+	beego.Router("/api/robots", &SystemRobotAPI{}, "post:Post;get:List")
+	beego.Router("/api/projects/:pid([0-9]+)/robots/:id([0-9]+)/secret", &RobotAPI{}, "post:RegenerateSecret")
+	beego.Router("/api/robots/:id([0-9]+)", &SystemRobotAPI{}, "get:Get;put:Put;delete:Delete")
+	beego.Router("/api/robots/:id([0-9]+)/secret", &SystemRobotAPI{}, "post:RegenerateSecret")
+	beego.Router("/api/roles", &RoleAPI{}, "post:Post;get:List")
+	beego.Router("/api/roles/:id([0-9]+)", &RoleAPI{}, "get:Get;put:Put;delete:Delete")
 
 	beego.Router("/api/replication/adapters", &ReplicationAdapterAPI{}, "get:List")
 	beego.Router("/api/replication/executions", &ReplicationOperationAPI{}, "get:ListExecutions;post:CreateExecution")
-	beego.Router("/api/replication/executions/:id([0-9]+)", &ReplicationOperationAPI{}, "get:GetExecution;put:StopExecution")
+	beego.Router("/api/replication/executions/:id([0-9]+)", &ReplicationOperationAPI{}, "get:GetExecution;put:OperateExecution")
 	beego.Router("/api/replication/executions/:id([0-9]+)/tasks", &ReplicationOperationAPI{}, "get:ListTasks")
 	beego.Router("/api/replication/executions/:id([0-9]+)/tasks/:tid([0-9]+)/log", &ReplicationOperationAPI{}, "get:GetTaskLog")
 
@@ -163,6 +183,7 @@ func init() {
 	beego.Router("/api/replication/policies/:id([0-9]+)", &ReplicationPolicyAPI{}, "get:Get;put:Update;delete:Delete")
 
 	beego.Router("/api/retentions/metadatas", &RetentionAPI{}, "get:GetMetadatas")
+	beego.Router("/api/retentions/system", &RetentionAPI{}, "get:GetSystemRetention")
 	beego.Router("/api/retentions/:id", &RetentionAPI{}, "get:GetRetention")
 	beego.Router("/api/retentions", &RetentionAPI{}, "post:CreateRetention")
 	beego.Router("/api/retentions/:id", &RetentionAPI{}, "put:UpdateRetention")
@@ -171,12 +192,16 @@ func init() {
 	beego.Router("/api/retentions/:id/executions", &RetentionAPI{}, "get:ListRetentionExecs")
 	beego.Router("/api/retentions/:id/executions/:eid/tasks", &RetentionAPI{}, "get:ListRetentionExecTasks")
 	beego.Router("/api/retentions/:id/executions/:eid/tasks/:tid", &RetentionAPI{}, "get:GetRetentionExecTaskLog")
+	beego.Router("/api/retentions/:id/executions/:eid/report", &RetentionAPI{}, "get:GetRetentionExecReport")
 
 	beego.Router("/api/projects/:pid([0-9]+)/webhook/policies", &NotificationPolicyAPI{}, "get:List;post:Post")
 	beego.Router("/api/projects/:pid([0-9]+)/webhook/policies/:id([0-9]+)", &NotificationPolicyAPI{})
 	beego.Router("/api/projects/:pid([0-9]+)/webhook/policies/test", &NotificationPolicyAPI{}, "post:Test")
 	beego.Router("/api/projects/:pid([0-9]+)/webhook/lasttrigger", &NotificationPolicyAPI{}, "get:ListGroupByEventType")
 	beego.Router("/api/projects/:pid([0-9]+)/webhook/jobs/", &NotificationJobAPI{}, "get:List")
+	beego.Router("/api/projects/:pid([0-9]+)/webhook/jobs/:id([0-9]+)", &NotificationJobAPI{}, "put:Operate")
+	beego.Router("/api/projects/:pid([0-9]+)/webhook/jobs/:id([0-9]+)/deliveries", &NotificationJobAPI{}, "get:ListDeliveries")
+	beego.Router("/api/projects/:pid([0-9]+)/webhook/jobs/:id([0-9]+)/redeliver", &NotificationJobAPI{}, "post:Redeliver")
 	beego.Router("/api/projects/:pid([0-9]+)/immutabletagrules", &ImmutableTagRuleAPI{}, "get:List;post:Post")
 	beego.Router("/api/projects/:pid([0-9]+)/immutabletagrules/:id([0-9]+)", &ImmutableTagRuleAPI{})
 	// Charts are controlled under projects
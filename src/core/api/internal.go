@@ -128,8 +128,13 @@ func (ia *InternalAPI) ensureQuota() error {
 			continue
 		}
 		pCount := int64(len(afs))
+		repos := make(map[string]struct{})
+		for _, af := range afs {
+			repos[af.Repo] = struct{}{}
+		}
+		var chartSize int64
 
-		// it needs to append the chart count
+		// it needs to append the chart count and chart storage
 		if config.WithChartMuseum() {
 			count, err := chartController.GetCountOfCharts([]string{project.Name})
 			if err != nil {
@@ -138,6 +143,13 @@ func (ia *InternalAPI) ensureQuota() error {
 				continue
 			}
 			pCount = pCount + int64(count)
+
+			chartSize, err = chartController.GetStorageOfCharts(project.Name)
+			if err != nil {
+				err = errors.Wrap(err, fmt.Sprintf("get chart storage of project %d failed", project.ProjectID))
+				logger.Error(err)
+				continue
+			}
 		}
 
 		quotaMgr, err := common_quota.NewManager("project", strconv.FormatInt(project.ProjectID, 10))
@@ -146,8 +158,10 @@ func (ia *InternalAPI) ensureQuota() error {
 			continue
 		}
 		used := common_quota.ResourceList{
-			common_quota.ResourceStorage: pSize,
-			common_quota.ResourceCount:   pCount,
+			common_quota.ResourceStorage:         pSize,
+			common_quota.ResourceCount:           pCount,
+			common_quota.ResourceRepositoryCount: int64(len(repos)),
+			common_quota.ResourceChartStorage:    chartSize,
 		}
 		if err := quotaMgr.EnsureQuota(used); err != nil {
 			logger.Errorf("cannot ensure quota for the project: %d, err: %v, just skip it.", project.ProjectID, err)
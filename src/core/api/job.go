@@ -0,0 +1,90 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+
+	common_job "github.com/goharbor/harbor/src/common/job"
+	"github.com/goharbor/harbor/src/core/api/models"
+	"github.com/goharbor/harbor/src/core/utils"
+)
+
+// JobAPI handles the requests to stop or retry the jobs of jobservice directly by the job ID,
+// the status changes are reported to the feature owning the job by the hooks as usual
+type JobAPI struct {
+	BaseController
+}
+
+// Prepare validates the request initially, it needs the system admin permission
+func (j *JobAPI) Prepare() {
+	j.BaseController.Prepare()
+	if !j.SecurityCtx.IsAuthenticated() {
+		j.SendUnAuthorizedError(errors.New("UnAuthorized"))
+		return
+	}
+	if !j.SecurityCtx.IsSysAdmin() {
+		j.SendForbiddenError(errors.New(j.SecurityCtx.GetUsername()))
+		return
+	}
+}
+
+// Operate stops or retries the job specified by the ID of jobservice
+func (j *JobAPI) Operate() {
+	uuid := j.GetStringFromPath(":uuid")
+	if len(uuid) == 0 {
+		j.SendBadRequestError(errors.New("empty job ID"))
+		return
+	}
+	action, ok := j.decodeJobAction("")
+	if !ok {
+		return
+	}
+	j.operateJob(uuid, action)
+}
+
+// decodeJobAction returns the action in the request, the default action is used if the request body is empty
+// and it is specified. The error is sent and false is returned if the action is invalid.
+func (b *BaseController) decodeJobAction(defaultAction string) (string, bool) {
+	if len(b.Ctx.Input.RequestBody) == 0 && len(defaultAction) > 0 {
+		return defaultAction, true
+	}
+	req := &models.JobActionReq{}
+	isValid, err := b.DecodeJSONReqAndValidate(req)
+	if !isValid {
+		b.SendBadRequestError(err)
+		return "", false
+	}
+	return req.Action, true
+}
+
+// operateJob sends the action to jobservice for the job specified by the uuid
+// and sends the error if it fails
+func (b *BaseController) operateJob(uuid, action string) bool {
+	jobAction := common_job.JobActionStop
+	if action == models.JobActionRetry {
+		jobAction = common_job.JobActionRetry
+	}
+	if err := utils.GetJobServiceClient().PostAction(uuid, jobAction); err != nil {
+		if e, ok := err.(*common_job.StatusBehindError); ok {
+			b.SendBadRequestError(fmt.Errorf("the job %s is already in %s status", uuid, e.Status()))
+			return false
+		}
+		b.ParseAndHandleError(fmt.Sprintf("failed to %s the job %s", action, uuid), err)
+		return false
+	}
+	return true
+}
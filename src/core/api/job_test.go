@@ -0,0 +1,94 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/goharbor/harbor/src/core/api/models"
+)
+
+func TestJobAPI(t *testing.T) {
+	cases := []*codeCheckingCase{
+		// 401
+		{
+			request: &testingRequest{
+				method: http.MethodPut,
+				url:    "/api/jobs/u-1234-5678-9012",
+			},
+			code: http.StatusUnauthorized,
+		},
+		// 403
+		{
+			request: &testingRequest{
+				method:     http.MethodPut,
+				url:        "/api/jobs/u-1234-5678-9012",
+				credential: nonSysAdmin,
+				bodyJSON: &models.JobActionReq{
+					Action: models.JobActionStop,
+				},
+			},
+			code: http.StatusForbidden,
+		},
+		// 400, no action
+		{
+			request: &testingRequest{
+				method:     http.MethodPut,
+				url:        "/api/jobs/u-1234-5678-9012",
+				credential: sysAdmin,
+				bodyJSON:   &models.JobActionReq{},
+			},
+			code: http.StatusBadRequest,
+		},
+		// 400, invalid action
+		{
+			request: &testingRequest{
+				method:     http.MethodPut,
+				url:        "/api/jobs/u-1234-5678-9012",
+				credential: sysAdmin,
+				bodyJSON: &models.JobActionReq{
+					Action: "pause",
+				},
+			},
+			code: http.StatusBadRequest,
+		},
+		// 200
+		{
+			request: &testingRequest{
+				method:     http.MethodPut,
+				url:        "/api/jobs/u-1234-5678-9012",
+				credential: sysAdmin,
+				bodyJSON: &models.JobActionReq{
+					Action: models.JobActionStop,
+				},
+			},
+			code: http.StatusOK,
+		},
+		// 200
+		{
+			request: &testingRequest{
+				method:     http.MethodPut,
+				url:        "/api/jobs/u-1234-5678-9012",
+				credential: sysAdmin,
+				bodyJSON: &models.JobActionReq{
+					Action: models.JobActionRetry,
+				},
+			},
+			code: http.StatusOK,
+		},
+	}
+	runCodeCheckingCases(t, cases...)
+}
@@ -0,0 +1,87 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/goharbor/harbor/src/core/utils"
+)
+
+const (
+	// defaultLatencyWindow is the window(seconds) used to calculate the job latency when it isn't specified
+	defaultLatencyWindow = 3600
+	// maxLatencyWindow is the max window(seconds), jobservice keeps the latency samples of the last 24 hours only
+	maxLatencyWindow = 24 * 3600
+)
+
+// JobServiceDashboardAPI handles the requests to inspect the status of the job queues of jobservice
+type JobServiceDashboardAPI struct {
+	BaseController
+}
+
+// Prepare validates the request initially, it needs the system admin permission
+func (j *JobServiceDashboardAPI) Prepare() {
+	j.BaseController.Prepare()
+	if !j.SecurityCtx.IsAuthenticated() {
+		j.SendUnAuthorizedError(errors.New("UnAuthorized"))
+		return
+	}
+	if !j.SecurityCtx.IsSysAdmin() {
+		j.SendForbiddenError(errors.New(j.SecurityCtx.GetUsername()))
+		return
+	}
+}
+
+// GetQueues returns the pending, running and retrying job count of each job kind
+func (j *JobServiceDashboardAPI) GetQueues() {
+	queues, err := utils.GetJobServiceClient().GetQueues()
+	if err != nil {
+		j.ParseAndHandleError("failed to get the job queues", err)
+		return
+	}
+	j.WriteJSONData(queues)
+}
+
+// GetRunningJobs returns the jobs being executed by the workers of jobservice
+func (j *JobServiceDashboardAPI) GetRunningJobs() {
+	jobs, err := utils.GetJobServiceClient().GetRunningJobs()
+	if err != nil {
+		j.ParseAndHandleError("failed to get the running jobs", err)
+		return
+	}
+	j.WriteJSONData(jobs)
+}
+
+// GetLatency returns the p50/p95 execution latency of each job kind finished in the window,
+// the window is in seconds and can't exceed 24 hours
+func (j *JobServiceDashboardAPI) GetLatency() {
+	window, err := j.GetInt64("window", defaultLatencyWindow)
+	if err != nil || window <= 0 {
+		j.SendBadRequestError(fmt.Errorf("invalid window: %s", j.GetString("window")))
+		return
+	}
+	if window > maxLatencyWindow {
+		j.SendBadRequestError(fmt.Errorf("the window %d exceeds the max value %d", window, maxLatencyWindow))
+		return
+	}
+	latency, err := utils.GetJobServiceClient().GetLatency(window)
+	if err != nil {
+		j.ParseAndHandleError("failed to get the job latency", err)
+		return
+	}
+	j.WriteJSONData(latency)
+}
@@ -244,6 +244,7 @@ func (l *LabelAPI) Put() {
 	}
 
 	oldName := l.label.Name
+	l.SetAuditLogBefore(*l.label)
 
 	// only name, description and color can be changed
 	l.label.Name = label.Name
@@ -289,6 +290,7 @@ func (l *LabelAPI) Delete() {
 	}
 
 	id := l.label.ID
+	l.SetAuditLogBefore(*l.label)
 	if err := dao.DeleteResourceLabelByLabel(id); err != nil {
 		l.SendInternalServerError(fmt.Errorf("failed to delete resource label mappings of label %d: %v", id, err))
 		return
@@ -134,6 +134,11 @@ func (m *MetadataAPI) Post() {
 		return
 	}
 
+	if _, ok := ms[models.ProMetaProxyCacheRegistryID]; ok {
+		m.SendBadRequestError(errors.New("the upstream registry of proxy cache project can only be set when creating project"))
+		return
+	}
+
 	if len(ms) != 1 {
 		m.SendBadRequestError(errors.New("invalid request: has no valid key/value pairs or has more than one valid key/value pairs"))
 		return
@@ -177,6 +182,11 @@ func (m *MetadataAPI) Put() {
 		return
 	}
 
+	if m.name == models.ProMetaProxyCacheRegistryID {
+		m.SendBadRequestError(errors.New("the upstream registry of proxy cache project cannot be changed"))
+		return
+	}
+
 	ms, err := validateProjectMetadata(map[string]string{
 		m.name: meta,
 	})
@@ -215,7 +225,8 @@ func validateProjectMetadata(metas map[string]string) (map[string]string, error)
 		models.ProMetaPublic,
 		models.ProMetaEnableContentTrust,
 		models.ProMetaPreventVul,
-		models.ProMetaAutoScan}
+		models.ProMetaAutoScan,
+		models.ProMetaRetentionOptOut}
 
 	for _, boolMeta := range boolMetas {
 		value, exist := metas[boolMeta]
@@ -228,6 +239,21 @@ func validateProjectMetadata(metas map[string]string) (map[string]string, error)
 		}
 	}
 
+	intMetas := []string{
+		models.ProMetaProxyCacheRegistryID,
+		models.ProMetaProxyCacheExpiry}
+
+	for _, intMeta := range intMetas {
+		value, exist := metas[intMeta]
+		if exist {
+			i, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || i <= 0 {
+				return nil, fmt.Errorf("invalid %s %s, must be a positive integer", intMeta, value)
+			}
+			metas[intMeta] = strconv.FormatInt(i, 10)
+		}
+	}
+
 	value, exist := metas[models.ProMetaSeverity]
 	if exist {
 		switch strings.ToLower(value) {
@@ -80,6 +80,12 @@ type AdminJobRep struct {
 	UpdateTime   time.Time `json:"update_time"`
 }
 
+// GCRep holds the response of query GC job, the report is the one of the latest execution
+type GCRep struct {
+	AdminJobRep
+	Report *common_models.GCReport `json:"report,omitempty"`
+}
+
 // Valid validates the schedule type of a admin job request.
 // Only scheduleHourly, ScheduleDaily, ScheduleWeekly, ScheduleCustom, ScheduleManual, ScheduleNone are accepted.
 func (ar *AdminJobReq) Valid(v *validation.Validation) {
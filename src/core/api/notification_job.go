@@ -1,11 +1,15 @@
 package api
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 
 	"github.com/goharbor/harbor/src/common/models"
 	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/goharbor/harbor/src/core/notifier/event"
+	notifierModel "github.com/goharbor/harbor/src/core/notifier/model"
 	"github.com/goharbor/harbor/src/pkg/notification"
 )
 
@@ -88,6 +92,127 @@ func (w *NotificationJobAPI) List() {
 	w.WriteJSONData(jobs)
 }
 
+// ListDeliveries lists the delivery attempts of the notification job
+func (w *NotificationJobAPI) ListDeliveries() {
+	if !w.validateRBAC(rbac.ActionList, w.project.ProjectID) {
+		return
+	}
+
+	job, _ := w.getJob()
+	if job == nil {
+		return
+	}
+
+	deliveries, err := notification.JobMgr.ListDeliveries(job.ID)
+	if err != nil {
+		w.SendInternalServerError(fmt.Errorf("failed to list the deliveries of notification job %d: %v", job.ID, err))
+		return
+	}
+	w.WriteJSONData(deliveries)
+}
+
+// Redeliver sends the event of the notification job to the targets of the policy again,
+// a new notification job is created for each target
+func (w *NotificationJobAPI) Redeliver() {
+	if !w.validateRBAC(rbac.ActionCreate, w.project.ProjectID) {
+		return
+	}
+
+	job, policy := w.getJob()
+	if job == nil {
+		return
+	}
+
+	payload := &notifierModel.Payload{}
+	if err := json.Unmarshal([]byte(job.JobDetail), payload); err != nil {
+		w.SendInternalServerError(fmt.Errorf("failed to resolve the payload of notification job %d: %v", job.ID, err))
+		return
+	}
+
+	redelivered := false
+	for i := range policy.Targets {
+		target := policy.Targets[i]
+		if target.Type != job.NotifyType {
+			continue
+		}
+		evt := &event.Event{}
+		if err := evt.Build(&event.HookMetaData{
+			PolicyID:  policy.ID,
+			EventType: job.EventType,
+			Target:    &target,
+			Payload:   payload,
+			Secret:    policy.Secret,
+		}); err != nil {
+			w.SendInternalServerError(fmt.Errorf("failed to build the event of notification job %d: %v", job.ID, err))
+			return
+		}
+		if err := evt.Publish(); err != nil {
+			w.SendInternalServerError(fmt.Errorf("failed to redeliver notification job %d: %v", job.ID, err))
+			return
+		}
+		redelivered = true
+		log.Debugf("notification job %d is redelivered to %s", job.ID, target.Address)
+	}
+
+	if !redelivered {
+		w.SendBadRequestError(fmt.Errorf("no %s target found in policy %d", job.NotifyType, policy.ID))
+		return
+	}
+}
+
+// Operate stops or retries the notification job
+func (w *NotificationJobAPI) Operate() {
+	if !w.validateRBAC(rbac.ActionCreate, w.project.ProjectID) {
+		return
+	}
+
+	job, _ := w.getJob()
+	if job == nil {
+		return
+	}
+
+	action, ok := w.decodeJobAction("")
+	if !ok {
+		return
+	}
+	if len(job.UUID) == 0 {
+		w.SendBadRequestError(fmt.Errorf("notification job %d isn't submitted to jobservice yet", job.ID))
+		return
+	}
+	w.operateJob(job.UUID, action)
+}
+
+// getJob returns the notification job specified in URL and its policy,
+// nil is returned and the error is sent if the job isn't found in the project
+func (w *NotificationJobAPI) getJob() (*models.NotificationJob, *models.NotificationPolicy) {
+	id, err := w.GetIDFromURL()
+	if err != nil {
+		w.SendBadRequestError(err)
+		return nil, nil
+	}
+
+	job, err := notification.JobMgr.Get(id)
+	if err != nil {
+		w.SendInternalServerError(fmt.Errorf("failed to get notification job %d: %v", id, err))
+		return nil, nil
+	}
+	if job == nil {
+		w.SendNotFoundError(fmt.Errorf("notification job %d not found", id))
+		return nil, nil
+	}
+
+	policy, err := notification.PolicyMgr.Get(job.PolicyID)
+	if err != nil {
+		w.SendInternalServerError(fmt.Errorf("failed to get policy %d: %v", job.PolicyID, err))
+		return nil, nil
+	}
+	if policy == nil || policy.ProjectID != w.project.ProjectID {
+		w.SendNotFoundError(fmt.Errorf("notification job %d not found", id))
+		return nil, nil
+	}
+	return job, policy
+}
+
 func (w *NotificationJobAPI) validateRBAC(action rbac.Action, projectID int64) bool {
 	if w.SecurityCtx.IsSysAdmin() {
 		return true
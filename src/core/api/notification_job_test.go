@@ -38,6 +38,31 @@ func (f *fakedNotificationJobMgr) ListJobsGroupByEventType(policyID int64) ([]*m
 	}, nil
 }
 
+func (f *fakedNotificationJobMgr) Get(id int64) (*models.NotificationJob, error) {
+	switch id {
+	case 1:
+		return &models.NotificationJob{ID: 1, PolicyID: 1, NotifyType: "http", JobDetail: `{"type":"pushImage"}`}, nil
+	case 2:
+		return &models.NotificationJob{ID: 2, PolicyID: 2, NotifyType: "http", JobDetail: `{"type":"pushImage"}`}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func (f *fakedNotificationJobMgr) CreateDelivery(delivery *models.NotificationDelivery) (int64, error) {
+	return 1, nil
+}
+
+func (f *fakedNotificationJobMgr) ListDeliveries(jobID int64) ([]*models.NotificationDelivery, error) {
+	return []*models.NotificationDelivery{
+		{
+			ID:         1,
+			JobID:      jobID,
+			StatusCode: http.StatusOK,
+		},
+	}, nil
+}
+
 func TestNotificationJobAPI_List(t *testing.T) {
 	policyMgr := notification.PolicyMgr
 	jobMgr := notification.JobMgr
@@ -105,3 +130,112 @@ func TestNotificationJobAPI_List(t *testing.T) {
 	}
 	runCodeCheckingCases(t, cases...)
 }
+
+func TestNotificationJobAPI_ListDeliveries(t *testing.T) {
+	policyMgr := notification.PolicyMgr
+	jobMgr := notification.JobMgr
+	defer func() {
+		notification.PolicyMgr = policyMgr
+		notification.JobMgr = jobMgr
+	}()
+	notification.PolicyMgr = &fakedNotificationPlyMgr{}
+	notification.JobMgr = &fakedNotificationJobMgr{}
+
+	cases := []*codeCheckingCase{
+		// 401
+		{
+			request: &testingRequest{
+				method: http.MethodGet,
+				url:    "/api/projects/1/webhook/jobs/1/deliveries",
+			},
+			code: http.StatusUnauthorized,
+		},
+		// 403
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/projects/1/webhook/jobs/1/deliveries",
+				credential: nonSysAdmin,
+			},
+			code: http.StatusForbidden,
+		},
+		// 404 job not found
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/projects/1/webhook/jobs/123/deliveries",
+				credential: sysAdmin,
+			},
+			code: http.StatusNotFound,
+		},
+		// 404 job not in the project
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/projects/1/webhook/jobs/2/deliveries",
+				credential: sysAdmin,
+			},
+			code: http.StatusNotFound,
+		},
+		// 200
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/projects/1/webhook/jobs/1/deliveries",
+				credential: sysAdmin,
+			},
+			code: http.StatusOK,
+		},
+	}
+	runCodeCheckingCases(t, cases...)
+}
+
+func TestNotificationJobAPI_Redeliver(t *testing.T) {
+	policyMgr := notification.PolicyMgr
+	jobMgr := notification.JobMgr
+	defer func() {
+		notification.PolicyMgr = policyMgr
+		notification.JobMgr = jobMgr
+	}()
+	notification.PolicyMgr = &fakedNotificationPlyMgr{}
+	notification.JobMgr = &fakedNotificationJobMgr{}
+
+	cases := []*codeCheckingCase{
+		// 401
+		{
+			request: &testingRequest{
+				method: http.MethodPost,
+				url:    "/api/projects/1/webhook/jobs/1/redeliver",
+			},
+			code: http.StatusUnauthorized,
+		},
+		// 403
+		{
+			request: &testingRequest{
+				method:     http.MethodPost,
+				url:        "/api/projects/1/webhook/jobs/1/redeliver",
+				credential: nonSysAdmin,
+			},
+			code: http.StatusForbidden,
+		},
+		// 404 job not found
+		{
+			request: &testingRequest{
+				method:     http.MethodPost,
+				url:        "/api/projects/1/webhook/jobs/123/redeliver",
+				credential: sysAdmin,
+			},
+			code: http.StatusNotFound,
+		},
+		// 400 no http target in the policy
+		{
+			request: &testingRequest{
+				method:     http.MethodPost,
+				url:        "/api/projects/1/webhook/jobs/1/redeliver",
+				credential: sysAdmin,
+			},
+			code: http.StatusBadRequest,
+		},
+	}
+	runCodeCheckingCases(t, cases...)
+}
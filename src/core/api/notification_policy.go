@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/mail"
 	"strconv"
 	"time"
 
@@ -12,7 +13,9 @@ import (
 	"github.com/goharbor/harbor/src/common/models"
 	"github.com/goharbor/harbor/src/common/rbac"
 	"github.com/goharbor/harbor/src/common/utils"
+	notifierHandler "github.com/goharbor/harbor/src/core/notifier/handler/notification"
 	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/notification/model"
 )
 
 // NotificationPolicyAPI ...
@@ -86,6 +89,8 @@ func (w *NotificationPolicyAPI) Get() {
 		return
 	}
 
+	// the secret is write only
+	policy.Secret = ""
 	w.WriteJSONData(policy)
 }
 
@@ -170,6 +175,10 @@ func (w *NotificationPolicyAPI) Put() {
 
 	policy.ID = id
 	policy.ProjectID = w.project.ProjectID
+	// keep the secret if it isn't specified in the request
+	if len(policy.Secret) == 0 {
+		policy.Secret = oriPolicy.Secret
+	}
 
 	if err = notification.PolicyMgr.Update(policy); err != nil {
 		w.SendInternalServerError(fmt.Errorf("failed to update the notification policy: %v", err))
@@ -193,6 +202,7 @@ func (w *NotificationPolicyAPI) List() {
 	policies := []*models.NotificationPolicy{}
 	if res != nil {
 		for _, policy := range res {
+			policy.Secret = ""
 			policies = append(policies, policy)
 		}
 	}
@@ -296,6 +306,18 @@ func (w *NotificationPolicyAPI) validateTargets(policy *models.NotificationPolic
 	}
 
 	for _, target := range policy.Targets {
+		if target.Type == model.NotifyTypeEmail {
+			if _, err := mail.ParseAddressList(target.Address); err != nil {
+				w.SendBadRequestError(fmt.Errorf("invalid email address %s: %v", target.Address, err))
+				return false
+			}
+			if err := notifierHandler.ValidateEmailTemplates(target.Subject, target.Template); err != nil {
+				w.SendBadRequestError(err)
+				return false
+			}
+			continue
+		}
+
 		url, err := utils.ParseEndpoint(target.Address)
 		if err != nil {
 			w.SendBadRequestError(err)
@@ -304,6 +326,11 @@ func (w *NotificationPolicyAPI) validateTargets(policy *models.NotificationPolic
 		// Prevent SSRF security issue #3755
 		target.Address = url.Scheme + "://" + url.Host + url.Path
 
+		if !notifierHandler.IsValidPayloadFormat(target.PayloadFormat) {
+			w.SendBadRequestError(fmt.Errorf("unsupported payload format %s with policy %s", target.PayloadFormat, policy.Name))
+			return false
+		}
+
 		_, ok := notification.SupportedNotifyTypes[target.Type]
 		if !ok {
 			w.SendBadRequestError(fmt.Errorf("unsupport target type %s with policy %s", target.Type, policy.Name))
@@ -32,7 +32,9 @@ import (
 	errutil "github.com/goharbor/harbor/src/common/utils/error"
 	"github.com/goharbor/harbor/src/common/utils/log"
 	"github.com/goharbor/harbor/src/core/config"
+	"github.com/goharbor/harbor/src/pkg/proxy"
 	"github.com/goharbor/harbor/src/pkg/types"
+	"github.com/goharbor/harbor/src/replication"
 	"github.com/pkg/errors"
 )
 
@@ -96,6 +98,10 @@ func (p *ProjectAPI) Post() {
 		p.SendUnAuthorizedError(errors.New("Unauthorized"))
 		return
 	}
+	// the creator becomes the admin of the project, which is out of the scopes of the token
+	if !p.RequireNotScopedAccessToken() {
+		return
+	}
 	var onlyAdmin bool
 	var err error
 	if config.WithAdmiral() {
@@ -127,6 +133,16 @@ func (p *ProjectAPI) Post() {
 		return
 	}
 
+	if _, ok := pro.Metadata[models.ProMetaProxyCacheRegistryID]; ok {
+		if !p.SecurityCtx.IsSysAdmin() {
+			p.SendForbiddenError(errors.New("Only system admin can create proxy cache project"))
+			return
+		}
+		if !p.validateProxyCacheRegistry(pro) {
+			return
+		}
+	}
+
 	var hardLimits types.ResourceList
 	if config.QuotaPerProjectEnable() {
 		setting, err := config.QuotaSetting()
@@ -139,6 +155,8 @@ func (p *ProjectAPI) Post() {
 		if !p.SecurityCtx.IsSysAdmin() {
 			pro.CountLimit = &setting.CountPerProject
 			pro.StorageLimit = &setting.StoragePerProject
+			pro.RepositoryCountLimit = &setting.RepositoryCountPerProject
+			pro.ChartStorageLimit = &setting.ChartStoragePerProject
 		}
 
 		hardLimits, err = projectQuotaHardLimits(pro, setting)
@@ -232,6 +250,21 @@ func (p *ProjectAPI) Post() {
 	p.Redirect(http.StatusCreated, strconv.FormatInt(projectID, 10))
 }
 
+// validateProxyCacheRegistry checks the existence of the upstream registry the proxy cache project bound to
+func (p *ProjectAPI) validateProxyCacheRegistry(pro *models.ProjectRequest) bool {
+	id, _ := strconv.ParseInt(pro.Metadata[models.ProMetaProxyCacheRegistryID], 10, 64)
+	registry, err := replication.RegistryMgr.Get(id)
+	if err != nil {
+		p.SendInternalServerError(fmt.Errorf("failed to get registry %d: %v", id, err))
+		return false
+	}
+	if registry == nil {
+		p.SendBadRequestError(fmt.Errorf("registry %d not found", id))
+		return false
+	}
+	return true
+}
+
 // Head ...
 func (p *ProjectAPI) Head() {
 
@@ -278,6 +311,7 @@ func (p *ProjectAPI) Delete() {
 	if !p.requireAccess(rbac.ActionDelete) {
 		return
 	}
+	p.SetAuditLogBefore(*p.project)
 
 	result, err := p.deletable(p.project.ProjectID)
 	if err != nil {
@@ -295,6 +329,12 @@ func (p *ProjectAPI) Delete() {
 		return
 	}
 
+	if p.project.IsProxyCache() {
+		if err := proxy.Mgr.DeleteByProject(p.project.ProjectID); err != nil {
+			log.Errorf("failed to delete the cache entries of project %d: %v", p.project.ProjectID, err)
+		}
+	}
+
 	quotaMgr, err := quota.NewManager("project", strconv.FormatInt(p.project.ProjectID, 10))
 	if err != nil {
 		p.SendInternalServerError(fmt.Errorf("failed to get quota manager: %v", err))
@@ -505,6 +545,12 @@ func (p *ProjectAPI) Put() {
 		return
 	}
 
+	if _, ok := req.Metadata[models.ProMetaProxyCacheRegistryID]; ok {
+		p.SendBadRequestError(errors.New("the upstream registry of proxy cache project cannot be changed"))
+		return
+	}
+	p.SetAuditLogBefore(*p.project)
+
 	if err := p.ProjectMgr.Update(p.project.ProjectID,
 		&models.Project{
 			Metadata:     req.Metadata,
@@ -605,6 +651,10 @@ func (p *ProjectAPI) Summary() {
 	}
 	wg.Wait()
 
+	if p.project.IsProxyCache() {
+		getProjectProxyCacheSummary(p.project, summary)
+	}
+
 	p.Data["json"] = summary
 	p.ServeJSON()
 }
@@ -644,6 +694,18 @@ func projectQuotaHardLimits(req *models.ProjectRequest, setting *models.QuotaSet
 		hardLimits[types.ResourceStorage] = setting.StoragePerProject
 	}
 
+	if req.RepositoryCountLimit != nil {
+		hardLimits[types.ResourceRepositoryCount] = *req.RepositoryCountLimit
+	} else {
+		hardLimits[types.ResourceRepositoryCount] = setting.RepositoryCountPerProject
+	}
+
+	if req.ChartStorageLimit != nil {
+		hardLimits[types.ResourceChartStorage] = *req.ChartStorageLimit
+	} else {
+		hardLimits[types.ResourceChartStorage] = setting.ChartStoragePerProject
+	}
+
 	if err := quota.Validate("project", hardLimits); err != nil {
 		return nil, err
 	}
@@ -674,18 +736,43 @@ func getProjectQuotaSummary(projectID int64, summary *models.ProjectSummary) {
 	summary.Quota.Used, _ = types.NewResourceList(quota.Used)
 }
 
+func getProjectProxyCacheSummary(project *models.Project, summary *models.ProjectSummary) {
+	proxyCache, err := proxy.Mgr.Summary(project.ProjectID)
+	if err != nil {
+		log.Debugf("failed to get proxy cache summary for project: %d", project.ProjectID)
+		return
+	}
+
+	proxyCache.RegistryID = project.ProxyCacheRegistryID()
+	summary.ProxyCache = proxyCache
+}
+
 func getProjectMemberSummary(projectID int64, summary *models.ProjectSummary) {
 	var wg sync.WaitGroup
 
-	for _, e := range []struct {
+	type roleCount struct {
 		role  int
 		count *int64
-	}{
+	}
+	counts := []roleCount{
 		{common.RoleProjectAdmin, &summary.ProjectAdminCount},
 		{common.RoleMaster, &summary.MasterCount},
 		{common.RoleDeveloper, &summary.DeveloperCount},
 		{common.RoleGuest, &summary.GuestCount},
-	} {
+	}
+
+	customCounts := []roleCount{}
+	roles, err := dao.ListRoles()
+	if err != nil {
+		log.Debugf("failed to list roles: %v", err)
+	}
+	for _, role := range roles {
+		if role.IsCustom() {
+			customCounts = append(customCounts, roleCount{role.RoleID, new(int64)})
+		}
+	}
+
+	for _, e := range append(counts, customCounts...) {
 		wg.Add(1)
 		go func(role int, count *int64) {
 			defer wg.Done()
@@ -701,4 +788,11 @@ func getProjectMemberSummary(projectID int64, summary *models.ProjectSummary) {
 	}
 
 	wg.Wait()
+
+	if len(customCounts) > 0 {
+		summary.CustomRoleCount = map[int]int64{}
+		for _, e := range customCounts {
+			summary.CustomRoleCount[e.role] = *e.count
+		}
+	}
 }
@@ -46,7 +46,7 @@ type ProjectMemberAPI struct {
 var ErrDuplicateProjectMember = errors.New("The project member specified already exist")
 
 // ErrInvalidRole ...
-var ErrInvalidRole = errors.New("Failed to update project member, role is not in 1,2,3")
+var ErrInvalidRole = errors.New("Failed to update project member, role doesn't exist")
 
 // Prepare validates the URL and parms
 func (pma *ProjectMemberAPI) Prepare() {
@@ -186,16 +186,22 @@ func (pma *ProjectMemberAPI) Put() {
 	}
 	pid := pma.project.ProjectID
 	pmID := pma.id
+	pma.setMemberAuditLogBefore()
 	var req models.Member
 	if err := pma.DecodeJSONReq(&req); err != nil {
 		pma.SendBadRequestError(err)
 		return
 	}
-	if req.Role < 1 || req.Role > 4 {
+	valid, err := isValidRole(req.Role)
+	if err != nil {
+		pma.SendInternalServerError(fmt.Errorf("Failed to check the role %d: %v", req.Role, err))
+		return
+	}
+	if !valid {
 		pma.SendBadRequestError(fmt.Errorf("Invalid role id %v", req.Role))
 		return
 	}
-	err := project.UpdateProjectMemberRole(pmID, req.Role)
+	err = project.UpdateProjectMemberRole(pmID, req.Role)
 	if err != nil {
 		pma.SendInternalServerError(fmt.Errorf("Failed to update DB to add project user role, project id: %d, pmid : %d, role id: %d", pid, pmID, req.Role))
 		return
@@ -208,6 +214,7 @@ func (pma *ProjectMemberAPI) Delete() {
 		return
 	}
 	pmid := pma.id
+	pma.setMemberAuditLogBefore()
 	err := project.DeleteProjectMemberByID(pmid)
 	if err != nil {
 		pma.SendInternalServerError(fmt.Errorf("Failed to delete project roles for user, project member id: %d, error: %v", pmid, err))
@@ -215,6 +222,18 @@ func (pma *ProjectMemberAPI) Delete() {
 	}
 }
 
+// setMemberAuditLogBefore records the member before the change in the audit log
+func (pma *ProjectMemberAPI) setMemberAuditLogBefore() {
+	members, err := project.GetProjectMember(models.Member{ProjectID: pma.project.ProjectID, ID: pma.id})
+	if err != nil {
+		log.Warningf("failed to get project member %d for audit log: %v", pma.id, err)
+		return
+	}
+	if len(members) > 0 {
+		pma.SetAuditLogBefore(members[0])
+	}
+}
+
 // AddProjectMember ...
 func AddProjectMember(projectID int64, request models.MemberReq) (int, error) {
 	var member models.Member
@@ -284,7 +303,11 @@ func AddProjectMember(projectID int64, request models.MemberReq) (int, error) {
 		return 0, ErrDuplicateProjectMember
 	}
 
-	if member.Role < 1 || member.Role > 4 {
+	valid, err := isValidRole(member.Role)
+	if err != nil {
+		return 0, err
+	}
+	if !valid {
 		// Return invalid role error
 		return 0, ErrInvalidRole
 	}
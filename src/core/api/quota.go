@@ -20,6 +20,7 @@ import (
 	"github.com/goharbor/harbor/src/common/dao"
 	"github.com/goharbor/harbor/src/common/models"
 	"github.com/goharbor/harbor/src/common/quota"
+	"github.com/goharbor/harbor/src/pkg/types"
 	"github.com/pkg/errors"
 )
 
@@ -100,6 +101,21 @@ func (qa *QuotaAPI) Put() {
 		return
 	}
 
+	// keep the current hard limits of the resources missing in the request,
+	// so that the clients which only know count and storage still work
+	if len(req.Hard) > 0 {
+		hardLimits, err := types.NewResourceList(qa.quota.Hard)
+		if err != nil {
+			qa.SendInternalServerError(fmt.Errorf("failed to parse hard limits of the quota, error: %v", err))
+			return
+		}
+		for name, value := range hardLimits {
+			if _, ok := req.Hard[name]; !ok {
+				req.Hard[name] = value
+			}
+		}
+	}
+
 	if err := quota.Validate(qa.quota.Reference, req.Hard); err != nil {
 		qa.SendBadRequestError(err)
 		return
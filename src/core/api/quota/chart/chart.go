@@ -120,6 +120,7 @@ func (rm *Migrator) Dump() ([]quota.ProjectInfo, error) {
 			// repo
 			for _, chart := range chartInfo {
 				var afs []*models.Artifact
+				var blobs []*models.Blob
 				chartVersions, err := ctr.GetChart(project.Name, chart.Name)
 				if err != nil {
 					errChan <- err
@@ -134,10 +135,22 @@ func (rm *Migrator) Dump() ([]quota.ProjectInfo, error) {
 						Kind:   "Chart",
 					}
 					afs = append(afs, af)
+
+					// the chart package is taken as the blob of the chart version to compute the chart storage
+					size, err := ctr.GetChartVersionSize(project.Name, chart.Name, chart.Version)
+					if err != nil {
+						errChan <- err
+						continue
+					}
+					blobs = append(blobs, &models.Blob{
+						Digest: chart.Digest,
+						Size:   size,
+					})
 				}
 				repoData := quota.RepoData{
-					Name: project.Name,
-					Afs:  afs,
+					Name:  project.Name,
+					Afs:   afs,
+					Blobs: blobs,
 				}
 				repos = append(repos, repoData)
 			}
@@ -164,20 +177,25 @@ func (rm *Migrator) Dump() ([]quota.ProjectInfo, error) {
 }
 
 // Usage ...
-// Chart will not cover size.
+// Chart will not cover storage, the size of chart packages is counted in chart storage.
 func (rm *Migrator) Usage(projects []quota.ProjectInfo) ([]quota.ProjectUsage, error) {
 	var pros []quota.ProjectUsage
 	for _, project := range projects {
-		var count int64
+		var count, size int64
 		// usage count
 		for _, repo := range project.Repos {
 			count = count + int64(len(repo.Afs))
+			for _, blob := range repo.Blobs {
+				size = size + blob.Size
+			}
 		}
 		proUsage := quota.ProjectUsage{
 			Project: project.Name,
 			Used: common_quota.ResourceList{
-				common_quota.ResourceCount:   count,
-				common_quota.ResourceStorage: 0,
+				common_quota.ResourceCount:           count,
+				common_quota.ResourceStorage:         0,
+				common_quota.ResourceRepositoryCount: 0,
+				common_quota.ResourceChartStorage:    size,
 			},
 		}
 		pros = append(pros, proUsage)
@@ -19,6 +19,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/manifestlist"
 	"github.com/docker/distribution/manifest/schema1"
 	"github.com/docker/distribution/manifest/schema2"
 	"github.com/goharbor/harbor/src/common"
@@ -31,6 +33,7 @@ import (
 	quota "github.com/goharbor/harbor/src/core/api/quota"
 	"github.com/goharbor/harbor/src/core/promgr"
 	coreutils "github.com/goharbor/harbor/src/core/utils"
+	"github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 )
 
@@ -149,12 +152,15 @@ func (rm *Migrator) Usage(projects []quota.ProjectInfo) ([]quota.ProjectUsage, e
 	var pros []quota.ProjectUsage
 
 	for _, project := range projects {
-		var size, count int64
+		var size, count, repoCount int64
 		var blobs = make(map[string]int64)
 
 		// usage count
 		for _, repo := range project.Repos {
 			count = count + int64(len(repo.Afs))
+			if len(repo.Afs) > 0 {
+				repoCount++
+			}
 			// Because that there are some shared blobs between repositories, it needs to remove the duplicate items.
 			for _, blob := range repo.Blobs {
 				_, exist := blobs[blob.Digest]
@@ -172,8 +178,10 @@ func (rm *Migrator) Usage(projects []quota.ProjectInfo) ([]quota.ProjectUsage, e
 		proUsage := quota.ProjectUsage{
 			Project: project.Name,
 			Used: common_quota.ResourceList{
-				common_quota.ResourceCount:   count,
-				common_quota.ResourceStorage: size,
+				common_quota.ResourceCount:           count,
+				common_quota.ResourceStorage:         size,
+				common_quota.ResourceRepositoryCount: repoCount,
+				common_quota.ResourceChartStorage:    0,
 			},
 		}
 		pros = append(pros, proUsage)
@@ -379,54 +387,24 @@ func infoOfRepo(pid int64, repo string) (quota.RepoData, error) {
 	var blobs []*models.Blob
 
 	for _, tag := range tags {
-		_, mediaType, payload, err := repoClient.PullManifest(tag, []string{
-			schema1.MediaTypeManifest,
-			schema1.MediaTypeSignedManifest,
-			schema2.MediaTypeManifest,
-		})
-		if err != nil {
-			log.Error(err)
-			return quota.RepoData{}, err
-		}
-		manifest, desc, err := registry.UnMarshal(mediaType, payload)
+		desc, manifestAfnbs, manifestBlobs, err := infoOfManifest(repoClient, tag)
 		if err != nil {
 			log.Error(err)
 			return quota.RepoData{}, err
 		}
-		// self
-		afnb := &models.ArtifactAndBlob{
-			DigestAF:   desc.Digest.String(),
-			DigestBlob: desc.Digest.String(),
-		}
-		afnbs = append(afnbs, afnb)
-		// add manifest as a blob.
-		blob := &models.Blob{
-			Digest:       desc.Digest.String(),
-			ContentType:  desc.MediaType,
-			Size:         desc.Size,
-			CreationTime: time.Now(),
-		}
-		blobs = append(blobs, blob)
-		for _, layer := range manifest.References() {
-			afnb := &models.ArtifactAndBlob{
-				DigestAF:   desc.Digest.String(),
-				DigestBlob: layer.Digest.String(),
-			}
-			afnbs = append(afnbs, afnb)
-			blob := &models.Blob{
-				Digest:       layer.Digest.String(),
-				ContentType:  layer.MediaType,
-				Size:         layer.Size,
-				CreationTime: time.Now(),
-			}
-			blobs = append(blobs, blob)
+		afnbs = append(afnbs, manifestAfnbs...)
+		blobs = append(blobs, manifestBlobs...)
+
+		kind := models.ArtifactKindImage
+		if desc.MediaType == manifestlist.MediaTypeManifestList || desc.MediaType == v1.MediaTypeImageIndex {
+			kind = models.ArtifactKindImageIndex
 		}
 		af := &models.Artifact{
 			PID:          pid,
 			Repo:         repo,
 			Tag:          tag,
 			Digest:       desc.Digest.String(),
-			Kind:         "Docker-Image",
+			Kind:         kind,
 			CreationTime: time.Now(),
 		}
 		afs = append(afs, af)
@@ -439,6 +417,71 @@ func infoOfRepo(pid int64, repo string) (quota.RepoData, error) {
 	}, nil
 }
 
+// infoOfManifest returns the relationships of the manifest and its blobs,
+// the manifests referenced by the manifest list or image index are included
+func infoOfManifest(repoClient *registry.Repository, reference string) (distribution.Descriptor, []*models.ArtifactAndBlob, []*models.Blob, error) {
+	_, mediaType, payload, err := repoClient.PullManifest(reference, []string{
+		schema1.MediaTypeManifest,
+		schema1.MediaTypeSignedManifest,
+		schema2.MediaTypeManifest,
+		v1.MediaTypeImageManifest,
+		manifestlist.MediaTypeManifestList,
+		v1.MediaTypeImageIndex,
+	})
+	if err != nil {
+		return distribution.Descriptor{}, nil, nil, err
+	}
+	manifest, desc, err := registry.UnMarshal(mediaType, payload)
+	if err != nil {
+		return distribution.Descriptor{}, nil, nil, err
+	}
+
+	var afnbs []*models.ArtifactAndBlob
+	var blobs []*models.Blob
+	// self
+	afnb := &models.ArtifactAndBlob{
+		DigestAF:   desc.Digest.String(),
+		DigestBlob: desc.Digest.String(),
+	}
+	afnbs = append(afnbs, afnb)
+	// add manifest as a blob.
+	blob := &models.Blob{
+		Digest:       desc.Digest.String(),
+		ContentType:  desc.MediaType,
+		Size:         desc.Size,
+		CreationTime: time.Now(),
+	}
+	blobs = append(blobs, blob)
+	for _, layer := range manifest.References() {
+		afnb := &models.ArtifactAndBlob{
+			DigestAF:   desc.Digest.String(),
+			DigestBlob: layer.Digest.String(),
+		}
+		afnbs = append(afnbs, afnb)
+
+		// the manifest referenced by manifest list or image index, collect its blobs
+		if _, ok := manifest.(*manifestlist.DeserializedManifestList); ok {
+			_, refAfnbs, refBlobs, err := infoOfManifest(repoClient, layer.Digest.String())
+			if err != nil {
+				return distribution.Descriptor{}, nil, nil, err
+			}
+			afnbs = append(afnbs, refAfnbs...)
+			blobs = append(blobs, refBlobs...)
+			continue
+		}
+
+		blob := &models.Blob{
+			Digest:       layer.Digest.String(),
+			ContentType:  layer.MediaType,
+			Size:         layer.Size,
+			CreationTime: time.Now(),
+		}
+		blobs = append(blobs, blob)
+	}
+
+	return desc, afnbs, blobs, nil
+}
+
 func init() {
 	quota.Register("registry", NewRegistryMigrator)
 }
@@ -130,4 +130,14 @@ func TestQuotaPut(t *testing.T) {
 	assert.Nil(err)
 	assert.Equal(int(200), code)
 	assert.Equal(map[string]int64{"count": 100, "storage": 100}, quota.Hard)
+
+	// the resources missing in the request keep the current hard limits
+	code, err = apiTest.QuotasPut(*admin, fmt.Sprintf("%d", quotaID), models.QuotaUpdateRequest{Hard: types.ResourceList{types.ResourceCount: 10}})
+	assert.Nil(err)
+	assert.Equal(int(200), code)
+
+	code, quota, err = apiTest.QuotasGetByID(*admin, fmt.Sprintf("%d", quotaID))
+	assert.Nil(err)
+	assert.Equal(int(200), code)
+	assert.Equal(map[string]int64{"count": 10, "storage": 100}, quota.Hard)
 }
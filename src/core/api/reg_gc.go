@@ -16,11 +16,14 @@ package api
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"strconv"
 
+	"github.com/goharbor/harbor/src/common/dao"
 	common_job "github.com/goharbor/harbor/src/common/job"
+	common_models "github.com/goharbor/harbor/src/common/models"
 	"github.com/goharbor/harbor/src/core/api/models"
 )
 
@@ -56,6 +59,25 @@ func (gc *GCAPI) Prepare() {
 //    "type": "Manual"
 //  }
 //	}
+// create a manual trigger for the non-blocking GC which only deletes the blobs unreferenced for more than 2 hours
+// 	{
+//  "schedule": {
+//    "type": "Manual"
+//  },
+//  "parameters": {
+//    "non_blocking": true,
+//    "time_window": 2
+//  }
+//	}
+// create a manual trigger for the dry run of GC which reports the blobs would be deleted and the space would be reclaimed
+// 	{
+//  "schedule": {
+//    "type": "Manual"
+//  },
+//  "parameters": {
+//    "dry_run": true
+//  }
+//	}
 func (gc *GCAPI) Post() {
 	ajr := models.AdminJobReq{}
 	isValid, err := gc.DecodeJSONReqAndValidate(&ajr)
@@ -64,9 +86,12 @@ func (gc *GCAPI) Post() {
 		return
 	}
 	ajr.Name = common_job.ImageGC
-	ajr.Parameters = map[string]interface{}{
-		"redis_url_reg": os.Getenv("_REDIS_URL_REG"),
+	params, err := gcParameters(ajr.Parameters)
+	if err != nil {
+		gc.SendBadRequestError(err)
+		return
 	}
+	ajr.Parameters = params
 	gc.submit(&ajr)
 	gc.Redirect(http.StatusCreated, strconv.FormatInt(ajr.ID, 10))
 }
@@ -87,20 +112,53 @@ func (gc *GCAPI) Put() {
 		return
 	}
 	ajr.Name = common_job.ImageGC
-	ajr.Parameters = map[string]interface{}{
-		"redis_url_reg": os.Getenv("_REDIS_URL_REG"),
+	params, err := gcParameters(ajr.Parameters)
+	if err != nil {
+		gc.SendBadRequestError(err)
+		return
 	}
+	ajr.Parameters = params
 	gc.updateSchedule(ajr)
 }
 
-// GetGC ...
+// GetGC returns the GC job and the report of its latest execution
 func (gc *GCAPI) GetGC() {
 	id, err := gc.GetInt64FromPath(":id")
 	if err != nil {
 		gc.SendInternalServerError(errors.New("need to specify gc id"))
 		return
 	}
-	gc.get(id)
+
+	jobs, err := dao.GetAdminJobs(&common_models.AdminJobQuery{
+		ID: id,
+	})
+	if err != nil {
+		gc.SendInternalServerError(fmt.Errorf("failed to get admin jobs: %v", err))
+		return
+	}
+	if len(jobs) == 0 {
+		gc.SendNotFoundError(errors.New("no admin job found"))
+		return
+	}
+
+	adminJobRep, err := convertToAdminJobRep(jobs[0])
+	if err != nil {
+		gc.SendInternalServerError(fmt.Errorf("failed to convert admin job response: %v", err))
+		return
+	}
+
+	rep := models.GCRep{AdminJobRep: adminJobRep}
+	if len(jobs[0].UUID) > 0 {
+		report, err := dao.GetLatestGCReport(jobs[0].UUID)
+		if err != nil {
+			gc.SendInternalServerError(fmt.Errorf("failed to get the report of gc: %v", err))
+			return
+		}
+		rep.Report = report
+	}
+
+	gc.Data["json"] = rep
+	gc.ServeJSON()
 }
 
 // List returns the top 10 executions of GC which includes manual and cron.
@@ -122,3 +180,49 @@ func (gc *GCAPI) GetLog() {
 	}
 	gc.getLog(id)
 }
+
+// Operate stops or retries the GC job
+// 	{
+//  "action": "stop"
+//	}
+func (gc *GCAPI) Operate() {
+	id, err := gc.GetInt64FromPath(":id")
+	if err != nil {
+		gc.SendBadRequestError(errors.New("invalid ID"))
+		return
+	}
+	action, ok := gc.decodeJobAction("")
+	if !ok {
+		return
+	}
+	gc.operate(id, action)
+}
+
+// gcParameters validates the parameters in the request and returns the parameters of GC job
+func gcParameters(reqParams map[string]interface{}) (map[string]interface{}, error) {
+	params := map[string]interface{}{
+		"redis_url_reg": os.Getenv("_REDIS_URL_REG"),
+	}
+
+	for _, name := range []string{"non_blocking", "dry_run", "delete_untagged"} {
+		if v, ok := reqParams[name]; ok {
+			b, ok := v.(bool)
+			if !ok {
+				return nil, fmt.Errorf("invalid parameter %s: %v", name, v)
+			}
+			params[name] = b
+		}
+	}
+
+	for _, name := range []string{"time_window", "untagged_threshold"} {
+		if v, ok := reqParams[name]; ok {
+			hours, ok := v.(float64)
+			if !ok || hours < 0 || hours != float64(int64(hours)) {
+				return nil, fmt.Errorf("invalid parameter %s: %v, it should be a non-negative integer", name, v)
+			}
+			params[name] = int64(hours)
+		}
+	}
+
+	return params, nil
+}
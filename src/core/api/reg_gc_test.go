@@ -1,8 +1,10 @@
 package api
 
 import (
+	"net/http"
 	"testing"
 
+	"github.com/goharbor/harbor/src/core/api/models"
 	"github.com/goharbor/harbor/src/testing/apitests/apilib"
 	"github.com/stretchr/testify/assert"
 )
@@ -36,3 +38,80 @@ func TestGCGet(t *testing.T) {
 		assert.Equal(200, code, "Get adminjob status should be 200")
 	}
 }
+
+func TestGCParameters(t *testing.T) {
+	assert := assert.New(t)
+
+	params, err := gcParameters(nil)
+	assert.Nil(err)
+	assert.Len(params, 1)
+	assert.Contains(params, "redis_url_reg")
+
+	params, err = gcParameters(map[string]interface{}{
+		"non_blocking":       true,
+		"dry_run":            false,
+		"time_window":        float64(2),
+		"delete_untagged":    true,
+		"untagged_threshold": float64(48),
+		"redis_url_reg":      "redis://localhost:6380",
+	})
+	assert.Nil(err)
+	assert.Equal(true, params["non_blocking"])
+	assert.Equal(false, params["dry_run"])
+	assert.Equal(int64(2), params["time_window"])
+	assert.Equal(true, params["delete_untagged"])
+	assert.Equal(int64(48), params["untagged_threshold"])
+	assert.NotEqual("redis://localhost:6380", params["redis_url_reg"])
+
+	_, err = gcParameters(map[string]interface{}{"non_blocking": "true"})
+	assert.NotNil(err)
+	_, err = gcParameters(map[string]interface{}{"dry_run": 1})
+	assert.NotNil(err)
+	_, err = gcParameters(map[string]interface{}{"time_window": float64(-1)})
+	assert.NotNil(err)
+	_, err = gcParameters(map[string]interface{}{"time_window": 1.5})
+	assert.NotNil(err)
+	_, err = gcParameters(map[string]interface{}{"delete_untagged": "yes"})
+	assert.NotNil(err)
+	_, err = gcParameters(map[string]interface{}{"untagged_threshold": float64(-24)})
+	assert.NotNil(err)
+}
+
+func TestGCOperate(t *testing.T) {
+	cases := []*codeCheckingCase{
+		// 403
+		{
+			request: &testingRequest{
+				method:     http.MethodPut,
+				url:        "/api/system/gc/1",
+				credential: nonSysAdmin,
+			},
+			code: http.StatusForbidden,
+		},
+		// 400, invalid action
+		{
+			request: &testingRequest{
+				method:     http.MethodPut,
+				url:        "/api/system/gc/1",
+				credential: sysAdmin,
+				bodyJSON: &models.JobActionReq{
+					Action: "pause",
+				},
+			},
+			code: http.StatusBadRequest,
+		},
+		// 404
+		{
+			request: &testingRequest{
+				method:     http.MethodPut,
+				url:        "/api/system/gc/100000",
+				credential: sysAdmin,
+				bodyJSON: &models.JobActionReq{
+					Action: models.JobActionStop,
+				},
+			},
+			code: http.StatusNotFound,
+		},
+	}
+	runCodeCheckingCases(t, cases...)
+}
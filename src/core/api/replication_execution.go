@@ -21,6 +21,7 @@ import (
 	"strconv"
 
 	common_http "github.com/goharbor/harbor/src/common/http"
+	api_models "github.com/goharbor/harbor/src/core/api/models"
 	"github.com/goharbor/harbor/src/replication"
 	"github.com/goharbor/harbor/src/replication/dao/models"
 	"github.com/goharbor/harbor/src/replication/event"
@@ -164,8 +165,9 @@ func (r *ReplicationOperationAPI) GetExecution() {
 	r.WriteJSONData(execution)
 }
 
-// StopExecution stops one execution of the replication
-func (r *ReplicationOperationAPI) StopExecution() {
+// OperateExecution stops one execution of the replication or retries its failed and stopped tasks,
+// the execution is stopped if no action is specified in the request
+func (r *ReplicationOperationAPI) OperateExecution() {
 	executionID, err := r.GetInt64FromPath(":id")
 	if err != nil || executionID <= 0 {
 		r.SendBadRequestError(errors.New("invalid execution ID"))
@@ -182,6 +184,18 @@ func (r *ReplicationOperationAPI) StopExecution() {
 		return
 	}
 
+	action, ok := r.decodeJobAction(api_models.JobActionStop)
+	if !ok {
+		return
+	}
+
+	if action == api_models.JobActionRetry {
+		if err := replication.OperationCtl.RetryReplication(executionID); err != nil {
+			r.SendInternalServerError(fmt.Errorf("failed to retry execution %d: %v", executionID, err))
+		}
+		return
+	}
+
 	if err := replication.OperationCtl.StopReplication(executionID); err != nil {
 		r.SendInternalServerError(fmt.Errorf("failed to stop execution %d: %v", executionID, err))
 		return
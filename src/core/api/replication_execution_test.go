@@ -18,6 +18,7 @@ import (
 	"net/http"
 	"testing"
 
+	api_models "github.com/goharbor/harbor/src/core/api/models"
 	"github.com/goharbor/harbor/src/replication"
 
 	"github.com/goharbor/harbor/src/replication/dao/models"
@@ -32,6 +33,9 @@ func (f *fakedOperationController) StartReplication(policy *model.Policy, resour
 func (f *fakedOperationController) StopReplication(int64) error {
 	return nil
 }
+func (f *fakedOperationController) RetryReplication(int64) error {
+	return nil
+}
 func (f *fakedOperationController) ListExecutions(...*models.ExecutionQuery) (int64, []*models.Execution, error) {
 	return 1, []*models.Execution{
 		{
@@ -49,6 +53,9 @@ func (f *fakedOperationController) GetExecution(id int64) (*models.Execution, er
 	}
 	return nil, nil
 }
+func (f *fakedOperationController) FinishExecution(int64) (bool, error) {
+	return true, nil
+}
 func (f *fakedOperationController) ListTasks(...*models.TaskQuery) (int64, []*models.Task, error) {
 	return 1, []*models.Task{
 		{
@@ -275,7 +282,7 @@ func TestGetExecution(t *testing.T) {
 
 	runCodeCheckingCases(t, cases...)
 }
-func TestStopExecution(t *testing.T) {
+func TestOperateExecution(t *testing.T) {
 	operationCtl := replication.OperationCtl
 	defer func() {
 		replication.OperationCtl = operationCtl
@@ -309,6 +316,18 @@ func TestStopExecution(t *testing.T) {
 			},
 			code: http.StatusNotFound,
 		},
+		// 400, invalid action
+		{
+			request: &testingRequest{
+				method:     http.MethodPut,
+				url:        "/api/replication/executions/1",
+				credential: sysAdmin,
+				bodyJSON: &api_models.JobActionReq{
+					Action: "pause",
+				},
+			},
+			code: http.StatusBadRequest,
+		},
 		// 200
 		{
 			request: &testingRequest{
@@ -318,6 +337,18 @@ func TestStopExecution(t *testing.T) {
 			},
 			code: http.StatusOK,
 		},
+		// 200, retry
+		{
+			request: &testingRequest{
+				method:     http.MethodPut,
+				url:        "/api/replication/executions/1",
+				credential: sysAdmin,
+				bodyJSON: &api_models.JobActionReq{
+					Action: api_models.JobActionRetry,
+				},
+			},
+			code: http.StatusOK,
+		},
 	}
 
 	runCodeCheckingCases(t, cases...)
@@ -25,6 +25,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/docker/distribution/manifest/manifestlist"
 	"github.com/docker/distribution/manifest/schema1"
 	"github.com/docker/distribution/manifest/schema2"
 	"github.com/goharbor/harbor/src/common"
@@ -38,12 +39,15 @@ import (
 	notarymodel "github.com/goharbor/harbor/src/common/utils/notary/model"
 	"github.com/goharbor/harbor/src/common/utils/registry"
 	"github.com/goharbor/harbor/src/core/config"
+	"github.com/goharbor/harbor/src/core/filter"
 	notifierEvt "github.com/goharbor/harbor/src/core/notifier/event"
 	coreutils "github.com/goharbor/harbor/src/core/utils"
 	"github.com/goharbor/harbor/src/pkg/scan"
 	"github.com/goharbor/harbor/src/replication"
 	"github.com/goharbor/harbor/src/replication/event"
 	"github.com/goharbor/harbor/src/replication/model"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 // RepositoryAPI handles request to /api/repositories /api/repositories/tags /api/repositories/manifests, the parm has to be put
@@ -378,6 +382,139 @@ func (ra *RepositoryAPI) Delete() {
 	}
 }
 
+// DeleteManifest deletes the untagged manifest specified by the digest from the repository,
+// the manifest which is still tagged, signed or protected by the immutable tag rules is kept
+func (ra *RepositoryAPI) DeleteManifest() {
+	repoName := ra.GetString(":splat")
+	dgt := ra.GetString(":digest")
+	if _, err := digest.Parse(dgt); err != nil {
+		ra.SendBadRequestError(fmt.Errorf("invalid digest %s: %v", dgt, err))
+		return
+	}
+
+	projectName, _ := utils.ParseRepository(repoName)
+	project, err := ra.ProjectMgr.Get(projectName)
+	if err != nil {
+		ra.ParseAndHandleError(fmt.Sprintf("failed to get the project %s",
+			projectName), err)
+		return
+	}
+
+	if project == nil {
+		ra.SendNotFoundError(fmt.Errorf("project %s not found", projectName))
+		return
+	}
+
+	if !ra.RequireAuthenticated() ||
+		!ra.RequireProjectAccess(project.ProjectID, rbac.ActionDelete, rbac.ResourceRepository) {
+		return
+	}
+
+	referenced, err := dao.IsBlobReferenced(dgt)
+	if err != nil {
+		ra.SendInternalServerError(fmt.Errorf("failed to check the reference of manifest %s: %v", dgt, err))
+		return
+	}
+	if referenced {
+		ra.SendPreconditionFailedError(fmt.Errorf("manifest %s is tagged or referenced by other manifest", dgt))
+		return
+	}
+
+	// the tags which the manifest had are unknown, so keep all the untagged
+	// manifests of the project once there is any enabled immutable tag rule
+	rules, err := dao.QueryEnabledImmutableRuleByProjectID(project.ProjectID)
+	if err != nil {
+		ra.SendInternalServerError(fmt.Errorf("failed to get the immutable tag rules of project %s: %v", projectName, err))
+		return
+	}
+	if len(rules) > 0 {
+		ra.notifyImmutableTagViolation(project, repoName, dgt)
+		ra.SendPreconditionFailedError(fmt.Errorf("manifest %s is protected by the immutable tag rules of project %s", dgt, projectName))
+		return
+	}
+
+	if config.WithNotary() {
+		signatures, err := getSignatures(ra.SecurityCtx.GetUsername(), repoName)
+		if err != nil {
+			ra.SendInternalServerError(fmt.Errorf(
+				"failed to get signatures for repository %s: %v", repoName, err))
+			return
+		}
+		if _, ok := signatures[dgt]; ok {
+			ra.SendPreconditionFailedError(fmt.Errorf("manifest %s is signed", dgt))
+			return
+		}
+	}
+
+	rc, err := coreutils.NewRepositoryClientForLocal(ra.SecurityCtx.GetUsername(), repoName)
+	if err != nil {
+		log.Errorf("error occurred while initializing repository client for %s: %v", repoName, err)
+		ra.SendInternalServerError(errors.New("internal error"))
+		return
+	}
+
+	if err = rc.DeleteManifest(dgt); err != nil {
+		if regErr, ok := err.(*commonhttp.Error); ok && regErr.Code == http.StatusNotFound {
+			ra.SendNotFoundError(fmt.Errorf("manifest %s not found in repository %s", dgt, repoName))
+			return
+		}
+		ra.ParseAndHandleError(fmt.Sprintf("failed to delete manifest %s", dgt), err)
+		return
+	}
+	log.Infof("delete untagged manifest: %s@%s", repoName, dgt)
+}
+
+// GetUntaggedManifests lists the untagged manifests which are not referenced by any manifest list in the repository
+func (ra *RepositoryAPI) GetUntaggedManifests() {
+	repoName := ra.GetString(":splat")
+
+	projectName, _ := utils.ParseRepository(repoName)
+	exist, err := ra.ProjectMgr.Exists(projectName)
+	if err != nil {
+		ra.ParseAndHandleError(fmt.Sprintf("failed to check the existence of project %s",
+			projectName), err)
+		return
+	}
+
+	if !exist {
+		ra.SendNotFoundError(fmt.Errorf("project %s not found", projectName))
+		return
+	}
+
+	if !ra.RequireProjectAccess(projectName, rbac.ActionList, rbac.ResourceRepositoryTag) {
+		return
+	}
+
+	manifests, err := dao.ListUntaggedManifestsOfRepository(repoName)
+	if err != nil {
+		ra.SendInternalServerError(fmt.Errorf("failed to list the untagged manifests of %s: %v", repoName, err))
+		return
+	}
+
+	ra.Data["json"] = manifests
+	ra.ServeJSON()
+}
+
+// notifyImmutableTagViolation publishes the tag immutability violation event for the
+// manifest kept by the immutable tag rules, the tag is unknown as the manifest is untagged
+func (ra *RepositoryAPI) notifyImmutableTagViolation(project *models.Project, repoName, dgt string) {
+	evt := &notifierEvt.Event{}
+	metadata := &notifierEvt.ImmutableTagViolationMetaData{
+		Project:  project,
+		Digest:   dgt,
+		RepoName: repoName,
+		OccurAt:  time.Now(),
+		Operator: ra.SecurityCtx.GetUsername(),
+	}
+	if err := evt.Build(metadata); err != nil {
+		log.Errorf("failed to build tag immutability violation event metadata: %v", err)
+		return
+	}
+	if err := evt.Publish(); err != nil {
+		log.Errorf("failed to publish tag immutability violation event: %v", err)
+	}
+}
+
 // GetTag returns the tag of a repository
 func (ra *RepositoryAPI) GetTag() {
 	repository := ra.GetString(":splat")
@@ -692,12 +829,17 @@ func assembleTag(c chan *models.TagResp, client *registry.Repository,
 
 // getTagDetail returns the detail information for v2 manifest image
 // The information contains architecture, os, author, size, etc.
+// For manifest list and OCI image index, the information of each platform is contained
 func getTagDetail(client *registry.Repository, tag string) (*models.TagDetail, error) {
 	detail := &models.TagDetail{
 		Name: tag,
 	}
 
-	digest, mediaType, payload, err := client.PullManifest(tag, []string{schema2.MediaTypeManifest})
+	digest, mediaType, payload, err := client.PullManifest(tag, []string{
+		schema2.MediaTypeManifest,
+		manifestlist.MediaTypeManifestList,
+		v1.MediaTypeImageIndex,
+	})
 	if err != nil {
 		return detail, err
 	}
@@ -717,6 +859,14 @@ func getTagDetail(client *registry.Repository, tag string) (*models.TagDetail, e
 		detail.Size += ref.Size
 	}
 
+	if list, ok := manifest.(*manifestlist.DeserializedManifestList); ok {
+		if err = populatePlatforms(client, detail, list); err != nil {
+			return detail, err
+		}
+		detail.Size += int64(len(payload))
+		return detail, nil
+	}
+
 	// if the media type of the manifest isn't v2, doesn't parse image config
 	// and return directly
 	// this impacts that some detail information(os, arch, ...) of old images
@@ -750,6 +900,32 @@ func getTagDetail(client *registry.Repository, tag string) (*models.TagDetail, e
 	return detail, nil
 }
 
+// populatePlatforms populates the detail information of the manifests referenced by the manifest list,
+// the size of the manifest list is the sum of the referenced manifests
+func populatePlatforms(client *registry.Repository, detail *models.TagDetail,
+	list *manifestlist.DeserializedManifestList) error {
+	detail.Size = 0
+	for _, m := range list.Manifests {
+		platformDetail, err := getTagDetail(client, m.Digest.String())
+		if err != nil {
+			return err
+		}
+
+		detail.Size += platformDetail.Size
+		detail.Platforms = append(detail.Platforms, &models.PlatformDetail{
+			Digest:       m.Digest.String(),
+			Size:         platformDetail.Size,
+			Architecture: m.Platform.Architecture,
+			OS:           m.Platform.OS,
+			OSVersion:    m.Platform.OSVersion,
+			Variant:      m.Platform.Variant,
+			Created:      platformDetail.Created,
+		})
+	}
+
+	return nil
+}
+
 func populateAuthor(detail *models.TagDetail) {
 	// has author info already
 	if len(detail.Author) > 0 {
@@ -825,7 +1001,8 @@ func getManifest(client *registry.Repository,
 	case "v1":
 		mediaTypes = append(mediaTypes, schema1.MediaTypeManifest)
 	case "v2":
-		mediaTypes = append(mediaTypes, schema2.MediaTypeManifest)
+		mediaTypes = append(mediaTypes, schema2.MediaTypeManifest,
+			manifestlist.MediaTypeManifestList, v1.MediaTypeImageIndex)
 	}
 
 	_, mediaType, payload, err := client.PullManifest(tag, mediaTypes)
@@ -996,9 +1173,9 @@ func (ra *RepositoryAPI) ScanImage() {
 	if !ra.RequireProjectAccess(projectName, rbac.ActionCreate, rbac.ResourceRepositoryTagScanJob) {
 		return
 	}
-	err = coreutils.TriggerImageScan(repoName, tag)
+	err = coreutils.TriggerImageScan(repoName, tag, filter.GetRequestID(ra.Ctx.Request))
 	if err != nil {
-		log.Errorf("Error while calling job service to trigger image scan: %v", err)
+		ra.Logger().Errorf("Error while calling job service to trigger image scan: %v", err)
 		ra.SendInternalServerError(errors.New("Failed to scan image, please check log for details"))
 		return
 	}
@@ -470,3 +470,89 @@ func TestRetag(t *testing.T) {
 
 	fmt.Printf("\n")
 }
+
+func TestDeleteManifest(t *testing.T) {
+	dgt := "sha256:0d5fbe8db1eb5d3e8a0c3bba8ba1d1e4fe3f1c2fb0f5c4a6e0e0b2f4c7d0b5a1"
+	id, err := dao.AddArtifact(&models.Artifact{
+		PID:    1,
+		Repo:   "library/hello-world",
+		Tag:    "manifest-test",
+		Digest: dgt,
+		Kind:   "Docker-Image",
+	})
+	require.Nil(t, err)
+	defer dao.DeleteArtifact(id)
+
+	base := "/api/repositories/library/hello-world/manifests/"
+	cases := []*codeCheckingCase{
+		// 400 invalid digest
+		{
+			request: &testingRequest{
+				method:     http.MethodDelete,
+				url:        base + "invalid_digest",
+				credential: sysAdmin,
+			},
+			code: http.StatusBadRequest,
+		},
+		// 404 project not found
+		{
+			request: &testingRequest{
+				method:     http.MethodDelete,
+				url:        "/api/repositories/non_exist_project/hello-world/manifests/" + dgt,
+				credential: sysAdmin,
+			},
+			code: http.StatusNotFound,
+		},
+		// 401
+		{
+			request: &testingRequest{
+				method: http.MethodDelete,
+				url:    base + dgt,
+			},
+			code: http.StatusUnauthorized,
+		},
+		// 403 non-member
+		{
+			request: &testingRequest{
+				method:     http.MethodDelete,
+				url:        base + dgt,
+				credential: nonSysAdmin,
+			},
+			code: http.StatusForbidden,
+		},
+		// 412 the manifest is tagged
+		{
+			request: &testingRequest{
+				method:     http.MethodDelete,
+				url:        base + dgt,
+				credential: sysAdmin,
+			},
+			code: http.StatusPreconditionFailed,
+		},
+	}
+	runCodeCheckingCases(t, cases...)
+}
+
+func TestGetUntaggedManifests(t *testing.T) {
+	cases := []*codeCheckingCase{
+		// 404 project not found
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/repositories/non_exist_project/hello-world/manifests",
+				credential: sysAdmin,
+			},
+			code: http.StatusNotFound,
+		},
+		// 200
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/repositories/library/hello-world/manifests",
+				credential: sysAdmin,
+			},
+			code: http.StatusOK,
+		},
+	}
+	runCodeCheckingCases(t, cases...)
+}
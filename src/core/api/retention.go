@@ -1,12 +1,14 @@
 package api
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 
+	"github.com/goharbor/harbor/src/common/models"
 	"github.com/goharbor/harbor/src/common/rbac"
 	"github.com/goharbor/harbor/src/core/filter"
 	"github.com/goharbor/harbor/src/core/promgr"
@@ -90,6 +92,42 @@ func (r *RetentionAPI) GetMetadatas() {
 				}
 			]
 		},
+		{
+			"rule_template": "severityLowerThan",
+			"display_text": "scanned with vulnerability severity lower than #",
+			"action": "retain",
+			"params": [
+				{
+					"type": "string",
+					"unit": "SEVERITY",
+					"required": true
+				}
+			]
+		},
+		{
+			"rule_template": "scannedOrNDaysSinceLastPush",
+			"display_text": "scanned or pushed within the last # days",
+			"action": "retain",
+			"params": [
+				{
+					"type": "int",
+					"unit": "DAYS",
+					"required": true
+				}
+			]
+		},
+		{
+			"rule_template": "sizeNotLargerThanNMB",
+			"display_text": "not larger than # MB",
+			"action": "retain",
+			"params": [
+				{
+					"type": "int",
+					"unit": "MB",
+					"required": true
+				}
+			]
+		},
 		{
             "rule_template": "always",
             "display_text": "always",
@@ -115,6 +153,14 @@ func (r *RetentionAPI) GetMetadatas() {
                 "matches",
                 "excludes"
             ]
+        },
+        {
+            "display_text": "Labels",
+            "kind": "label",
+            "decorations": [
+                "withLabels",
+                "withoutLabels"
+            ]
         }
     ]
 }
@@ -143,6 +189,24 @@ func (r *RetentionAPI) GetRetention() {
 	r.WriteJSONData(p)
 }
 
+// GetSystemRetention Get the system level Retention
+func (r *RetentionAPI) GetSystemRetention() {
+	if !r.SecurityCtx.IsSysAdmin() {
+		r.SendForbiddenError(errors.New(r.SecurityCtx.GetUsername()))
+		return
+	}
+	p, err := retentionController.GetSystemRetention()
+	if err != nil {
+		r.SendInternalServerError(err)
+		return
+	}
+	if p == nil {
+		r.SendNotFoundError(errors.New("system level retention policy not found"))
+		return
+	}
+	r.WriteJSONData(p)
+}
+
 // CreateRetention Create Retention
 func (r *RetentionAPI) CreateRetention() {
 	p := &policy.Metadata{}
@@ -176,27 +240,40 @@ func (r *RetentionAPI) CreateRetention() {
 		if proj == nil {
 			r.SendBadRequestError(fmt.Errorf("invalid Project id %d", p.Scope.Reference))
 		}
+		old, err := r.pm.GetMetadataManager().Get(p.Scope.Reference, models.ProMetaRetentionID)
+		if err != nil {
+			r.SendInternalServerError(err)
+			return
+		}
+		if old != nil && len(old) > 0 {
+			r.SendBadRequestError(fmt.Errorf("project %v already has retention policy %v", p.Scope.Reference, old[models.ProMetaRetentionID]))
+			return
+		}
+	case policy.ScopeLevelSystem:
+		p.Scope.Reference = 0
+		old, err := retentionController.GetSystemRetention()
+		if err != nil {
+			r.SendInternalServerError(err)
+			return
+		}
+		if old != nil {
+			r.SendBadRequestError(fmt.Errorf("system level retention policy %v already exists", old.ID))
+			return
+		}
 	default:
 		r.SendBadRequestError(fmt.Errorf("scope %s is not support", p.Scope.Level))
 		return
 	}
-	old, err := r.pm.GetMetadataManager().Get(p.Scope.Reference, "retention_id")
-	if err != nil {
-		r.SendInternalServerError(err)
-		return
-	}
-	if old != nil && len(old) > 0 {
-		r.SendBadRequestError(fmt.Errorf("project %v already has retention policy %v", p.Scope.Reference, old["retention_id"]))
-		return
-	}
 	id, err := retentionController.CreateRetention(p)
 	if err != nil {
 		r.SendInternalServerError(err)
 		return
 	}
-	if err := r.pm.GetMetadataManager().Add(p.Scope.Reference,
-		map[string]string{"retention_id": strconv.FormatInt(id, 10)}); err != nil {
-		r.SendInternalServerError(err)
+	if p.Scope.Level == policy.ScopeLevelProject {
+		if err := r.pm.GetMetadataManager().Add(p.Scope.Reference,
+			map[string]string{models.ProMetaRetentionID: strconv.FormatInt(id, 10)}); err != nil {
+			r.SendInternalServerError(err)
+		}
 	}
 	r.Redirect(http.StatusCreated, strconv.FormatInt(id, 10))
 }
@@ -215,6 +292,11 @@ func (r *RetentionAPI) UpdateRetention() {
 		return
 	}
 	p.ID = id
+	// check the access against the scope in the request before loading the policy,
+	// so that the existence of the policy isn't exposed to the ones without permission
+	if !r.requireAccess(p, rbac.ActionUpdate) {
+		return
+	}
 	if len(p.Rules) > 15 {
 		r.SendBadRequestError(errors.New("only 15 rules are allowed at most"))
 		return
@@ -223,7 +305,13 @@ func (r *RetentionAPI) UpdateRetention() {
 		r.SendConflictError(err)
 		return
 	}
-	if !r.requireAccess(p, rbac.ActionUpdate) {
+	p0, err := retentionController.GetRetention(id)
+	if err != nil {
+		r.SendBadRequestError(err)
+		return
+	}
+	if p0.Scope.Level != p.Scope.Level || p0.Scope.Reference != p.Scope.Reference {
+		r.SendBadRequestError(errors.New("the scope of the retention policy can not be changed"))
 		return
 	}
 	if err = retentionController.UpdateRetention(p); err != nil {
@@ -292,7 +380,7 @@ func (r *RetentionAPI) OperateRetentionExec() {
 		return
 	}
 	a := &struct {
-		Action string `json:"action" valid:"Required;Match(stop)"`
+		Action string `json:"action" valid:"Required;Match(/^(stop|retry)$/)"`
 	}{}
 	isValid, err := r.DecodeJSONReqAndValidate(a)
 	if !isValid {
@@ -425,6 +513,63 @@ func (r *RetentionAPI) GetRetentionExecTaskLog() {
 	w.Write(log)
 }
 
+// GetRetentionExecReport Get Retention Execution Report, the report is returned in CSV format
+// when the query parameter "format" is "csv", otherwise in JSON format
+func (r *RetentionAPI) GetRetentionExecReport() {
+	id, err := r.GetIDFromURL()
+	if err != nil {
+		r.SendBadRequestError(err)
+		return
+	}
+	eid, err := r.GetInt64FromPath(":eid")
+	if err != nil {
+		r.SendBadRequestError(err)
+		return
+	}
+	format := r.GetString("format", "json")
+	if format != "json" && format != "csv" {
+		r.SendBadRequestError(fmt.Errorf("unsupported report format %s", format))
+		return
+	}
+	p, err := retentionController.GetRetention(id)
+	if err != nil {
+		r.SendBadRequestError(err)
+		return
+	}
+	if !r.requireAccess(p, rbac.ActionRead) {
+		return
+	}
+	exec, err := retentionController.GetRetentionExec(eid)
+	if err != nil {
+		r.SendBadRequestError(err)
+		return
+	}
+	if exec.PolicyID != id {
+		r.SendNotFoundError(fmt.Errorf("execution %d not found in retention %d", eid, id))
+		return
+	}
+	items, err := retentionController.GetRetentionExecReport(eid)
+	if err != nil {
+		r.SendInternalServerError(err)
+		return
+	}
+	if format == "json" {
+		r.WriteJSONData(items)
+		return
+	}
+
+	w := r.Ctx.ResponseWriter
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=retention-%d-execution-%d.csv", id, eid))
+	w.WriteHeader(http.StatusOK)
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"Repository", "Tag", "Digest", "Action", "Rules", "Reason"})
+	for _, item := range items {
+		_ = cw.Write([]string{item.Repository, item.Tag, item.Digest, item.Action, item.Rules, item.Reason})
+	}
+	cw.Flush()
+}
+
 func (r *RetentionAPI) requireAccess(p *policy.Metadata, action rbac.Action, subresources ...rbac.Resource) bool {
 	var hasPermission bool
 
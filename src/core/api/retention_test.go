@@ -397,6 +397,25 @@ func TestPolicy(t *testing.T) {
 			},
 			code: http.StatusConflict,
 		},
+		// the policy doesn't exist, but the user without permission gets 403 rather than 400
+		{
+			request: &testingRequest{
+				method:     http.MethodPut,
+				url:        fmt.Sprintf("/api/retentions/%d", id+1000),
+				bodyJSON:   p,
+				credential: nonSysAdmin,
+			},
+			code: http.StatusForbidden,
+		},
+		{
+			request: &testingRequest{
+				method:     http.MethodPut,
+				url:        fmt.Sprintf("/api/retentions/%d", id),
+				bodyJSON:   p,
+				credential: nonSysAdmin,
+			},
+			code: http.StatusForbidden,
+		},
 		{
 			request: &testingRequest{
 				method: http.MethodPost,
@@ -422,3 +441,91 @@ func TestPolicy(t *testing.T) {
 
 	runCodeCheckingCases(t, cases...)
 }
+
+func TestSystemPolicy(t *testing.T) {
+	p := &policy.Metadata{
+		Algorithm: "or",
+		Rules: []rule.Metadata{
+			{
+				ID:       1,
+				Priority: 1,
+				Template: "latestPushedK",
+				Action:   "retain",
+				Parameters: rule.Parameters{
+					"latestPushedK": 10,
+				},
+				TagSelectors: []*rule.Selector{
+					{
+						Kind:       "doublestar",
+						Decoration: "matches",
+						Pattern:    "**",
+					},
+				},
+				ScopeSelectors: map[string][]*rule.Selector{
+					"project": {
+						{
+							Kind:       "doublestar",
+							Decoration: "nsMatches",
+							Pattern:    "**",
+						},
+					},
+				},
+			},
+		},
+		Trigger: &policy.Trigger{
+			Kind: "Schedule",
+			Settings: map[string]interface{}{
+				"cron": "* 22 11 * * *",
+			},
+		},
+		Scope: &policy.Scope{
+			Level: "system",
+		},
+	}
+	p1 := &models.RetentionPolicy{
+		ScopeLevel:  p.Scope.Level,
+		TriggerKind: p.Trigger.Kind,
+		CreateTime:  time.Now(),
+		UpdateTime:  time.Now(),
+	}
+	data, _ := json.Marshal(p)
+	p1.Data = string(data)
+
+	id, err := dao.CreatePolicy(p1)
+	require.Nil(t, err)
+	require.True(t, id > 0)
+	defer dao.DeletePolicyAndExec(id)
+
+	cases := []*codeCheckingCase{
+		// 403
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/retentions/system",
+				credential: nonSysAdmin,
+			},
+			code: http.StatusForbidden,
+		},
+		// 200
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/retentions/system",
+				credential: sysAdmin,
+			},
+			code: http.StatusOK,
+		},
+		// 400, only one system level policy is allowed
+		{
+			request: &testingRequest{
+				method:     http.MethodPost,
+				url:        "/api/retentions",
+				bodyJSON:   p,
+				credential: sysAdmin,
+			},
+			code: http.StatusBadRequest,
+		},
+	}
+
+	runCodeCheckingCases(t, cases...)
+}
@@ -15,10 +15,12 @@
 package api
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/goharbor/harbor/src/common"
@@ -26,6 +28,7 @@ import (
 	"github.com/goharbor/harbor/src/common/models"
 	"github.com/goharbor/harbor/src/common/rbac"
 	"github.com/goharbor/harbor/src/common/token"
+	"github.com/goharbor/harbor/src/common/utils"
 	"github.com/goharbor/harbor/src/core/config"
 )
 
@@ -68,7 +71,9 @@ func (r *RobotAPI) Prepare() {
 	}
 	r.project = project
 
-	if method == http.MethodPut || method == http.MethodDelete {
+	// the secret of the robot is regenerated by POST with the robot ID
+	if method == http.MethodPut || method == http.MethodDelete ||
+		(method == http.MethodPost && len(r.GetStringFromPath(":id")) > 0) {
 		id, err := r.GetInt64FromPath(":id")
 		if err != nil || id <= 0 {
 			r.SendBadRequestError(errors.New("invalid robot ID"))
@@ -81,7 +86,7 @@ func (r *RobotAPI) Prepare() {
 			return
 		}
 
-		if robot == nil {
+		if robot == nil || robot.ProjectID != r.project.ProjectID {
 			r.SendNotFoundError(fmt.Errorf("robot %d not found", id))
 			return
 		}
@@ -96,6 +101,9 @@ func (r *RobotAPI) requireAccess(action rbac.Action) bool {
 
 // Post ...
 func (r *RobotAPI) Post() {
+	if !r.RequireNotAccessToken() {
+		return
+	}
 	if !r.requireAccess(rbac.ActionCreate) {
 		return
 	}
@@ -106,10 +114,11 @@ func (r *RobotAPI) Post() {
 		r.SendBadRequestError(err)
 		return
 	}
+	if err := r.validateAccess(robotReq.Access); err != nil {
+		r.SendBadRequestError(err)
+		return
+	}
 
-	// Token duration in minutes
-	tokenDuration := time.Duration(config.RobotTokenDuration()) * time.Minute
-	expiresAt := time.Now().UTC().Add(tokenDuration).Unix()
 	createdName := common.RobotPrefix + robotReq.Name
 
 	// first to add a robot account, and get its id.
@@ -117,7 +126,12 @@ func (r *RobotAPI) Post() {
 		Name:        createdName,
 		Description: robotReq.Description,
 		ProjectID:   r.project.ProjectID,
-		ExpiresAt:   expiresAt,
+		Level:       models.RobotLevelProject,
+		Duration:    robotReq.Duration,
+	}
+	if err := setRobotSecret(&robot, robotReq.Access); err != nil {
+		r.SendInternalServerError(fmt.Errorf("failed to generate secret for robot account, %v", err))
+		return
 	}
 	id, err := dao.AddRobot(&robot)
 	if err != nil {
@@ -130,8 +144,8 @@ func (r *RobotAPI) Post() {
 	}
 
 	// generate the token, and return it with response data.
-	// token is not stored in the database.
-	jwtToken, err := token.New(id, r.project.ProjectID, expiresAt, robotReq.Access)
+	// token is not stored in the database, only the secret as the ID of it is stored.
+	jwtToken, err := token.New(id, r.project.ProjectID, robot.ExpiresAt, robot.Secret, robotReq.Access)
 	if err != nil {
 		r.SendInternalServerError(fmt.Errorf("failed to valid parameters to generate token for robot account, %v", err))
 		err := dao.DeleteRobot(id)
@@ -213,7 +227,7 @@ func (r *RobotAPI) Get() {
 		r.SendInternalServerError(fmt.Errorf("failed to get robot %d: %v", id, err))
 		return
 	}
-	if robot == nil {
+	if robot == nil || robot.ProjectID != r.project.ProjectID {
 		r.SendNotFoundError(fmt.Errorf("robot %d not found", id))
 		return
 	}
@@ -234,6 +248,7 @@ func (r *RobotAPI) Put() {
 		return
 	}
 
+	r.SetAuditLogBefore(*r.robot)
 	r.robot.Disabled = robotReq.Disabled
 
 	if err := dao.UpdateRobot(r.robot); err != nil {
@@ -243,14 +258,130 @@ func (r *RobotAPI) Put() {
 
 }
 
+// RegenerateSecret regenerates the secret of the robot account and returns the new token
+func (r *RobotAPI) RegenerateSecret() {
+	if !r.requireAccess(rbac.ActionUpdate) {
+		return
+	}
+
+	r.regenerateRobotSecret(r.robot)
+}
+
 // Delete delete robot by id
 func (r *RobotAPI) Delete() {
 	if !r.requireAccess(rbac.ActionDelete) {
 		return
 	}
 
+	r.SetAuditLogBefore(*r.robot)
 	if err := dao.DeleteRobot(r.robot.ID); err != nil {
 		r.SendInternalServerError(fmt.Errorf("failed to delete robot %d: %v", r.robot.ID, err))
 		return
 	}
 }
+
+// validateAccess checks that the access of the robot is limited to the project,
+// the resources must be under the namespace of the project, e.g. "/project/1/repository",
+// as the ones under other namespaces, e.g. "/project/*/repository", grant access to other projects
+func (r *RobotAPI) validateAccess(access []*rbac.Policy) error {
+	namespace := rbac.NewProjectNamespace(r.project.ProjectID).Resource().String()
+	for _, policy := range access {
+		if policy == nil {
+			return errors.New("empty access of the robot account")
+		}
+		resource := policy.Resource.String()
+		if resource != namespace && !strings.HasPrefix(resource, namespace+"/") {
+			return fmt.Errorf("the resource %s isn't in the project %d", resource, r.project.ProjectID)
+		}
+	}
+	return nil
+}
+
+// setRobotSecret generates a new secret for the robot, and sets the expiration and access of the token,
+// the token duration of the robot is in minutes, the global one is used if it's 0 and the token never expires if it's -1
+func setRobotSecret(robot *models.Robot, access []*rbac.Policy) error {
+	data, err := json.Marshal(access)
+	if err != nil {
+		return err
+	}
+
+	var expiresAt int64
+	if robot.Duration >= 0 {
+		duration := robot.Duration
+		if duration == 0 {
+			duration = int64(config.RobotTokenDuration())
+		}
+		expiresAt = time.Now().UTC().Add(time.Duration(duration) * time.Minute).Unix()
+	}
+
+	robot.Secret = utils.GenerateRandomString()
+	robot.ExpiresAt = expiresAt
+	robot.Access = string(data)
+	return nil
+}
+
+// robotToken generates the raw token of the robot with its current secret
+func robotToken(robot *models.Robot, access []*rbac.Policy) (string, error) {
+	jwtToken, err := token.New(robot.ID, robot.ProjectID, robot.ExpiresAt, robot.Secret, access)
+	if err != nil {
+		return "", err
+	}
+	return jwtToken.Raw()
+}
+
+// regenerateRobotSecret regenerates the secret of the robot and responds the new token,
+// the previous token keeps valid in the overlap window specified in the request
+func (b *BaseController) regenerateRobotSecret(robot *models.Robot) {
+	if !b.RequireNotAccessToken() {
+		return
+	}
+	req := &models.RobotSecretReq{}
+	if len(b.Ctx.Input.RequestBody) > 0 {
+		isValid, err := b.DecodeJSONReqAndValidate(req)
+		if !isValid {
+			b.SendBadRequestError(err)
+			return
+		}
+	}
+
+	// the access isn't stored for the robots created by the previous versions
+	if len(robot.Access) == 0 {
+		b.SendBadRequestError(fmt.Errorf("the access of robot %d is unknown, it needs to be recreated", robot.ID))
+		return
+	}
+	access := []*rbac.Policy{}
+	if err := json.Unmarshal([]byte(robot.Access), &access); err != nil {
+		b.SendInternalServerError(fmt.Errorf("failed to parse the access of robot %d: %v", robot.ID, err))
+		return
+	}
+
+	if req.Duration != 0 {
+		robot.Duration = req.Duration
+	}
+	previous := robot.Secret
+	if err := setRobotSecret(robot, access); err != nil {
+		b.SendInternalServerError(fmt.Errorf("failed to generate secret for robot %d: %v", robot.ID, err))
+		return
+	}
+	robot.PreviousSecret = previous
+	robot.PreviousSecretExpiresAt = 0
+	if req.Overlap > 0 {
+		robot.PreviousSecretExpiresAt = time.Now().Add(time.Duration(req.Overlap) * time.Minute).Unix()
+	}
+
+	rawTk, err := robotToken(robot, access)
+	if err != nil {
+		b.SendInternalServerError(fmt.Errorf("failed to generate token for robot %d: %v", robot.ID, err))
+		return
+	}
+	if err := dao.UpdateRobot(robot); err != nil {
+		b.SendInternalServerError(fmt.Errorf("failed to update robot %d: %v", robot.ID, err))
+		return
+	}
+
+	b.Data["json"] = models.RobotRep{
+		Name:  robot.Name,
+		Token: rawTk,
+	}
+	b.ServeJSON()
+}
@@ -30,7 +30,7 @@ var (
 func TestRobotAPIPost(t *testing.T) {
 
 	rbacPolicy := &rbac.Policy{
-		Resource: "/project/libray/repository",
+		Resource: "/project/1/repository",
 		Action:   "pull",
 	}
 	policies := []*rbac.Policy{}
@@ -83,6 +83,44 @@ func TestRobotAPIPost(t *testing.T) {
 			},
 			code: http.StatusBadRequest,
 		},
+		// 400 -- the access of other projects
+		{
+			request: &testingRequest{
+				method: http.MethodPost,
+				url:    robotPath,
+				bodyJSON: &models.RobotReq{
+					Name:        "test3",
+					Description: "test3 desc",
+					Access: []*rbac.Policy{
+						{
+							Resource: "/project/*/repository",
+							Action:   "pull",
+						},
+					},
+				},
+				credential: projAdmin4Robot,
+			},
+			code: http.StatusBadRequest,
+		},
+		// 400 -- the access of another project
+		{
+			request: &testingRequest{
+				method: http.MethodPost,
+				url:    robotPath,
+				bodyJSON: &models.RobotReq{
+					Name:        "test4",
+					Description: "test4 desc",
+					Access: []*rbac.Policy{
+						{
+							Resource: "/project/10/repository",
+							Action:   "push",
+						},
+					},
+				},
+				credential: projAdmin4Robot,
+			},
+			code: http.StatusBadRequest,
+		},
 		// 403 -- developer
 		{
 			request: &testingRequest{
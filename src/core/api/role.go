@@ -0,0 +1,216 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/goharbor/harbor/src/common/rbac/project"
+)
+
+// RoleAPI handles the requests of the project roles. All the authenticated users can read the roles,
+// only system admin can manage the custom roles, the predefined roles can't be modified
+type RoleAPI struct {
+	BaseController
+	role *models.Role
+}
+
+// Prepare ...
+func (r *RoleAPI) Prepare() {
+	r.BaseController.Prepare()
+	if !r.SecurityCtx.IsAuthenticated() {
+		r.SendUnAuthorizedError(errors.New("UnAuthorized"))
+		return
+	}
+	method := r.Ctx.Request.Method
+	if method != http.MethodGet && !r.SecurityCtx.IsSysAdmin() {
+		r.SendForbiddenError(errors.New(r.SecurityCtx.GetUsername()))
+		return
+	}
+
+	if idStr := r.GetStringFromPath(":id"); len(idStr) > 0 {
+		id, err := r.GetInt64FromPath(":id")
+		if err != nil || id <= 0 {
+			r.SendBadRequestError(fmt.Errorf("invalid role ID: %s", idStr))
+			return
+		}
+		role, err := getRole(int(id))
+		if err != nil {
+			r.SendInternalServerError(fmt.Errorf("failed to get role %d: %v", id, err))
+			return
+		}
+		if role == nil {
+			r.SendNotFoundError(fmt.Errorf("role %d not found", id))
+			return
+		}
+		if method != http.MethodGet && !role.IsCustom() {
+			r.SendBadRequestError(fmt.Errorf("the predefined role %s can't be modified", role.Name))
+			return
+		}
+		r.role = role
+	}
+}
+
+// Post creates the custom role
+func (r *RoleAPI) Post() {
+	req := &models.RoleReq{}
+	if !r.validateRoleReq(req, 0) {
+		return
+	}
+
+	id, err := dao.AddCustomRole(&models.Role{
+		Name:        req.Name,
+		Permissions: req.Permissions,
+	})
+	if err != nil {
+		r.SendInternalServerError(fmt.Errorf("failed to create role: %v", err))
+		return
+	}
+
+	r.Redirect(http.StatusCreated, strconv.Itoa(id))
+}
+
+// List lists all the roles, the permissions of the custom roles are included
+func (r *RoleAPI) List() {
+	roles, err := dao.ListRoles()
+	if err != nil {
+		r.SendInternalServerError(fmt.Errorf("failed to list roles: %v", err))
+		return
+	}
+	for _, role := range roles {
+		if !role.IsCustom() {
+			continue
+		}
+		if role.Permissions, err = dao.GetRolePermissions(role.RoleID); err != nil {
+			r.SendInternalServerError(fmt.Errorf("failed to get permissions of role %d: %v", role.RoleID, err))
+			return
+		}
+	}
+
+	r.Data["json"] = roles
+	r.ServeJSON()
+}
+
+// Get gets the role by ID
+func (r *RoleAPI) Get() {
+	r.Data["json"] = r.role
+	r.ServeJSON()
+}
+
+// Put updates the name and permissions of the custom role
+func (r *RoleAPI) Put() {
+	req := &models.RoleReq{}
+	if !r.validateRoleReq(req, r.role.RoleID) {
+		return
+	}
+
+	r.role.Name = req.Name
+	r.role.Permissions = req.Permissions
+	if err := dao.UpdateCustomRole(r.role); err != nil {
+		r.SendInternalServerError(fmt.Errorf("failed to update role %d: %v", r.role.RoleID, err))
+		return
+	}
+}
+
+// Delete deletes the custom role, the role in use by the project members can't be deleted
+func (r *RoleAPI) Delete() {
+	if err := dao.DeleteCustomRole(r.role.RoleID); err != nil {
+		if err == dao.ErrRoleInUse {
+			r.SendPreconditionFailedError(fmt.Errorf("role %d is in use by project members", r.role.RoleID))
+			return
+		}
+		r.SendInternalServerError(fmt.Errorf("failed to delete role %d: %v", r.role.RoleID, err))
+		return
+	}
+}
+
+// validateRoleReq decodes and validates the request, the duplicated permissions are removed.
+// The name of role must be unique, the role specified by "id" is excluded when checking.
+func (r *RoleAPI) validateRoleReq(req *models.RoleReq, id int) bool {
+	isValid, err := r.DecodeJSONReqAndValidate(req)
+	if !isValid {
+		r.SendBadRequestError(err)
+		return false
+	}
+
+	permissions := []*rbac.Policy{}
+	exist := map[string]bool{}
+	for _, permission := range req.Permissions {
+		if !project.IsValidPolicy(permission) {
+			r.SendBadRequestError(fmt.Errorf("invalid permission: %s %s", permission.Action, permission.Resource))
+			return false
+		}
+		key := permission.Resource.String() + ":" + permission.Action.String()
+		if exist[key] {
+			continue
+		}
+		exist[key] = true
+		permissions = append(permissions, &rbac.Policy{
+			Resource: permission.Resource,
+			Action:   permission.Action,
+		})
+	}
+	req.Permissions = permissions
+
+	roles, err := dao.ListRoles()
+	if err != nil {
+		r.SendInternalServerError(fmt.Errorf("failed to list roles: %v", err))
+		return false
+	}
+	for _, role := range roles {
+		if role.RoleID != id && strings.EqualFold(role.Name, req.Name) {
+			r.SendConflictError(fmt.Errorf("role %s already exists", req.Name))
+			return false
+		}
+	}
+	return true
+}
+
+// getRole gets the role by ID, the permissions are populated for the custom role
+func getRole(id int) (*models.Role, error) {
+	role, err := dao.GetRoleByID(id)
+	if err != nil || role == nil {
+		return role, err
+	}
+	if role.IsCustom() {
+		if role.Permissions, err = dao.GetRolePermissions(id); err != nil {
+			return nil, err
+		}
+	}
+	return role, nil
+}
+
+// isValidRole returns whether the role can be assigned to the project members,
+// it's either a predefined role or a custom role
+func isValidRole(roleID int) (bool, error) {
+	if roleID <= 0 {
+		return false, nil
+	}
+	if project.IsPredefinedRole(roleID) {
+		return true, nil
+	}
+	role, err := dao.GetRoleByID(roleID)
+	if err != nil {
+		return false, err
+	}
+	return role != nil && role.IsCustom(), nil
+}
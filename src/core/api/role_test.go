@@ -0,0 +1,246 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/common/dao/project"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleAPI(t *testing.T) {
+	rolePath := "/api/roles"
+	req := &models.RoleReq{
+		Name: "member-viewer",
+		Permissions: []*rbac.Policy{
+			{Resource: rbac.ResourceMember, Action: rbac.ActionList},
+			{Resource: rbac.ResourceMember, Action: rbac.ActionList},
+		},
+	}
+
+	cases := []*codeCheckingCase{
+		// 401
+		{
+			request: &testingRequest{
+				method: http.MethodGet,
+				url:    rolePath,
+			},
+			code: http.StatusUnauthorized,
+		},
+		// 403, only system admin can create role
+		{
+			request: &testingRequest{
+				method:     http.MethodPost,
+				url:        rolePath,
+				bodyJSON:   req,
+				credential: nonSysAdmin,
+			},
+			code: http.StatusForbidden,
+		},
+		// 400, empty permissions
+		{
+			request: &testingRequest{
+				method: http.MethodPost,
+				url:    rolePath,
+				bodyJSON: &models.RoleReq{
+					Name: "empty",
+				},
+				credential: sysAdmin,
+			},
+			code: http.StatusBadRequest,
+		},
+		// 400, the permission out of the project
+		{
+			request: &testingRequest{
+				method: http.MethodPost,
+				url:    rolePath,
+				bodyJSON: &models.RoleReq{
+					Name: "system",
+					Permissions: []*rbac.Policy{
+						{Resource: rbac.Resource("/system"), Action: rbac.ActionRead},
+					},
+				},
+				credential: sysAdmin,
+			},
+			code: http.StatusBadRequest,
+		},
+		// 409, the name of predefined role
+		{
+			request: &testingRequest{
+				method: http.MethodPost,
+				url:    rolePath,
+				bodyJSON: &models.RoleReq{
+					Name:        "guest",
+					Permissions: req.Permissions,
+				},
+				credential: sysAdmin,
+			},
+			code: http.StatusConflict,
+		},
+		// 400, the predefined role can't be modified
+		{
+			request: &testingRequest{
+				method:     http.MethodDelete,
+				url:        fmt.Sprintf("%s/%d", rolePath, common.RoleGuest),
+				credential: sysAdmin,
+			},
+			code: http.StatusBadRequest,
+		},
+		// 404
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        fmt.Sprintf("%s/%d", rolePath, 10000),
+				credential: nonSysAdmin,
+			},
+			code: http.StatusNotFound,
+		},
+		// 201
+		{
+			request: &testingRequest{
+				method:     http.MethodPost,
+				url:        rolePath,
+				bodyJSON:   req,
+				credential: sysAdmin,
+			},
+			code: http.StatusCreated,
+		},
+	}
+	runCodeCheckingCases(t, cases...)
+
+	roles := []*models.Role{}
+	err := handleAndParse(&testingRequest{
+		method:     http.MethodGet,
+		url:        rolePath,
+		credential: nonSysAdmin,
+	}, &roles)
+	require.Nil(t, err)
+	var role *models.Role
+	for _, r := range roles {
+		if r.Name == req.Name {
+			role = r
+		}
+	}
+	require.NotNil(t, role)
+	assert.True(t, role.IsCustom())
+	require.Equal(t, 1, len(role.Permissions))
+	defer dao.DeleteCustomRole(role.RoleID)
+
+	cases = []*codeCheckingCase{
+		// 403, the user isn't the member of project
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/projects/1/members",
+				credential: nonSysAdmin,
+			},
+			code: http.StatusForbidden,
+		},
+		// 201, assign the custom role to the member
+		{
+			request: &testingRequest{
+				method: http.MethodPost,
+				url:    "/api/projects/1/members",
+				bodyJSON: &models.MemberReq{
+					Role: role.RoleID,
+					MemberUser: models.User{
+						UserID: int(nonSysAdminID),
+					},
+				},
+				credential: sysAdmin,
+			},
+			code: http.StatusCreated,
+		},
+		// 200, the permission is granted by the custom role
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        "/api/projects/1/members",
+				credential: nonSysAdmin,
+			},
+			code: http.StatusOK,
+		},
+		// 403, the permission isn't granted by the custom role
+		{
+			request: &testingRequest{
+				method: http.MethodPost,
+				url:    "/api/projects/1/members",
+				bodyJSON: &models.MemberReq{
+					Role: common.RoleGuest,
+					MemberUser: models.User{
+						UserID: int(projGuestID),
+					},
+				},
+				credential: nonSysAdmin,
+			},
+			code: http.StatusForbidden,
+		},
+		// 412, the role is in use
+		{
+			request: &testingRequest{
+				method:     http.MethodDelete,
+				url:        fmt.Sprintf("%s/%d", rolePath, role.RoleID),
+				credential: sysAdmin,
+			},
+			code: http.StatusPreconditionFailed,
+		},
+	}
+	runCodeCheckingCases(t, cases...)
+
+	members, err := project.GetProjectMember(models.Member{
+		ProjectID:  1,
+		EntityID:   int(nonSysAdminID),
+		EntityType: common.UserMember,
+	})
+	require.Nil(t, err)
+	require.Equal(t, 1, len(members))
+	require.Nil(t, project.DeleteProjectMemberByID(members[0].ID))
+
+	cases = []*codeCheckingCase{
+		// 200
+		{
+			request: &testingRequest{
+				method: http.MethodPut,
+				url:    fmt.Sprintf("%s/%d", rolePath, role.RoleID),
+				bodyJSON: &models.RoleReq{
+					Name: "webhook-manager",
+					Permissions: []*rbac.Policy{
+						{Resource: rbac.ResourceNotificationPolicy, Action: rbac.ActionCreate},
+					},
+				},
+				credential: sysAdmin,
+			},
+			code: http.StatusOK,
+		},
+		// 200
+		{
+			request: &testingRequest{
+				method:     http.MethodDelete,
+				url:        fmt.Sprintf("%s/%d", rolePath, role.RoleID),
+				credential: sysAdmin,
+			},
+			code: http.StatusOK,
+		},
+	}
+	runCodeCheckingCases(t, cases...)
+}
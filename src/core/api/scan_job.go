@@ -27,7 +27,7 @@ import (
 	"strings"
 )
 
-// ScanJobAPI handles request to /api/scanJobs/:id/log
+// ScanJobAPI handles request to /api/jobs/scan/:id and /api/jobs/scan/:id/log
 type ScanJobAPI struct {
 	BaseController
 	jobID       int64
@@ -65,6 +65,22 @@ func (sj *ScanJobAPI) Prepare() {
 	sj.jobUUID = data.UUID
 }
 
+// Operate stops or retries the scan job, it needs the permission to scan the image
+func (sj *ScanJobAPI) Operate() {
+	if !sj.RequireProjectAccess(sj.projectName, rbac.ActionCreate, rbac.ResourceRepositoryTagScanJob) {
+		return
+	}
+	action, ok := sj.decodeJobAction("")
+	if !ok {
+		return
+	}
+	if len(sj.jobUUID) == 0 {
+		sj.SendBadRequestError(fmt.Errorf("scan job %d isn't submitted to jobservice yet", sj.jobID))
+		return
+	}
+	sj.operateJob(sj.jobUUID, action)
+}
+
 // GetLog ...
 func (sj *ScanJobAPI) GetLog() {
 	logBytes, err := utils.GetJobServiceClient().GetJobLog(sj.jobUUID)
@@ -0,0 +1,260 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/rbac"
+)
+
+// the resources of the projects which can be accessed by the system level robot
+var systemRobotResources = map[rbac.Resource]bool{
+	rbac.ResourceRepository:       true,
+	rbac.ResourceHelmChart:        true,
+	rbac.ResourceHelmChartVersion: true,
+}
+
+// SystemRobotAPI handles the requests of the system level robot accounts,
+// which can access the resources of multiple projects. Only system admin has the permission.
+type SystemRobotAPI struct {
+	BaseController
+	robot *models.Robot
+}
+
+// Prepare ...
+func (r *SystemRobotAPI) Prepare() {
+	r.BaseController.Prepare()
+	if !r.SecurityCtx.IsAuthenticated() {
+		r.SendUnAuthorizedError(errors.New("UnAuthorized"))
+		return
+	}
+	if !r.SecurityCtx.IsSysAdmin() {
+		r.SendForbiddenError(errors.New(r.SecurityCtx.GetUsername()))
+		return
+	}
+
+	if idStr := r.GetStringFromPath(":id"); len(idStr) > 0 {
+		id, err := r.GetInt64FromPath(":id")
+		if err != nil || id <= 0 {
+			r.SendBadRequestError(fmt.Errorf("invalid robot ID: %s", idStr))
+			return
+		}
+
+		robot, err := dao.GetRobotByID(id)
+		if err != nil {
+			r.SendInternalServerError(fmt.Errorf("failed to get robot %d: %v", id, err))
+			return
+		}
+		if robot == nil || robot.Level != models.RobotLevelSystem {
+			r.SendNotFoundError(fmt.Errorf("robot %d not found", id))
+			return
+		}
+
+		r.robot = robot
+	}
+}
+
+// Post creates a system level robot account, the permissions are specified by the project names:
+//
+//	{
+//	  "name": "ci",
+//	  "permissions": [
+//	    {
+//	      "namespace": "library",
+//	      "access": [{"resource": "repository", "action": "push"}]
+//	    },
+//	    {
+//	      "namespace": "*",
+//	      "access": [{"resource": "repository", "action": "pull"}]
+//	    }
+//	  ]
+//	}
+func (r *SystemRobotAPI) Post() {
+	if !r.RequireNotAccessToken() {
+		return
+	}
+	var robotReq models.RobotReq
+	isValid, err := r.DecodeJSONReqAndValidate(&robotReq)
+	if !isValid {
+		r.SendBadRequestError(err)
+		return
+	}
+
+	access, err := r.toAccess(robotReq.Permissions)
+	if err != nil {
+		r.SendBadRequestError(err)
+		return
+	}
+
+	robot := models.Robot{
+		Name:        common.RobotPrefix + robotReq.Name,
+		Description: robotReq.Description,
+		Level:       models.RobotLevelSystem,
+		Duration:    robotReq.Duration,
+	}
+	if err := setRobotSecret(&robot, access); err != nil {
+		r.SendInternalServerError(fmt.Errorf("failed to generate secret for robot account: %v", err))
+		return
+	}
+	id, err := dao.AddRobot(&robot)
+	if err != nil {
+		if err == dao.ErrDupRows {
+			r.SendConflictError(errors.New("conflict robot account"))
+			return
+		}
+		r.SendInternalServerError(fmt.Errorf("failed to create robot account: %v", err))
+		return
+	}
+
+	// the token is not stored in the database, and the project ID of it is 0 as the robot has no project
+	robot.ID = id
+	rawTk, err := robotToken(&robot, access)
+	if err != nil {
+		r.SendInternalServerError(fmt.Errorf("failed to generate token for robot account: %v", err))
+		if err := dao.DeleteRobot(id); err != nil {
+			r.SendInternalServerError(fmt.Errorf("failed to delete the robot account: %d, %v", id, err))
+		}
+		return
+	}
+
+	r.Redirect(http.StatusCreated, strconv.FormatInt(id, 10))
+	r.Data["json"] = models.RobotRep{
+		Name:  robot.Name,
+		Token: rawTk,
+	}
+	r.ServeJSON()
+}
+
+// List lists the system level robot accounts
+func (r *SystemRobotAPI) List() {
+	query := models.RobotQuery{
+		Name:           r.GetString("name"),
+		Level:          models.RobotLevelSystem,
+		FuzzyMatchName: true,
+	}
+
+	count, err := dao.CountRobot(&query)
+	if err != nil {
+		r.SendInternalServerError(fmt.Errorf("failed to count system robots: %v", err))
+		return
+	}
+	query.Page, query.Size, err = r.GetPaginationParams()
+	if err != nil {
+		r.SendBadRequestError(err)
+		return
+	}
+
+	robots, err := dao.ListRobots(&query)
+	if err != nil {
+		r.SendInternalServerError(fmt.Errorf("failed to list system robots: %v", err))
+		return
+	}
+
+	r.SetPaginationHeader(count, query.Page, query.Size)
+	r.Data["json"] = robots
+	r.ServeJSON()
+}
+
+// Get gets the system level robot account by ID
+func (r *SystemRobotAPI) Get() {
+	r.Data["json"] = r.robot
+	r.ServeJSON()
+}
+
+// Put disables or enables the system level robot account
+func (r *SystemRobotAPI) Put() {
+	var robotReq models.RobotReq
+	if err := r.DecodeJSONReq(&robotReq); err != nil {
+		r.SendBadRequestError(err)
+		return
+	}
+
+	r.SetAuditLogBefore(*r.robot)
+	r.robot.Disabled = robotReq.Disabled
+	if err := dao.UpdateRobot(r.robot); err != nil {
+		r.SendInternalServerError(fmt.Errorf("failed to update robot %d: %v", r.robot.ID, err))
+		return
+	}
+}
+
+// RegenerateSecret regenerates the secret of the system level robot account and returns the new token
+func (r *SystemRobotAPI) RegenerateSecret() {
+	r.regenerateRobotSecret(r.robot)
+}
+
+// Delete deletes the system level robot account
+func (r *SystemRobotAPI) Delete() {
+	r.SetAuditLogBefore(*r.robot)
+	if err := dao.DeleteRobot(r.robot.ID); err != nil {
+		r.SendInternalServerError(fmt.Errorf("failed to delete robot %d: %v", r.robot.ID, err))
+		return
+	}
+}
+
+// toAccess converts the permissions on the projects to the policies carried by the token,
+// the resources of the policies are prefixed by the project namespace, e.g. "/project/1/repository",
+// and "/project/*/repository" for all the projects
+func (r *SystemRobotAPI) toAccess(permissions []*models.RobotPermission) ([]*rbac.Policy, error) {
+	if len(permissions) == 0 {
+		return nil, errors.New("no permission of the projects is specified")
+	}
+
+	access := []*rbac.Policy{}
+	for _, perm := range permissions {
+		if perm == nil || len(perm.Access) == 0 {
+			return nil, errors.New("empty access of the permission")
+		}
+
+		var namespace rbac.Resource
+		if perm.Namespace == models.RobotAllProjects {
+			namespace = rbac.Resource("/project/*")
+		} else {
+			if len(perm.Namespace) == 0 {
+				return nil, errors.New("empty namespace of the permission")
+			}
+			project, err := r.ProjectMgr.Get(perm.Namespace)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get project %s: %v", perm.Namespace, err)
+			}
+			if project == nil {
+				return nil, fmt.Errorf("project %s not found", perm.Namespace)
+			}
+			namespace = rbac.NewProjectNamespace(project.ProjectID).Resource()
+		}
+
+		for _, policy := range perm.Access {
+			if policy == nil || !systemRobotResources[policy.Resource] {
+				return nil, fmt.Errorf("unsupported resource of the permission on %s", perm.Namespace)
+			}
+			if len(policy.Action) == 0 {
+				return nil, fmt.Errorf("empty action of the permission on %s", perm.Namespace)
+			}
+			access = append(access, &rbac.Policy{
+				Resource: namespace.Subresource(policy.Resource),
+				Action:   policy.Action,
+				Effect:   policy.Effect,
+			})
+		}
+	}
+
+	return access, nil
+}
@@ -0,0 +1,247 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/rbac"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var systemRobotPath = "/api/robots"
+
+func TestSystemRobotAPI(t *testing.T) {
+	permissions := []*models.RobotPermission{
+		{
+			Namespace: "library",
+			Access: []*rbac.Policy{
+				{Resource: rbac.ResourceRepository, Action: rbac.ActionPush},
+			},
+		},
+		{
+			Namespace: models.RobotAllProjects,
+			Access: []*rbac.Policy{
+				{Resource: rbac.ResourceRepository, Action: rbac.ActionPull},
+			},
+		},
+	}
+
+	cases := []*codeCheckingCase{
+		// 401
+		{
+			request: &testingRequest{
+				method: http.MethodPost,
+				url:    systemRobotPath,
+			},
+			code: http.StatusUnauthorized,
+		},
+		// 403
+		{
+			request: &testingRequest{
+				method:     http.MethodPost,
+				url:        systemRobotPath,
+				bodyJSON:   &models.RobotReq{},
+				credential: projAdmin4Robot,
+			},
+			code: http.StatusForbidden,
+		},
+		// 400, no permission
+		{
+			request: &testingRequest{
+				method: http.MethodPost,
+				url:    systemRobotPath,
+				bodyJSON: &models.RobotReq{
+					Name: "system-test",
+				},
+				credential: sysAdmin,
+			},
+			code: http.StatusBadRequest,
+		},
+		// 400, project not found
+		{
+			request: &testingRequest{
+				method: http.MethodPost,
+				url:    systemRobotPath,
+				bodyJSON: &models.RobotReq{
+					Name: "system-test",
+					Permissions: []*models.RobotPermission{
+						{
+							Namespace: "non-exist-project",
+							Access:    permissions[0].Access,
+						},
+					},
+				},
+				credential: sysAdmin,
+			},
+			code: http.StatusBadRequest,
+		},
+		// 400, unsupported resource
+		{
+			request: &testingRequest{
+				method: http.MethodPost,
+				url:    systemRobotPath,
+				bodyJSON: &models.RobotReq{
+					Name: "system-test",
+					Permissions: []*models.RobotPermission{
+						{
+							Namespace: "library",
+							Access: []*rbac.Policy{
+								{Resource: rbac.ResourceMember, Action: rbac.ActionCreate},
+							},
+						},
+					},
+				},
+				credential: sysAdmin,
+			},
+			code: http.StatusBadRequest,
+		},
+		// 201
+		{
+			request: &testingRequest{
+				method: http.MethodPost,
+				url:    systemRobotPath,
+				bodyJSON: &models.RobotReq{
+					Name:        "system-test",
+					Permissions: permissions,
+				},
+				credential: sysAdmin,
+			},
+			code: http.StatusCreated,
+		},
+		// 409
+		{
+			request: &testingRequest{
+				method: http.MethodPost,
+				url:    systemRobotPath,
+				bodyJSON: &models.RobotReq{
+					Name:        "system-test",
+					Permissions: permissions,
+				},
+				credential: sysAdmin,
+			},
+			code: http.StatusConflict,
+		},
+		// 200
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        systemRobotPath,
+				credential: sysAdmin,
+			},
+			code: http.StatusOK,
+		},
+	}
+	runCodeCheckingCases(t, cases...)
+
+	robots, err := dao.ListRobots(&models.RobotQuery{
+		Level: models.RobotLevelSystem,
+	})
+	require.Nil(t, err)
+	require.Equal(t, 1, len(robots))
+	robotPath := fmt.Sprintf("%s/%d", systemRobotPath, robots[0].ID)
+
+	cases = []*codeCheckingCase{
+		// 404, the project level robot
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        fmt.Sprintf("%s/%d", systemRobotPath, 1),
+				credential: sysAdmin,
+			},
+			code: http.StatusNotFound,
+		},
+		// 200
+		{
+			request: &testingRequest{
+				method:     http.MethodGet,
+				url:        robotPath,
+				credential: sysAdmin,
+			},
+			code: http.StatusOK,
+		},
+		// 200
+		{
+			request: &testingRequest{
+				method: http.MethodPut,
+				url:    robotPath,
+				bodyJSON: &models.RobotReq{
+					Disabled: true,
+				},
+				credential: sysAdmin,
+			},
+			code: http.StatusOK,
+		},
+		// 400, invalid overlap
+		{
+			request: &testingRequest{
+				method: http.MethodPost,
+				url:    robotPath + "/secret",
+				bodyJSON: &models.RobotSecretReq{
+					Overlap: -1,
+				},
+				credential: sysAdmin,
+			},
+			code: http.StatusBadRequest,
+		},
+		// 200
+		{
+			request: &testingRequest{
+				method: http.MethodPost,
+				url:    robotPath + "/secret",
+				bodyJSON: &models.RobotSecretReq{
+					Duration: -1,
+					Overlap:  10,
+				},
+				credential: sysAdmin,
+			},
+			code: http.StatusOK,
+		},
+		// 404, the system level robot isn't in the project
+		{
+			request: &testingRequest{
+				method:     http.MethodDelete,
+				url:        fmt.Sprintf("/api/projects/1/robots/%d", robots[0].ID),
+				credential: sysAdmin,
+			},
+			code: http.StatusNotFound,
+		},
+	}
+	runCodeCheckingCases(t, cases...)
+
+	robot, err := dao.GetRobotByID(robots[0].ID)
+	require.Nil(t, err)
+	assert.Equal(t, int64(-1), robot.Duration)
+	assert.Equal(t, int64(0), robot.ExpiresAt)
+	assert.True(t, robot.MatchSecret(robots[0].Secret))
+
+	cases = []*codeCheckingCase{
+		// 200
+		{
+			request: &testingRequest{
+				method:     http.MethodDelete,
+				url:        robotPath,
+				credential: sysAdmin,
+			},
+			code: http.StatusOK,
+		},
+	}
+	runCodeCheckingCases(t, cases...)
+}
@@ -256,6 +256,9 @@ func (ua *UserAPI) Search() {
 
 // Put ...
 func (ua *UserAPI) Put() {
+	if !ua.RequireNotAccessToken() {
+		return
+	}
 	if !ua.modifiable() {
 		ua.SendForbiddenError(fmt.Errorf("User with ID %d cannot be modified", ua.userID))
 		return
@@ -283,6 +286,7 @@ func (ua *UserAPI) Put() {
 		ua.SendNotFoundError(errors.New(""))
 		return
 	}
+	ua.SetAuditLogBefore(*u)
 	if u.Email != user.Email {
 		emailExist, err := dao.UserExists(user, "email")
 		if err != nil {
@@ -305,6 +309,9 @@ func (ua *UserAPI) Put() {
 
 // Post ...
 func (ua *UserAPI) Post() {
+	if !ua.RequireNotScopedAccessToken() {
+		return
+	}
 
 	if !(ua.AuthMode == common.DBAuth) {
 		ua.SendForbiddenError(errors.New(""))
@@ -383,10 +390,16 @@ func (ua *UserAPI) Delete() {
 		ua.SendInternalServerError(errors.New("failed to delete User"))
 		return
 	}
+	if err = dao.DeleteAccessTokensOfUser(ua.userID); err != nil {
+		log.Errorf("Failed to delete the personal access tokens of user %d, error: %v", ua.userID, err)
+	}
 }
 
 // ChangePassword handles PUT to /api/users/{}/password
 func (ua *UserAPI) ChangePassword() {
+	if !ua.RequireNotAccessToken() {
+		return
+	}
 	if !ua.modifiable() {
 		ua.SendForbiddenError(fmt.Errorf("User with ID: %d is not modifiable", ua.userID))
 		return
@@ -514,6 +527,9 @@ func (ua *UserAPI) ListUserPermissions() {
 
 // GenCLISecret generates a new CLI secret and replace the old one
 func (ua *UserAPI) GenCLISecret() {
+	if !ua.RequireNotAccessToken() {
+		return
+	}
 	if ua.AuthMode != common.OIDCAuth {
 		ua.SendPreconditionFailedError(errors.New("the auth mode has to be oidc auth"))
 		return
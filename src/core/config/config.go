@@ -103,6 +103,8 @@ func initKeyProvider() {
 func initSecretStore() {
 	m := map[string]string{}
 	m[JobserviceSecret()] = secret.JobserviceUser
+	// the requests sent by core to itself, e.g. caching the images of proxy cache projects
+	m[CoreSecret()] = secret.CoreUser
 	SecretStore = secret.NewStore(m)
 }
 
@@ -534,7 +536,9 @@ func QuotaSetting() (*models.QuotaSetting, error) {
 		return nil, err
 	}
 	return &models.QuotaSetting{
-		CountPerProject:   cfgMgr.Get(common.CountPerProject).GetInt64(),
-		StoragePerProject: cfgMgr.Get(common.StoragePerProject).GetInt64(),
+		CountPerProject:           cfgMgr.Get(common.CountPerProject).GetInt64(),
+		StoragePerProject:         cfgMgr.Get(common.StoragePerProject).GetInt64(),
+		RepositoryCountPerProject: cfgMgr.Get(common.RepositoryCountPerProject).GetInt64(),
+		ChartStoragePerProject:    cfgMgr.Get(common.ChartStoragePerProject).GetInt64(),
 	}, nil
 }
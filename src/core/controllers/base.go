@@ -27,6 +27,7 @@ import (
 	"strings"
 
 	"github.com/astaxie/beego"
+	beegoctx "github.com/astaxie/beego/context"
 	"github.com/beego/i18n"
 	"github.com/goharbor/harbor/src/common"
 	"github.com/goharbor/harbor/src/common/dao"
@@ -107,15 +108,38 @@ func (cc *CommonController) Login() {
 	})
 	if err != nil {
 		log.Errorf("Error occurred in UserLogin: %v", err)
+		auditLogin(cc.Ctx, principal, http.StatusUnauthorized)
 		cc.CustomAbort(http.StatusUnauthorized, "")
 	}
 
 	if user == nil {
+		auditLogin(cc.Ctx, principal, http.StatusUnauthorized)
 		cc.CustomAbort(http.StatusUnauthorized, "")
 	}
+	auditLogin(cc.Ctx, user.Username, http.StatusOK)
 	cc.PopulateUserSession(*user)
 }
 
+// auditLogin records the login into the audit log, the failed ones are recorded as well
+func auditLogin(ctx *beegoctx.Context, username string, code int) {
+	result := models.AuditLogResultSuccess
+	if code != http.StatusOK {
+		result = models.AuditLogResultFailure
+	}
+	if _, err := dao.AddAuditLog(&models.AuditLog{
+		Username:     username,
+		SourceIP:     ctx.Input.IP(),
+		Operation:    models.AuditLogOperationLogin,
+		ResourceType: "user",
+		Resource:     ctx.Request.URL.Path,
+		Method:       ctx.Request.Method,
+		StatusCode:   code,
+		Result:       result,
+	}); err != nil {
+		log.Errorf("failed to add audit log for the login of %s: %v", username, err)
+	}
+}
+
 // LogOut Habor UI
 func (cc *CommonController) LogOut() {
 	cc.DestroySession()
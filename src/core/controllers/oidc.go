@@ -155,6 +155,7 @@ func (oc *OIDCController) Callback() {
 			oc.SendInternalServerError(err)
 			return
 		}
+		auditLogin(oc.Ctx, u.Username, http.StatusOK)
 		oc.PopulateUserSession(*u)
 		oc.Controller.Redirect("/", http.StatusFound)
 	}
@@ -228,6 +229,7 @@ func (oc *OIDCController) Onboard() {
 
 	user.OIDCUserMeta = nil
 	oc.DelSession(userInfoKey)
+	auditLogin(oc.Ctx, user.Username, http.StatusOK)
 	oc.PopulateUserSession(user)
 }
 
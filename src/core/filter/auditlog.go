@@ -0,0 +1,267 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/astaxie/beego/context"
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/utils/log"
+)
+
+const (
+	// AuditLogBeforeKey is the key of the resource before the change kept in the beego context,
+	// the API handlers set it to record the before/after summary in the audit log
+	AuditLogBeforeKey = "auditLogBefore"
+
+	maskedValue      = "******"
+	maxResourceLen   = 1024
+	maxSummaryLength = 4096
+)
+
+var (
+	// the resource types of the path segments, the last matched segment decides the type
+	auditResourceTypes = map[string]string{
+		"projects":          "project",
+		"metadatas":         "project",
+		"members":           "member",
+		"robots":            "robot",
+		"labels":            "label",
+		"policies":          "policy",
+		"retentions":        "policy",
+		"immutabletagrules": "policy",
+		"configurations":    "configuration",
+		"users":             "user",
+		"usergroups":        "user group",
+		"tokens":            "access token",
+		"roles":             "role",
+		"registries":        "registry",
+		"scanners":          "scanner",
+		"quotas":            "quota",
+		"system":            "system",
+	}
+	// the segments after them are the names of artifacts which may conflict with the ones above
+	artifactResourceTypes = map[string]string{
+		"repositories": "repository",
+		"chartrepo":    "chart",
+	}
+	// the fields recorded in the summary of all the resource types
+	commonAuditFields = []string{"id", "name", "description", "project_id", "enabled", "disabled",
+		"creation_time", "update_time", "creator"}
+	// the fields recorded in the summary of each resource type, the values of the other fields are masked,
+	// the fields of the nested objects are checked against the same list
+	auditFields = map[string]map[string]bool{
+		"project": auditFieldSet("project_name", "public", "metadata", "auto_scan", "enable_content_trust",
+			"prevent_vul", "severity", "reuse_sys_cve_whitelist", "retention_id", "cve_whitelist", "items",
+			"cve_id", "expires_at", "count_limit", "storage_limit", "repository_count_limit", "chart_storage_limit",
+			"owner_id", "owner_name", "registry_id"),
+		"member": auditFieldSet("role_id", "role_name", "entity_id", "entity_name", "entity_type",
+			"member_user", "member_group", "user_id", "username", "group_name", "group_type", "ldap_group_dn"),
+		"robot": auditFieldSet("level", "expires_at", "duration", "overlap", "access", "permissions",
+			"namespace", "resource", "action", "effect"),
+		"label": auditFieldSet("color", "scope", "deleted"),
+		"policy": auditFieldSet("type", "targets", "address", "skip_cert_verify", "event_types", "rules",
+			"action", "template", "params", "tag_selectors", "scope_selectors", "kind", "decoration", "pattern",
+			"extras", "trigger", "trigger_settings", "settings", "cron", "algorithm", "scope", "level", "ref",
+			"priority", "src_registry", "dest_registry", "dest_namespace", "filters", "value", "deletion",
+			"override", "schedule"),
+		"configuration": auditFieldSet(common.AUTHMode, common.SelfRegistration, common.ProjectCreationRestriction,
+			common.TokenExpiration, common.RobotTokenDuration, common.ReadOnly, common.NotificationEnable,
+			common.AuditLogRetentionDays, common.ScanAllPolicy, "parameter", "daily_time",
+			common.EmailHost, common.EmailPort, common.EmailUsername, common.EmailFrom, common.EmailSSL,
+			common.EmailIdentity, common.EmailInsecure,
+			common.LDAPURL, common.LDAPSearchDN, common.LDAPBaseDN, common.LDAPUID, common.LDAPFilter,
+			common.LDAPScope, common.LDAPTimeout, common.LDAPVerifyCert, common.LDAPGroupBaseDN,
+			common.LDAPGroupSearchFilter, common.LDAPGroupAttributeName, common.LDAPGroupSearchScope,
+			common.LDAPGroupAdminDn, common.LDAPGroupMembershipAttribute,
+			common.UAAEndpoint, common.UAAClientID, common.UAAVerifyCert,
+			common.HTTPAuthProxyEndpoint, common.HTTPAuthProxyTokenReviewEndpoint,
+			common.HTTPAuthProxyVerifyCert, common.HTTPAuthProxySkipSearch,
+			common.OIDCName, common.OIDCEndpoint, common.OIDCCLientID, common.OIDCVerifyCert,
+			common.OIDCGroupsClaim, common.OIDCScope,
+			common.QuotaPerProjectEnable, common.CountPerProject, common.StoragePerProject,
+			common.RepositoryCountPerProject, common.ChartStoragePerProject),
+		"user": auditFieldSet("user_id", "username", "email", "realname", "comment", "role_id", "role_name",
+			"has_admin_role", "sysadmin_flag", "deleted"),
+		"user group":   auditFieldSet("group_name", "group_type", "ldap_group_dn"),
+		"access token": auditFieldSet("user_id", "read_only", "project_ids", "expires_at"),
+		"role":         auditFieldSet("role_id", "role_code", "role_name", "permissions", "resource", "action", "effect"),
+		"registry":     auditFieldSet("url", "type", "insecure", "status"),
+		"scanner": auditFieldSet("uuid", "url", "auth", "skip_certVerify", "use_internal_addr", "is_default",
+			"health"),
+		"quota": auditFieldSet("hard", "count", "storage", "repository_count", "chart_storage",
+			"reference", "reference_id"),
+		"system": auditFieldSet("schedule", "type", "cron", "weekday", "offtime", "parameters",
+			"delete_untagged", "untagged_threshold", "time_window", "dry_run"),
+		"repository": auditFieldSet("repo_name", "tag", "label_id"),
+		"chart":      auditFieldSet("version", "label_id"),
+		"email": auditFieldSet(common.EmailHost, common.EmailPort, common.EmailUsername, common.EmailFrom,
+			common.EmailSSL, common.EmailIdentity, common.EmailInsecure),
+	}
+)
+
+func auditFieldSet(fields ...string) map[string]bool {
+	set := map[string]bool{}
+	for _, field := range append(commonAuditFields, fields...) {
+		set[field] = true
+	}
+	return set
+}
+
+// AuditLogFilter records the mutating API calls, including the failed ones, into the audit log
+func AuditLogFilter(ctx *context.Context) {
+	req := ctx.Request
+	operation := auditOperation(req.Method)
+	if len(operation) == 0 || !strings.HasPrefix(req.URL.Path, "/api/") {
+		return
+	}
+
+	code := ctx.ResponseWriter.Status
+	if code == 0 {
+		code = http.StatusOK
+	}
+	result := models.AuditLogResultSuccess
+	if code >= http.StatusBadRequest {
+		result = models.AuditLogResultFailure
+	}
+
+	username := "anonymous"
+	if sc, err := GetSecurityContext(req); err == nil && sc.IsAuthenticated() {
+		username = sc.GetUsername()
+	}
+
+	resourceType := auditResourceType(req.URL.Path)
+	resource := req.URL.Path
+	if len(resource) > maxResourceLen {
+		resource = resource[:maxResourceLen]
+	}
+
+	auditLog := &models.AuditLog{
+		Username:     username,
+		SourceIP:     ctx.Input.IP(),
+		Operation:    operation,
+		ResourceType: resourceType,
+		Resource:     resource,
+		Method:       req.Method,
+		StatusCode:   code,
+		Result:       result,
+		Summary:      auditSummary(resourceType, ctx.Input.GetData(AuditLogBeforeKey), ctx.Input.RequestBody),
+		OpTime:       time.Now(),
+	}
+	if _, err := dao.AddAuditLog(auditLog); err != nil {
+		log.Errorf("failed to add audit log for %s %s: %v", req.Method, req.URL.Path, err)
+	}
+}
+
+func auditOperation(method string) string {
+	switch method {
+	case http.MethodPost:
+		return models.AuditLogOperationCreate
+	case http.MethodPut, http.MethodPatch:
+		return models.AuditLogOperationUpdate
+	case http.MethodDelete:
+		return models.AuditLogOperationDelete
+	default:
+		return ""
+	}
+}
+
+// auditResourceType returns the type of the resource in the path, e.g. "member" for "/api/projects/1/members/2"
+func auditResourceType(path string) string {
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(path, "/api/"), "/"), "/")
+	resourceType := segments[0]
+	for i, segment := range segments {
+		if t, ok := artifactResourceTypes[segment]; ok {
+			resourceType = t
+			// the labels added to or removed from the artifacts
+			for _, s := range segments[i+1:] {
+				if s == "labels" {
+					resourceType = auditResourceTypes[s]
+				}
+			}
+			break
+		}
+		if t, ok := auditResourceTypes[segment]; ok {
+			resourceType = t
+		}
+	}
+	return resourceType
+}
+
+// auditSummary returns the JSON summary of the resource before the change and the request body,
+// only the values of the fields of the resource type are recorded and the summary is truncated if it's too long
+func auditSummary(resourceType string, before interface{}, body []byte) string {
+	fields, ok := auditFields[resourceType]
+	if !ok {
+		fields = auditFieldSet()
+	}
+	summary := map[string]interface{}{}
+	if before != nil {
+		data, err := json.Marshal(before)
+		if err == nil {
+			var v interface{}
+			if err = json.Unmarshal(data, &v); err == nil {
+				summary["before"] = maskFields(v, fields)
+			}
+		}
+		if err != nil {
+			log.Warningf("failed to convert the resource before the change for audit log: %v", err)
+		}
+	}
+	if len(body) > 0 {
+		var v interface{}
+		if err := json.Unmarshal(body, &v); err == nil {
+			summary["after"] = maskFields(v, fields)
+		}
+	}
+	if len(summary) == 0 {
+		return ""
+	}
+
+	data, err := json.Marshal(summary)
+	if err != nil {
+		log.Warningf("failed to marshal the summary for audit log: %v", err)
+		return ""
+	}
+	if len(data) > maxSummaryLength {
+		data = data[:maxSummaryLength]
+	}
+	return string(data)
+}
+
+// maskFields masks the values of the fields not in the list
+func maskFields(v interface{}, fields map[string]bool) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, val := range value {
+			if !fields[key] {
+				value[key] = maskedValue
+				continue
+			}
+			value[key] = maskFields(val, fields)
+		}
+	case []interface{}:
+		for i, val := range value {
+			value[i] = maskFields(val, fields)
+		}
+	}
+	return v
+}
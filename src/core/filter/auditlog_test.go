@@ -0,0 +1,103 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditOperation(t *testing.T) {
+	assert.Equal(t, models.AuditLogOperationCreate, auditOperation(http.MethodPost))
+	assert.Equal(t, models.AuditLogOperationUpdate, auditOperation(http.MethodPut))
+	assert.Equal(t, models.AuditLogOperationUpdate, auditOperation(http.MethodPatch))
+	assert.Equal(t, models.AuditLogOperationDelete, auditOperation(http.MethodDelete))
+	assert.Equal(t, "", auditOperation(http.MethodGet))
+}
+
+func TestAuditResourceType(t *testing.T) {
+	cases := []struct {
+		path         string
+		resourceType string
+	}{
+		{"/api/projects", "project"},
+		{"/api/projects/1/metadatas/public", "project"},
+		{"/api/projects/1/members/2", "member"},
+		{"/api/projects/1/robots/1/secret", "robot"},
+		{"/api/projects/1/webhook/policies/1", "policy"},
+		{"/api/labels/1", "label"},
+		{"/api/configurations", "configuration"},
+		{"/api/users/1/password", "user"},
+		{"/api/repositories/library/users/tags/latest", "repository"},
+		{"/api/repositories/library/ubuntu/tags/latest/labels/1", "label"},
+		{"/api/chartrepo/library/charts/harbor/1.0.0", "chart"},
+		{"/api/email/ping", "email"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.resourceType, auditResourceType(c.path), c.path)
+	}
+}
+
+func TestAuditSummary(t *testing.T) {
+	assert.Equal(t, "", auditSummary("user", nil, nil))
+	// the body which isn't JSON is ignored
+	assert.Equal(t, "", auditSummary("user", nil, []byte("not json")))
+
+	before := &models.User{
+		Username: "test",
+		Password: "Harbor12345",
+	}
+	body := []byte(`{"email":"test@example.com","old_password":"Harbor12345","new_password":"Harbor123456",
+		"credential":{"access_key":"key","access_secret":"secret"},"token_expiration":30,"items":[{"token":"abc"}]}`)
+	summary := map[string]interface{}{}
+	require.Nil(t, json.Unmarshal([]byte(auditSummary("user", before, body)), &summary))
+
+	b := summary["before"].(map[string]interface{})
+	assert.Equal(t, "test", b["username"])
+	assert.Equal(t, maskedValue, b["password"])
+
+	a := summary["after"].(map[string]interface{})
+	assert.Equal(t, "test@example.com", a["email"])
+	assert.Equal(t, maskedValue, a["old_password"])
+	assert.Equal(t, maskedValue, a["new_password"])
+	assert.Equal(t, maskedValue, a["credential"])
+	assert.Equal(t, maskedValue, a["token_expiration"])
+	assert.Equal(t, maskedValue, a["items"])
+
+	// the fields of the other resource types and the nested sensitive ones are masked
+	body = []byte(`{"name":"hook","targets":[{"type":"http","address":"http://hook.example.com",
+		"auth_header":"Bearer abc"}],"headers":{"Authorization":"Basic abc"},"authorization":"Basic abc"}`)
+	summary = map[string]interface{}{}
+	require.Nil(t, json.Unmarshal([]byte(auditSummary("policy", nil, body)), &summary))
+	a = summary["after"].(map[string]interface{})
+	assert.Equal(t, "hook", a["name"])
+	target := a["targets"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "http://hook.example.com", target["address"])
+	assert.Equal(t, maskedValue, target["auth_header"])
+	assert.Equal(t, maskedValue, a["headers"])
+	assert.Equal(t, maskedValue, a["authorization"])
+
+	// only the common fields are recorded for the unknown resource types
+	summary = map[string]interface{}{}
+	require.Nil(t, json.Unmarshal([]byte(auditSummary("unknown", nil, []byte(`{"name":"n","url":"u"}`))), &summary))
+	a = summary["after"].(map[string]interface{})
+	assert.Equal(t, "n", a["name"])
+	assert.Equal(t, maskedValue, a["url"])
+}
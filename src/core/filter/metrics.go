@@ -0,0 +1,123 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	beegoctx "github.com/astaxie/beego/context"
+	"github.com/goharbor/harbor/src/pkg/metrics"
+)
+
+const (
+	// the key of the matched route pattern set by beego
+	routerPatternKey = "RouterPattern"
+)
+
+// metricsKey is the key of the metrics of the request kept in the context of the request
+type metricsKey struct{}
+
+// requestMetrics holds the info of the request only known inside beego
+type requestMetrics struct {
+	route string
+}
+
+var (
+	manifestRe   = regexp.MustCompile(`^/v2/(?:[a-z0-9]+(?:[._-][a-z0-9]+)*/)+manifests/[^/]+$`)
+	blobUploadRe = regexp.MustCompile(`^/v2/(?:[a-z0-9]+(?:[._-][a-z0-9]+)*/)+blobs/uploads/?`)
+	blobRe       = regexp.MustCompile(`^/v2/(?:[a-z0-9]+(?:[._-][a-z0-9]+)*/)+blobs/[^/]+$`)
+	tagsListRe   = regexp.MustCompile(`^/v2/(?:[a-z0-9]+(?:[._-][a-z0-9]+)*/)+tags/list$`)
+
+	httpMetrics      = metrics.NewHTTPMetrics(metrics.SubsystemCore)
+	registryRequests = metrics.NewCounterVec(metrics.SubsystemCore, "registry_requests_total",
+		"The total number of the requests to the registry API", "operation", "code")
+	registryDuration = metrics.NewHistogramVec(metrics.SubsystemCore, "registry_request_duration_seconds",
+		"The duration of the requests to the registry API", "operation")
+)
+
+// MetricsHandler wraps the handlers of beego to record the count and the duration of the requests, the requests
+// to the registry API are recorded by the operation and others by the route pattern. As it wraps beego, the
+// responses written by the filters or the aborted controllers are recorded as well
+func MetricsHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+		rm := &requestMetrics{}
+		rec := metrics.NewStatusRecorder(w)
+		next.ServeHTTP(rec, req.WithContext(context.WithValue(req.Context(), metricsKey{}, rm)))
+		observe(req, rm.route, rec.Status(), time.Since(start))
+	})
+}
+
+// MetricsFilter passes the route pattern matched by beego to the metrics handler, the route of the
+// requests which don't reach the filter, e.g. rejected before routing or aborted, is "unknown"
+func MetricsFilter(ctx *beegoctx.Context) {
+	rm, ok := ctx.Request.Context().Value(metricsKey{}).(*requestMetrics)
+	if !ok {
+		return
+	}
+	if route, ok := ctx.Input.GetData(routerPatternKey).(string); ok {
+		rm.route = route
+	}
+}
+
+func observe(req *http.Request, route string, code int, elapsed time.Duration) {
+	if strings.HasPrefix(req.URL.Path, "/v2") {
+		operation := registryOperation(req.Method, req.URL.Path)
+		registryRequests.WithLabelValues(operation, strconv.Itoa(code)).Inc()
+		registryDuration.WithLabelValues(operation).Observe(elapsed.Seconds())
+		return
+	}
+
+	if len(route) == 0 {
+		route = "unknown"
+	}
+	httpMetrics.Observe(route, req.Method, code, elapsed)
+}
+
+// registryOperation returns the operation of the request to the registry API
+func registryOperation(method, path string) string {
+	switch {
+	case path == "/v2" || path == "/v2/":
+		return "ping"
+	case path == "/v2/_catalog":
+		return "catalog"
+	case tagsListRe.MatchString(path):
+		return "list_tags"
+	case manifestRe.MatchString(path):
+		switch method {
+		case http.MethodGet, http.MethodHead:
+			return "pull_manifest"
+		case http.MethodPut:
+			return "push_manifest"
+		case http.MethodDelete:
+			return "delete_manifest"
+		}
+	case blobUploadRe.MatchString(path):
+		return "push_blob"
+	case blobRe.MatchString(path):
+		switch method {
+		case http.MethodGet, http.MethodHead:
+			return "pull_blob"
+		case http.MethodDelete:
+			return "delete_blob"
+		}
+	}
+	return "other"
+}
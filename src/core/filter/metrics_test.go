@@ -0,0 +1,99 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/astaxie/beego"
+	beegoctx "github.com/astaxie/beego/context"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryOperation(t *testing.T) {
+	cases := []struct {
+		method    string
+		path      string
+		operation string
+	}{
+		{http.MethodGet, "/v2/", "ping"},
+		{http.MethodGet, "/v2/_catalog", "catalog"},
+		{http.MethodGet, "/v2/library/ubuntu/tags/list", "list_tags"},
+		{http.MethodGet, "/v2/library/ubuntu/manifests/latest", "pull_manifest"},
+		{http.MethodHead, "/v2/library/photon/ubuntu/manifests/sha256:3e17b60ab9d92d953fb8ebefa25624c0d23fb95f78dde5572285d10158044059", "pull_manifest"},
+		{http.MethodPut, "/v2/library/ubuntu/manifests/latest", "push_manifest"},
+		{http.MethodDelete, "/v2/library/ubuntu/manifests/sha256:3e17b60ab9d92d953fb8ebefa25624c0d23fb95f78dde5572285d10158044059", "delete_manifest"},
+		{http.MethodPost, "/v2/library/ubuntu/blobs/uploads/", "push_blob"},
+		{http.MethodPatch, "/v2/library/ubuntu/blobs/uploads/0d5e2a0b-5d4c-4a0a-9f4b-0e5c6b0f4a1d", "push_blob"},
+		{http.MethodGet, "/v2/library/ubuntu/blobs/sha256:3e17b60ab9d92d953fb8ebefa25624c0d23fb95f78dde5572285d10158044059", "pull_blob"},
+		{http.MethodDelete, "/v2/library/ubuntu/blobs/sha256:3e17b60ab9d92d953fb8ebefa25624c0d23fb95f78dde5572285d10158044059", "delete_blob"},
+		{http.MethodPost, "/v2/library/ubuntu/manifests/latest", "other"},
+		{http.MethodGet, "/v2/library", "other"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.operation, registryOperation(c.method, c.path), "%s %s", c.method, c.path)
+	}
+}
+
+func TestMetricsHandler(t *testing.T) {
+	handlers := beego.NewControllerRegister()
+	handlers.InsertFilter("/*", beego.BeforeRouter, func(ctx *beegoctx.Context) {
+		if ctx.Request.Method == http.MethodDelete {
+			ctx.ResponseWriter.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+	handlers.Get("/api/metrics-test/:id", func(ctx *beegoctx.Context) {
+		ctx.Output.SetStatus(http.StatusAccepted)
+	})
+	handlers.Delete("/api/metrics-test/:id", func(ctx *beegoctx.Context) {})
+	handlers.InsertFilter("/*", beego.FinishRouter, MetricsFilter, false)
+	h := MetricsHandler(handlers)
+
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/api/metrics-test/1", nil))
+	require.Equal(t, http.StatusAccepted, rw.Code)
+	assert.Equal(t, float64(1), requestCount(t, "/api/metrics-test/:id", http.MethodGet, "202"))
+
+	// the response written by the filter before routing
+	rw = httptest.NewRecorder()
+	h.ServeHTTP(rw, httptest.NewRequest(http.MethodDelete, "/api/metrics-test/1", nil))
+	require.Equal(t, http.StatusServiceUnavailable, rw.Code)
+	assert.Equal(t, float64(1), requestCount(t, "unknown", http.MethodDelete, "503"))
+}
+
+// requestCount returns the count of the HTTP requests of core with the labels
+func requestCount(t *testing.T, route, method, code string) float64 {
+	mfs, err := prometheus.DefaultGatherer.Gather()
+	require.Nil(t, err)
+	for _, mf := range mfs {
+		if mf.GetName() != "harbor_core_http_requests_total" {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			labels := map[string]string{}
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["route"] == route && labels["method"] == method && labels["code"] == code {
+				return m.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
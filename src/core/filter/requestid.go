@@ -0,0 +1,78 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"net/http"
+	"regexp"
+
+	beegoctx "github.com/astaxie/beego/context"
+	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/google/uuid"
+)
+
+// HeaderRequestID is the header which carries the ID of the request
+const HeaderRequestID = "X-Request-ID"
+
+var (
+	// matches the project in the path of the registry API and the repository API
+	projectInPathRe = regexp.MustCompile(`^/(?:v2|api/repositories)/([a-z0-9]+(?:[._-][a-z0-9]+)*)/`)
+	// the request ID provided by the client, it's limited as it's written to the logs and database
+	requestIDRe = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+)
+
+// RequestIDFilter reads the request ID from the header or generates one if it's not provided or invalid,
+// and puts the logger with the request ID, user and project into the context of the request.
+// It should be inserted after the SecurityFilter to get the user of the request.
+func RequestIDFilter(ctx *beegoctx.Context) {
+	req := ctx.Request
+	id := req.Header.Get(HeaderRequestID)
+	if !requestIDRe.MatchString(id) {
+		id = uuid.New().String()
+		// Keep the ID in the header to pass it to the registry and the handlers
+		req.Header.Set(HeaderRequestID, id)
+	}
+	ctx.ResponseWriter.Header().Set(HeaderRequestID, id)
+
+	fields := log.Fields{
+		log.FieldRequestID: id,
+	}
+	if sc, err := GetSecurityContext(req); err == nil && sc.IsAuthenticated() {
+		fields[log.FieldUser] = sc.GetUsername()
+	}
+	if project := projectInPath(req.URL.Path); len(project) > 0 {
+		fields[log.FieldProject] = project
+	}
+
+	*req = *(req.WithContext(log.NewContext(req.Context(), log.WithFields(fields))))
+}
+
+// GetRequestID returns the ID of the request set by the RequestIDFilter
+func GetRequestID(req *http.Request) string {
+	if req == nil {
+		return ""
+	}
+	return req.Header.Get(HeaderRequestID)
+}
+
+// projectInPath returns the name of the project in the path or empty string if the path
+// doesn't contain one
+func projectInPath(path string) string {
+	s := projectInPathRe.FindStringSubmatch(path)
+	if len(s) != 2 {
+		return ""
+	}
+	return s[1]
+}
@@ -0,0 +1,70 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	beegoctx "github.com/astaxie/beego/context"
+	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestIDFilter(t *testing.T) {
+	// generate the request ID
+	req, err := http.NewRequest(http.MethodGet, "http://127.0.0.1/v2/library/hello-world/manifests/latest", nil)
+	assert.Nil(t, err)
+	ctx := beegoctx.NewContext()
+	ctx.Reset(httptest.NewRecorder(), req)
+	RequestIDFilter(ctx)
+	id := GetRequestID(ctx.Request)
+	assert.NotEmpty(t, id)
+	assert.Equal(t, id, ctx.ResponseWriter.Header().Get(HeaderRequestID))
+	assert.NotNil(t, log.FromContext(ctx.Request.Context()))
+
+	// keep the request ID provided by the client
+	req, err = http.NewRequest(http.MethodGet, "http://127.0.0.1/api/projects", nil)
+	assert.Nil(t, err)
+	req.Header.Set(HeaderRequestID, "id")
+	ctx = beegoctx.NewContext()
+	ctx.Reset(httptest.NewRecorder(), req)
+	RequestIDFilter(ctx)
+	assert.Equal(t, "id", GetRequestID(ctx.Request))
+	assert.Equal(t, "id", ctx.ResponseWriter.Header().Get(HeaderRequestID))
+
+	// replace the invalid request ID provided by the client
+	for _, invalid := range []string{strings.Repeat("a", 65), "id\nforged", "id with space"} {
+		req, err = http.NewRequest(http.MethodGet, "http://127.0.0.1/api/projects", nil)
+		assert.Nil(t, err)
+		req.Header.Set(HeaderRequestID, invalid)
+		ctx = beegoctx.NewContext()
+		ctx.Reset(httptest.NewRecorder(), req)
+		RequestIDFilter(ctx)
+		id = GetRequestID(ctx.Request)
+		assert.NotEqual(t, invalid, id)
+		assert.True(t, requestIDRe.MatchString(id))
+		assert.Equal(t, id, ctx.ResponseWriter.Header().Get(HeaderRequestID))
+	}
+}
+
+func TestProjectInPath(t *testing.T) {
+	assert.Equal(t, "library", projectInPath("/v2/library/hello-world/manifests/latest"))
+	assert.Equal(t, "library", projectInPath("/api/repositories/library/hello-world/tags"))
+	assert.Equal(t, "", projectInPath("/v2/_catalog"))
+	assert.Equal(t, "", projectInPath("/api/projects"))
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/goharbor/harbor/src/common/utils/oidc"
 	"net/http"
 	"regexp"
+	"time"
 
 	beegoctx "github.com/astaxie/beego/context"
 	"github.com/docker/distribution/reference"
@@ -29,6 +30,7 @@ import (
 	"github.com/goharbor/harbor/src/common/models"
 	secstore "github.com/goharbor/harbor/src/common/secret"
 	"github.com/goharbor/harbor/src/common/security"
+	"github.com/goharbor/harbor/src/common/security/accesstoken"
 	admr "github.com/goharbor/harbor/src/common/security/admiral"
 	"github.com/goharbor/harbor/src/common/security/admiral/authcontext"
 	"github.com/goharbor/harbor/src/common/security/local"
@@ -211,6 +213,21 @@ func (r *robotAuthReqCtxModifier) Modify(ctx *beegoctx.Context) bool {
 		log.Errorf("the robot account %s is disabled", robot.Name)
 		return false
 	}
+	// the project of the token must be the one of the robot, it's 0 for the system level robot
+	if rClaims.ProjectID != robot.ProjectID {
+		log.Errorf("the project of token doesn't match the robot account %s", robot.Name)
+		return false
+	}
+	if !robot.MatchSecret(rClaims.Id) {
+		log.Errorf("the secret of robot account %s is regenerated, the token is invalid", robot.Name)
+		return false
+	}
+	// the last used time is updated at most once per minute to reduce the writes to database
+	if now := time.Now().Unix(); now-robot.LastUsedAt >= 60 {
+		if err := dao.UpdateRobotLastUsedAt(robot.ID, now); err != nil {
+			log.Warningf("failed to update the last used time of robot account %s: %v", robot.Name, err)
+		}
+	}
 	log.Debug("creating robot account security context...")
 	pm := config.GlobalProjectMgr
 	securCtx := robotCtx.NewSecurityContext(robot, pm, htk.Claims.(*token.RobotClaims).Access)
@@ -419,6 +436,16 @@ func (b *basicAuthReqCtxModifier) Modify(ctx *beegoctx.Context) bool {
 	}
 
 	// standalone
+	// the personal access token is accepted in all the auth modes,
+	// the password which happens to have the prefix of token is still checked by the authenticator
+	if strings.HasPrefix(password, common.AccessTokenPrefix) {
+		pm := config.GlobalProjectMgr
+		if securCtx := accessTokenSecurityContext(username, password, pm); securCtx != nil {
+			setSecurCtxAndPM(ctx.Request, securCtx, pm)
+			return true
+		}
+	}
+
 	user, err := auth.Login(models.AuthModel{
 		Principal: username,
 		Password:  password,
@@ -439,6 +466,43 @@ func (b *basicAuthReqCtxModifier) Modify(ctx *beegoctx.Context) bool {
 	return true
 }
 
+// accessTokenSecurityContext returns the security context for the user authenticated by the personal access token,
+// nil is returned if the token is invalid
+func accessTokenSecurityContext(username, tk string, pm promgr.ProjectManager) security.Context {
+	at, err := dao.GetAccessTokenByHash(models.HashAccessToken(tk))
+	if err != nil {
+		log.Errorf("failed to get the personal access token of %s: %v", username, err)
+		return nil
+	}
+	if at == nil {
+		log.Debugf("the personal access token of %s doesn't exist", username)
+		return nil
+	}
+	if at.IsExpired() {
+		log.Errorf("the personal access token %s of %s is expired", at.Name, username)
+		return nil
+	}
+	user, err := dao.GetUser(models.User{
+		UserID: at.UserID,
+	})
+	if err != nil {
+		log.Errorf("failed to get user %d: %v", at.UserID, err)
+		return nil
+	}
+	if user == nil || user.Username != username {
+		log.Errorf("the personal access token %s doesn't belong to %s", at.Name, username)
+		return nil
+	}
+	// the last used time is updated at most once per minute to reduce the writes to database
+	if now := time.Now().Unix(); now-at.LastUsedAt >= 60 {
+		if err := dao.UpdateAccessTokenLastUsedAt(at.ID, now); err != nil {
+			log.Warningf("failed to update the last used time of personal access token %s: %v", at.Name, err)
+		}
+	}
+	log.Debug("creating personal access token security context...")
+	return accesstoken.NewSecurityContext(user, at, pm)
+}
+
 type sessionReqCtxModifier struct{}
 
 func (s *sessionReqCtxModifier) Modify(ctx *beegoctx.Context) bool {
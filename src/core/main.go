@@ -156,6 +156,7 @@ func gracefulShutdown(closing, done chan struct{}) {
 }
 
 func main() {
+	log.SetComponent("core")
 	beego.BConfig.WebConfig.Session.SessionOn = true
 	beego.BConfig.WebConfig.Session.SessionName = "sid"
 
@@ -224,8 +225,13 @@ func main() {
 
 	filter.Init()
 	beego.InsertFilter("/*", beego.BeforeRouter, filter.SecurityFilter)
+	beego.InsertFilter("/*", beego.BeforeRouter, filter.RequestIDFilter)
 	beego.InsertFilter("/*", beego.BeforeRouter, filter.ReadonlyFilter)
 	beego.InsertFilter("/api/*", beego.BeforeRouter, filter.MediaTypeFilter("application/json", "multipart/form-data", "application/octet-stream"))
+	// pass the matched route to the metrics handler even if the response has been written
+	beego.InsertFilter("/*", beego.FinishRouter, filter.MetricsFilter, false)
+	// record the mutating API calls after the responses are written to get the status codes
+	beego.InsertFilter("/api/*", beego.FinishRouter, filter.AuditLogFilter, false)
 
 	initRouters()
 
@@ -253,5 +259,12 @@ func main() {
 		log.Fatalf("quota migration error, %v", err)
 	}
 
+	// wrap the handlers of beego with the metrics handler to record the responses written by the
+	// filters or the aborted controllers as well, beego only serves a *ControllerRegister, so the
+	// wrapped handlers are served by a register routing all the requests to them
+	handlers := beego.NewControllerRegister()
+	handlers.Handler("/", filter.MetricsHandler(beego.BeeApp.Handlers), true)
+	beego.BeeApp.Handlers = handlers
+
 	beego.Run()
 }
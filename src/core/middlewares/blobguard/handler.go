@@ -0,0 +1,172 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobguard
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/goharbor/harbor/src/core/middlewares/util"
+	"github.com/opencontainers/go-digest"
+)
+
+var (
+	blobUploadURLRe         = regexp.MustCompile(`^/v2/((?:[a-z0-9]+(?:[._-][a-z0-9]+)*/)+)blobs/uploads/([a-zA-Z0-9-_.=]+)/?$`)
+	initiateBlobUploadURLRe = regexp.MustCompile(`^/v2/((?:[a-z0-9]+(?:[._-][a-z0-9]+)*/)+)blobs/uploads/?$`)
+	blobURLRe               = regexp.MustCompile(`^/v2/((?:[a-z0-9]+(?:[._-][a-z0-9]+)*/)+)blobs/([a-zA-Z0-9-_+.:]+)$`)
+)
+
+// blobGuardHandler protects the blobs used by the pushing from being deleted by the non-blocking GC,
+// the blobs are touched to start a new time window of GC, the ones marked as deletion candidates are
+// reset and the pushing is rejected when the blobs are being deleted
+type blobGuardHandler struct {
+	next http.Handler
+}
+
+// New ...
+func New(next http.Handler) http.Handler {
+	return &blobGuardHandler{
+		next: next,
+	}
+}
+
+// ServeHTTP ...
+func (h *blobGuardHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	digests, err := getDigests(req)
+	if err != nil {
+		// leave the invalid request to the handlers behind
+		log.Debugf("Failed to get the blobs of the request in blob guard handler: %v", err)
+		h.next.ServeHTTP(rw, req)
+		return
+	}
+
+	if len(digests) == 0 {
+		h.next.ServeHTTP(rw, req)
+		return
+	}
+
+	if err := dao.TouchBlobs(digests...); err != nil {
+		log.Warningf("Error occurred when to touch the blobs in blob guard handler: %v", err)
+		http.Error(rw, util.MarshalError("InternalError", fmt.Sprintf("Error occurred when to touch the blobs: %v", err)),
+			http.StatusInternalServerError)
+		return
+	}
+
+	blobs, err := dao.ListBlobs(&models.BlobQuery{Digests: digests, Status: models.BlobStatusDeleting})
+	if err != nil {
+		log.Warningf("Error occurred when to list the blobs in blob guard handler: %v", err)
+		http.Error(rw, util.MarshalError("InternalError", fmt.Sprintf("Error occurred when to list the blobs: %v", err)),
+			http.StatusInternalServerError)
+		return
+	}
+	if len(blobs) > 0 {
+		log.Warningf("The blob %s is being deleted by GC, reject the request %s", blobs[0].Digest, req.URL.Path)
+		http.Error(rw, util.MarshalError("UNAVAILABLE", fmt.Sprintf("The blob %s is being deleted by GC, please retry later.", blobs[0].Digest)),
+			http.StatusServiceUnavailable)
+		return
+	}
+
+	h.next.ServeHTTP(rw, req)
+}
+
+// getDigests returns the digests of the blobs used by the request, returns nil when the request
+// is not blob existence checking, blob upload complete, blob mount or manifest push
+func getDigests(req *http.Request) ([]string, error) {
+	switch {
+	case req.Method == http.MethodHead && blobURLRe.MatchString(req.URL.Path):
+		// the client skips uploading the blob which exists, so it's going to be referenced by the manifest
+		dgt, err := digest.Parse(blobURLRe.FindStringSubmatch(req.URL.Path)[2])
+		if err != nil {
+			return nil, err
+		}
+		return []string{dgt.String()}, nil
+	case req.Method == http.MethodPut && blobUploadURLRe.MatchString(req.URL.Path):
+		if req.FormValue("digest") == "" {
+			return nil, nil
+		}
+		dgt, err := digest.Parse(req.FormValue("digest"))
+		if err != nil {
+			return nil, err
+		}
+		return []string{dgt.String()}, nil
+	case req.Method == http.MethodPost && initiateBlobUploadURLRe.MatchString(req.URL.Path):
+		if req.FormValue("mount") == "" {
+			return nil, nil
+		}
+		dgt, err := digest.Parse(req.FormValue("mount"))
+		if err != nil {
+			return nil, err
+		}
+		return []string{dgt.String()}, nil
+	}
+
+	if match, _, _ := util.MatchPushManifest(req); match {
+		return getManifestDigests(req)
+	}
+
+	return nil, nil
+}
+
+// getManifestDigests returns the digests of the manifest and the blobs referenced by it,
+// the blobs of the manifests referenced by the manifest list or image index are included
+func getManifestDigests(req *http.Request) ([]string, error) {
+	info, ok := util.ManifestInfoFromContext(req.Context())
+	if !ok {
+		manifest, err := util.ParseManifestInfoFromReq(req)
+		if err != nil {
+			return nil, err
+		}
+
+		info = manifest
+
+		// replace the request with manifest info
+		*req = *(req.WithContext(util.NewManifestInfoContext(req.Context(), info)))
+	}
+
+	digests := []string{info.Digest}
+	for _, reference := range info.References {
+		digests = append(digests, reference.Digest.String())
+
+		if !info.IsManifestList() {
+			continue
+		}
+		blobs, err := dao.GetBlobsByArtifact(reference.Digest.String())
+		if err != nil {
+			return nil, err
+		}
+		for _, blob := range blobs {
+			digests = append(digests, blob.Digest)
+		}
+	}
+
+	return unique(digests), nil
+}
+
+func unique(digests []string) []string {
+	var result []string
+	set := map[string]bool{}
+	for _, d := range digests {
+		if d == "" || set[d] {
+			continue
+		}
+		set[d] = true
+		result = append(result, d)
+	}
+	return result
+}
@@ -0,0 +1,216 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobguard
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest"
+	"github.com/docker/distribution/manifest/schema2"
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/core/config"
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/suite"
+)
+
+func randomDigest() string {
+	return digest.FromString(utils.GenerateRandomString()).String()
+}
+
+func doHandle(req *http.Request) int {
+	rr := httptest.NewRecorder()
+	h := New(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	h.ServeHTTP(rr, req)
+
+	return rr.Code
+}
+
+func putBlobUpload(projectName, blobDigest string) int {
+	url := fmt.Sprintf("/v2/%s/photon/blobs/uploads/%s?digest=%s", projectName, "8A5D3E6F-1B2C-4D5E-8F9A-0B1C2D3E4F5A", blobDigest)
+	req, _ := http.NewRequest(http.MethodPut, url, nil)
+	return doHandle(req)
+}
+
+func mountBlob(projectName, blobDigest string) int {
+	url := fmt.Sprintf("/v2/%s/photon/blobs/uploads/?mount=%s&from=library/photon", projectName, blobDigest)
+	req, _ := http.NewRequest(http.MethodPost, url, nil)
+	return doHandle(req)
+}
+
+func headBlob(projectName, blobDigest string) int {
+	url := fmt.Sprintf("/v2/%s/photon/blobs/%s", projectName, blobDigest)
+	req, _ := http.NewRequest(http.MethodHead, url, nil)
+	return doHandle(req)
+}
+
+func putManifest(projectName string, m schema2.Manifest) int {
+	buf, _ := json.Marshal(m)
+	url := fmt.Sprintf("/v2/%s/photon/manifests/latest", projectName)
+	req, _ := http.NewRequest(http.MethodPut, url, bytes.NewReader(buf))
+	req.Header.Add("Content-Type", m.MediaType)
+	return doHandle(req)
+}
+
+func withProject(f func(int64, string)) {
+	projectName := utils.GenerateRandomString()
+
+	projectID, err := dao.AddProject(models.Project{
+		Name:    projectName,
+		OwnerID: 1,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	defer func() {
+		dao.DeleteProject(projectID)
+	}()
+
+	f(projectID, projectName)
+}
+
+type HandlerSuite struct {
+	suite.Suite
+}
+
+func (suite *HandlerSuite) addBlob(status string) *models.Blob {
+	blob := &models.Blob{Digest: randomDigest(), ContentType: schema2.MediaTypeLayer, Size: 1}
+	id, err := dao.AddBlob(blob)
+	suite.Require().Nil(err)
+	blob.ID = id
+
+	blob.Status = status
+	_, err = dao.UpdateBlobStatus(blob)
+	suite.Require().Nil(err)
+
+	return blob
+}
+
+func (suite *HandlerSuite) checkStatus(expected, digest string) {
+	blob, err := dao.GetBlob(digest)
+	suite.Nil(err)
+	suite.Equal(expected, blob.Status)
+}
+
+func (suite *HandlerSuite) TestPutBlobUpload() {
+	withProject(func(projectID int64, projectName string) {
+		// the blob not exists
+		suite.Equal(http.StatusCreated, putBlobUpload(projectName, randomDigest()))
+
+		blob := suite.addBlob(models.BlobStatusDelete)
+		defer dao.DeleteBlob(blob.Digest)
+		suite.Equal(http.StatusCreated, putBlobUpload(projectName, blob.Digest))
+		suite.checkStatus(models.BlobStatusNone, blob.Digest)
+
+		deleting := suite.addBlob(models.BlobStatusDeleting)
+		defer dao.DeleteBlob(deleting.Digest)
+		suite.Equal(http.StatusServiceUnavailable, putBlobUpload(projectName, deleting.Digest))
+		suite.checkStatus(models.BlobStatusDeleting, deleting.Digest)
+	})
+}
+
+func (suite *HandlerSuite) TestMountBlob() {
+	withProject(func(projectID int64, projectName string) {
+		blob := suite.addBlob(models.BlobStatusDeleteFailed)
+		defer dao.DeleteBlob(blob.Digest)
+		suite.Equal(http.StatusCreated, mountBlob(projectName, blob.Digest))
+		suite.checkStatus(models.BlobStatusNone, blob.Digest)
+
+		deleting := suite.addBlob(models.BlobStatusDeleting)
+		defer dao.DeleteBlob(deleting.Digest)
+		suite.Equal(http.StatusServiceUnavailable, mountBlob(projectName, deleting.Digest))
+	})
+}
+
+func (suite *HandlerSuite) TestHeadBlob() {
+	withProject(func(projectID int64, projectName string) {
+		blob := suite.addBlob(models.BlobStatusNone)
+		defer dao.DeleteBlob(blob.Digest)
+		before, err := dao.GetBlob(blob.Digest)
+		suite.Require().Nil(err)
+		suite.Equal(http.StatusCreated, headBlob(projectName, blob.Digest))
+		after, err := dao.GetBlob(blob.Digest)
+		suite.Require().Nil(err)
+		suite.Equal(before.Version+1, after.Version)
+		suite.True(after.UpdateTime.After(before.UpdateTime))
+
+		candidate := suite.addBlob(models.BlobStatusDelete)
+		defer dao.DeleteBlob(candidate.Digest)
+		suite.Equal(http.StatusCreated, headBlob(projectName, candidate.Digest))
+		suite.checkStatus(models.BlobStatusNone, candidate.Digest)
+
+		deleting := suite.addBlob(models.BlobStatusDeleting)
+		defer dao.DeleteBlob(deleting.Digest)
+		suite.Equal(http.StatusServiceUnavailable, headBlob(projectName, deleting.Digest))
+	})
+}
+
+func (suite *HandlerSuite) TestPutManifest() {
+	withProject(func(projectID int64, projectName string) {
+		config := suite.addBlob(models.BlobStatusDelete)
+		defer dao.DeleteBlob(config.Digest)
+		layer := suite.addBlob(models.BlobStatusDelete)
+		defer dao.DeleteBlob(layer.Digest)
+
+		m := schema2.Manifest{
+			Versioned: manifest.Versioned{SchemaVersion: 2, MediaType: schema2.MediaTypeManifest},
+			Config:    distribution.Descriptor{MediaType: schema2.MediaTypeImageConfig, Size: 1, Digest: digest.Digest(config.Digest)},
+			Layers:    []distribution.Descriptor{{MediaType: schema2.MediaTypeLayer, Size: 1, Digest: digest.Digest(layer.Digest)}},
+		}
+		suite.Equal(http.StatusCreated, putManifest(projectName, m))
+		suite.checkStatus(models.BlobStatusNone, config.Digest)
+		suite.checkStatus(models.BlobStatusNone, layer.Digest)
+
+		deleting := suite.addBlob(models.BlobStatusDeleting)
+		defer dao.DeleteBlob(deleting.Digest)
+		m.Layers = append(m.Layers, distribution.Descriptor{MediaType: schema2.MediaTypeLayer, Size: 1, Digest: digest.Digest(deleting.Digest)})
+		suite.Equal(http.StatusServiceUnavailable, putManifest(projectName, m))
+	})
+}
+
+func (suite *HandlerSuite) TestNotMatched() {
+	req, _ := http.NewRequest(http.MethodGet, "/v2/library/photon/manifests/latest", nil)
+	suite.Equal(http.StatusCreated, doHandle(req))
+
+	req, _ = http.NewRequest(http.MethodPatch, "/v2/library/photon/blobs/uploads/8A5D3E6F-1B2C-4D5E-8F9A-0B1C2D3E4F5A", nil)
+	suite.Equal(http.StatusCreated, doHandle(req))
+
+	req, _ = http.NewRequest(http.MethodGet, "/v2/library/photon/blobs/"+randomDigest(), nil)
+	suite.Equal(http.StatusCreated, doHandle(req))
+}
+
+func TestMain(m *testing.M) {
+	config.Init()
+	dao.PrepareTestForPostgresSQL()
+
+	if result := m.Run(); result != 0 {
+		os.Exit(result)
+	}
+}
+
+func TestRunHandlerSuite(t *testing.T) {
+	suite.Run(t, new(HandlerSuite))
+}
@@ -18,11 +18,13 @@ import (
 	"net/http"
 
 	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/goharbor/harbor/src/core/middlewares/blobguard"
 	"github.com/goharbor/harbor/src/core/middlewares/chart"
 	"github.com/goharbor/harbor/src/core/middlewares/contenttrust"
 	"github.com/goharbor/harbor/src/core/middlewares/countquota"
 	"github.com/goharbor/harbor/src/core/middlewares/listrepo"
 	"github.com/goharbor/harbor/src/core/middlewares/multiplmanifest"
+	"github.com/goharbor/harbor/src/core/middlewares/proxycache"
 	"github.com/goharbor/harbor/src/core/middlewares/readonly"
 	"github.com/goharbor/harbor/src/core/middlewares/sizequota"
 	"github.com/goharbor/harbor/src/core/middlewares/url"
@@ -64,10 +66,12 @@ func (b *DefaultCreator) geMiddleware(mName string) alice.Constructor {
 		CHART:            func(next http.Handler) http.Handler { return chart.New(next) },
 		READONLY:         func(next http.Handler) http.Handler { return readonly.New(next) },
 		URL:              func(next http.Handler) http.Handler { return url.New(next) },
+		PROXYCACHE:       func(next http.Handler) http.Handler { return proxycache.New(next) },
 		MUITIPLEMANIFEST: func(next http.Handler) http.Handler { return multiplmanifest.New(next) },
 		LISTREPO:         func(next http.Handler) http.Handler { return listrepo.New(next) },
 		CONTENTTRUST:     func(next http.Handler) http.Handler { return contenttrust.New(next) },
 		VULNERABLE:       func(next http.Handler) http.Handler { return vulnerable.New(next) },
+		BLOBGUARD:        func(next http.Handler) http.Handler { return blobguard.New(next) },
 		SIZEQUOTA:        func(next http.Handler) http.Handler { return sizequota.New(next) },
 		COUNTQUOTA:       func(next http.Handler) http.Handler { return countquota.New(next) },
 	}
@@ -25,7 +25,6 @@ import (
 	"github.com/goharbor/harbor/src/core/middlewares/interceptor"
 	"github.com/goharbor/harbor/src/core/middlewares/interceptor/quota"
 	"github.com/goharbor/harbor/src/core/middlewares/util"
-	"github.com/goharbor/harbor/src/pkg/types"
 )
 
 var (
@@ -68,6 +67,8 @@ func (*chartVersionDeletionBuilder) Build(req *http.Request) (interceptor.Interc
 		ChartName: chartName,
 		Version:   version,
 	}
+	// Chart version info will be used by computeResourcesForChartVersionDeletion
+	*req = *req.WithContext(util.NewChartVersionInfoContext(req.Context(), info))
 
 	opts := []quota.Option{
 		quota.EnforceResources(config.QuotaPerProjectEnable()),
@@ -75,7 +76,7 @@ func (*chartVersionDeletionBuilder) Build(req *http.Request) (interceptor.Interc
 		quota.WithAction(quota.SubtractAction),
 		quota.StatusCode(http.StatusOK),
 		quota.MutexKeys(info.MutexKey()),
-		quota.Resources(types.ResourceList{types.ResourceCount: 1}),
+		quota.OnResources(computeResourcesForChartVersionDeletion),
 	}
 
 	return quota.New(opts...), nil
@@ -105,7 +106,7 @@ func (*chartVersionCreationBuilder) Build(req *http.Request) (interceptor.Interc
 
 	info, ok := util.ChartVersionInfoFromContext(req.Context())
 	if !ok {
-		chart, err := parseChart(req)
+		chart, size, err := parseChart(req)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse chart from body, error: %v", err)
 		}
@@ -116,8 +117,9 @@ func (*chartVersionCreationBuilder) Build(req *http.Request) (interceptor.Interc
 			Namespace: namespace,
 			ChartName: chartName,
 			Version:   version,
+			Size:      size,
 		}
-		// Chart version info will be used by computeQuotaForUpload
+		// Chart version info will be used by computeResourcesForChartVersionCreation
 		*req = *req.WithContext(util.NewChartVersionInfoContext(req.Context(), info))
 	}
 
@@ -43,6 +43,10 @@ func deleteChartVersion(projectName, chartName, version string) {
 }
 
 func uploadChartVersion(projectID int64, projectName, chartName, version string) {
+	uploadChartVersionWithSize(projectID, projectName, chartName, version, 0)
+}
+
+func uploadChartVersionWithSize(projectID int64, projectName, chartName, version string, size int64) {
 	url := fmt.Sprintf("/api/chartrepo/%s/charts/", projectName)
 	req, _ := http.NewRequest(http.MethodPost, url, nil)
 
@@ -51,6 +55,7 @@ func uploadChartVersion(projectID int64, projectName, chartName, version string)
 		Namespace: projectName,
 		ChartName: chartName,
 		Version:   version,
+		Size:      size,
 	}
 	*req = *req.WithContext(util.NewChartVersionInfoContext(req.Context(), info))
 
@@ -171,6 +176,19 @@ func (suite *HandlerSuite) TestDelete() {
 	}, "123456")
 }
 
+func (suite *HandlerSuite) TestChartStorage() {
+	suite.WithProject(func(projectID int64, projectName string) {
+		uploadChartVersionWithSize(projectID, projectName, "harbor", "0.2.1", 1024)
+		suite.AssertResourceUsage(1, types.ResourceCount, projectID)
+		suite.AssertResourceUsage(1024, types.ResourceChartStorage, projectID)
+
+		// harbor:0.2.0 exists in repo1, upload it again
+		uploadChartVersionWithSize(projectID, projectName, "harbor", "0.2.0", 1024)
+		suite.AssertResourceUsage(1, types.ResourceCount, projectID)
+		suite.AssertResourceUsage(1024, types.ResourceChartStorage, projectID)
+	}, "repo1")
+}
+
 func TestRunHandlerSuite(t *testing.T) {
 	suite.Run(t, new(HandlerSuite))
 }
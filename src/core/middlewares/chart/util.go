@@ -19,10 +19,12 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/goharbor/harbor/src/chartserver"
+	"github.com/goharbor/harbor/src/common/quota"
 	"github.com/goharbor/harbor/src/common/utils/log"
 	"github.com/goharbor/harbor/src/core/config"
 	"github.com/goharbor/harbor/src/core/middlewares/util"
@@ -85,8 +87,8 @@ func chartVersionExists(namespace, chartName, version string) bool {
 	return !chartVersion.Removed
 }
 
-// computeResourcesForChartVersionCreation returns count resource required for the chart package
-// no count required if the chart package of version exists in project
+// computeResourcesForChartVersionCreation returns count and chart storage resources required for the chart package
+// no resources required if the chart package of version exists in project
 func computeResourcesForChartVersionCreation(req *http.Request) (types.ResourceList, error) {
 	info, ok := util.ChartVersionInfoFromContext(req.Context())
 	if !ok {
@@ -98,19 +100,60 @@ func computeResourcesForChartVersionCreation(req *http.Request) (types.ResourceL
 		return nil, nil
 	}
 
-	return types.ResourceList{types.ResourceCount: 1}, nil
+	return types.ResourceList{types.ResourceCount: 1, types.ResourceChartStorage: info.Size}, nil
 }
 
-func parseChart(req *http.Request) (*chart.Chart, error) {
-	chartFile, _, err := req.FormFile(formFieldNameForChart)
+// computeResourcesForChartVersionDeletion returns count and chart storage resources released when the chart package deleted
+// only count released if the size of the chart package cannot be got, and it will be corrected by the quota sync,
+// the chart storage released never exceeds the usage as the chart packages uploaded before may be not charged
+func computeResourcesForChartVersionDeletion(req *http.Request) (types.ResourceList, error) {
+	info, ok := util.ChartVersionInfoFromContext(req.Context())
+	if !ok {
+		return nil, errors.New("chart version info missing")
+	}
+
+	resources := types.ResourceList{types.ResourceCount: 1}
+
+	ctr, err := chartController()
+	if err != nil {
+		log.Warningf("Failed to get chart controller, error: %v", err)
+		return resources, nil
+	}
+
+	size, err := ctr.GetChartVersionSize(info.Namespace, info.ChartName, info.Version)
+	if err != nil {
+		log.Warningf("Get size of chart %s with version %s in namespace %s failed, error: %v", info.ChartName, info.Version, info.Namespace, err)
+		return resources, nil
+	}
+
+	mgr, err := quota.NewManager("project", strconv.FormatInt(info.ProjectID, 10))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quota manager of project %d, error: %v", info.ProjectID, err)
+	}
+	_, used, err := mgr.GetQuota()
+	if err != nil {
+		log.Warningf("Get quota of project %d failed, error: %v", info.ProjectID, err)
+		return resources, nil
+	}
+	if size > used[types.ResourceChartStorage] {
+		size = used[types.ResourceChartStorage]
+	}
+	resources[types.ResourceChartStorage] = size
+
+	return resources, nil
+}
+
+// parseChart returns the chart and the size of the chart package uploaded in the request
+func parseChart(req *http.Request) (*chart.Chart, int64, error) {
+	chartFile, header, err := req.FormFile(formFieldNameForChart)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	chart, err := chartutil.LoadArchive(chartFile)
 	if err != nil {
-		return nil, fmt.Errorf("load chart from archive failed: %s", err.Error())
+		return nil, 0, fmt.Errorf("load chart from archive failed: %s", err.Error())
 	}
 
-	return chart, nil
+	return chart, header.Size, nil
 }
@@ -19,10 +19,12 @@ const (
 	CHART            = "chart"
 	READONLY         = "readonly"
 	URL              = "url"
+	PROXYCACHE       = "proxycache"
 	MUITIPLEMANIFEST = "manifest"
 	LISTREPO         = "listrepo"
 	CONTENTTRUST     = "contenttrust"
 	VULNERABLE       = "vulnerable"
+	BLOBGUARD        = "blobguard"
 	SIZEQUOTA        = "sizequota"
 	COUNTQUOTA       = "countquota"
 )
@@ -31,7 +33,7 @@ const (
 var ChartMiddlewares = []string{CHART}
 
 // Middlewares with sequential organization
-var Middlewares = []string{READONLY, URL, MUITIPLEMANIFEST, LISTREPO, CONTENTTRUST, VULNERABLE, SIZEQUOTA, COUNTQUOTA}
+var Middlewares = []string{READONLY, URL, PROXYCACHE, MUITIPLEMANIFEST, LISTREPO, CONTENTTRUST, VULNERABLE, BLOBGUARD, SIZEQUOTA, COUNTQUOTA}
 
 // MiddlewaresLocal ...
-var MiddlewaresLocal = []string{SIZEQUOTA, COUNTQUOTA}
+var MiddlewaresLocal = []string{BLOBGUARD, SIZEQUOTA, COUNTQUOTA}
@@ -57,9 +57,12 @@ func (*manifestDeletionBuilder) Build(req *http.Request) (interceptor.Intercepto
 		quota.WithManager("project", strconv.FormatInt(info.ProjectID, 10)),
 		quota.WithAction(quota.SubtractAction),
 		quota.StatusCode(http.StatusAccepted),
-		quota.MutexKeys(info.MutexKey("count")),
+		quota.MutexKeys(info.MutexKey("count"), info.RepositoryMutexKey("count")),
 		quota.OnResources(computeResourcesForManifestDeletion),
 		quota.OnFulfilled(func(http.ResponseWriter, *http.Request) error {
+			if err := dao.RemoveManifestFromRepository(info.Repository, info.Digest); err != nil {
+				return err
+			}
 			return dao.DeleteArtifactByDigest(info.ProjectID, info.Repository, info.Digest)
 		}),
 	}
@@ -91,7 +94,7 @@ func (*manifestCreationBuilder) Build(req *http.Request) (interceptor.Intercepto
 		quota.WithManager("project", strconv.FormatInt(info.ProjectID, 10)),
 		quota.WithAction(quota.AddAction),
 		quota.StatusCode(http.StatusCreated),
-		quota.MutexKeys(info.MutexKey("count")),
+		quota.MutexKeys(info.MutexKey("count"), info.RepositoryMutexKey("count")),
 		quota.OnResources(computeResourcesForManifestCreation),
 		quota.OnFulfilled(afterManifestCreated),
 	}
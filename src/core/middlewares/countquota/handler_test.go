@@ -38,6 +38,10 @@ func init() {
 }
 
 func getProjectCountUsage(projectID int64) (int64, error) {
+	return getProjectUsage(projectID, types.ResourceCount)
+}
+
+func getProjectUsage(projectID int64, resource types.ResourceName) (int64, error) {
 	usage := models.QuotaUsage{Reference: "project", ReferenceID: fmt.Sprintf("%d", projectID)}
 	err := dao.GetOrmer().Read(&usage, "reference", "reference_id")
 	if err != nil {
@@ -48,7 +52,7 @@ func getProjectCountUsage(projectID int64) (int64, error) {
 		return 0, err
 	}
 
-	return used[types.ResourceCount], nil
+	return used[resource], nil
 }
 
 func randomString(n int) string {
@@ -94,7 +98,13 @@ func doDeleteManifestRequest(projectID int64, projectName, name, dgt string, nex
 func doPutManifestRequest(projectID int64, projectName, name, tag, dgt string, withDupBlob bool, next ...http.HandlerFunc) int {
 	repository := fmt.Sprintf("%s/%s", projectName, name)
 
-	url := fmt.Sprintf("/v2/%s/manifests/%s", repository, tag)
+	reference := tag
+	if reference == "" {
+		// push manifest by digest
+		reference = dgt
+	}
+
+	url := fmt.Sprintf("/v2/%s/manifests/%s", repository, reference)
 	req, _ := http.NewRequest("PUT", url, nil)
 
 	mfInfo := &util.ManifestInfo{
@@ -153,6 +163,12 @@ func (suite *HandlerSuite) checkCountUsage(expected, projectID int64) {
 	suite.Equal(expected, count, "Failed to check count usage for project %d", projectID)
 }
 
+func (suite *HandlerSuite) checkRepositoryCountUsage(expected, projectID int64) {
+	count, err := getProjectUsage(projectID, types.ResourceRepositoryCount)
+	suite.Nil(err, fmt.Sprintf("Failed to get repository count usage of project %d, error: %v", projectID, err))
+	suite.Equal(expected, count, "Failed to check repository count usage for project %d", projectID)
+}
+
 func (suite *HandlerSuite) TearDownTest() {
 	for _, table := range []string{
 		"artifact", "blob",
@@ -220,6 +236,31 @@ func (suite *HandlerSuite) TestPutManifestCreatedDupBlobs() {
 	suite.Equal(int64(4), count)
 }
 
+func (suite *HandlerSuite) TestPutManifestByDigest() {
+	projectName := randomString(5)
+
+	projectID := suite.addProject(projectName)
+	defer func() {
+		dao.DeleteProject(projectID)
+	}()
+
+	// the manifests pushed by digest are referenced by manifest list, no count required
+	dgt := digest.FromString(randomString(15)).String()
+	code := doPutManifestRequest(projectID, projectName, "photon", "", dgt, false)
+	suite.Equal(http.StatusCreated, code)
+	suite.checkCountUsage(0, projectID)
+
+	total, err := dao.GetTotalOfArtifacts(&models.ArtifactQuery{Digest: dgt})
+	suite.Nil(err)
+	suite.Equal(int64(0), total, "Artifact should not be created")
+
+	var count int64
+	err = dao.GetOrmer().Raw("select count(*) from artifact_blob where digest_af = ?", dgt).QueryRow(&count)
+	suite.Nil(err)
+	// 3 = self + 2 blobs
+	suite.Equal(int64(3), count)
+}
+
 func (suite *HandlerSuite) TestPutManifestFailed() {
 	projectName := randomString(5)
 
@@ -260,6 +301,40 @@ func (suite *HandlerSuite) TestDeleteManifestAccepted() {
 	suite.checkCountUsage(0, projectID)
 }
 
+func (suite *HandlerSuite) TestRepositoryCount() {
+	projectName := randomString(5)
+
+	projectID := suite.addProject(projectName)
+	defer func() {
+		dao.DeleteProject(projectID)
+	}()
+
+	dgt := digest.FromString(randomString(15)).String()
+	code := doPutManifestRequest(projectID, projectName, "photon", "latest", dgt, false)
+	suite.Equal(http.StatusCreated, code)
+	suite.checkRepositoryCountUsage(1, projectID)
+
+	// push new tag to the existing repository
+	code = doPutManifestRequest(projectID, projectName, "photon", "dev", dgt, false)
+	suite.Equal(http.StatusCreated, code)
+	suite.checkRepositoryCountUsage(1, projectID)
+
+	newDgt := digest.FromString(randomString(15)).String()
+	code = doPutManifestRequest(projectID, projectName, "redis", "latest", newDgt, false)
+	suite.Equal(http.StatusCreated, code)
+	suite.checkRepositoryCountUsage(2, projectID)
+
+	code = doDeleteManifestRequest(projectID, projectName, "redis", newDgt)
+	suite.Equal(http.StatusAccepted, code)
+	suite.checkRepositoryCountUsage(1, projectID)
+
+	// all the tags of photon are deleted with the manifest
+	code = doDeleteManifestRequest(projectID, projectName, "photon", dgt)
+	suite.Equal(http.StatusAccepted, code)
+	suite.checkRepositoryCountUsage(0, projectID)
+	suite.checkCountUsage(0, projectID)
+}
+
 func (suite *HandlerSuite) TestDeleteManifestFailed() {
 	projectName := randomString(5)
 
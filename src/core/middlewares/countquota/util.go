@@ -30,6 +30,8 @@ import (
 
 // computeResourcesForManifestCreation returns count resource required for manifest
 // no count required if the tag of the repository exists in the project
+// or the manifest is pushed by digest, which will be referenced by a manifest list or an image index,
+// and the repository count is required when the first tag pushed to the repository
 func computeResourcesForManifestCreation(req *http.Request) (types.ResourceList, error) {
 	info, ok := util.ManifestInfoFromContext(req.Context())
 	if !ok {
@@ -37,15 +39,26 @@ func computeResourcesForManifestCreation(req *http.Request) (types.ResourceList,
 	}
 
 	// only count quota required when push new tag
-	if info.IsNewTag() {
-		return quota.ResourceList{quota.ResourceCount: 1}, nil
+	if !info.IsTagged() || !info.IsNewTag() {
+		return nil, nil
 	}
 
-	return nil, nil
+	resources := quota.ResourceList{quota.ResourceCount: 1}
+
+	newRepository, err := info.IsNewRepository()
+	if err != nil {
+		return nil, fmt.Errorf("error occurred when get artifacts %v ", err)
+	}
+	if newRepository {
+		resources[quota.ResourceRepositoryCount] = 1
+	}
+
+	return resources, nil
 }
 
 // computeResourcesForManifestDeletion returns count resource will be released when manifest deleted
-// then result will be the sum of manifest count of the same repository in the project
+// then result will be the sum of manifest count of the same repository in the project,
+// and the repository count will be released when all the artifacts of the repository deleted
 func computeResourcesForManifestDeletion(req *http.Request) (types.ResourceList, error) {
 	info, ok := util.ManifestInfoFromContext(req.Context())
 	if !ok {
@@ -62,17 +75,44 @@ func computeResourcesForManifestDeletion(req *http.Request) (types.ResourceList,
 		return nil, fmt.Errorf("error occurred when get artifacts %v ", err)
 	}
 
-	return types.ResourceList{types.ResourceCount: total}, nil
+	resources := types.ResourceList{types.ResourceCount: total}
+	if total == 0 {
+		return resources, nil
+	}
+
+	totalOfRepository, err := dao.GetTotalOfArtifacts(&models.ArtifactQuery{
+		PID:  info.ProjectID,
+		Repo: info.Repository,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error occurred when get artifacts %v ", err)
+	}
+	if totalOfRepository == total {
+		resources[types.ResourceRepositoryCount] = 1
+	}
+
+	return resources, nil
 }
 
 // afterManifestCreated the handler after manifest created success
 // it will create or update the artifact info in db, and then attach blobs to artifact
+// the manifest pushed by digest is not an artifact, only its blobs are attached
+// so that the manifest list or image index referencing it can reach them
 func afterManifestCreated(w http.ResponseWriter, req *http.Request) error {
 	info, ok := util.ManifestInfoFromContext(req.Context())
 	if !ok {
 		return errors.New("manifest info missing")
 	}
 
+	// record the repository of the manifest so that it can be deleted from there by GC once it's untagged
+	if _, err := dao.AddManifestToRepository(info.ProjectID, info.Repository, info.Digest); err != nil {
+		return fmt.Errorf("error to add manifest to repository, %v", err)
+	}
+
+	if !info.IsTagged() {
+		return attachBlobsToArtifact(info)
+	}
+
 	artifact := info.Artifact()
 	if artifact.ID == 0 {
 		if _, err := dao.AddArtifact(artifact); err != nil {
@@ -39,7 +39,7 @@ func Init() error {
 func Handle(rw http.ResponseWriter, req *http.Request) {
 	securityCtx, err := filter.GetSecurityContext(req)
 	if err != nil {
-		log.Errorf("failed to get security context in middlerware: %v", err)
+		log.FromContext(req.Context()).Errorf("failed to get security context in middlerware: %v", err)
 		// error to get security context, use the default chain.
 		head = New(Middlewares).Create().Then(proxy)
 	} else {
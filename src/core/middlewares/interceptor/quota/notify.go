@@ -0,0 +1,196 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/quota"
+	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/goharbor/harbor/src/core/filter"
+	"github.com/goharbor/harbor/src/core/middlewares/util"
+	"github.com/goharbor/harbor/src/core/notifier/event"
+	"github.com/goharbor/harbor/src/pkg/metrics"
+	"github.com/goharbor/harbor/src/pkg/types"
+)
+
+const (
+	// projectReference is the quota reference of project
+	projectReference = "project"
+
+	// warningThreshold is the percentage of the hard limit from which the quota is near limit
+	warningThreshold = 85
+)
+
+// rejections counts the requests rejected by the hard limits of each resource
+var rejections = metrics.NewCounterVec(metrics.SubsystemCore, "quota_rejections_total",
+	"The total number of the requests rejected by the quota", "resource")
+
+// isOverflow returns true when the err is caused by exceeding the hard limits
+func isOverflow(err error) bool {
+	return len(overflowResources(err)) > 0
+}
+
+// overflowResources returns the resources exceeding the hard limits in the err
+func overflowResources(err error) []types.ResourceName {
+	var names []types.ResourceName
+	switch e := err.(type) {
+	case *quota.ResourceOverflow:
+		names = append(names, e.Resource)
+	case quota.Errors:
+		for _, ee := range e {
+			names = append(names, overflowResources(ee)...)
+		}
+	}
+
+	return names
+}
+
+// nearLimit returns the resources whose usage crosses the warning threshold after the resources added
+func nearLimit(hardLimits, used, added types.ResourceList) []types.ResourceName {
+	var names []types.ResourceName
+	for name, hard := range hardLimits {
+		if hard == types.UNLIMITED || hard <= 0 {
+			continue
+		}
+
+		newUsed, ok := used[name]
+		if !ok {
+			continue
+		}
+		oldUsed := newUsed - added[name]
+		if oldUsed*100 < hard*warningThreshold && newUsed*100 >= hard*warningThreshold {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// notifyExceed publishes the quota exceeded event when the resources are rejected by the hard limits
+func (qi *quotaInterceptor) notifyExceed(req *http.Request, err error) {
+	if !isOverflow(err) {
+		return
+	}
+
+	for _, name := range overflowResources(err) {
+		rejections.WithLabelValues(string(name)).Inc()
+	}
+
+	md, ok := qi.newMetaData(req)
+	if !ok {
+		return
+	}
+	md.Details = err.Error()
+	md.Used = qi.resources
+
+	if hardLimits, used, err := qi.opts.Manager.GetQuota(); err == nil {
+		md.Hard = hardLimits
+		md.Used = used
+	}
+
+	qi.publish(&event.QuotaExceedMetaData{QuotaMetaData: *md})
+}
+
+// notifyWarning publishes the quota warning event when the usage crosses the warning threshold
+func (qi *quotaInterceptor) notifyWarning(req *http.Request) {
+	if len(qi.resources) == 0 {
+		return
+	}
+
+	md, ok := qi.newMetaData(req)
+	if !ok {
+		return
+	}
+
+	hardLimits, used, err := qi.opts.Manager.GetQuota()
+	if err != nil {
+		log.Errorf("Failed to get quota for near-limit checking, error: %v", err)
+		return
+	}
+
+	names := nearLimit(hardLimits, used, qi.resources)
+	if len(names) == 0 {
+		return
+	}
+
+	md.Hard = hardLimits
+	md.Used = used
+	md.Details = fmt.Sprintf("quota usage of %s reaches %d%% of the hard limits", prettyResourceNames(names), warningThreshold)
+
+	qi.publish(&event.QuotaWarningMetaData{QuotaMetaData: *md})
+}
+
+func (qi *quotaInterceptor) newMetaData(req *http.Request) (*event.QuotaMetaData, bool) {
+	if qi.opts.Manager == nil {
+		return nil, false
+	}
+
+	reference, referenceID := qi.opts.Manager.Reference()
+	if reference != projectReference {
+		return nil, false
+	}
+
+	projectID, err := strconv.ParseInt(referenceID, 10, 64)
+	if err != nil {
+		return nil, false
+	}
+
+	md := &event.QuotaMetaData{
+		ProjectID: projectID,
+		OccurAt:   time.Now(),
+	}
+
+	if sc, err := filter.GetSecurityContext(req); err == nil && sc.IsAuthenticated() {
+		md.Operator = sc.GetUsername()
+	}
+
+	ctx := req.Context()
+	if info, ok := util.ManifestInfoFromContext(ctx); ok {
+		md.RepoName = info.Repository
+		md.Tag = info.Tag
+		md.Digest = info.Digest
+	} else if info, ok := util.BlobInfoFromContext(ctx); ok {
+		md.RepoName = info.Repository
+		md.Digest = info.Digest
+	}
+
+	return md, true
+}
+
+func (qi *quotaInterceptor) publish(md event.Metadata) {
+	e := &event.Event{}
+	if err := e.Build(md); err != nil {
+		log.Errorf("Failed to build quota event metadata, error: %v", err)
+		return
+	}
+
+	if err := e.Publish(); err != nil {
+		log.Errorf("Failed to publish quota event, error: %v", err)
+	}
+}
+
+func prettyResourceNames(names []types.ResourceName) string {
+	var s []string
+	for _, name := range names {
+		s = append(s, string(name))
+	}
+
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,66 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/goharbor/harbor/src/common/quota"
+	"github.com/goharbor/harbor/src/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsOverflow(t *testing.T) {
+	overflow := quota.NewResourceOverflowError(types.ResourceStorage, 100, 90, 110)
+
+	assert.False(t, isOverflow(errors.New("failed")))
+	assert.True(t, isOverflow(overflow))
+	assert.True(t, isOverflow(quota.Errors{}.Add(overflow)))
+	assert.False(t, isOverflow(quota.Errors{}.Add(quota.NewResourceNotFoundError(types.ResourceCount))))
+}
+
+func TestOverflowResources(t *testing.T) {
+	storage := quota.NewResourceOverflowError(types.ResourceStorage, 100, 90, 110)
+	count := quota.NewResourceOverflowError(types.ResourceCount, 10, 10, 11)
+
+	assert.Empty(t, overflowResources(errors.New("failed")))
+	assert.Equal(t, []types.ResourceName{types.ResourceStorage}, overflowResources(storage))
+	assert.Equal(t, []types.ResourceName{types.ResourceStorage, types.ResourceCount},
+		overflowResources(quota.Errors{}.Add(storage).Add(count)))
+}
+
+func TestNearLimit(t *testing.T) {
+	hardLimits := types.ResourceList{types.ResourceCount: types.UNLIMITED, types.ResourceStorage: 100}
+
+	// crosses the threshold
+	names := nearLimit(hardLimits, types.ResourceList{types.ResourceCount: 10, types.ResourceStorage: 90},
+		types.ResourceList{types.ResourceCount: 1, types.ResourceStorage: 10})
+	assert.Equal(t, []types.ResourceName{types.ResourceStorage}, names)
+
+	// already above the threshold before the resources added
+	names = nearLimit(hardLimits, types.ResourceList{types.ResourceCount: 10, types.ResourceStorage: 95},
+		types.ResourceList{types.ResourceCount: 1, types.ResourceStorage: 5})
+	assert.Empty(t, names)
+
+	// below the threshold
+	names = nearLimit(hardLimits, types.ResourceList{types.ResourceCount: 10, types.ResourceStorage: 50},
+		types.ResourceList{types.ResourceCount: 1, types.ResourceStorage: 10})
+	assert.Empty(t, names)
+}
+
+func TestPrettyResourceNames(t *testing.T) {
+	assert.Equal(t, "count, storage", prettyResourceNames([]types.ResourceName{types.ResourceCount, types.ResourceStorage}))
+}
@@ -68,6 +68,7 @@ func (qi *quotaInterceptor) HandleRequest(req *http.Request) (err error) {
 	err = qi.doTry()
 	if err != nil {
 		log.Errorf("Failed to %s resources, error: %v", qi.opts.Action, err)
+		qi.notifyExceed(req, err)
 	}
 
 	return
@@ -90,6 +91,10 @@ func (qi *quotaInterceptor) HandleResponse(w http.ResponseWriter, req *http.Requ
 			log.Errorf("Failed to confirm for resource, error: %v", err)
 		}
 
+		if opts.EnforceResources() && opts.Action == AddAction {
+			qi.notifyWarning(req)
+		}
+
 		if opts.OnFulfilled != nil {
 			if err := opts.OnFulfilled(w, req); err != nil {
 				log.Errorf("Failed to handle on fulfilled, error: %v", err)
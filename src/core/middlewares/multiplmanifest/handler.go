@@ -15,10 +15,12 @@
 package multiplmanifest
 
 import (
+	"fmt"
+	"net/http"
+
+	"github.com/goharbor/harbor/src/common/dao"
 	"github.com/goharbor/harbor/src/common/utils/log"
 	"github.com/goharbor/harbor/src/core/middlewares/util"
-	"net/http"
-	"strings"
 )
 
 type multipleManifestHandler struct {
@@ -32,17 +34,34 @@ func New(next http.Handler) http.Handler {
 	}
 }
 
-// ServeHTTP The handler is responsible for blocking request to upload manifest list by docker client, which is not supported so far by Harbor.
+// ServeHTTP The handler is responsible for checking the request to upload manifest list or OCI image index,
+// the manifests referenced by it must have been pushed to the project before.
 func (mh multipleManifestHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	match, _, _ := util.MatchPushManifest(req)
-	if match {
-		contentType := req.Header.Get("Content-type")
-		// application/vnd.docker.distribution.manifest.list.v2+json
-		if strings.Contains(contentType, "manifest.list.v2") {
-			log.Debugf("Content-type: %s is not supported, failing the response.", contentType)
-			http.Error(rw, util.MarshalError("UNSUPPORTED_MEDIA_TYPE", "Manifest.list is not supported."), http.StatusUnsupportedMediaType)
+	if match && util.IsManifestListMediaType(req.Header.Get("Content-Type")) {
+		info, err := util.ParseManifestInfoFromReq(req)
+		if err != nil {
+			log.Warningf("Error occurred when to parse manifest list: %v", err)
+			http.Error(rw, util.MarshalError("MANIFEST_INVALID", fmt.Sprintf("Failed to parse manifest list: %v", err)), http.StatusBadRequest)
 			return
 		}
+
+		for _, reference := range info.References {
+			exist, err := dao.HasBlobInProject(info.ProjectID, reference.Digest.String())
+			if err != nil {
+				log.Errorf("Error occurred when to check the existence of manifest %s: %v", reference.Digest, err)
+				http.Error(rw, util.MarshalError("InternalError", fmt.Sprintf("Error occurred when to check the manifest %s", reference.Digest)), http.StatusInternalServerError)
+				return
+			}
+			if !exist {
+				log.Debugf("Manifest %s referenced by manifest list %s is not found in project %d", reference.Digest, info.Digest, info.ProjectID)
+				http.Error(rw, util.MarshalError("MANIFEST_BLOB_UNKNOWN", fmt.Sprintf("Manifest %s referenced by the manifest list is unknown", reference.Digest)), http.StatusBadRequest)
+				return
+			}
+		}
+
+		// Manifest info will be used by the quota handlers
+		*req = *(req.WithContext(util.NewManifestInfoContext(req.Context(), info)))
 	}
 	mh.next.ServeHTTP(rw, req)
 }
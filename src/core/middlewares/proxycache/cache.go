@@ -0,0 +1,133 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxycache
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/distribution/manifest/schema1"
+	"github.com/docker/distribution/manifest/schema2"
+	coreutils "github.com/goharbor/harbor/src/core/utils"
+	"github.com/goharbor/harbor/src/replication"
+	"github.com/goharbor/harbor/src/replication/adapter"
+	"github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+var (
+	acceptedMediaTypes = []string{
+		schema1.MediaTypeManifest,
+		schema1.MediaTypeSignedManifest,
+		schema2.MediaTypeManifest,
+		v1.MediaTypeImageManifest,
+		manifestlist.MediaTypeManifestList,
+		v1.MediaTypeImageIndex,
+	}
+
+	// for testing
+	now = time.Now
+)
+
+// localRegistry is the repository of local registry which the images are cached in
+type localRegistry interface {
+	BlobExist(digest string) (bool, error)
+	PushBlob(digest string, size int64, data io.Reader) error
+	PushManifest(reference, mediaType string, payload []byte) (string, error)
+}
+
+// localRepository returns the client of repository in local registry, the requests are sent to core
+// with the secret of core so that they're charged by quota but not refused as the writes to proxy cache project
+func localRepository(repository string) (localRegistry, error) {
+	return coreutils.NewRepositoryClientForCore(repository)
+}
+
+// upstreamRegistry returns the client of the upstream registry the proxy cache project bound to
+func upstreamRegistry(registryID int64) (adapter.ImageRegistry, error) {
+	reg, err := replication.RegistryMgr.Get(registryID)
+	if err != nil {
+		return nil, err
+	}
+	if reg == nil {
+		return nil, errors.New("upstream registry not found")
+	}
+	factory, err := adapter.GetFactory(reg.Type)
+	if err != nil {
+		return nil, err
+	}
+	ad, err := factory(reg)
+	if err != nil {
+		return nil, err
+	}
+	registry, ok := ad.(adapter.ImageRegistry)
+	if !ok {
+		return nil, errors.New("the adapter doesn't implement the \"ImageRegistry\" interface")
+	}
+	return registry, nil
+}
+
+// copyManifest copies the blobs and manifests referenced by the manifest from the upstream registry to local,
+// and then pushes the manifest itself
+func copyManifest(upstream adapter.ImageRegistry, local localRegistry, upstreamRepository, reference string,
+	manifest distribution.Manifest) error {
+	for _, ref := range manifest.References() {
+		dgt := ref.Digest.String()
+		switch ref.MediaType {
+		// the manifests referenced by manifest list or image index
+		case schema2.MediaTypeManifest, v1.MediaTypeImageManifest,
+			schema1.MediaTypeSignedManifest, schema1.MediaTypeManifest:
+			m, _, err := upstream.PullManifest(upstreamRepository, dgt, acceptedMediaTypes)
+			if err != nil {
+				return err
+			}
+			if err = copyManifest(upstream, local, upstreamRepository, dgt, m); err != nil {
+				return err
+			}
+		case schema2.MediaTypeForeignLayer:
+			continue
+		default:
+			if err := copyBlob(upstream, local, upstreamRepository, dgt); err != nil {
+				return err
+			}
+		}
+	}
+
+	mediaType, payload, err := manifest.Payload()
+	if err != nil {
+		return err
+	}
+	_, err = local.PushManifest(reference, mediaType, payload)
+	return err
+}
+
+func copyBlob(upstream adapter.ImageRegistry, local localRegistry, upstreamRepository, dgt string) error {
+	exist, err := local.BlobExist(dgt)
+	if err != nil {
+		return err
+	}
+	if exist {
+		return nil
+	}
+
+	size, blob, err := upstream.PullBlob(upstreamRepository, dgt)
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+
+	return local.PushBlob(dgt, size, blob)
+}
@@ -0,0 +1,311 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxycache
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/goharbor/harbor/src/common/dao"
+	commonhttp "github.com/goharbor/harbor/src/common/http"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/goharbor/harbor/src/core/config"
+	"github.com/goharbor/harbor/src/core/middlewares/util"
+	"github.com/goharbor/harbor/src/pkg/proxy"
+	proxydao "github.com/goharbor/harbor/src/pkg/proxy/dao"
+	"github.com/goharbor/harbor/src/replication/adapter"
+	"github.com/opencontainers/go-digest"
+)
+
+var (
+	repositoryURLRe = regexp.MustCompile(`^/v2/((?:[a-z0-9]+(?:[._-][a-z0-9]+)*/)+)(manifests|blobs|tags)/`)
+	blobURLRe       = regexp.MustCompile(`^/v2/((?:[a-z0-9]+(?:[._-][a-z0-9]+)*/)+)blobs/([a-zA-Z0-9-_+.]+:[a-fA-F0-9]+)$`)
+	// the references being cached, to avoid caching the same reference concurrently,
+	// it's shared by the handlers as the handler is created for each request
+	caching sync.Map
+)
+
+type proxyCacheHandler struct {
+	next       http.Handler
+	mgr        proxy.Manager
+	getProject func(name string) (*models.Project, error)
+	hasBlob    func(projectID int64, digest string) (bool, error)
+	upstream   func(registryID int64) (adapter.ImageRegistry, error)
+	local      func(repository string) (localRegistry, error)
+}
+
+// New ...
+func New(next http.Handler) http.Handler {
+	return &proxyCacheHandler{
+		next:       next,
+		mgr:        proxy.Mgr,
+		getProject: func(name string) (*models.Project, error) { return config.GlobalProjectMgr.Get(name) },
+		hasBlob:    dao.HasBlobInProject,
+		upstream:   upstreamRegistry,
+		local:      localRepository,
+	}
+}
+
+// ServeHTTP The handler is responsible for the requests to proxy cache projects,
+// the manifests and blobs which are not in local registry are fetched from the upstream registry,
+// and the images are cached in local registry for the following requests.
+func (h *proxyCacheHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	s := repositoryURLRe.FindStringSubmatch(req.URL.Path)
+	if len(s) != 3 {
+		h.next.ServeHTTP(rw, req)
+		return
+	}
+
+	repository := strings.TrimSuffix(s[1], "/")
+	projectName, upstreamRepository := utils.ParseRepository(repository)
+	project, err := h.getProject(projectName)
+	if err != nil {
+		log.Errorf("Failed to get project %s: %v", projectName, err)
+		http.Error(rw, util.MarshalError("InternalError", fmt.Sprintf("Failed to get project %s", projectName)), http.StatusInternalServerError)
+		return
+	}
+	if project == nil || !project.IsProxyCache() {
+		h.next.ServeHTTP(rw, req)
+		return
+	}
+
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		http.Error(rw, util.MarshalError("DENIED", fmt.Sprintf("The proxy cache project %s is read only", projectName)), http.StatusForbidden)
+		return
+	}
+
+	if match, _, reference := util.MatchManifestURL(req); match {
+		h.serveManifest(rw, req, project, repository, upstreamRepository, reference)
+		return
+	}
+
+	if b := blobURLRe.FindStringSubmatch(req.URL.Path); len(b) == 3 {
+		h.serveBlob(rw, req, project, repository, upstreamRepository, b[2])
+		return
+	}
+
+	h.next.ServeHTTP(rw, req)
+}
+
+func (h *proxyCacheHandler) serveManifest(rw http.ResponseWriter, req *http.Request, project *models.Project,
+	repository, upstreamRepository, reference string) {
+	entry, err := h.mgr.Get(project.ProjectID, repository, reference)
+	if err != nil {
+		log.Errorf("Failed to get cache entry of %s:%s: %v", repository, reference, err)
+		http.Error(rw, util.MarshalError("InternalError", fmt.Sprintf("Failed to get cache of %s:%s", repository, reference)), http.StatusInternalServerError)
+		return
+	}
+
+	// the manifest referenced by digest never changes, the one referenced by tag is checked with the upstream after expired
+	_, err = digest.Parse(reference)
+	byDigest := err == nil
+	if entry != nil && (byDigest || !entry.Expired(now())) {
+		h.hit(entry)
+		h.next.ServeHTTP(rw, req)
+		return
+	}
+
+	upstream, err := h.upstream(project.ProxyCacheRegistryID())
+	if err != nil {
+		log.Errorf("Failed to create client for upstream registry of project %s: %v", project.Name, err)
+		http.Error(rw, util.MarshalError("InternalError", "Failed to create client for upstream registry"), http.StatusInternalServerError)
+		return
+	}
+
+	manifest, dgt, err := upstream.PullManifest(upstreamRepository, reference, acceptedMediaTypes)
+	if err != nil {
+		// serve the expired cache when the upstream registry isn't available, e.g. hits the rate limit
+		if entry != nil {
+			log.Warningf("Failed to pull manifest %s:%s from upstream, serve the cached one: %v", upstreamRepository, reference, err)
+			h.hit(entry)
+			h.next.ServeHTTP(rw, req)
+			return
+		}
+		h.handleUpstreamError(rw, "MANIFEST_UNKNOWN", fmt.Sprintf("%s:%s", upstreamRepository, reference), err)
+		return
+	}
+
+	// the tag isn't changed in the upstream registry, serve the cached one and renew the expiry
+	if entry != nil && entry.Digest == dgt {
+		if err := h.mgr.Renew(entry, project.ProxyCacheExpiry()); err != nil {
+			log.Errorf("Failed to renew cache entry of %s:%s: %v", repository, reference, err)
+		}
+		h.next.ServeHTTP(rw, req)
+		return
+	}
+
+	mediaType, payload, err := manifest.Payload()
+	if err != nil {
+		log.Errorf("Failed to get payload of manifest %s:%s: %v", upstreamRepository, reference, err)
+		http.Error(rw, util.MarshalError("InternalError", "Failed to get payload of manifest"), http.StatusInternalServerError)
+		return
+	}
+
+	rw.Header().Set("Content-Type", mediaType)
+	rw.Header().Set("Content-Length", strconv.Itoa(len(payload)))
+	rw.Header().Set("Docker-Content-Digest", dgt)
+	rw.WriteHeader(http.StatusOK)
+	if req.Method == http.MethodGet {
+		if _, err := rw.Write(payload); err != nil {
+			log.Warningf("Failed to write manifest %s:%s to client: %v", upstreamRepository, reference, err)
+		}
+	}
+
+	key := fmt.Sprintf("%s:%s", repository, reference)
+	if _, loaded := caching.LoadOrStore(key, struct{}{}); loaded {
+		log.Debugf("%s is being cached, skip", key)
+		return
+	}
+	go func() {
+		defer caching.Delete(key)
+
+		local, err := h.local(repository)
+		if err != nil {
+			log.Errorf("Failed to create client for local repository %s: %v", repository, err)
+			return
+		}
+		if err := copyManifest(upstream, local, upstreamRepository, reference, manifest); err != nil {
+			log.Errorf("Failed to cache %s:%s in project %s: %v", upstreamRepository, reference, project.Name, err)
+			return
+		}
+		if err := h.mgr.Miss(project.ProjectID, repository, reference, dgt, project.ProxyCacheExpiry()); err != nil {
+			log.Errorf("Failed to record cache entry of %s:%s: %v", repository, reference, err)
+		}
+	}()
+}
+
+func (h *proxyCacheHandler) serveBlob(rw http.ResponseWriter, req *http.Request, project *models.Project,
+	repository, upstreamRepository, dgt string) {
+	exist, err := h.hasBlob(project.ProjectID, dgt)
+	if err != nil {
+		log.Errorf("Failed to check the existence of blob %s in project %s: %v", dgt, project.Name, err)
+		http.Error(rw, util.MarshalError("InternalError", fmt.Sprintf("Failed to check blob %s", dgt)), http.StatusInternalServerError)
+		return
+	}
+	if exist {
+		h.next.ServeHTTP(rw, req)
+		return
+	}
+
+	upstream, err := h.upstream(project.ProxyCacheRegistryID())
+	if err != nil {
+		log.Errorf("Failed to create client for upstream registry of project %s: %v", project.Name, err)
+		http.Error(rw, util.MarshalError("InternalError", "Failed to create client for upstream registry"), http.StatusInternalServerError)
+		return
+	}
+
+	if req.Method == http.MethodHead {
+		exist, err := upstream.BlobExist(upstreamRepository, dgt)
+		if err != nil {
+			h.handleUpstreamError(rw, "BLOB_UNKNOWN", dgt, err)
+			return
+		}
+		if !exist {
+			http.Error(rw, util.MarshalError("BLOB_UNKNOWN", fmt.Sprintf("Blob %s unknown to registry", dgt)), http.StatusNotFound)
+			return
+		}
+		rw.Header().Set("Docker-Content-Digest", dgt)
+		rw.WriteHeader(http.StatusOK)
+		return
+	}
+
+	size, blob, err := upstream.PullBlob(upstreamRepository, dgt)
+	if err != nil {
+		h.handleUpstreamError(rw, "BLOB_UNKNOWN", dgt, err)
+		return
+	}
+	defer blob.Close()
+
+	// store the blob in local registry while streaming it to the client,
+	// so that the following pulls of the blob are served locally
+	body, finish := h.teeToLocal(repository, dgt, size, blob)
+	rw.Header().Set("Content-Type", "application/octet-stream")
+	rw.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+	rw.Header().Set("Docker-Content-Digest", dgt)
+	rw.WriteHeader(http.StatusOK)
+	_, err = io.Copy(rw, body)
+	if err != nil {
+		log.Warningf("Failed to stream blob %s to client: %v", dgt, err)
+	}
+	// the incomplete blob is discarded by aborting the pushing with the error
+	finish(err)
+}
+
+// teeToLocal returns the reader of the blob which pushes the content read to the local registry,
+// the returned function must be called when the reading is done to complete or abort the pushing
+func (h *proxyCacheHandler) teeToLocal(repository, dgt string, size int64, blob io.Reader) (io.Reader, func(error)) {
+	key := fmt.Sprintf("%s@%s", repository, dgt)
+	if _, loaded := caching.LoadOrStore(key, struct{}{}); loaded {
+		log.Debugf("%s is being cached, skip", key)
+		return blob, func(error) {}
+	}
+
+	local, err := h.local(repository)
+	if err != nil {
+		caching.Delete(key)
+		log.Errorf("Failed to create client for local repository %s: %v", repository, err)
+		return blob, func(error) {}
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		defer caching.Delete(key)
+		err := local.PushBlob(dgt, size, pr)
+		// unblock the writing side if the pushing fails before all the content is read
+		pr.CloseWithError(err)
+		if err != nil {
+			log.Errorf("Failed to cache blob %s in %s: %v", dgt, repository, err)
+			return
+		}
+		log.Debugf("Blob %s cached in %s", dgt, repository)
+	}()
+	return io.TeeReader(blob, &localWriter{pw: pw}), func(err error) { pw.CloseWithError(err) }
+}
+
+// localWriter writes the content to the pushing to local registry, the failure of
+// the pushing is ignored so that it doesn't break the streaming to the client
+type localWriter struct {
+	pw  *io.PipeWriter
+	err error
+}
+
+func (l *localWriter) Write(p []byte) (int, error) {
+	if l.err == nil {
+		_, l.err = l.pw.Write(p)
+	}
+	return len(p), nil
+}
+
+func (h *proxyCacheHandler) hit(entry *proxydao.CacheEntry) {
+	if err := h.mgr.Hit(entry); err != nil {
+		log.Errorf("Failed to record hit of cache entry %d: %v", entry.ID, err)
+	}
+}
+
+func (h *proxyCacheHandler) handleUpstreamError(rw http.ResponseWriter, code, target string, err error) {
+	if e, ok := err.(*commonhttp.Error); ok && e.Code == http.StatusNotFound {
+		http.Error(rw, util.MarshalError(code, fmt.Sprintf("%s unknown to upstream registry", target)), http.StatusNotFound)
+		return
+	}
+	log.Errorf("Failed to fetch %s from upstream registry: %v", target, err)
+	http.Error(rw, util.MarshalError("UNAVAILABLE", fmt.Sprintf("Failed to fetch %s from upstream registry", target)), http.StatusBadGateway)
+}
@@ -0,0 +1,296 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxycache
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/schema2"
+	"github.com/goharbor/harbor/src/common/models"
+	pkg_registry "github.com/goharbor/harbor/src/common/utils/registry"
+	"github.com/goharbor/harbor/src/pkg/proxy/dao"
+	"github.com/goharbor/harbor/src/replication/adapter"
+	"github.com/goharbor/harbor/src/replication/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	manifestDigest = "sha256:c6b2b2c507a0944348e0303114d8d93aaaa081732b86451d9bce1f432a537bc7"
+	manifest       = `{
+		"schemaVersion": 2,
+		"mediaType": "application/vnd.docker.distribution.manifest.v2+json",
+		"config": {
+			"mediaType": "application/vnd.docker.container.image.v1+json",
+			"size": 7023,
+			"digest": "sha256:b5b2b2c507a0944348e0303114d8d93aaaa081732b86451d9bce1f432a537bc7"
+		},
+		"layers": [
+			{
+				"mediaType": "application/vnd.docker.image.rootfs.diff.tar.gzip",
+				"size": 32654,
+				"digest": "sha256:e692418e4cbaf90ca69d05a66403747baa33ee08806650b51fab815ad7fc331f"
+			}
+		]
+	}`
+)
+
+type fakeManager struct {
+	entry   *dao.CacheEntry
+	hits    int
+	renewed int
+	missed  chan string
+}
+
+func (f *fakeManager) Get(projectID int64, repository, reference string) (*dao.CacheEntry, error) {
+	return f.entry, nil
+}
+func (f *fakeManager) Hit(entry *dao.CacheEntry) error {
+	f.hits++
+	return nil
+}
+func (f *fakeManager) Miss(projectID int64, repository, reference, digest string, expiry time.Duration) error {
+	f.missed <- digest
+	return nil
+}
+func (f *fakeManager) Renew(entry *dao.CacheEntry, expiry time.Duration) error {
+	f.renewed++
+	return nil
+}
+func (f *fakeManager) Summary(projectID int64) (*models.ProxyCacheSummary, error) {
+	return &models.ProxyCacheSummary{}, nil
+}
+func (f *fakeManager) DeleteByProject(projectID int64) error {
+	return nil
+}
+
+type fakeUpstream struct{}
+
+func (f *fakeUpstream) FetchImages([]*model.Filter) ([]*model.Resource, error) {
+	return nil, nil
+}
+func (f *fakeUpstream) ManifestExist(repository, reference string) (bool, string, error) {
+	return true, manifestDigest, nil
+}
+func (f *fakeUpstream) PullManifest(repository, reference string, accepttedMediaTypes []string) (distribution.Manifest, string, error) {
+	mani, _, err := pkg_registry.UnMarshal(schema2.MediaTypeManifest, []byte(manifest))
+	if err != nil {
+		return nil, "", err
+	}
+	return mani, manifestDigest, nil
+}
+func (f *fakeUpstream) PushManifest(repository, reference, mediaType string, payload []byte) error {
+	return nil
+}
+func (f *fakeUpstream) DeleteManifest(repository, reference string) error {
+	return nil
+}
+func (f *fakeUpstream) BlobExist(repository, digest string) (bool, error) {
+	return true, nil
+}
+func (f *fakeUpstream) PullBlob(repository, digest string) (int64, io.ReadCloser, error) {
+	return 1, ioutil.NopCloser(bytes.NewReader([]byte{'a'})), nil
+}
+func (f *fakeUpstream) PushBlob(repository, digest string, size int64, blob io.Reader) error {
+	return nil
+}
+
+type fakeLocal struct {
+	blobs     []string
+	manifests []string
+	// the content of the blobs pushed, only sent if it isn't nil
+	pushed chan []byte
+}
+
+func (f *fakeLocal) BlobExist(digest string) (bool, error) {
+	return false, nil
+}
+func (f *fakeLocal) PushBlob(digest string, size int64, data io.Reader) error {
+	content, err := ioutil.ReadAll(data)
+	if err != nil {
+		return err
+	}
+	f.blobs = append(f.blobs, digest)
+	if f.pushed != nil {
+		f.pushed <- content
+	}
+	return nil
+}
+func (f *fakeLocal) PushManifest(reference, mediaType string, payload []byte) (string, error) {
+	f.manifests = append(f.manifests, reference)
+	return manifestDigest, nil
+}
+
+type nextHandler struct {
+	called bool
+}
+
+func (n *nextHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	n.called = true
+	rw.WriteHeader(http.StatusOK)
+}
+
+func newHandler(next http.Handler, mgr *fakeManager, local *fakeLocal) *proxyCacheHandler {
+	return &proxyCacheHandler{
+		next: next,
+		mgr:  mgr,
+		getProject: func(name string) (*models.Project, error) {
+			project := &models.Project{ProjectID: 1, Name: name}
+			if name == "proxy" {
+				project.Metadata = map[string]string{models.ProMetaProxyCacheRegistryID: "1"}
+			}
+			return project, nil
+		},
+		hasBlob:  func(projectID int64, digest string) (bool, error) { return false, nil },
+		upstream: func(registryID int64) (adapter.ImageRegistry, error) { return &fakeUpstream{}, nil },
+		local:    func(repository string) (localRegistry, error) { return local, nil },
+	}
+}
+
+func TestNotProxyCacheProject(t *testing.T) {
+	next := &nextHandler{}
+	h := newHandler(next, &fakeManager{}, &fakeLocal{})
+
+	req := httptest.NewRequest(http.MethodGet, "/v2/library/hello-world/manifests/latest", nil)
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, req)
+	assert.True(t, next.called)
+	assert.Equal(t, http.StatusOK, rw.Code)
+}
+
+func TestPushToProxyCacheProject(t *testing.T) {
+	next := &nextHandler{}
+	h := newHandler(next, &fakeManager{}, &fakeLocal{})
+
+	req := httptest.NewRequest(http.MethodPut, "/v2/proxy/hello-world/manifests/latest", nil)
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, req)
+	assert.False(t, next.called)
+	assert.Equal(t, http.StatusForbidden, rw.Code)
+}
+
+func TestCacheHit(t *testing.T) {
+	next := &nextHandler{}
+	mgr := &fakeManager{
+		entry: &dao.CacheEntry{
+			ID:         1,
+			Digest:     manifestDigest,
+			ExpireTime: time.Now().Add(time.Hour),
+		},
+	}
+	h := newHandler(next, mgr, &fakeLocal{})
+
+	req := httptest.NewRequest(http.MethodGet, "/v2/proxy/hello-world/manifests/latest", nil)
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, req)
+	assert.True(t, next.called)
+	assert.Equal(t, 1, mgr.hits)
+}
+
+func TestCacheMiss(t *testing.T) {
+	next := &nextHandler{}
+	mgr := &fakeManager{missed: make(chan string, 1)}
+	local := &fakeLocal{}
+	h := newHandler(next, mgr, local)
+
+	req := httptest.NewRequest(http.MethodGet, "/v2/proxy/hello-world/manifests/latest", nil)
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, req)
+	assert.False(t, next.called)
+	require.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, manifestDigest, rw.Header().Get("Docker-Content-Digest"))
+	assert.Equal(t, schema2.MediaTypeManifest, rw.Header().Get("Content-Type"))
+
+	select {
+	case dgt := <-mgr.missed:
+		assert.Equal(t, manifestDigest, dgt)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the manifest isn't cached")
+	}
+	assert.Equal(t, []string{"latest"}, local.manifests)
+	assert.Equal(t, 2, len(local.blobs))
+}
+
+func TestExpiredCacheNotChanged(t *testing.T) {
+	next := &nextHandler{}
+	mgr := &fakeManager{
+		entry: &dao.CacheEntry{
+			ID:         1,
+			Digest:     manifestDigest,
+			ExpireTime: time.Now().Add(-time.Hour),
+		},
+		missed: make(chan string, 1),
+	}
+	h := newHandler(next, mgr, &fakeLocal{})
+
+	req := httptest.NewRequest(http.MethodHead, "/v2/proxy/hello-world/manifests/latest", nil)
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, req)
+	assert.True(t, next.called)
+	// the revalidated cache is counted as a hit
+	assert.Equal(t, 1, mgr.renewed)
+	assert.Equal(t, 0, len(mgr.missed))
+}
+
+func TestBlobCached(t *testing.T) {
+	next := &nextHandler{}
+	local := &fakeLocal{pushed: make(chan []byte, 1)}
+	h := newHandler(next, &fakeManager{}, local)
+
+	req := httptest.NewRequest(http.MethodGet, "/v2/proxy/hello-world/blobs/"+manifestDigest, nil)
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, req)
+	assert.False(t, next.called)
+	require.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "a", rw.Body.String())
+
+	select {
+	case content := <-local.pushed:
+		assert.Equal(t, []byte{'a'}, content)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the blob isn't cached")
+	}
+	assert.Equal(t, []string{manifestDigest}, local.blobs)
+}
+
+func TestCachingByOtherHandler(t *testing.T) {
+	// the reference is being cached by the handler of another request
+	key := "proxy/hello-world:latest"
+	caching.Store(key, struct{}{})
+	defer caching.Delete(key)
+
+	mgr := &fakeManager{missed: make(chan string, 1)}
+	local := &fakeLocal{}
+	h := newHandler(&nextHandler{}, mgr, local)
+
+	req := httptest.NewRequest(http.MethodGet, "/v2/proxy/hello-world/manifests/latest", nil)
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, req)
+	require.Equal(t, http.StatusOK, rw.Code)
+
+	select {
+	case <-mgr.missed:
+		t.Fatal("the manifest being cached is cached again")
+	case <-time.After(200 * time.Millisecond):
+	}
+	assert.Equal(t, 0, len(local.manifests))
+}
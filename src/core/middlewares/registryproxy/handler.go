@@ -15,13 +15,24 @@
 package registryproxy
 
 import (
-	"github.com/goharbor/harbor/src/common/utils/log"
-	"github.com/goharbor/harbor/src/core/config"
+	"fmt"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/docker/distribution/registry/auth/token"
+	"github.com/goharbor/harbor/src/common/secret"
+	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/goharbor/harbor/src/core/config"
+	"github.com/goharbor/harbor/src/core/filter"
+	"github.com/goharbor/harbor/src/core/middlewares/util"
+	tokenservice "github.com/goharbor/harbor/src/core/service/token"
 )
 
+var repositoryURLRe = regexp.MustCompile(`^/v2/((?:[a-z0-9]+(?:[._-][a-z0-9]+)*/)+)(manifests|blobs|tags)/`)
+
 type proxyHandler struct {
 	handler http.Handler
 }
@@ -49,13 +60,65 @@ func New(urls ...string) http.Handler {
 		return nil
 	}
 
+	proxy := httputil.NewSingleHostReverseProxy(targetURL)
+	proxy.ModifyResponse = keepPushRequestID
+
 	return &proxyHandler{
-		handler: httputil.NewSingleHostReverseProxy(targetURL),
+		handler: proxy,
 	}
 
 }
 
 // ServeHTTP ...
 func (ph proxyHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if len(secret.FromRequest(req)) > 0 {
+		if err := exchangeSecret(req); err != nil {
+			log.Errorf("failed to make token for the request authenticated by secret: %v", err)
+			http.Error(rw, util.MarshalError("InternalError", "Failed to authorize the request to registry"), http.StatusInternalServerError)
+			return
+		}
+	}
 	ph.handler.ServeHTTP(rw, req)
 }
+
+// exchangeSecret replaces the secret of the internal components, which the registry doesn't accept,
+// with a token of the repository in the request, the secret has been authenticated by the security filter
+func exchangeSecret(req *http.Request) error {
+	req.Header.Del("Authorization")
+	s := repositoryURLRe.FindStringSubmatch(req.URL.Path)
+	if len(s) != 3 {
+		return nil
+	}
+	tk, err := tokenservice.MakeToken(util.TokenUsername, tokenservice.Registry, []*token.ResourceActions{
+		{
+			Type:    "repository",
+			Name:    strings.TrimSuffix(s[1], "/"),
+			Actions: []string{"pull", "push"},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tk.Token))
+	return nil
+}
+
+// keepPushRequestID keeps the request ID of the successful manifest push to correlate it with
+// the follow up actions of the notification sent by the registry
+func keepPushRequestID(resp *http.Response) error {
+	if resp.StatusCode != http.StatusCreated {
+		return nil
+	}
+	match, repository, _ := util.MatchPushManifest(resp.Request)
+	if !match {
+		return nil
+	}
+	requestID := filter.GetRequestID(resp.Request)
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if len(requestID) > 0 && len(digest) > 0 {
+		if err := util.SetPushRequestID(repository, digest, requestID); err != nil {
+			log.Warningf("failed to keep the ID of the request pushing %s@%s: %v", repository, digest, err)
+		}
+	}
+	return nil
+}
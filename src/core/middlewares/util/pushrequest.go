@@ -0,0 +1,60 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+)
+
+// pushRequestTTL is how long the ID of the push request is kept for the notification of the registry
+const pushRequestTTL = 10 * time.Minute
+
+// SetPushRequestID keeps the ID of the request which pushes the manifest, the registry generates
+// its own request ID in the notification, so the ID is used to correlate the push request with
+// the follow up actions of the notification, e.g. the scan job. The ID is kept in redis as the
+// notification may be handled by another core instance, and redis expires it after the TTL.
+func SetPushRequestID(repository, digest, requestID string) error {
+	conn, err := GetRegRedisCon()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("SET", pushRequestKey(repository, digest), requestID, "EX", int(pushRequestTTL.Seconds()))
+	return err
+}
+
+// GetPushRequestID returns the ID of the request which pushes the manifest,
+// empty string is returned if it's not found or expired
+func GetPushRequestID(repository, digest string) (string, error) {
+	conn, err := GetRegRedisCon()
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	id, err := redis.String(conn.Do("GET", pushRequestKey(repository, digest)))
+	if err == redis.ErrNil {
+		return "", nil
+	}
+	return id, err
+}
+
+func pushRequestKey(repository, digest string) string {
+	return fmt.Sprintf("push_request:%s@%s", repository, digest)
+}
@@ -0,0 +1,40 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushRequestID(t *testing.T) {
+	require.Nil(t, SetPushRequestID("library/hello-world", "sha256:digest", "id"))
+	defer func() {
+		conn, err := GetRegRedisCon()
+		require.Nil(t, err)
+		defer conn.Close()
+		conn.Do("DEL", pushRequestKey("library/hello-world", "sha256:digest"))
+	}()
+
+	id, err := GetPushRequestID("library/hello-world", "sha256:digest")
+	require.Nil(t, err)
+	assert.Equal(t, "id", id)
+
+	id, err = GetPushRequestID("library/hello-world", "sha256:other")
+	require.Nil(t, err)
+	assert.Equal(t, "", id)
+}
@@ -29,6 +29,9 @@ import (
 	"time"
 
 	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/manifestlist"
+	// register the unmarshal function of OCI image manifest
+	_ "github.com/docker/distribution/manifest/ocischema"
 	"github.com/docker/distribution/manifest/schema1"
 	"github.com/docker/distribution/manifest/schema2"
 	"github.com/garyburd/redigo/redis"
@@ -41,6 +44,7 @@ import (
 	"github.com/goharbor/harbor/src/core/promgr"
 	"github.com/goharbor/harbor/src/pkg/scan/whitelist"
 	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 type contextKey string
@@ -77,6 +81,7 @@ type ChartVersionInfo struct {
 	Namespace string
 	ChartName string
 	Version   string
+	Size      int64
 }
 
 // MutexKey returns mutex key of the chart version
@@ -166,6 +171,14 @@ func (info *ManifestInfo) MutexKey(suffix ...string) string {
 	return strings.Join(append(a, suffix...), ":")
 }
 
+// RepositoryMutexKey returns mutex key of the repository of the manifest
+func (info *ManifestInfo) RepositoryMutexKey(suffix ...string) string {
+	projectName, _ := utils.ParseRepository(info.Repository)
+	a := []string{"quota", projectName, "repository", info.Repository}
+
+	return strings.Join(append(a, suffix...), ":")
+}
+
 // BlobMutexKey returns mutex key of the blob in manifest
 func (info *ManifestInfo) BlobMutexKey(blob *models.Blob, suffix ...string) string {
 	projectName, _ := utils.ParseRepository(info.Repository)
@@ -215,14 +228,43 @@ func (info *ManifestInfo) IsNewTag() bool {
 	return artifact == nil
 }
 
+// IsNewRepository returns true if there is no artifact of the repository in project
+func (info *ManifestInfo) IsNewRepository() (bool, error) {
+	total, err := dao.GetTotalOfArtifacts(&models.ArtifactQuery{
+		PID:  info.ProjectID,
+		Repo: info.Repository,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return total == 0, nil
+}
+
+// IsTagged returns true if the manifest is pushed with a tag,
+// manifests pushed by digest are the ones referenced by a manifest list or an image index
+func (info *ManifestInfo) IsTagged() bool {
+	return info.Tag != ""
+}
+
+// IsManifestList returns true if the manifest is a manifest list or an OCI image index
+func (info *ManifestInfo) IsManifestList() bool {
+	return IsManifestListMediaType(info.Descriptor.MediaType)
+}
+
 // Artifact returns artifact of the manifest
 func (info *ManifestInfo) Artifact() *models.Artifact {
+	kind := models.ArtifactKindImage
+	if info.IsManifestList() {
+		kind = models.ArtifactKindImageIndex
+	}
+
 	result := &models.Artifact{
 		PID:    info.ProjectID,
 		Repo:   info.Repository,
 		Tag:    info.Tag,
 		Digest: info.Digest,
-		Kind:   "Docker-Image",
+		Kind:   kind,
 	}
 
 	if artifact, _ := info.fetchArtifact(); artifact != nil {
@@ -274,6 +316,11 @@ func MarshalError(code, msg string) string {
 	return string(str)
 }
 
+// IsManifestListMediaType returns true if the media type is the one of manifest list or OCI image index
+func IsManifestListMediaType(mediaType string) bool {
+	return mediaType == manifestlist.MediaTypeManifestList || mediaType == v1.MediaTypeImageIndex
+}
+
 // MatchManifestURL ...
 func MatchManifestURL(req *http.Request) (bool, string, string) {
 	s := manifestURLRe.FindStringSubmatch(req.URL.Path)
@@ -466,7 +513,9 @@ func ParseManifestInfoFromReq(req *http.Request) (*ManifestInfo, error) {
 	mediaType := req.Header.Get("Content-Type")
 	if mediaType != schema1.MediaTypeManifest &&
 		mediaType != schema1.MediaTypeSignedManifest &&
-		mediaType != schema2.MediaTypeManifest {
+		mediaType != schema2.MediaTypeManifest &&
+		mediaType != v1.MediaTypeImageManifest &&
+		!IsManifestListMediaType(mediaType) {
 		return nil, fmt.Errorf("unsupported content type for manifest: %s", mediaType)
 	}
 
@@ -25,6 +25,7 @@ import (
 
 	"github.com/docker/distribution"
 	"github.com/docker/distribution/manifest"
+	"github.com/docker/distribution/manifest/manifestlist"
 	"github.com/docker/distribution/manifest/schema2"
 	"github.com/goharbor/harbor/src/common"
 	"github.com/goharbor/harbor/src/common/models"
@@ -235,8 +236,24 @@ func getDescriptor(manifest schema2.Manifest) distribution.Descriptor {
 	return desc
 }
 
+func makeManifestList(manifests ...schema2.Manifest) *manifestlist.DeserializedManifestList {
+	var descriptors []manifestlist.ManifestDescriptor
+	for _, m := range manifests {
+		descriptors = append(descriptors, manifestlist.ManifestDescriptor{
+			Descriptor: getDescriptor(m),
+			Platform:   manifestlist.PlatformSpec{Architecture: "amd64", OS: "linux"},
+		})
+	}
+
+	list, _ := manifestlist.FromDescriptors(descriptors)
+	return list
+}
+
 func TestParseManifestInfo(t *testing.T) {
 	manifest := makeManifest(1, []int64{2, 3, 4})
+	list := makeManifestList(manifest, makeManifest(5, []int64{6}))
+	_, listPayload, _ := list.Payload()
+	_, listDesc, _ := distribution.UnmarshalManifest(manifestlist.MediaTypeManifestList, listPayload)
 
 	tests := []struct {
 		name    string
@@ -263,6 +280,24 @@ func TestParseManifestInfo(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"manifest list",
+			func() *http.Request {
+				req, _ := http.NewRequest(http.MethodPut, "/v2/library/photon/manifests/latest", bytes.NewReader(listPayload))
+				req.Header.Add("Content-Type", manifestlist.MediaTypeManifestList)
+
+				return req
+			},
+			&ManifestInfo{
+				ProjectID:  1,
+				Repository: "library/photon",
+				Tag:        "latest",
+				Digest:     listDesc.Digest.String(),
+				References: list.References(),
+				Descriptor: listDesc,
+			},
+			false,
+		},
 		{
 			"bad content type",
 			func() *http.Request {
@@ -338,6 +373,15 @@ func TestParseManifestInfo(t *testing.T) {
 	}
 }
 
+func TestIsManifestListMediaType(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(IsManifestListMediaType(manifestlist.MediaTypeManifestList))
+	assert.True(IsManifestListMediaType("application/vnd.oci.image.index.v1+json"))
+	assert.False(IsManifestListMediaType(schema2.MediaTypeManifest))
+	assert.False(IsManifestListMediaType("application/json"))
+}
+
 func TestParseManifestInfoFromPath(t *testing.T) {
 	mustRequest := func(method, url string) *http.Request {
 		req, _ := http.NewRequest(method, url, nil)
@@ -8,6 +8,9 @@ import (
 	"github.com/goharbor/harbor/src/core/notifier"
 	"github.com/goharbor/harbor/src/core/notifier/model"
 	notifyModel "github.com/goharbor/harbor/src/pkg/notification/model"
+	retModels "github.com/goharbor/harbor/src/pkg/retention/dao/models"
+	"github.com/goharbor/harbor/src/pkg/types"
+	repModels "github.com/goharbor/harbor/src/replication/dao/models"
 	"github.com/pkg/errors"
 )
 
@@ -214,12 +217,162 @@ func (si *ScanImageMetaData) Resolve(evt *Event) error {
 	return nil
 }
 
+// QuotaMetaData defines meta data of quota event
+type QuotaMetaData struct {
+	ProjectID int64
+	RepoName  string
+	Tag       string
+	Digest    string
+	Hard      types.ResourceList
+	Used      types.ResourceList
+	Details   string
+	OccurAt   time.Time
+	Operator  string
+}
+
+func (qm *QuotaMetaData) convert(evt *model.QuotaEvent) {
+	evt.ProjectID = qm.ProjectID
+	evt.RepoName = qm.RepoName
+	evt.Hard = qm.Hard
+	evt.Used = qm.Used
+	evt.Details = qm.Details
+	evt.OccurAt = qm.OccurAt
+	evt.Operator = qm.Operator
+	if qm.Tag != "" || qm.Digest != "" {
+		evt.Resource = &model.ImgResource{
+			Tag:    qm.Tag,
+			Digest: qm.Digest,
+		}
+	}
+}
+
+// QuotaExceedMetaData defines meta data of quota exceeded event
+type QuotaExceedMetaData struct {
+	QuotaMetaData
+}
+
+// Resolve quota exceeded metadata into common quota event
+func (qe *QuotaExceedMetaData) Resolve(evt *Event) error {
+	data := &model.QuotaEvent{
+		EventType: notifyModel.EventTypeQuotaExceed,
+	}
+	qe.convert(data)
+
+	evt.Topic = model.QuotaExceedTopic
+	evt.Data = data
+	return nil
+}
+
+// QuotaWarningMetaData defines meta data of quota near-limit event
+type QuotaWarningMetaData struct {
+	QuotaMetaData
+}
+
+// Resolve quota near-limit metadata into common quota event
+func (qw *QuotaWarningMetaData) Resolve(evt *Event) error {
+	data := &model.QuotaEvent{
+		EventType: notifyModel.EventTypeQuotaWarning,
+	}
+	qw.convert(data)
+
+	evt.Topic = model.QuotaWarningTopic
+	evt.Data = data
+	return nil
+}
+
+// ReplicationMetaData defines meta data of replication execution event
+type ReplicationMetaData struct {
+	ExecutionID int64
+	Status      string
+}
+
+// Resolve replication execution metadata into common replication event
+func (r *ReplicationMetaData) Resolve(evt *Event) error {
+	var eventType string
+	var topic string
+	switch r.Status {
+	case repModels.ExecutionStatusSucceed:
+		eventType = notifyModel.EventTypeReplicationCompleted
+		topic = model.ReplicationCompletedTopic
+	case repModels.ExecutionStatusFailed, repModels.ExecutionStatusStopped:
+		eventType = notifyModel.EventTypeReplicationFailed
+		topic = model.ReplicationFailedTopic
+	default:
+		return errors.New("not supported replication execution status")
+	}
+	data := &model.ReplicationEvent{
+		EventType:   eventType,
+		ExecutionID: r.ExecutionID,
+		OccurAt:     time.Now(),
+		Operator:    autoTriggeredOperator,
+	}
+
+	evt.Topic = topic
+	evt.Data = data
+	return nil
+}
+
+// RetentionMetaData defines meta data of tag retention execution event
+type RetentionMetaData struct {
+	ExecutionID int64
+	Status      string
+}
+
+// Resolve tag retention execution metadata into common retention event
+func (r *RetentionMetaData) Resolve(evt *Event) error {
+	if r.Status == "" || r.Status == retModels.ExecutionStatusInProgress {
+		return errors.New("not supported retention execution status")
+	}
+	data := &model.RetentionEvent{
+		EventType:   notifyModel.EventTypeRetentionCompleted,
+		ExecutionID: r.ExecutionID,
+		OccurAt:     time.Now(),
+		Operator:    autoTriggeredOperator,
+	}
+
+	evt.Topic = model.RetentionCompletedTopic
+	evt.Data = data
+	return nil
+}
+
+// ImmutableTagViolationMetaData defines meta data of tag immutability violation event
+type ImmutableTagViolationMetaData struct {
+	Project  *models.Project
+	Tag      string
+	Digest   string
+	OccurAt  time.Time
+	Operator string
+	RepoName string
+}
+
+// Resolve tag immutability violation metadata into common image event
+func (i *ImmutableTagViolationMetaData) Resolve(evt *Event) error {
+	data := &model.ImageEvent{
+		EventType: notifyModel.EventTypeImmutableTagViolation,
+		Project:   i.Project,
+		OccurAt:   i.OccurAt,
+		Operator:  i.Operator,
+		RepoName:  i.RepoName,
+		Resource: []*model.ImgResource{
+			{
+				Tag:    i.Tag,
+				Digest: i.Digest,
+			},
+		},
+	}
+
+	evt.Topic = model.ImmutableTagViolationTopic
+	evt.Data = data
+	return nil
+}
+
 // HookMetaData defines hook notification related event data
 type HookMetaData struct {
 	PolicyID  int64
 	EventType string
 	Target    *models.EventTarget
 	Payload   *model.Payload
+	Secret    string
 }
 
 // Resolve hook metadata into hook event
@@ -229,6 +382,7 @@ func (h *HookMetaData) Resolve(evt *Event) error {
 		EventType: h.EventType,
 		Target:    h.Target,
 		Payload:   h.Payload,
+		Secret:    h.Secret,
 	}
 
 	evt.Topic = h.Target.Type
@@ -6,6 +6,8 @@ import (
 
 	"github.com/goharbor/harbor/src/common/models"
 	notifierModel "github.com/goharbor/harbor/src/core/notifier/model"
+	notifyModel "github.com/goharbor/harbor/src/pkg/notification/model"
+	"github.com/goharbor/harbor/src/pkg/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -179,6 +181,145 @@ func TestHookEvent_Build(t *testing.T) {
 	}
 }
 
+func TestQuotaEvent_Build(t *testing.T) {
+	md := QuotaMetaData{
+		ProjectID: 1,
+		RepoName:  "library/alpine",
+		Tag:       "latest",
+		Hard:      types.ResourceList{types.ResourceStorage: 100},
+		Used:      types.ResourceList{types.ResourceStorage: 90},
+		OccurAt:   time.Now(),
+		Operator:  "admin",
+	}
+
+	tests := []struct {
+		name      string
+		metadata  Metadata
+		wantTopic string
+		wantType  string
+	}{
+		{
+			name:      "Build Quota Exceed Event",
+			metadata:  &QuotaExceedMetaData{QuotaMetaData: md},
+			wantTopic: notifierModel.QuotaExceedTopic,
+			wantType:  notifyModel.EventTypeQuotaExceed,
+		},
+		{
+			name:      "Build Quota Warning Event",
+			metadata:  &QuotaWarningMetaData{QuotaMetaData: md},
+			wantTopic: notifierModel.QuotaWarningTopic,
+			wantType:  notifyModel.EventTypeQuotaWarning,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := &Event{}
+			require.Nil(t, event.Build(tt.metadata))
+			assert.Equal(t, tt.wantTopic, event.Topic)
+			data, ok := event.Data.(*notifierModel.QuotaEvent)
+			require.True(t, ok)
+			assert.Equal(t, tt.wantType, data.EventType)
+			assert.Equal(t, int64(1), data.ProjectID)
+			require.NotNil(t, data.Resource)
+			assert.Equal(t, "latest", data.Resource.Tag)
+		})
+	}
+}
+
+func TestReplicationEvent_Build(t *testing.T) {
+	tests := []struct {
+		name      string
+		metadata  *ReplicationMetaData
+		wantErr   bool
+		wantTopic string
+	}{
+		{
+			name:      "Build Replication Completed Event",
+			metadata:  &ReplicationMetaData{ExecutionID: 1, Status: "Succeed"},
+			wantTopic: notifierModel.ReplicationCompletedTopic,
+		},
+		{
+			name:      "Build Replication Failed Event",
+			metadata:  &ReplicationMetaData{ExecutionID: 1, Status: "Failed"},
+			wantTopic: notifierModel.ReplicationFailedTopic,
+		},
+		{
+			name:      "Build Replication Stopped Event",
+			metadata:  &ReplicationMetaData{ExecutionID: 1, Status: "Stopped"},
+			wantTopic: notifierModel.ReplicationFailedTopic,
+		},
+		{
+			name:     "Build Replication In Progress Event",
+			metadata: &ReplicationMetaData{ExecutionID: 1, Status: "InProgress"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := &Event{}
+			err := event.Build(tt.metadata)
+			if tt.wantErr {
+				require.NotNil(t, err, "Error: %s", err)
+				return
+			}
+			assert.Equal(t, tt.wantTopic, event.Topic)
+		})
+	}
+}
+
+func TestRetentionEvent_Build(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata *RetentionMetaData
+		wantErr  bool
+	}{
+		{
+			name:     "Build Retention Succeed Event",
+			metadata: &RetentionMetaData{ExecutionID: 1, Status: "Succeed"},
+		},
+		{
+			name:     "Build Retention Failed Event",
+			metadata: &RetentionMetaData{ExecutionID: 1, Status: "Failed"},
+		},
+		{
+			name:     "Build Retention In Progress Event",
+			metadata: &RetentionMetaData{ExecutionID: 1, Status: "InProgress"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := &Event{}
+			err := event.Build(tt.metadata)
+			if tt.wantErr {
+				require.NotNil(t, err, "Error: %s", err)
+				return
+			}
+			assert.Equal(t, notifierModel.RetentionCompletedTopic, event.Topic)
+		})
+	}
+}
+
+func TestImmutableTagViolationEvent_Build(t *testing.T) {
+	event := &Event{}
+	err := event.Build(&ImmutableTagViolationMetaData{
+		Project:  &models.Project{ProjectID: 1, Name: "library"},
+		Tag:      "v1.0",
+		Digest:   "abcd",
+		OccurAt:  time.Now(),
+		Operator: "admin",
+		RepoName: "library/alpine",
+	})
+	require.Nil(t, err)
+	assert.Equal(t, notifierModel.ImmutableTagViolationTopic, event.Topic)
+	data, ok := event.Data.(*notifierModel.ImageEvent)
+	require.True(t, ok)
+	assert.Equal(t, notifyModel.EventTypeImmutableTagViolation, data.EventType)
+}
+
 func TestEvent_Publish(t *testing.T) {
 	type args struct {
 		event *Event
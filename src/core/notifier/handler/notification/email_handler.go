@@ -0,0 +1,129 @@
+package notification
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/job/models"
+	"github.com/goharbor/harbor/src/core/notifier/model"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/pkg/notification"
+)
+
+const (
+	defaultEmailSubject = `[Harbor] {{.Type}}{{with .EventData}}{{with .Repository}} on {{.RepoFullName}}{{end}}{{end}}`
+
+	defaultEmailTemplate = `<p>Event <b>{{.Type}}</b> occurred at {{formatTime .OccurAt}}{{if .Operator}}, triggered by {{.Operator}}{{end}}.</p>
+{{with .EventData}}{{with .Repository}}<p>Repository: {{.RepoFullName}}</p>{{end}}
+<ul>
+{{range .Resources}}<li>{{if .ResourceURL}}{{.ResourceURL}}{{else}}{{.Tag}}{{end}}{{if .Digest}} ({{.Digest}}){{end}}{{with .ScanOverview}}, scan status: {{.Status}}, severity: {{.Sev}}{{end}}</li>
+{{end}}</ul>
+{{with .Quota}}<p>{{if .Details}}{{.Details}}<br/>{{end}}Hard limits: {{range $k, $v := .Hard}}{{$k}}={{$v}} {{end}}<br/>Used: {{range $k, $v := .Used}}{{$k}}={{$v}} {{end}}</p>{{end}}
+{{with .Replication}}<p>Replication policy {{.PolicyName}} execution {{.ExecutionID}} {{.Status}}: {{.Succeed}} succeed, {{.Failed}} failed, {{.Stopped}} stopped of {{.Total}} in total</p>{{end}}
+{{with .Retention}}<p>Tag retention execution {{.ExecutionID}} {{.Status}}{{if .DryRun}} (dry run){{end}}: {{.Deleted}} deleted, {{.Retained}} retained of {{.Total}} in total</p>{{end}}{{end}}`
+)
+
+var templateFuncs = map[string]interface{}{
+	"formatTime": func(t int64) string {
+		return time.Unix(t, 0).UTC().Format(time.RFC3339)
+	},
+}
+
+// EmailHandler renders the event into email and start the hook processing
+type EmailHandler struct {
+}
+
+// Handle handles email event
+func (e *EmailHandler) Handle(value interface{}) error {
+	if value == nil {
+		return errors.New("EmailHandler cannot handle nil value")
+	}
+
+	event, ok := value.(*model.HookEvent)
+	if !ok || event == nil || event.Target == nil {
+		return errors.New("invalid notification email event")
+	}
+
+	return e.process(event)
+}
+
+// IsStateful ...
+func (e *EmailHandler) IsStateful() bool {
+	return false
+}
+
+func (e *EmailHandler) process(event *model.HookEvent) error {
+	subject, message, err := RenderEmail(event.Target.Subject, event.Target.Template, event.Payload)
+	if err != nil {
+		return err
+	}
+
+	j := &models.JobData{
+		Metadata: &models.JobMetadata{
+			JobKind: job.KindGeneric,
+		},
+	}
+	j.Name = job.EmailJob
+	j.Parameters = map[string]interface{}{
+		"to":      event.Target.Address,
+		"subject": subject,
+		"message": message,
+	}
+	return notification.HookManager.StartHook(event, j)
+}
+
+// ValidateEmailTemplates checks whether the subject and message templates can be parsed,
+// the empty ones are valid as the default templates are used for them
+func ValidateEmailTemplates(subject, message string) error {
+	if len(subject) > 0 {
+		if _, err := template.New("subject").Funcs(templateFuncs).Parse(subject); err != nil {
+			return fmt.Errorf("invalid email subject template: %v", err)
+		}
+	}
+	if len(message) > 0 {
+		if _, err := htmltemplate.New("message").Funcs(templateFuncs).Parse(message); err != nil {
+			return fmt.Errorf("invalid email template: %v", err)
+		}
+	}
+	return nil
+}
+
+// RenderEmail renders the payload into the subject and message of email with the templates,
+// the default templates are used if they're empty
+func RenderEmail(subjectTmpl, messageTmpl string, payload *model.Payload) (string, string, error) {
+	if payload == nil {
+		return "", "", errors.New("empty payload of email event")
+	}
+	if len(subjectTmpl) == 0 {
+		subjectTmpl = defaultEmailSubject
+	}
+	if len(messageTmpl) == 0 {
+		messageTmpl = defaultEmailTemplate
+	}
+
+	st, err := template.New("subject").Funcs(templateFuncs).Parse(subjectTmpl)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid email subject template: %v", err)
+	}
+	subject := &bytes.Buffer{}
+	if err = st.Execute(subject, payload); err != nil {
+		return "", "", fmt.Errorf("failed to render email subject: %v", err)
+	}
+
+	mt, err := htmltemplate.New("message").Funcs(templateFuncs).Parse(messageTmpl)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid email template: %v", err)
+	}
+	message := &bytes.Buffer{}
+	if err = mt.Execute(message, payload); err != nil {
+		return "", "", fmt.Errorf("failed to render email message: %v", err)
+	}
+
+	// the subject is a header of the mail, so no line break is allowed
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(subject.String()), message.String(), nil
+}
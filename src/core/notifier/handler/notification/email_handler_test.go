@@ -0,0 +1,118 @@
+package notification
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	cModels "github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/core/notifier/model"
+	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailHandler_Handle(t *testing.T) {
+	hookMgr := notification.HookManager
+	defer func() {
+		notification.HookManager = hookMgr
+	}()
+	notification.HookManager = &fakedHookManager{}
+
+	handler := &EmailHandler{}
+
+	// nil value
+	assert.NotNil(t, handler.Handle(nil))
+	// invalid event
+	assert.NotNil(t, handler.Handle(&model.ImageEvent{}))
+	// invalid template
+	assert.NotNil(t, handler.Handle(&model.HookEvent{
+		PolicyID:  1,
+		EventType: "pushImage",
+		Target: &cModels.EventTarget{
+			Type:     "email",
+			Address:  "admin@example.com",
+			Template: "{{.Type",
+		},
+		Payload: &model.Payload{
+			OccurAt: time.Now().Unix(),
+		},
+	}))
+	// valid event
+	assert.Nil(t, handler.Handle(&model.HookEvent{
+		PolicyID:  1,
+		EventType: "pushImage",
+		Target: &cModels.EventTarget{
+			Type:    "email",
+			Address: "admin@example.com",
+		},
+		Payload: &model.Payload{
+			OccurAt: time.Now().Unix(),
+		},
+	}))
+}
+
+func TestEmailHandler_IsStateful(t *testing.T) {
+	handler := &EmailHandler{}
+	assert.False(t, handler.IsStateful())
+}
+
+func TestRenderEmail(t *testing.T) {
+	payload := &model.Payload{
+		Type:    "pushImage",
+		OccurAt: 0,
+		EventData: &model.EventData{
+			Repository: &model.Repository{
+				RepoFullName: "library/hello-world",
+			},
+			Resources: []*model.Resource{
+				{
+					Tag:         "latest",
+					Digest:      "sha256:c6b2b2c507a0944348e0303114d8d93aaaa081732b86451d9bce1f432a537bc7",
+					ResourceURL: "harbor.example.com/library/hello-world:latest",
+				},
+			},
+		},
+		Operator: "admin",
+	}
+
+	// default templates
+	subject, message, err := RenderEmail("", "", payload)
+	require.Nil(t, err)
+	assert.Equal(t, "[Harbor] pushImage on library/hello-world", subject)
+	assert.True(t, strings.Contains(message, "harbor.example.com/library/hello-world:latest"))
+	assert.True(t, strings.Contains(message, "1970-01-01T00:00:00Z"))
+	assert.True(t, strings.Contains(message, "admin"))
+
+	// customized templates
+	subject, message, err = RenderEmail("{{.Type}}\r\nBcc: someone@example.com", "<b>{{.Operator}}</b>", payload)
+	require.Nil(t, err)
+	assert.Equal(t, "pushImage  Bcc: someone@example.com", subject)
+	assert.Equal(t, "<b>admin</b>", message)
+
+	// the payload of testing endpoint has no event data
+	subject, _, err = RenderEmail("", "", &model.Payload{Type: "testEndpoint"})
+	require.Nil(t, err)
+	assert.Equal(t, "[Harbor] testEndpoint", subject)
+
+	// the payload of retention event has no repository
+	_, message, err = RenderEmail("", "", &model.Payload{
+		Type: "retentionCompleted",
+		EventData: &model.EventData{
+			Retention: &model.Retention{ExecutionID: 1, Status: "Succeed", Total: 10, Retained: 4, Deleted: 6},
+		},
+	})
+	require.Nil(t, err)
+	assert.True(t, strings.Contains(message, "6 deleted, 4 retained of 10 in total"))
+
+	// invalid template
+	_, _, err = RenderEmail("", "{{.Type", payload)
+	assert.NotNil(t, err)
+}
+
+func TestValidateEmailTemplates(t *testing.T) {
+	assert.Nil(t, ValidateEmailTemplates("", ""))
+	assert.Nil(t, ValidateEmailTemplates("{{.Type}}", "{{range .EventData.Resources}}{{.Tag}}{{end}}"))
+	assert.NotNil(t, ValidateEmailTemplates("{{.Type", ""))
+	assert.NotNil(t, ValidateEmailTemplates("", "{{.Type"))
+}
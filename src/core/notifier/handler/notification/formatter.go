@@ -0,0 +1,126 @@
+package notification
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/core/notifier/model"
+	"github.com/google/uuid"
+)
+
+const (
+	// SignatureHeader is the header carrying the HMAC-SHA256 signature of the payload
+	SignatureHeader = "X-Harbor-Signature"
+
+	contentTypeJSON        = "application/json"
+	contentTypeCloudEvents = "application/cloudevents+json"
+)
+
+// cloudEvent is the event in the structured mode of CloudEvents 1.0
+type cloudEvent struct {
+	SpecVersion     string           `json:"specversion"`
+	ID              string           `json:"id"`
+	Source          string           `json:"source"`
+	Type            string           `json:"type"`
+	Time            string           `json:"time"`
+	DataContentType string           `json:"datacontenttype"`
+	Operator        string           `json:"operator,omitempty"`
+	Data            *model.EventData `json:"data,omitempty"`
+}
+
+// slackMessage is the message accepted by the Slack incoming webhook
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// IsValidPayloadFormat checks whether the payload format is supported, empty means the native format
+func IsValidPayloadFormat(format string) bool {
+	switch format {
+	case "", models.PayloadFormatNative, models.PayloadFormatCloudEvents, models.PayloadFormatSlack:
+		return true
+	default:
+		return false
+	}
+}
+
+// FormatPayload formats the payload according to the format of the target,
+// returns the body and its content type
+func FormatPayload(format string, policyID int64, payload *model.Payload) ([]byte, string, error) {
+	if payload == nil {
+		return nil, "", fmt.Errorf("empty payload")
+	}
+
+	switch format {
+	case "", models.PayloadFormatNative:
+		body, err := json.Marshal(payload)
+		return body, contentTypeJSON, err
+	case models.PayloadFormatCloudEvents:
+		body, err := json.Marshal(&cloudEvent{
+			SpecVersion:     "1.0",
+			ID:              uuid.New().String(),
+			Source:          fmt.Sprintf("/webhook/policies/%d", policyID),
+			Type:            fmt.Sprintf("io.goharbor.%s", payload.Type),
+			Time:            time.Unix(payload.OccurAt, 0).UTC().Format(time.RFC3339),
+			DataContentType: contentTypeJSON,
+			Operator:        payload.Operator,
+			Data:            payload.EventData,
+		})
+		return body, contentTypeCloudEvents, err
+	case models.PayloadFormatSlack:
+		body, err := json.Marshal(&slackMessage{
+			Text: slackText(payload),
+		})
+		return body, contentTypeJSON, err
+	default:
+		return nil, "", fmt.Errorf("unsupported payload format %s", format)
+	}
+}
+
+func slackText(payload *model.Payload) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%s* at %s", payload.Type, time.Unix(payload.OccurAt, 0).UTC().Format(time.RFC3339))
+	if len(payload.Operator) > 0 {
+		fmt.Fprintf(&b, " by %s", payload.Operator)
+	}
+	if payload.EventData == nil {
+		return b.String()
+	}
+	if payload.EventData.Repository != nil {
+		fmt.Fprintf(&b, "\nRepository: `%s`", payload.EventData.Repository.RepoFullName)
+	}
+	for _, res := range payload.EventData.Resources {
+		target := res.ResourceURL
+		if len(target) == 0 {
+			target = res.Tag
+		}
+		fmt.Fprintf(&b, "\n• `%s`", target)
+		if res.ScanOverview != nil {
+			fmt.Fprintf(&b, " scan status: %s, severity: %d", res.ScanOverview.Status, res.ScanOverview.Sev)
+		}
+	}
+	if q := payload.EventData.Quota; q != nil && len(q.Details) > 0 {
+		fmt.Fprintf(&b, "\n%s", q.Details)
+	}
+	if r := payload.EventData.Replication; r != nil {
+		fmt.Fprintf(&b, "\nReplication policy `%s` execution %d %s: %d succeed, %d failed, %d stopped of %d in total",
+			r.PolicyName, r.ExecutionID, r.Status, r.Succeed, r.Failed, r.Stopped, r.Total)
+	}
+	if r := payload.EventData.Retention; r != nil {
+		fmt.Fprintf(&b, "\nTag retention execution %d %s: %d deleted, %d retained of %d in total",
+			r.ExecutionID, r.Status, r.Deleted, r.Retained, r.Total)
+	}
+	return b.String()
+}
+
+// SignPayload signs the body with the secret by HMAC-SHA256, the result is in the format "sha256=<hex digest>"
+func SignPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
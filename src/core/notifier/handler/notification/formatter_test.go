@@ -0,0 +1,88 @@
+package notification
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/core/notifier/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPayload = &model.Payload{
+	Type:    "pushImage",
+	OccurAt: 0,
+	EventData: &model.EventData{
+		Repository: &model.Repository{
+			RepoFullName: "library/hello-world",
+		},
+		Resources: []*model.Resource{
+			{
+				Tag:         "latest",
+				ResourceURL: "harbor.example.com/library/hello-world:latest",
+			},
+		},
+	},
+	Operator: "admin",
+}
+
+func TestFormatPayload(t *testing.T) {
+	// native
+	body, contentType, err := FormatPayload("", 1, testPayload)
+	require.Nil(t, err)
+	assert.Equal(t, "application/json", contentType)
+	p := &model.Payload{}
+	require.Nil(t, json.Unmarshal(body, p))
+	assert.Equal(t, "pushImage", p.Type)
+
+	// cloud events
+	body, contentType, err = FormatPayload(models.PayloadFormatCloudEvents, 1, testPayload)
+	require.Nil(t, err)
+	assert.Equal(t, "application/cloudevents+json", contentType)
+	ce := map[string]interface{}{}
+	require.Nil(t, json.Unmarshal(body, &ce))
+	assert.Equal(t, "1.0", ce["specversion"])
+	assert.Equal(t, "io.goharbor.pushImage", ce["type"])
+	assert.Equal(t, "/webhook/policies/1", ce["source"])
+	assert.Equal(t, "1970-01-01T00:00:00Z", ce["time"])
+	assert.NotEmpty(t, ce["id"])
+	assert.NotNil(t, ce["data"])
+
+	// slack
+	body, contentType, err = FormatPayload(models.PayloadFormatSlack, 1, testPayload)
+	require.Nil(t, err)
+	assert.Equal(t, "application/json", contentType)
+	msg := &slackMessage{}
+	require.Nil(t, json.Unmarshal(body, msg))
+	assert.True(t, strings.HasPrefix(msg.Text, "*pushImage*"))
+	assert.True(t, strings.Contains(msg.Text, "harbor.example.com/library/hello-world:latest"))
+
+	body, _, err = FormatPayload(models.PayloadFormatSlack, 1, &model.Payload{
+		Type: "replicationFailed",
+		EventData: &model.EventData{
+			Replication: &model.Replication{PolicyName: "rule", ExecutionID: 1, Status: "Failed", Total: 2, Succeed: 1, Failed: 1},
+		},
+	})
+	require.Nil(t, err)
+	require.Nil(t, json.Unmarshal(body, msg))
+	assert.True(t, strings.Contains(msg.Text, "Replication policy `rule` execution 1 Failed: 1 succeed, 1 failed, 0 stopped of 2 in total"))
+
+	// unsupported
+	_, _, err = FormatPayload("xml", 1, testPayload)
+	assert.NotNil(t, err)
+	assert.False(t, IsValidPayloadFormat("xml"))
+	assert.True(t, IsValidPayloadFormat(models.PayloadFormatSlack))
+}
+
+func TestSignPayload(t *testing.T) {
+	body := []byte(`{"type":"pushImage"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), SignPayload("secret", body))
+	assert.NotEqual(t, SignPayload("secret", body), SignPayload("another", body))
+}
@@ -1,7 +1,6 @@
 package notification
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -42,18 +41,23 @@ func (h *HTTPHandler) process(event *model.HookEvent) error {
 	}
 	j.Name = job.WebhookJob
 
-	payload, err := json.Marshal(event.Payload)
+	payload, contentType, err := FormatPayload(event.Target.PayloadFormat, event.PolicyID, event.Payload)
 	if err != nil {
-		return fmt.Errorf("marshal from payload %v failed: %v", event.Payload, err)
+		return fmt.Errorf("format payload %v failed: %v", event.Payload, err)
 	}
 
 	j.Parameters = map[string]interface{}{
-		"payload": string(payload),
-		"address": event.Target.Address,
+		"payload":      string(payload),
+		"content_type": contentType,
+		"address":      event.Target.Address,
 		// Users can define a auth header in http statement in notification(webhook) policy.
 		// So it will be sent in header in http request.
 		"auth_header":      event.Target.AuthHeader,
 		"skip_cert_verify": event.Target.SkipCertVerify,
 	}
+	// the payload is signed here so that the secret isn't passed to jobservice
+	if len(event.Secret) > 0 {
+		j.Parameters["signature"] = SignPayload(event.Secret, payload)
+	}
 	return notification.HookManager.StartHook(event, j)
 }
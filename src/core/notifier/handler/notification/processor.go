@@ -108,6 +108,7 @@ func sendHookWithPolicies(policies []*models.NotificationPolicy, payload *notify
 				PolicyID:  ply.ID,
 				Payload:   payload,
 				Target:    &target,
+				Secret:    ply.Secret,
 			}
 			// It should never affect evaluating other policies when one is failed, but error should return
 			if err := evt.Build(hookMetadata); err == nil {
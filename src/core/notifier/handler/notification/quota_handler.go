@@ -0,0 +1,112 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/goharbor/harbor/src/core/config"
+	"github.com/goharbor/harbor/src/core/notifier/model"
+	"github.com/goharbor/harbor/src/pkg/notification"
+)
+
+// QuotaPreprocessHandler preprocess quota event data
+type QuotaPreprocessHandler struct {
+}
+
+// Handle preprocess quota event data and then publish hook event
+func (qp *QuotaPreprocessHandler) Handle(value interface{}) error {
+	// if global notification configured disabled, return directly
+	if !config.NotificationEnable() {
+		log.Debug("notification feature is not enabled")
+		return nil
+	}
+
+	quotaEvent, ok := value.(*model.QuotaEvent)
+	if !ok {
+		return errors.New("invalid quota event type")
+	}
+
+	if quotaEvent == nil || quotaEvent.ProjectID == 0 {
+		return fmt.Errorf("data miss in quota event: %v", quotaEvent)
+	}
+
+	project, err := config.GlobalProjectMgr.Get(quotaEvent.ProjectID)
+	if err != nil {
+		log.Errorf("failed to find project[%d] for quota event: %v", quotaEvent.ProjectID, err)
+		return err
+	}
+	if project == nil {
+		return fmt.Errorf("project not found for quota event: %d", quotaEvent.ProjectID)
+	}
+	policies, err := notification.PolicyMgr.GetRelatedPolices(project.ProjectID, quotaEvent.EventType)
+	if err != nil {
+		log.Errorf("failed to find policy for %s event: %v", quotaEvent.EventType, err)
+		return err
+	}
+	// if cannot find policy including event type in project, return directly
+	if len(policies) == 0 {
+		log.Debugf("cannot find policy for %s event: %v", quotaEvent.EventType, quotaEvent)
+		return nil
+	}
+
+	payload, err := constructQuotaPayload(quotaEvent, project)
+	if err != nil {
+		return err
+	}
+
+	return sendHookWithPolicies(policies, payload, quotaEvent.EventType)
+}
+
+// IsStateful ...
+func (qp *QuotaPreprocessHandler) IsStateful() bool {
+	return false
+}
+
+func constructQuotaPayload(event *model.QuotaEvent, project *models.Project) (*model.Payload, error) {
+	payload := &model.Payload{
+		Type:    event.EventType,
+		OccurAt: event.OccurAt.Unix(),
+		EventData: &model.EventData{
+			Quota: &model.Quota{
+				Hard:    event.Hard,
+				Used:    event.Used,
+				Details: event.Details,
+			},
+		},
+		Operator: event.Operator,
+	}
+
+	if event.RepoName == "" {
+		return payload, nil
+	}
+
+	repoType := models.ProjectPrivate
+	if project.IsPublic() {
+		repoType = models.ProjectPublic
+	}
+	payload.EventData.Repository = &model.Repository{
+		Name:         getNameFromImgRepoFullName(event.RepoName),
+		Namespace:    project.Name,
+		RepoFullName: event.RepoName,
+		RepoType:     repoType,
+	}
+
+	if event.Resource != nil {
+		resource := &model.Resource{
+			Tag:    event.Resource.Tag,
+			Digest: event.Resource.Digest,
+		}
+		if event.Resource.Tag != "" {
+			extURL, err := config.ExtURL()
+			if err != nil {
+				return nil, fmt.Errorf("get external endpoint failed: %v", err)
+			}
+			resource.ResourceURL, _ = buildImageResourceURL(extURL, event.RepoName, event.Resource.Tag)
+		}
+		payload.EventData.Resources = append(payload.EventData.Resources, resource)
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,109 @@
+package notification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/core/config"
+	"github.com/goharbor/harbor/src/core/notifier/model"
+	"github.com/goharbor/harbor/src/pkg/notification"
+	notificationModel "github.com/goharbor/harbor/src/pkg/notification/model"
+	"github.com/goharbor/harbor/src/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotaPreprocessHandler_Handle(t *testing.T) {
+	PolicyMgr := notification.PolicyMgr
+	defer func() {
+		notification.PolicyMgr = PolicyMgr
+	}()
+	notification.PolicyMgr = &fakedPolicyMgr{}
+
+	handler := &QuotaPreprocessHandler{}
+	config.Init()
+
+	type args struct {
+		data interface{}
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "QuotaPreprocessHandler Want Error 1",
+			args: args{
+				data: nil,
+			},
+			wantErr: true,
+		},
+		{
+			name: "QuotaPreprocessHandler Want Error 2",
+			args: args{
+				data: &model.QuotaEvent{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "QuotaPreprocessHandler Want Error 3",
+			args: args{
+				data: &model.QuotaEvent{
+					EventType: notificationModel.EventTypeQuotaExceed,
+					ProjectID: 10000,
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := handler.Handle(tt.args.data)
+			if tt.wantErr {
+				require.NotNil(t, err, "Error: %s", err)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestQuotaPreprocessHandler_IsStateful(t *testing.T) {
+	handler := &QuotaPreprocessHandler{}
+	assert.False(t, handler.IsStateful())
+}
+
+func TestConstructQuotaPayload(t *testing.T) {
+	project := &models.Project{ProjectID: 1, Name: "library"}
+	event := &model.QuotaEvent{
+		EventType: notificationModel.EventTypeQuotaWarning,
+		ProjectID: 1,
+		Hard:      types.ResourceList{types.ResourceStorage: 100},
+		Used:      types.ResourceList{types.ResourceStorage: 90},
+		Details:   "near limit",
+		OccurAt:   time.Now(),
+		Operator:  "admin",
+	}
+
+	payload, err := constructQuotaPayload(event, project)
+	require.Nil(t, err)
+	assert.Equal(t, notificationModel.EventTypeQuotaWarning, payload.Type)
+	assert.Equal(t, "admin", payload.Operator)
+	assert.Nil(t, payload.EventData.Repository)
+	require.NotNil(t, payload.EventData.Quota)
+	assert.Equal(t, int64(90), payload.EventData.Quota.Used[types.ResourceStorage])
+	assert.Equal(t, "near limit", payload.EventData.Quota.Details)
+
+	event.RepoName = "library/alpine"
+	event.Resource = &model.ImgResource{Digest: "sha256:abcd"}
+	payload, err = constructQuotaPayload(event, project)
+	require.Nil(t, err)
+	require.NotNil(t, payload.EventData.Repository)
+	assert.Equal(t, "alpine", payload.EventData.Repository.Name)
+	assert.Equal(t, "library", payload.EventData.Repository.Namespace)
+	assert.Equal(t, models.ProjectPrivate, payload.EventData.Repository.RepoType)
+	require.Len(t, payload.EventData.Resources, 1)
+	assert.Equal(t, "sha256:abcd", payload.EventData.Resources[0].Digest)
+}
@@ -0,0 +1,150 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/goharbor/harbor/src/core/config"
+	"github.com/goharbor/harbor/src/core/notifier/model"
+	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/replication"
+	repModels "github.com/goharbor/harbor/src/replication/dao/models"
+	repModel "github.com/goharbor/harbor/src/replication/model"
+)
+
+// ReplicationPreprocessHandler preprocess replication event data
+type ReplicationPreprocessHandler struct {
+}
+
+// Handle preprocess replication event data and then publish hook event
+func (r *ReplicationPreprocessHandler) Handle(value interface{}) error {
+	// if global notification configured disabled, return directly
+	if !config.NotificationEnable() {
+		log.Debug("notification feature is not enabled")
+		return nil
+	}
+
+	e, ok := value.(*model.ReplicationEvent)
+	if !ok {
+		return errors.New("invalid replication event type")
+	}
+
+	if e == nil {
+		return errors.New("empty replication event")
+	}
+
+	execution, err := replication.OperationCtl.GetExecution(e.ExecutionID)
+	if err != nil {
+		log.Errorf("failed to find replication execution[%d] for replication webhook: %v", e.ExecutionID, err)
+		return err
+	}
+	if execution == nil {
+		return fmt.Errorf("replication execution for replication webhook not found: %d", e.ExecutionID)
+	}
+
+	policy, err := replication.PolicyCtl.Get(execution.PolicyID)
+	if err != nil {
+		log.Errorf("failed to find replication policy[%d] for replication webhook: %v", execution.PolicyID, err)
+		return err
+	}
+	if policy == nil {
+		return fmt.Errorf("replication policy for replication webhook not found: %d", execution.PolicyID)
+	}
+
+	_, tasks, err := replication.OperationCtl.ListTasks(&repModels.TaskQuery{ExecutionID: execution.ID})
+	if err != nil {
+		log.Errorf("failed to list tasks of replication execution[%d]: %v", execution.ID, err)
+		return err
+	}
+
+	payload := constructReplicationPayload(e, execution, policy)
+	for _, projectName := range getReplicationProjects(policy, tasks) {
+		project, err := config.GlobalProjectMgr.Get(projectName)
+		if err != nil {
+			log.Errorf("failed to find project[%s] for replication event: %v", projectName, err)
+			continue
+		}
+		if project == nil {
+			log.Debugf("project[%s] not found for replication event", projectName)
+			continue
+		}
+		policies, err := notification.PolicyMgr.GetRelatedPolices(project.ProjectID, e.EventType)
+		if err != nil {
+			log.Errorf("failed to find policy for %s event: %v", e.EventType, err)
+			return err
+		}
+		// if cannot find policy including event type in project, skip it
+		if len(policies) == 0 {
+			log.Debugf("cannot find policy for %s event in project %s: %v", e.EventType, projectName, e)
+			continue
+		}
+		if err = sendHookWithPolicies(policies, payload, e.EventType); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsStateful ...
+func (r *ReplicationPreprocessHandler) IsStateful() bool {
+	return false
+}
+
+func constructReplicationPayload(event *model.ReplicationEvent, execution *repModels.Execution, policy *repModel.Policy) *model.Payload {
+	rep := &model.Replication{
+		PolicyID:    policy.ID,
+		PolicyName:  policy.Name,
+		ExecutionID: execution.ID,
+		Trigger:     string(execution.Trigger),
+		Status:      execution.Status,
+		Total:       execution.Total,
+		Succeed:     execution.Succeed,
+		Failed:      execution.Failed,
+		Stopped:     execution.Stopped,
+		StartTime:   execution.StartTime.Unix(),
+	}
+	if !execution.EndTime.IsZero() {
+		rep.EndTime = execution.EndTime.Unix()
+	}
+
+	return &model.Payload{
+		Type:    event.EventType,
+		OccurAt: event.OccurAt.Unix(),
+		EventData: &model.EventData{
+			Replication: rep,
+		},
+		Operator: event.Operator,
+	}
+}
+
+// getReplicationProjects returns the names of the local projects involved in the replication:
+// the source projects for the push-based policy and the destination projects for the pull-based one
+func getReplicationProjects(policy *repModel.Policy, tasks []*repModels.Task) []string {
+	push := policy.SrcRegistry == nil || policy.SrcRegistry.ID == 0
+	if !push && len(policy.DestNamespace) > 0 {
+		return []string{policy.DestNamespace}
+	}
+
+	var names []string
+	set := map[string]struct{}{}
+	for _, task := range tasks {
+		resource := task.DstResource
+		if push {
+			resource = task.SrcResource
+		}
+		idx := strings.Index(resource, "/")
+		if idx <= 0 {
+			continue
+		}
+		name := resource[:idx]
+		if _, exist := set[name]; exist {
+			continue
+		}
+		set[name] = struct{}{}
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,86 @@
+package notification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/core/config"
+	"github.com/goharbor/harbor/src/core/notifier/model"
+	notificationModel "github.com/goharbor/harbor/src/pkg/notification/model"
+	repModels "github.com/goharbor/harbor/src/replication/dao/models"
+	repModel "github.com/goharbor/harbor/src/replication/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplicationPreprocessHandler_Handle(t *testing.T) {
+	handler := &ReplicationPreprocessHandler{}
+	config.Init()
+
+	err := handler.Handle(nil)
+	require.NotNil(t, err)
+
+	var e *model.ReplicationEvent
+	err = handler.Handle(e)
+	require.NotNil(t, err)
+}
+
+func TestReplicationPreprocessHandler_IsStateful(t *testing.T) {
+	handler := &ReplicationPreprocessHandler{}
+	assert.False(t, handler.IsStateful())
+}
+
+func TestConstructReplicationPayload(t *testing.T) {
+	now := time.Now()
+	execution := &repModels.Execution{
+		ID:        2,
+		PolicyID:  1,
+		Status:    repModels.ExecutionStatusFailed,
+		Total:     3,
+		Succeed:   2,
+		Failed:    1,
+		Trigger:   repModel.TriggerTypeManual,
+		StartTime: now,
+	}
+	policy := &repModel.Policy{ID: 1, Name: "rule"}
+	event := &model.ReplicationEvent{
+		EventType:   notificationModel.EventTypeReplicationFailed,
+		ExecutionID: 2,
+		OccurAt:     now,
+		Operator:    "auto",
+	}
+
+	payload := constructReplicationPayload(event, execution, policy)
+	assert.Equal(t, notificationModel.EventTypeReplicationFailed, payload.Type)
+	require.NotNil(t, payload.EventData.Replication)
+	assert.Equal(t, "rule", payload.EventData.Replication.PolicyName)
+	assert.Equal(t, int64(2), payload.EventData.Replication.ExecutionID)
+	assert.Equal(t, 1, payload.EventData.Replication.Failed)
+	assert.Equal(t, int64(0), payload.EventData.Replication.EndTime)
+}
+
+func TestGetReplicationProjects(t *testing.T) {
+	tasks := []*repModels.Task{
+		{SrcResource: "library/hello-world:[latest]", DstResource: "dst/hello-world:[latest]"},
+		{SrcResource: "library/alpine:[3.9 ... 2 in total]", DstResource: "dst/alpine:[3.9 ... 2 in total]"},
+		{SrcResource: "test/busybox", DstResource: "dst/busybox"},
+	}
+
+	// push based policy
+	push := &repModel.Policy{
+		SrcRegistry:  &repModel.Registry{ID: 0},
+		DestRegistry: &repModel.Registry{ID: 1},
+	}
+	assert.Equal(t, []string{"library", "test"}, getReplicationProjects(push, tasks))
+
+	// pull based policy
+	pull := &repModel.Policy{
+		SrcRegistry:  &repModel.Registry{ID: 1},
+		DestRegistry: &repModel.Registry{ID: 0},
+	}
+	assert.Equal(t, []string{"dst"}, getReplicationProjects(pull, tasks))
+
+	// pull based policy with destination namespace
+	pull.DestNamespace = "ns"
+	assert.Equal(t, []string{"ns"}, getReplicationProjects(pull, tasks))
+}
@@ -0,0 +1,111 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/goharbor/harbor/src/core/config"
+	"github.com/goharbor/harbor/src/core/notifier/model"
+	"github.com/goharbor/harbor/src/pkg/notification"
+	"github.com/goharbor/harbor/src/pkg/retention"
+	"github.com/goharbor/harbor/src/pkg/retention/policy"
+	"github.com/goharbor/harbor/src/pkg/retention/q"
+)
+
+// retentionMgr is used to load the tag retention executions and tasks
+var retentionMgr = retention.NewManager()
+
+// RetentionPreprocessHandler preprocess tag retention event data
+type RetentionPreprocessHandler struct {
+}
+
+// Handle preprocess tag retention event data and then publish hook event
+func (r *RetentionPreprocessHandler) Handle(value interface{}) error {
+	// if global notification configured disabled, return directly
+	if !config.NotificationEnable() {
+		log.Debug("notification feature is not enabled")
+		return nil
+	}
+
+	e, ok := value.(*model.RetentionEvent)
+	if !ok {
+		return errors.New("invalid retention event type")
+	}
+
+	if e == nil {
+		return errors.New("empty retention event")
+	}
+
+	execution, err := retentionMgr.GetExecution(e.ExecutionID)
+	if err != nil {
+		log.Errorf("failed to find retention execution[%d] for retention webhook: %v", e.ExecutionID, err)
+		return err
+	}
+	if execution == nil {
+		return fmt.Errorf("retention execution for retention webhook not found: %d", e.ExecutionID)
+	}
+
+	p, err := retentionMgr.GetPolicy(execution.PolicyID)
+	if err != nil {
+		log.Errorf("failed to find retention policy[%d] for retention webhook: %v", execution.PolicyID, err)
+		return err
+	}
+	if p == nil || p.Scope == nil || p.Scope.Level != policy.ScopeLevelProject {
+		log.Debugf("retention policy[%d] is not a project level policy, skip the retention webhook", execution.PolicyID)
+		return nil
+	}
+
+	policies, err := notification.PolicyMgr.GetRelatedPolices(p.Scope.Reference, e.EventType)
+	if err != nil {
+		log.Errorf("failed to find policy for %s event: %v", e.EventType, err)
+		return err
+	}
+	// if cannot find policy including event type in project, return directly
+	if len(policies) == 0 {
+		log.Debugf("cannot find policy for %s event: %v", e.EventType, e)
+		return nil
+	}
+
+	tasks, err := retentionMgr.ListTasks(&q.TaskQuery{ExecutionID: execution.ID})
+	if err != nil {
+		log.Errorf("failed to list tasks of retention execution[%d]: %v", execution.ID, err)
+		return err
+	}
+
+	payload := constructRetentionPayload(e, execution, tasks)
+	return sendHookWithPolicies(policies, payload, e.EventType)
+}
+
+// IsStateful ...
+func (r *RetentionPreprocessHandler) IsStateful() bool {
+	return false
+}
+
+func constructRetentionPayload(event *model.RetentionEvent, execution *retention.Execution, tasks []*retention.Task) *model.Payload {
+	ret := &model.Retention{
+		PolicyID:    execution.PolicyID,
+		ExecutionID: execution.ID,
+		Trigger:     execution.Trigger,
+		Status:      execution.Status,
+		DryRun:      execution.DryRun,
+		StartTime:   execution.StartTime.Unix(),
+	}
+	if !execution.EndTime.IsZero() {
+		ret.EndTime = execution.EndTime.Unix()
+	}
+	for _, task := range tasks {
+		ret.Total += task.Total
+		ret.Retained += task.Retained
+	}
+	ret.Deleted = ret.Total - ret.Retained
+
+	return &model.Payload{
+		Type:    event.EventType,
+		OccurAt: event.OccurAt.Unix(),
+		EventData: &model.EventData{
+			Retention: ret,
+		},
+		Operator: event.Operator,
+	}
+}
@@ -0,0 +1,60 @@
+package notification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/core/config"
+	"github.com/goharbor/harbor/src/core/notifier/model"
+	notificationModel "github.com/goharbor/harbor/src/pkg/notification/model"
+	"github.com/goharbor/harbor/src/pkg/retention"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetentionPreprocessHandler_Handle(t *testing.T) {
+	handler := &RetentionPreprocessHandler{}
+	config.Init()
+
+	err := handler.Handle(nil)
+	require.NotNil(t, err)
+
+	var e *model.RetentionEvent
+	err = handler.Handle(e)
+	require.NotNil(t, err)
+}
+
+func TestRetentionPreprocessHandler_IsStateful(t *testing.T) {
+	handler := &RetentionPreprocessHandler{}
+	assert.False(t, handler.IsStateful())
+}
+
+func TestConstructRetentionPayload(t *testing.T) {
+	now := time.Now()
+	execution := &retention.Execution{
+		ID:        2,
+		PolicyID:  1,
+		Status:    "Succeed",
+		Trigger:   retention.ExecutionTriggerManual,
+		StartTime: now,
+		EndTime:   now,
+	}
+	tasks := []*retention.Task{
+		{ID: 1, ExecutionID: 2, Total: 10, Retained: 4},
+		{ID: 2, ExecutionID: 2, Total: 5, Retained: 5},
+	}
+	event := &model.RetentionEvent{
+		EventType:   notificationModel.EventTypeRetentionCompleted,
+		ExecutionID: 2,
+		OccurAt:     now,
+		Operator:    "auto",
+	}
+
+	payload := constructRetentionPayload(event, execution, tasks)
+	assert.Equal(t, notificationModel.EventTypeRetentionCompleted, payload.Type)
+	require.NotNil(t, payload.EventData.Retention)
+	assert.Equal(t, 15, payload.EventData.Retention.Total)
+	assert.Equal(t, 9, payload.EventData.Retention.Retained)
+	assert.Equal(t, 6, payload.EventData.Retention.Deleted)
+	assert.Equal(t, now.Unix(), payload.EventData.Retention.EndTime)
+}
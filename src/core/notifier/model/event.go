@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/pkg/types"
 )
 
 // ImageEvent is image related event data to publish
@@ -40,12 +41,43 @@ type ScanImageEvent struct {
 	Operator  string
 }
 
+// QuotaEvent is quota related event data to publish
+type QuotaEvent struct {
+	EventType string
+	ProjectID int64
+	RepoName  string
+	Resource  *ImgResource
+	Hard      types.ResourceList
+	Used      types.ResourceList
+	Details   string
+	OccurAt   time.Time
+	Operator  string
+}
+
+// ReplicationEvent is replication execution related event data to publish
+type ReplicationEvent struct {
+	EventType   string
+	ExecutionID int64
+	OccurAt     time.Time
+	Operator    string
+}
+
+// RetentionEvent is tag retention execution related event data to publish
+type RetentionEvent struct {
+	EventType   string
+	ExecutionID int64
+	OccurAt     time.Time
+	Operator    string
+}
+
 // HookEvent is hook related event data to publish
 type HookEvent struct {
 	PolicyID  int64
 	EventType string
 	Target    *models.EventTarget
 	Payload   *Payload
+	// Secret of the policy to sign the payload
+	Secret string
 }
 
 // Payload of notification event
@@ -58,8 +90,11 @@ type Payload struct {
 
 // EventData of notification event payload
 type EventData struct {
-	Resources  []*Resource `json:"resources"`
-	Repository *Repository `json:"repository"`
+	Resources   []*Resource  `json:"resources"`
+	Repository  *Repository  `json:"repository"`
+	Quota       *Quota       `json:"quota,omitempty"`
+	Replication *Replication `json:"replication,omitempty"`
+	Retention   *Retention   `json:"retention,omitempty"`
 }
 
 // Resource describe infos of resource triggered notification
@@ -78,3 +113,39 @@ type Repository struct {
 	RepoFullName string `json:"repo_full_name"`
 	RepoType     string `json:"repo_type"`
 }
+
+// Quota info of quota notification event
+type Quota struct {
+	Hard    types.ResourceList `json:"hard"`
+	Used    types.ResourceList `json:"used"`
+	Details string             `json:"details,omitempty"`
+}
+
+// Replication info of replication notification event
+type Replication struct {
+	PolicyID    int64  `json:"policy_id"`
+	PolicyName  string `json:"policy_name"`
+	ExecutionID int64  `json:"execution_id"`
+	Trigger     string `json:"trigger"`
+	Status      string `json:"status"`
+	Total       int    `json:"total"`
+	Succeed     int    `json:"succeed"`
+	Failed      int    `json:"failed"`
+	Stopped     int    `json:"stopped"`
+	StartTime   int64  `json:"start_time"`
+	EndTime     int64  `json:"end_time"`
+}
+
+// Retention info of tag retention notification event
+type Retention struct {
+	PolicyID    int64  `json:"policy_id"`
+	ExecutionID int64  `json:"execution_id"`
+	Trigger     string `json:"trigger"`
+	Status      string `json:"status"`
+	DryRun      bool   `json:"dry_run"`
+	Total       int    `json:"total"`
+	Retained    int    `json:"retained"`
+	Deleted     int    `json:"deleted"`
+	StartTime   int64  `json:"start_time"`
+	EndTime     int64  `json:"end_time"`
+}
@@ -18,6 +18,18 @@ const (
 	ScanningFailedTopic = "OnScanningFailed"
 	// ScanningCompletedTopic is topic for scanning completed event
 	ScanningCompletedTopic = "OnScanningCompleted"
+	// QuotaExceedTopic is topic for quota exceeded event
+	QuotaExceedTopic = "OnQuotaExceed"
+	// QuotaWarningTopic is topic for quota near-limit event
+	QuotaWarningTopic = "OnQuotaWarning"
+	// ReplicationCompletedTopic is topic for replication execution completed event
+	ReplicationCompletedTopic = "OnReplicationCompleted"
+	// ReplicationFailedTopic is topic for replication execution failed event
+	ReplicationFailedTopic = "OnReplicationFailed"
+	// RetentionCompletedTopic is topic for tag retention execution completed event
+	RetentionCompletedTopic = "OnRetentionCompleted"
+	// ImmutableTagViolationTopic is topic for tag immutability violation event
+	ImmutableTagViolationTopic = "OnImmutableTagViolation"
 
 	// WebhookTopic is topic for sending webhook payload
 	WebhookTopic = "http"
@@ -10,15 +10,22 @@ import (
 // Subscribe topics
 func init() {
 	handlersMap := map[string][]notifier.NotificationHandler{
-		model.PushImageTopic:         {&notification.ImagePreprocessHandler{}},
-		model.PullImageTopic:         {&notification.ImagePreprocessHandler{}},
-		model.DeleteImageTopic:       {&notification.ImagePreprocessHandler{}},
-		model.WebhookTopic:           {&notification.HTTPHandler{}},
-		model.UploadChartTopic:       {&notification.ChartPreprocessHandler{}},
-		model.DownloadChartTopic:     {&notification.ChartPreprocessHandler{}},
-		model.DeleteChartTopic:       {&notification.ChartPreprocessHandler{}},
-		model.ScanningCompletedTopic: {&notification.ScanImagePreprocessHandler{}},
-		model.ScanningFailedTopic:    {&notification.ScanImagePreprocessHandler{}},
+		model.PushImageTopic:             {&notification.ImagePreprocessHandler{}},
+		model.PullImageTopic:             {&notification.ImagePreprocessHandler{}},
+		model.DeleteImageTopic:           {&notification.ImagePreprocessHandler{}},
+		model.WebhookTopic:               {&notification.HTTPHandler{}},
+		model.EmailTopic:                 {&notification.EmailHandler{}},
+		model.UploadChartTopic:           {&notification.ChartPreprocessHandler{}},
+		model.DownloadChartTopic:         {&notification.ChartPreprocessHandler{}},
+		model.DeleteChartTopic:           {&notification.ChartPreprocessHandler{}},
+		model.ScanningCompletedTopic:     {&notification.ScanImagePreprocessHandler{}},
+		model.ScanningFailedTopic:        {&notification.ScanImagePreprocessHandler{}},
+		model.QuotaExceedTopic:           {&notification.QuotaPreprocessHandler{}},
+		model.QuotaWarningTopic:          {&notification.QuotaPreprocessHandler{}},
+		model.ReplicationCompletedTopic:  {&notification.ReplicationPreprocessHandler{}},
+		model.ReplicationFailedTopic:     {&notification.ReplicationPreprocessHandler{}},
+		model.RetentionCompletedTopic:    {&notification.RetentionPreprocessHandler{}},
+		model.ImmutableTagViolationTopic: {&notification.ImagePreprocessHandler{}},
 	}
 
 	for t, handlers := range handlersMap {
@@ -25,6 +25,7 @@ import (
 	"github.com/goharbor/harbor/src/core/service/notifications/registry"
 	"github.com/goharbor/harbor/src/core/service/notifications/scheduler"
 	"github.com/goharbor/harbor/src/core/service/token"
+	"github.com/goharbor/harbor/src/pkg/metrics"
 )
 
 func initRouters() {
@@ -53,6 +54,8 @@ func initRouters() {
 		beego.Router("/api/users/:id/permissions", &api.UserAPI{}, "get:ListUserPermissions")
 		beego.Router("/api/users/:id/sysadmin", &api.UserAPI{}, "put:ToggleUserAdminRole")
 		beego.Router("/api/users/:id/gen_cli_secret", &api.UserAPI{}, "post:GenCLISecret")
+		beego.Router("/api/users/:id/tokens", &api.AccessTokenAPI{}, "get:List;post:Post")
+		beego.Router("/api/users/:id/tokens/:tid([0-9]+)", &api.AccessTokenAPI{}, "delete:Delete")
 		beego.Router("/api/usergroups/?:ugid([0-9]+)", &api.UserGroupAPI{})
 		beego.Router("/api/ldap/ping", &api.LdapAPI{}, "post:Ping")
 		beego.Router("/api/ldap/users/search", &api.LdapAPI{}, "get:Search")
@@ -75,6 +78,12 @@ func initRouters() {
 
 	beego.Router("/api/projects/:pid([0-9]+)/robots", &api.RobotAPI{}, "post:Post;get:List")
 	beego.Router("/api/projects/:pid([0-9]+)/robots/:id([0-9]+)", &api.RobotAPI{}, "get:Get;put:Put;delete:Delete")
+	beego.Router("/api/robots", &api.SystemRobotAPI{}, "post:Post;get:List")
+	beego.Router("/api/projects/:pid([0-9]+)/robots/:id([0-9]+)/secret", &api.RobotAPI{}, "post:RegenerateSecret")
+	beego.Router("/api/robots/:id([0-9]+)", &api.SystemRobotAPI{}, "get:Get;put:Put;delete:Delete")
+	beego.Router("/api/robots/:id([0-9]+)/secret", &api.SystemRobotAPI{}, "post:RegenerateSecret")
+	beego.Router("/api/roles", &api.RoleAPI{}, "post:Post;get:List")
+	beego.Router("/api/roles/:id([0-9]+)", &api.RoleAPI{}, "get:Get;put:Put;delete:Delete")
 
 	beego.Router("/api/quotas", &api.QuotaAPI{}, "get:List")
 	beego.Router("/api/quotas/:id([0-9]+)", &api.QuotaAPI{}, "get:Get;put:Put")
@@ -84,6 +93,8 @@ func initRouters() {
 	beego.Router("/api/repositories/*/labels", &api.RepositoryLabelAPI{}, "get:GetOfRepository;post:AddToRepository")
 	beego.Router("/api/repositories/*/labels/:id([0-9]+)", &api.RepositoryLabelAPI{}, "delete:RemoveFromRepository")
 	beego.Router("/api/repositories/*/tags/:tag", &api.RepositoryAPI{}, "delete:Delete;get:GetTag")
+	beego.Router("/api/repositories/*/manifests", &api.RepositoryAPI{}, "get:GetUntaggedManifests")
+	beego.Router("/api/repositories/*/manifests/:digest", &api.RepositoryAPI{}, "delete:DeleteManifest")
 	beego.Router("/api/repositories/*/tags/:tag/labels", &api.RepositoryLabelAPI{}, "get:GetOfImage;post:AddToImage")
 	beego.Router("/api/repositories/*/tags/:tag/labels/:id([0-9]+)", &api.RepositoryLabelAPI{}, "delete:RemoveFromImage")
 	beego.Router("/api/repositories/*/tags", &api.RepositoryAPI{}, "get:GetTags;post:Retag")
@@ -92,21 +103,31 @@ func initRouters() {
 	beego.Router("/api/repositories/*/tags/:tag/manifest", &api.RepositoryAPI{}, "get:GetManifests")
 	beego.Router("/api/repositories/*/signatures", &api.RepositoryAPI{}, "get:GetSignatures")
 	beego.Router("/api/repositories/top", &api.RepositoryAPI{}, "get:GetTopRepos")
+	beego.Router("/api/jobs/scan/:id([0-9]+)", &api.ScanJobAPI{}, "put:Operate")
 	beego.Router("/api/jobs/scan/:id([0-9]+)/log", &api.ScanJobAPI{}, "get:GetLog")
+	beego.Router("/api/jobs/:uuid", &api.JobAPI{}, "put:Operate")
 
 	beego.Router("/api/system/gc", &api.GCAPI{}, "get:List")
 	beego.Router("/api/system/gc/:id", &api.GCAPI{}, "get:GetGC")
+	beego.Router("/api/system/gc/:id([0-9]+)", &api.GCAPI{}, "put:Operate")
 	beego.Router("/api/system/gc/:id([0-9]+)/log", &api.GCAPI{}, "get:GetLog")
 	beego.Router("/api/system/gc/schedule", &api.GCAPI{}, "get:Get;put:Put;post:Post")
 	beego.Router("/api/system/scanAll/schedule", &api.ScanAllAPI{}, "get:Get;put:Put;post:Post")
+	beego.Router("/api/system/auditlog/purge", &api.AuditLogPurgeAPI{}, "get:List")
+	beego.Router("/api/system/auditlog/purge/schedule", &api.AuditLogPurgeAPI{}, "get:Get;put:Put;post:Post")
+	beego.Router("/api/system/jobservice/queues", &api.JobServiceDashboardAPI{}, "get:GetQueues")
+	beego.Router("/api/system/jobservice/running", &api.JobServiceDashboardAPI{}, "get:GetRunningJobs")
+	beego.Router("/api/system/jobservice/latency", &api.JobServiceDashboardAPI{}, "get:GetLatency")
 	beego.Router("/api/system/CVEWhitelist", &api.SysCVEWhitelistAPI{}, "get:Get;put:Put")
 	beego.Router("/api/system/oidc/ping", &api.OIDCAPI{}, "post:Ping")
 
 	beego.Router("/api/logs", &api.LogAPI{})
+	beego.Router("/api/logs/audit", &api.AuditLogAPI{}, "get:List")
+	beego.Router("/api/logs/audit/export", &api.AuditLogAPI{}, "get:Export")
 
 	beego.Router("/api/replication/adapters", &api.ReplicationAdapterAPI{}, "get:List")
 	beego.Router("/api/replication/executions", &api.ReplicationOperationAPI{}, "get:ListExecutions;post:CreateExecution")
-	beego.Router("/api/replication/executions/:id([0-9]+)", &api.ReplicationOperationAPI{}, "get:GetExecution;put:StopExecution")
+	beego.Router("/api/replication/executions/:id([0-9]+)", &api.ReplicationOperationAPI{}, "get:GetExecution;put:OperateExecution")
 	beego.Router("/api/replication/executions/:id([0-9]+)/tasks", &api.ReplicationOperationAPI{}, "get:ListTasks")
 	beego.Router("/api/replication/executions/:id([0-9]+)/tasks/:tid([0-9]+)/log", &api.ReplicationOperationAPI{}, "get:GetTaskLog")
 
@@ -120,6 +141,9 @@ func initRouters() {
 	beego.Router("/api/projects/:pid([0-9]+)/webhook/lasttrigger", &api.NotificationPolicyAPI{}, "get:ListGroupByEventType")
 
 	beego.Router("/api/projects/:pid([0-9]+)/webhook/jobs/", &api.NotificationJobAPI{}, "get:List")
+	beego.Router("/api/projects/:pid([0-9]+)/webhook/jobs/:id([0-9]+)", &api.NotificationJobAPI{}, "put:Operate")
+	beego.Router("/api/projects/:pid([0-9]+)/webhook/jobs/:id([0-9]+)/deliveries", &api.NotificationJobAPI{}, "get:ListDeliveries")
+	beego.Router("/api/projects/:pid([0-9]+)/webhook/jobs/:id([0-9]+)/redeliver", &api.NotificationJobAPI{}, "post:Redeliver")
 
 	beego.Router("/api/projects/:pid([0-9]+)/immutabletagrules", &api.ImmutableTagRuleAPI{}, "get:List;post:Post")
 	beego.Router("/api/projects/:pid([0-9]+)/immutabletagrules/:id([0-9]+)", &api.ImmutableTagRuleAPI{})
@@ -159,6 +183,7 @@ func initRouters() {
 	beego.Router("/api/registries/:id/namespace", &api.RegistryAPI{}, "get:GetNamespace")
 
 	beego.Router("/api/retentions/metadatas", &api.RetentionAPI{}, "get:GetMetadatas")
+	beego.Router("/api/retentions/system", &api.RetentionAPI{}, "get:GetSystemRetention")
 	beego.Router("/api/retentions/:id", &api.RetentionAPI{}, "get:GetRetention")
 	beego.Router("/api/retentions", &api.RetentionAPI{}, "post:CreateRetention")
 	beego.Router("/api/retentions/:id", &api.RetentionAPI{}, "put:UpdateRetention")
@@ -167,11 +192,15 @@ func initRouters() {
 	beego.Router("/api/retentions/:id/executions", &api.RetentionAPI{}, "get:ListRetentionExecs")
 	beego.Router("/api/retentions/:id/executions/:eid/tasks", &api.RetentionAPI{}, "get:ListRetentionExecTasks")
 	beego.Router("/api/retentions/:id/executions/:eid/tasks/:tid", &api.RetentionAPI{}, "get:GetRetentionExecTaskLog")
+	beego.Router("/api/retentions/:id/executions/:eid/report", &api.RetentionAPI{}, "get:GetRetentionExecReport")
 	beego.Router("/api/projects/:pid([0-9]+)/immutabletagrules", &api.ImmutableTagRuleAPI{}, "get:List;post:Post")
 	beego.Router("/api/projects/:pid([0-9]+)/immutabletagrules/:id([0-9]+)", &api.ImmutableTagRuleAPI{})
 
 	beego.Router("/v2/*", &controllers.RegistryProxy{}, "*:Handle")
 
+	// Prometheus metrics
+	beego.Handler(metrics.Path, metrics.Handler())
+
 	// APIs for chart repository
 	if config.WithChartMuseum() {
 		// Charts are controlled under projects
@@ -27,6 +27,7 @@ import (
 	jjob "github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/pkg/notification"
 	"github.com/goharbor/harbor/src/pkg/retention"
+	retModels "github.com/goharbor/harbor/src/pkg/retention/dao/models"
 	"github.com/goharbor/harbor/src/replication"
 	"github.com/goharbor/harbor/src/replication/operation/hook"
 	"github.com/goharbor/harbor/src/replication/policy/scheduler"
@@ -126,6 +127,9 @@ func (h *Handler) HandleReplicationTask() {
 		h.SendInternalServerError(err)
 		return
 	}
+	if isFinalStatus(h.status) {
+		publishReplicationEvent(h.id)
+	}
 }
 
 // HandleRetentionTask handles the webhook of retention task
@@ -137,8 +141,9 @@ func (h *Handler) HandleRetentionTask() {
 	// handle checkin
 	if h.checkIn != "" {
 		var retainObj struct {
-			Total    int `json:"total"`
-			Retained int `json:"retained"`
+			Total      int                     `json:"total"`
+			Retained   int                     `json:"retained"`
+			Candidates []*retention.ReportItem `json:"candidates"`
 		}
 		if err := json.Unmarshal([]byte(h.checkIn), &retainObj); err != nil {
 			log.Errorf("failed to resolve checkin of retention task %d: %v", taskID, err)
@@ -154,6 +159,11 @@ func (h *Handler) HandleRetentionTask() {
 			h.SendInternalServerError(err)
 			return
 		}
+		if err := mgr.SaveReportItems(taskID, retainObj.Candidates); err != nil {
+			log.Errorf("failed to save the report of retention task %d: %v", taskID, err)
+			h.SendInternalServerError(err)
+			return
+		}
 		return
 	}
 
@@ -163,11 +173,37 @@ func (h *Handler) HandleRetentionTask() {
 		h.SendInternalServerError(err)
 		return
 	}
+	if isFinalStatus(h.status) {
+		publishRetentionEvent(mgr, taskID)
+		return
+	}
+	// the task re-runs, so the execution will transit into a final status again
+	if task, err := mgr.GetTask(taskID); err == nil && task != nil {
+		if err = mgr.ResetExecutionNotified(task.ExecutionID); err != nil {
+			log.Errorf("failed to reset the notified status of retention execution %d: %v", task.ExecutionID, err)
+		}
+	}
 }
 
 // HandleNotificationJob handles the hook of notification job
 func (h *Handler) HandleNotificationJob() {
 	log.Debugf("received notification job status update event: job-%d, status-%s", h.id, h.status)
+	// handle checkin, the webhook job checks in the result of each delivery
+	if h.checkIn != "" {
+		delivery := &models.NotificationDelivery{}
+		if err := json.Unmarshal([]byte(h.checkIn), delivery); err != nil {
+			log.Errorf("failed to resolve checkin of notification job %d: %v", h.id, err)
+			return
+		}
+		delivery.JobID = h.id
+		if _, err := notification.JobMgr.CreateDelivery(delivery); err != nil {
+			log.Errorf("failed to record the delivery of notification job %d: %v", h.id, err)
+			h.SendInternalServerError(err)
+			return
+		}
+		return
+	}
+
 	if err := notification.JobMgr.Update(&models.NotificationJob{
 		ID:         h.id,
 		Status:     h.status,
@@ -178,3 +214,84 @@ func (h *Handler) HandleNotificationJob() {
 		return
 	}
 }
+
+func isFinalStatus(status string) bool {
+	return status == models.JobFinished || status == models.JobError || status == models.JobStopped
+}
+
+// publishReplicationEvent publishes the replication event once all the tasks
+// of the execution which the task belongs to are in final status, the event is
+// published only by the hook which moves the execution out of "InProgress"
+func publishReplicationEvent(taskID int64) {
+	task, err := replication.OperationCtl.GetTask(taskID)
+	if err != nil || task == nil {
+		log.Errorf("failed to get the replication task %d: %v", taskID, err)
+		return
+	}
+	finished, err := replication.OperationCtl.FinishExecution(task.ExecutionID)
+	if err != nil {
+		log.Errorf("failed to finish the replication execution %d: %v", task.ExecutionID, err)
+		return
+	}
+	if !finished {
+		return
+	}
+	execution, err := replication.OperationCtl.GetExecution(task.ExecutionID)
+	if err != nil || execution == nil {
+		log.Errorf("failed to get the replication execution %d: %v", task.ExecutionID, err)
+		return
+	}
+
+	e := &event.Event{}
+	metaData := &event.ReplicationMetaData{
+		ExecutionID: execution.ID,
+		Status:      execution.Status,
+	}
+	if err := e.Build(metaData); err != nil {
+		log.Errorf("failed to build replication event metadata: %v", err)
+		return
+	}
+	if err := e.Publish(); err != nil {
+		log.Errorf("failed to publish replication event: %v", err)
+	}
+}
+
+// publishRetentionEvent publishes the tag retention event once all the tasks
+// of the execution which the task belongs to are in final status, the event is
+// published only once for each transition of the execution into a final status
+func publishRetentionEvent(mgr retention.Manager, taskID int64) {
+	task, err := mgr.GetTask(taskID)
+	if err != nil || task == nil {
+		log.Errorf("failed to get the retention task %d: %v", taskID, err)
+		return
+	}
+	execution, err := mgr.GetExecution(task.ExecutionID)
+	if err != nil || execution == nil {
+		log.Errorf("failed to get the retention execution %d: %v", task.ExecutionID, err)
+		return
+	}
+	if execution.Status == retModels.ExecutionStatusInProgress {
+		return
+	}
+	notified, err := mgr.MarkExecutionNotified(execution.ID, execution.Status)
+	if err != nil {
+		log.Errorf("failed to mark the retention execution %d as notified: %v", execution.ID, err)
+		return
+	}
+	if !notified {
+		return
+	}
+
+	e := &event.Event{}
+	metaData := &event.RetentionMetaData{
+		ExecutionID: execution.ID,
+		Status:      execution.Status,
+	}
+	if err := e.Build(metaData); err != nil {
+		log.Errorf("failed to build retention event metadata: %v", err)
+		return
+	}
+	if err := e.Publish(); err != nil {
+		log.Errorf("failed to publish retention event: %v", err)
+	}
+}
@@ -27,6 +27,8 @@ import (
 	"github.com/goharbor/harbor/src/common/utils/log"
 	"github.com/goharbor/harbor/src/core/api"
 	"github.com/goharbor/harbor/src/core/config"
+	"github.com/goharbor/harbor/src/core/filter"
+	"github.com/goharbor/harbor/src/core/middlewares/util"
 	notifierEvt "github.com/goharbor/harbor/src/core/notifier/event"
 	coreutils "github.com/goharbor/harbor/src/core/utils"
 	"github.com/goharbor/harbor/src/replication"
@@ -158,13 +160,27 @@ func (n *NotificationHandler) Post() {
 			}()
 
 			if autoScanEnabled(pro) {
+				// Use the ID of the push request to correlate the push with the scan job
+				requestID, err := util.GetPushRequestID(repository, event.Target.Digest)
+				if err != nil {
+					log.Warningf("failed to get the ID of the request pushing %s@%s: %v", repository, event.Target.Digest, err)
+				}
+				if len(requestID) == 0 {
+					requestID = filter.GetRequestID(n.Ctx.Request)
+				}
+				logger := log.WithFields(log.Fields{
+					log.FieldRequestID: requestID,
+					log.FieldUser:      user,
+					log.FieldProject:   project,
+				})
+
 				last, err := clairdao.GetLastUpdate()
 				if err != nil {
-					log.Errorf("Failed to get last update from Clair DB, error: %v, the auto scan will be skipped.", err)
+					logger.Errorf("Failed to get last update from Clair DB, error: %v, the auto scan will be skipped.", err)
 				} else if last == 0 {
-					log.Infof("The Vulnerability data is not ready in Clair DB, the auto scan will be skipped, error %v", err)
-				} else if err := coreutils.TriggerImageScan(repository, tag); err != nil {
-					log.Warningf("Failed to scan image, repository: %s, tag: %s, error: %v", repository, tag, err)
+					logger.Infof("The Vulnerability data is not ready in Clair DB, the auto scan will be skipped, error %v", err)
+				} else if err := coreutils.TriggerImageScan(repository, tag, requestID); err != nil {
+					logger.Warningf("Failed to scan image, repository: %s, tag: %s, error: %v", repository, tag, err)
 				}
 			}
 		}
@@ -20,8 +20,13 @@ import (
 
 	"github.com/astaxie/beego"
 	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/goharbor/harbor/src/pkg/metrics"
 )
 
+// tokensIssued counts the tokens issued for each service
+var tokensIssued = metrics.NewCounterVec(metrics.SubsystemCore, "tokens_issued_total",
+	"The total number of the tokens issued by the token service", "service")
+
 // Handler handles request on /service/token, which is the auth provider for registry.
 type Handler struct {
 	beego.Controller
@@ -48,6 +53,7 @@ func (h *Handler) Get() {
 		log.Errorf("Unexpected error when creating the token, error: %v", err)
 		h.CustomAbort(http.StatusInternalServerError, "")
 	}
+	tokensIssued.WithLabelValues(service).Inc()
 	h.Data["json"] = token
 	h.ServeJSON()
 
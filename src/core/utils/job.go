@@ -44,7 +44,8 @@ func GetJobServiceClient() job.Client {
 }
 
 // TriggerImageScan triggers an image scan job on jobservice.
-func TriggerImageScan(repository string, tag string) error {
+// The request ID is passed to the job to correlate the job with the request which triggers it.
+func TriggerImageScan(repository, tag, requestID string) error {
 	repoClient, err := NewRepositoryClientForUI("harbor-core", repository)
 	if err != nil {
 		return err
@@ -57,10 +58,10 @@ func TriggerImageScan(repository string, tag string) error {
 		log.Errorf("Failed to get Manifest for %s:%s", repository, tag)
 		return err
 	}
-	return triggerImageScan(repository, tag, digest, GetJobServiceClient())
+	return triggerImageScan(repository, tag, digest, requestID, GetJobServiceClient())
 }
 
-func triggerImageScan(repository, tag, digest string, client job.Client) error {
+func triggerImageScan(repository, tag, digest, requestID string, client job.Client) error {
 	id, err := dao.AddScanJob(models.ScanJob{
 		Repository: repository,
 		Digest:     digest,
@@ -74,7 +75,7 @@ func triggerImageScan(repository, tag, digest string, client job.Client) error {
 	if err != nil {
 		return err
 	}
-	data, err := buildScanJobData(id, repository, tag, digest)
+	data, err := buildScanJobData(id, repository, tag, digest, requestID)
 	if err != nil {
 		return err
 	}
@@ -89,7 +90,7 @@ func triggerImageScan(repository, tag, digest string, client job.Client) error {
 	return nil
 }
 
-func buildScanJobData(jobID int64, repository, tag, digest string) (*jobmodels.JobData, error) {
+func buildScanJobData(jobID int64, repository, tag, digest, requestID string) (*jobmodels.JobData, error) {
 	parms := job.ScanJobParms{
 		JobID:      jobID,
 		Repository: repository,
@@ -106,8 +107,9 @@ func buildScanJobData(jobID int64, repository, tag, digest string) (*jobmodels.J
 		return nil, err
 	}
 	meta := jobmodels.JobMetadata{
-		JobKind:  job.JobKindGeneric,
-		IsUnique: false,
+		JobKind:   job.JobKindGeneric,
+		IsUnique:  false,
+		RequestID: requestID,
 	}
 
 	data := &jobmodels.JobData{
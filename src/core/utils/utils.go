@@ -20,6 +20,7 @@ import (
 	"os"
 	"time"
 
+	common_http_auth "github.com/goharbor/harbor/src/common/http/modifier/auth"
 	"github.com/goharbor/harbor/src/common/utils/log"
 	"github.com/goharbor/harbor/src/common/utils/registry"
 	"github.com/goharbor/harbor/src/common/utils/registry/auth"
@@ -47,6 +48,23 @@ func NewRepositoryClientForLocal(username, repository string) (*registry.Reposit
 	return newRepositoryClient(config.LocalCoreURL(), username, repository)
 }
 
+// NewRepositoryClientForCore creates a repository client that sends the requests to core authenticated
+// by the secret of core, so only the local middlewares, e.g. the quota ones, are applied to the requests
+func NewRepositoryClientForCore(repository string) (*registry.Repository, error) {
+	// The 127.0.0.1:8080 is not reachable as we do not enable core in UT env.
+	if os.Getenv("UTTEST") == "true" {
+		return NewRepositoryClientForUI("harbor-core", repository)
+	}
+	uam := &auth.UserAgentModifier{
+		UserAgent: "harbor-registry-client",
+	}
+	transport := registry.NewTransport(http.DefaultTransport, common_http_auth.NewSecretAuthorizer(config.CoreSecret()), uam)
+	client := &http.Client{
+		Transport: transport,
+	}
+	return registry.NewRepository(repository, config.LocalCoreURL(), client)
+}
+
 func newRepositoryClient(endpoint, username, repository string) (*registry.Repository, error) {
 	uam := &auth.UserAgentModifier{
 		UserAgent: "harbor-registry-client",
@@ -32,11 +32,17 @@ import (
 	"github.com/goharbor/harbor/src/jobservice/logger"
 	"github.com/pkg/errors"
 	"strconv"
+	"time"
 )
 
 const (
 	totalHeaderKey = "Total-Count"
 	nextCursorKey  = "Next-Cursor"
+
+	// query parameter of the time window in seconds
+	paramKeyWindow = "window"
+	// default time window of the latency statistics
+	defaultLatencyWindow = time.Hour
 )
 
 // Handler defines approaches to handle the http requests.
@@ -61,6 +67,15 @@ type Handler interface {
 
 	// HandleGetJobsReq is used to handle the request of getting jobs
 	HandleGetJobsReq(w http.ResponseWriter, req *http.Request)
+
+	// HandleGetQueuesReq is used to handle the request of getting the queue status of jobs
+	HandleGetQueuesReq(w http.ResponseWriter, req *http.Request)
+
+	// HandleGetRunningJobsReq is used to handle the request of getting the running jobs
+	HandleGetRunningJobsReq(w http.ResponseWriter, req *http.Request)
+
+	// HandleGetLatencyReq is used to handle the request of getting the execution latency of jobs
+	HandleGetLatencyReq(w http.ResponseWriter, req *http.Request)
 }
 
 // DefaultHandler is the default request handler which implements the Handler interface.
@@ -152,22 +167,27 @@ func (dh *DefaultHandler) HandleJobActionReq(w http.ResponseWriter, req *http.Re
 		return
 	}
 
-	// Only support stop command now
 	cmd := job.OPCommand(jobActionReq.Action)
-	if !cmd.IsStop() {
+	switch {
+	case cmd.IsStop():
+		err = dh.controller.StopJob(jobID)
+	case cmd.IsRetry():
+		err = dh.controller.RetryJob(jobID)
+	default:
 		dh.handleError(w, req, http.StatusNotImplemented, errs.UnknownActionNameError(errors.Errorf("command: %s", jobActionReq.Action)))
 		return
 	}
 
-	// Stop job
-	if err := dh.controller.StopJob(jobID); err != nil {
+	if err != nil {
 		code := http.StatusInternalServerError
 		if errs.IsObjectNotFoundError(err) {
 			code = http.StatusNotFound
 		} else if errs.IsBadRequestError(err) {
 			code = http.StatusBadRequest
-		} else {
+		} else if cmd.IsStop() {
 			err = errs.StopJobError(err)
+		} else {
+			err = errs.RetryJobError(err)
 		}
 		dh.handleError(w, req, code, err)
 		return
@@ -268,6 +288,55 @@ func (dh *DefaultHandler) HandleGetJobsReq(w http.ResponseWriter, req *http.Requ
 	dh.handleJSONData(w, req, http.StatusOK, jobs)
 }
 
+// HandleGetQueuesReq is implementation of method defined in interface 'Handler'
+func (dh *DefaultHandler) HandleGetQueuesReq(w http.ResponseWriter, req *http.Request) {
+	queues, err := dh.controller.GetQueues()
+	if err != nil {
+		dh.handleError(w, req, http.StatusInternalServerError, errs.GetDashboardError("queues", err))
+		return
+	}
+
+	dh.handleJSONData(w, req, http.StatusOK, queues)
+}
+
+// HandleGetRunningJobsReq is implementation of method defined in interface 'Handler'
+func (dh *DefaultHandler) HandleGetRunningJobsReq(w http.ResponseWriter, req *http.Request) {
+	jobs, err := dh.controller.GetRunningJobs()
+	if err != nil {
+		dh.handleError(w, req, http.StatusInternalServerError, errs.GetDashboardError("running jobs", err))
+		return
+	}
+
+	dh.handleJSONData(w, req, http.StatusOK, jobs)
+}
+
+// HandleGetLatencyReq is implementation of method defined in interface 'Handler'
+func (dh *DefaultHandler) HandleGetLatencyReq(w http.ResponseWriter, req *http.Request) {
+	window := defaultLatencyWindow
+	if v := req.URL.Query().Get(paramKeyWindow); !utils.IsEmptyStr(v) {
+		seconds, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			dh.handleError(w, req, http.StatusBadRequest, errs.BadRequestError(errors.Errorf("invalid time window: %s", v)))
+			return
+		}
+		window = time.Duration(seconds) * time.Second
+	}
+
+	latency, err := dh.controller.GetLatency(window)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errs.IsBadRequestError(err) {
+			code = http.StatusBadRequest
+		} else {
+			err = errs.GetDashboardError("latency", err)
+		}
+		dh.handleError(w, req, code, err)
+		return
+	}
+
+	dh.handleJSONData(w, req, http.StatusOK, latency)
+}
+
 func (dh *DefaultHandler) handleJSONData(w http.ResponseWriter, req *http.Request, code int, object interface{}) {
 	data, err := json.Marshal(object)
 	if err != nil {
@@ -192,6 +192,25 @@ func (suite *APIHandlerTestSuite) TestJobActionSucceed() {
 	data, _ := json.Marshal(actionReq)
 	_, code := suite.postReq(fmt.Sprintf("%s/%s", suite.APIAddr, "jobs/fake_job_ID_not"), data)
 	assert.Equal(suite.T(), 204, code, "expected 204 no content but got %d", code)
+
+	fc1 := &fakeController{}
+	fc1.On("RetryJob", "fake_job_ID").Return(nil)
+	suite.controller = fc1
+	actionReq = createJobActionReq("retry")
+	data, _ = json.Marshal(actionReq)
+	_, code = suite.postReq(fmt.Sprintf("%s/%s", suite.APIAddr, "jobs/fake_job_ID"), data)
+	assert.Equal(suite.T(), 204, code, "expected 204 no content but got %d", code)
+}
+
+// TestJobRetryFailed ...
+func (suite *APIHandlerTestSuite) TestJobRetryFailed() {
+	fc := &fakeController{}
+	fc.On("RetryJob", "fake_job_ID").Return(errors.New("testing error"))
+	suite.controller = fc
+	actionReq := createJobActionReq("retry")
+	data, _ := json.Marshal(actionReq)
+	_, code := suite.postReq(fmt.Sprintf("%s/%s", suite.APIAddr, "jobs/fake_job_ID"), data)
+	assert.Equal(suite.T(), 500, code, "expected 500 internal server but got %d", code)
 }
 
 // TestCheckStatus ...
@@ -219,6 +238,90 @@ func (suite *APIHandlerTestSuite) TestCheckStatus() {
 	assert.Equal(suite.T(), "my-worker-pool-ID", poolStats.Pools[0].WorkerPoolID, "expected pool ID 'my-worker-pool-ID' but got %s", poolStats.Pools[0].WorkerPoolID)
 }
 
+// TestMetrics ...
+func (suite *APIHandlerTestSuite) TestMetrics() {
+	// No auth is required
+	res, err := suite.client.Get(strings.TrimSuffix(suite.APIAddr, "/api/v1") + "/metrics")
+	require.NoError(suite.T(), err, "get metrics: nil error expected but got %s", err)
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	assert.Equal(suite.T(), 200, res.StatusCode, "expected 200 ok when getting metrics but got %d", res.StatusCode)
+}
+
+// TestGetQueues ...
+func (suite *APIHandlerTestSuite) TestGetQueues() {
+	fc := &fakeController{}
+	fc.On("GetQueues").Return([]*job.QueueStats{
+		{
+			JobName: "fake_job",
+			Pending: 2,
+		},
+	}, nil)
+	suite.controller = fc
+
+	bytes, code := suite.getReq(fmt.Sprintf("%s/%s", suite.APIAddr, "dashboard/queues"))
+	require.Equal(suite.T(), 200, code, "expected 200 ok when getting queues but got %d", code)
+
+	queues := make([]*job.QueueStats, 0)
+	err := json.Unmarshal(bytes, &queues)
+	require.Nil(suite.T(), err, "no error should be occurred when unmarshal queues")
+	require.Equal(suite.T(), 1, len(queues), "expected 1 queue but got %d", len(queues))
+	assert.Equal(suite.T(), int64(2), queues[0].Pending, "expected 2 pending jobs but got %d", queues[0].Pending)
+}
+
+// TestGetRunningJobs ...
+func (suite *APIHandlerTestSuite) TestGetRunningJobs() {
+	fc := &fakeController{}
+	fc.On("GetRunningJobs").Return([]*job.RunningJob{
+		{
+			WorkerID: "fake_worker_ID",
+			JobID:    "fake_job_ID",
+			JobName:  "fake_job",
+		},
+	}, nil)
+	suite.controller = fc
+
+	bytes, code := suite.getReq(fmt.Sprintf("%s/%s", suite.APIAddr, "dashboard/running"))
+	require.Equal(suite.T(), 200, code, "expected 200 ok when getting running jobs but got %d", code)
+
+	jobs := make([]*job.RunningJob, 0)
+	err := json.Unmarshal(bytes, &jobs)
+	require.Nil(suite.T(), err, "no error should be occurred when unmarshal running jobs")
+	require.Equal(suite.T(), 1, len(jobs), "expected 1 running job but got %d", len(jobs))
+	assert.Equal(suite.T(), "fake_worker_ID", jobs[0].WorkerID, "expected worker ID 'fake_worker_ID' but got %s", jobs[0].WorkerID)
+}
+
+// TestGetLatency ...
+func (suite *APIHandlerTestSuite) TestGetLatency() {
+	fc := &fakeController{}
+	fc.On("GetLatency", 10*time.Minute).Return([]*job.LatencyStats{
+		{
+			JobName: "fake_job",
+			Count:   10,
+			P50:     100,
+			P95:     900,
+		},
+	}, nil)
+	fc.On("GetLatency", time.Hour).Return([]*job.LatencyStats{}, nil)
+	suite.controller = fc
+
+	bytes, code := suite.getReq(fmt.Sprintf("%s/%s", suite.APIAddr, "dashboard/latency?window=600"))
+	require.Equal(suite.T(), 200, code, "expected 200 ok when getting latency but got %d", code)
+
+	latency := make([]*job.LatencyStats, 0)
+	err := json.Unmarshal(bytes, &latency)
+	require.Nil(suite.T(), err, "no error should be occurred when unmarshal latency")
+	require.Equal(suite.T(), 1, len(latency), "expected latency of 1 job but got %d", len(latency))
+	assert.Equal(suite.T(), int64(900), latency[0].P95, "expected p95 900 but got %d", latency[0].P95)
+
+	_, code = suite.getReq(fmt.Sprintf("%s/%s", suite.APIAddr, "dashboard/latency"))
+	assert.Equal(suite.T(), 200, code, "expected 200 ok when getting latency with default window but got %d", code)
+
+	_, code = suite.getReq(fmt.Sprintf("%s/%s", suite.APIAddr, "dashboard/latency?window=abc"))
+	assert.Equal(suite.T(), 400, code, "expected 400 bad request when getting latency with invalid window but got %d", code)
+}
+
 // TestGetJobLogInvalidID ...
 func (suite *APIHandlerTestSuite) TestGetJobLogInvalidID() {
 	fc := &fakeController{}
@@ -410,6 +513,18 @@ func (suite *APIHandlerTestSuite) GetJobs(query *query.Parameter) ([]*job.Stats,
 	return suite.controller.GetJobs(query)
 }
 
+func (suite *APIHandlerTestSuite) GetQueues() ([]*job.QueueStats, error) {
+	return suite.controller.GetQueues()
+}
+
+func (suite *APIHandlerTestSuite) GetRunningJobs() ([]*job.RunningJob, error) {
+	return suite.controller.GetRunningJobs()
+}
+
+func (suite *APIHandlerTestSuite) GetLatency(window time.Duration) ([]*job.LatencyStats, error) {
+	return suite.controller.GetLatency(window)
+}
+
 type fakeController struct {
 	mock.Mock
 }
@@ -478,6 +593,33 @@ func (fc *fakeController) GetJobs(query *query.Parameter) ([]*job.Stats, int64,
 	return args.Get(0).([]*job.Stats), args.Get(1).(int64), nil
 }
 
+func (fc *fakeController) GetQueues() ([]*job.QueueStats, error) {
+	args := fc.Called()
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*job.QueueStats), nil
+}
+
+func (fc *fakeController) GetRunningJobs() ([]*job.RunningJob, error) {
+	args := fc.Called()
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*job.RunningJob), nil
+}
+
+func (fc *fakeController) GetLatency(window time.Duration) ([]*job.LatencyStats, error) {
+	args := fc.Called(window)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*job.LatencyStats), nil
+}
+
 func createJobStats(name, kind, cron string) *job.Stats {
 	now := time.Now()
 	params := make(job.Parameters)
@@ -21,6 +21,7 @@ import (
 
 	"github.com/goharbor/harbor/src/jobservice/errs"
 	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/pkg/metrics"
 	"github.com/gorilla/mux"
 )
 
@@ -29,6 +30,8 @@ const (
 	apiVersion = "v1"
 )
 
+var httpMetrics = metrics.NewHTTPMetrics(metrics.SubsystemJobService)
+
 // Router defines the related routes for the job service and directs the request
 // to the right handler method.
 type Router interface {
@@ -65,8 +68,9 @@ func NewBaseRouter(handler Handler, authenticator Authenticator) Router {
 // ServeHTTP is the implementation of Router interface.
 func (br *BaseRouter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	// No auth required for /stats as it is a health check endpoint
+	// and /metrics which is scraped by the monitoring system
 	// Do auth for other services
-	if req.URL.String() != fmt.Sprintf("%s/%s/stats", baseRoute, apiVersion) {
+	if req.URL.String() != fmt.Sprintf("%s/%s/stats", baseRoute, apiVersion) && req.URL.Path != metrics.Path {
 		if err := br.authenticator.DoAuth(req); err != nil {
 			authErr := errs.UnauthorizedError(err)
 			if authErr == nil {
@@ -85,6 +89,9 @@ func (br *BaseRouter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 
 // registerRoutes adds routes to the server mux.
 func (br *BaseRouter) registerRoutes() {
+	br.router.Use(httpMetrics.Middleware)
+	br.router.Handle(metrics.Path, metrics.Handler()).Methods(http.MethodGet)
+
 	subRouter := br.router.PathPrefix(fmt.Sprintf("%s/%s", baseRoute, apiVersion)).Subrouter()
 
 	subRouter.HandleFunc("/jobs", br.handler.HandleLaunchJobReq).Methods(http.MethodPost)
@@ -94,4 +101,7 @@ func (br *BaseRouter) registerRoutes() {
 	subRouter.HandleFunc("/jobs/{job_id}/log", br.handler.HandleJobLogReq).Methods(http.MethodGet)
 	subRouter.HandleFunc("/stats", br.handler.HandleCheckStatusReq).Methods(http.MethodGet)
 	subRouter.HandleFunc("/jobs/{job_id}/executions", br.handler.HandlePeriodicExecutions).Methods(http.MethodGet)
+	subRouter.HandleFunc("/dashboard/queues", br.handler.HandleGetQueuesReq).Methods(http.MethodGet)
+	subRouter.HandleFunc("/dashboard/running", br.handler.HandleGetRunningJobsReq).Methods(http.MethodGet)
+	subRouter.HandleFunc("/dashboard/latency", br.handler.HandleGetLatencyReq).Methods(http.MethodGet)
 }
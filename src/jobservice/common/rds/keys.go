@@ -32,6 +32,26 @@ func RedisKeyScheduled(namespace string) string {
 	return RedisNamespacePrefix(namespace) + "scheduled"
 }
 
+// RedisKeyJobs returns key of the queue of the specified job.
+func RedisKeyJobs(namespace, jobName string) string {
+	return RedisNamespacePrefix(namespace) + "jobs:" + jobName
+}
+
+// RedisKeyJobsLock returns key of the count of the running jobs with the specified name.
+func RedisKeyJobsLock(namespace, jobName string) string {
+	return RedisKeyJobs(namespace, jobName) + ":lock"
+}
+
+// RedisKeyKnownJobs returns key of the set of the registered job names.
+func RedisKeyKnownJobs(namespace string) string {
+	return RedisNamespacePrefix(namespace) + "known_jobs"
+}
+
+// RedisKeyRetry returns key of the retry queue.
+func RedisKeyRetry(namespace string) string {
+	return RedisNamespacePrefix(namespace) + "retry"
+}
+
 // RedisKeyLastPeriodicEnqueue returns key of timestamp if last periodic enqueue.
 func RedisKeyLastPeriodicEnqueue(namespace string) string {
 	return RedisNamespacePrefix(namespace) + "last_periodic_enqueue_h"
@@ -86,3 +106,8 @@ func KeyHookEventRetryQueue(namespace string) string {
 func KeyStatusUpdateRetryQueue(namespace string) string {
 	return fmt.Sprintf("%s%s", KeyNamespacePrefix(namespace), "status_change_events")
 }
+
+// KeyJobLatency returns the key of the execution latency samples of the specified job
+func KeyJobLatency(namespace string, jobName string) string {
+	return fmt.Sprintf("%s%s:%s", KeyNamespacePrefix(namespace), "job_latency", jobName)
+}
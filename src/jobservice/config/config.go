@@ -38,6 +38,7 @@ const (
 	jobServiceRedisURL                   = "JOB_SERVICE_POOL_REDIS_URL"
 	jobServiceRedisNamespace             = "JOB_SERVICE_POOL_REDIS_NAMESPACE"
 	jobServiceRedisIdleConnTimeoutSecond = "JOB_SERVICE_POOL_REDIS_CONN_IDLE_TIMEOUT_SECOND"
+	jobServicePGPollIntervalSecond       = "JOB_SERVICE_POOL_POSTGRESQL_POLL_INTERVAL_SECOND"
 	jobServiceAuthSecret                 = "JOBSERVICE_SECRET"
 	coreURL                              = "CORE_URL"
 
@@ -48,12 +49,18 @@ const (
 
 	// JobServicePoolBackendRedis represents redis backend
 	JobServicePoolBackendRedis = "redis"
+	// JobServicePoolBackendPostgreSQL represents PostgreSQL backend,
+	// the database is the one connected by the job context
+	JobServicePoolBackendPostgreSQL = "postgresql"
 
 	// secret of UI
 	uiAuthSecret = "CORE_SECRET"
 
 	// redis protocol schema
 	redisSchema = "redis://"
+
+	// the max priority of job supported by the worker pool
+	maxJobPriority = 10000
 )
 
 // DefaultConfig is the default configuration reference
@@ -96,12 +103,30 @@ type RedisPoolConfig struct {
 	IdleTimeoutSecond int64 `yaml:"idle_timeout_second"`
 }
 
+// PostgreSQLPoolConfig keeps the settings of the worker pool based on PostgreSQL.
+type PostgreSQLPoolConfig struct {
+	// The interval the idle workers poll the queued jobs, 2 seconds is used if it's not set
+	PollIntervalSecond int64 `yaml:"poll_interval_second"`
+}
+
 // PoolConfig keeps worker worker configurations.
 type PoolConfig struct {
 	// Worker concurrency
-	WorkerCount  uint             `yaml:"workers"`
-	Backend      string           `yaml:"backend"`
-	RedisPoolCfg *RedisPoolConfig `yaml:"redis_pool,omitempty"`
+	WorkerCount       uint                  `yaml:"workers"`
+	Backend           string                `yaml:"backend"`
+	RedisPoolCfg      *RedisPoolConfig      `yaml:"redis_pool,omitempty"`
+	PostgreSQLPoolCfg *PostgreSQLPoolConfig `yaml:"postgresql_pool,omitempty"`
+	// Options of the jobs, key is the job name
+	JobOptions map[string]*JobOptions `yaml:"job_options,omitempty"`
+}
+
+// JobOptions keeps the priority and concurrency limit of one kind of job.
+type JobOptions struct {
+	// Priority is from 1 to 10000, the job with higher priority is more likely to be
+	// picked up by the idle workers. 1 is used if it's not set.
+	Priority uint `yaml:"priority"`
+	// The max count of the jobs of this kind running at the same time, 0 means no limit
+	MaxConcurrency uint `yaml:"max_concurrency"`
 }
 
 // CustomizedSettings keeps the customized settings of logger
@@ -268,6 +293,21 @@ func (c *Configuration) loadEnvs() {
 		}
 	}
 
+	if c.PoolConfig != nil && c.PoolConfig.Backend == JobServicePoolBackendPostgreSQL {
+		pi := utils.ReadEnv(jobServicePGPollIntervalSecond)
+		if !utils.IsEmptyStr(pi) {
+			if c.PoolConfig.PostgreSQLPoolCfg == nil {
+				c.PoolConfig.PostgreSQLPoolCfg = &PostgreSQLPoolConfig{}
+			}
+			v, err := strconv.Atoi(pi)
+			if err != nil {
+				log.Warningf("Invalid poll interval second: %s, will use the default one instead", pi)
+			} else {
+				c.PoolConfig.PostgreSQLPoolCfg.PollIntervalSecond = int64(v)
+			}
+		}
+	}
+
 }
 
 // Check if the configurations are valid settings.
@@ -301,7 +341,8 @@ func (c *Configuration) validate() error {
 		return errors.New("no worker worker is configured")
 	}
 
-	if c.PoolConfig.Backend != JobServicePoolBackendRedis {
+	if c.PoolConfig.Backend != JobServicePoolBackendRedis &&
+		c.PoolConfig.Backend != JobServicePoolBackendPostgreSQL {
 		return fmt.Errorf("worker worker backend %s does not support", c.PoolConfig.Backend)
 	}
 
@@ -327,6 +368,22 @@ func (c *Configuration) validate() error {
 		}
 	}
 
+	// When backend is PostgreSQL
+	if c.PoolConfig.Backend == JobServicePoolBackendPostgreSQL && c.PoolConfig.PostgreSQLPoolCfg != nil {
+		if c.PoolConfig.PostgreSQLPoolCfg.PollIntervalSecond < 0 {
+			return fmt.Errorf("poll interval of PostgreSQL worker should not be negative, but current is %d", c.PoolConfig.PostgreSQLPoolCfg.PollIntervalSecond)
+		}
+	}
+
+	for name, opts := range c.PoolConfig.JobOptions {
+		if opts == nil {
+			return fmt.Errorf("options of job %s are empty", name)
+		}
+		if opts.Priority > maxJobPriority {
+			return fmt.Errorf("priority of job %s should be less or equal %d, but current is %d", name, maxJobPriority, opts.Priority)
+		}
+	}
+
 	// Job service loggers
 	if len(c.LoggerConfigs) == 0 {
 		return errors.New("missing logger config of job service")
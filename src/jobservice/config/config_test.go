@@ -96,6 +96,12 @@ func (suite *ConfigurationTestSuite) TestDefaultConfig() {
 	redisURL := DefaultConfig.PoolConfig.RedisPoolCfg.RedisURL
 	assert.Equal(suite.T(), "redis://localhost:6379", redisURL, "expect redisURL '%s' but got '%s'", "redis://localhost:6379", redisURL)
 
+	jobOptions := DefaultConfig.PoolConfig.JobOptions
+	require.Equal(suite.T(), 2, len(jobOptions), "expect 2 job options configured but got %d", len(jobOptions))
+	assert.Equal(suite.T(), uint(1000), jobOptions["WEBHOOK"].Priority, "expect priority of WEBHOOK to be 1000 but got %d", jobOptions["WEBHOOK"].Priority)
+	assert.Equal(suite.T(), uint(0), jobOptions["WEBHOOK"].MaxConcurrency, "expect no concurrency limit of WEBHOOK but got %d", jobOptions["WEBHOOK"].MaxConcurrency)
+	assert.Equal(suite.T(), uint(5), jobOptions["IMAGE_SCAN"].MaxConcurrency, "expect max concurrency of IMAGE_SCAN to be 5 but got %d", jobOptions["IMAGE_SCAN"].MaxConcurrency)
+
 	jLoggerCount := len(DefaultConfig.JobLoggerConfigs)
 	assert.Equal(suite.T(), 2, jLoggerCount, "expect 2 job loggers configured but got %d", jLoggerCount)
 
@@ -125,6 +131,33 @@ func (suite *ConfigurationTestSuite) TestDefaultConfig() {
 	)
 }
 
+// TestInvalidJobPriority ...
+func (suite *ConfigurationTestSuite) TestInvalidJobPriority() {
+	cfg := &Configuration{}
+	err := cfg.Load("../config_test.yml", false)
+	require.Nil(suite.T(), err, "load config from yaml file, expect nil error but got error '%s'", err)
+
+	cfg.PoolConfig.JobOptions["WEBHOOK"].Priority = 10001
+	assert.NotNil(suite.T(), cfg.validate(), "expect non nil error when the job priority is out of range")
+}
+
+// TestPostgreSQLBackend ...
+func (suite *ConfigurationTestSuite) TestPostgreSQLBackend() {
+	cfg := &Configuration{}
+	err := cfg.Load("../config_test.yml", false)
+	require.Nil(suite.T(), err, "load config from yaml file, expect nil error but got error '%s'", err)
+
+	cfg.PoolConfig.Backend = JobServicePoolBackendPostgreSQL
+	cfg.PoolConfig.RedisPoolCfg = nil
+	assert.Nil(suite.T(), cfg.validate(), "expect nil error when the backend is postgresql without redis configured")
+
+	cfg.PoolConfig.PostgreSQLPoolCfg = &PostgreSQLPoolConfig{PollIntervalSecond: -1}
+	assert.NotNil(suite.T(), cfg.validate(), "expect non nil error when the poll interval is negative")
+
+	cfg.PoolConfig.Backend = "mysql"
+	assert.NotNil(suite.T(), cfg.validate(), "expect non nil error when the backend is not supported")
+}
+
 func setENV() error {
 	err := os.Setenv("JOB_SERVICE_PROTOCOL", "https")
 	err = os.Setenv("JOB_SERVICE_PORT", "8989")
@@ -16,6 +16,7 @@ package core
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/robfig/cron"
@@ -29,6 +30,9 @@ import (
 	"github.com/goharbor/harbor/src/jobservice/worker"
 )
 
+// The max time window of the latency statistics, it's limited by the expire time of the samples
+const maxLatencyWindow = 24 * time.Hour
+
 // basicController implement the core interface and provides related job handle methods.
 // basicController will coordinate the lower components to complete the process as a commander role.
 type basicController struct {
@@ -92,6 +96,8 @@ func (bc *basicController) LaunchJob(req *job.Request) (res *job.Stats, err erro
 
 	// Save job stats
 	if err == nil {
+		// Keep the ID of the request which triggers the job for correlating the logs
+		res.Info.RequestID = req.Job.Metadata.RequestID
 		if err := bc.manager.SaveJob(res); err != nil {
 			return nil, err
 		}
@@ -214,3 +220,22 @@ func validJobReq(req *job.Request) error {
 
 	return nil
 }
+
+// GetQueues is implementation of same method in core interface.
+func (bc *basicController) GetQueues() ([]*job.QueueStats, error) {
+	return bc.manager.GetQueues()
+}
+
+// GetRunningJobs is implementation of same method in core interface.
+func (bc *basicController) GetRunningJobs() ([]*job.RunningJob, error) {
+	return bc.manager.GetRunningJobs()
+}
+
+// GetLatency is implementation of same method in core interface.
+func (bc *basicController) GetLatency(window time.Duration) ([]*job.LatencyStats, error) {
+	if window <= 0 || window > maxLatencyWindow {
+		return nil, errs.BadRequestError(errors.Errorf("time window should be in (0, %s]", maxLatencyWindow))
+	}
+
+	return bc.manager.GetLatency(window)
+}
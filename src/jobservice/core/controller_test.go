@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"testing"
+	"time"
 )
 
 // ControllerTestSuite tests functions of core controller
@@ -187,6 +188,23 @@ func (suite *ControllerTestSuite) TestGetScheduledJobs() {
 	assert.Equal(suite.T(), int64(1), total, "expected 1 item but got 0")
 }
 
+// TestGetLatency tests GetLatency
+func (suite *ControllerTestSuite) TestGetLatency() {
+	fakeMgr := &fakeManager{}
+	fakeMgr.On("GetLatency", time.Hour).Return([]*job.LatencyStats{{JobName: job.SampleJob}}, nil)
+	suite.manager = fakeMgr
+
+	latency, err := suite.ctl.GetLatency(time.Hour)
+	require.Nil(suite.T(), err, "get latency: nil error expected but got %s", err)
+	assert.Equal(suite.T(), 1, len(latency), "expected latency of 1 job but got %d", len(latency))
+
+	_, err = suite.ctl.GetLatency(0)
+	assert.NotNil(suite.T(), err, "zero time window: error expected but got nil")
+
+	_, err = suite.ctl.GetLatency(25 * time.Hour)
+	assert.NotNil(suite.T(), err, "too large time window: error expected but got nil")
+}
+
 // TestGetPeriodicExecutions tests GetPeriodicExecutions
 func (suite *ControllerTestSuite) TestGetPeriodicExecutions() {
 	q := &query.Parameter{
@@ -286,6 +304,18 @@ func (suite *ControllerTestSuite) SaveJob(j *job.Stats) error {
 	return suite.manager.SaveJob(j)
 }
 
+func (suite *ControllerTestSuite) GetQueues() ([]*job.QueueStats, error) {
+	return suite.manager.GetQueues()
+}
+
+func (suite *ControllerTestSuite) GetRunningJobs() ([]*job.RunningJob, error) {
+	return suite.manager.GetRunningJobs()
+}
+
+func (suite *ControllerTestSuite) GetLatency(window time.Duration) ([]*job.LatencyStats, error) {
+	return suite.manager.GetLatency(window)
+}
+
 // fake worker
 type fakeWorker struct {
 	mock.Mock
@@ -401,3 +431,30 @@ func (fm *fakeManager) SaveJob(j *job.Stats) error {
 	args := fm.Called(j)
 	return args.Error(0)
 }
+
+func (fm *fakeManager) GetQueues() ([]*job.QueueStats, error) {
+	args := fm.Called()
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*job.QueueStats), nil
+}
+
+func (fm *fakeManager) GetRunningJobs() ([]*job.RunningJob, error) {
+	args := fm.Called()
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*job.RunningJob), nil
+}
+
+func (fm *fakeManager) GetLatency(window time.Duration) ([]*job.LatencyStats, error) {
+	args := fm.Called(window)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*job.LatencyStats), nil
+}
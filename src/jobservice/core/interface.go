@@ -16,6 +16,8 @@
 package core
 
 import (
+	"time"
+
 	"github.com/goharbor/harbor/src/jobservice/common/query"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/worker"
@@ -75,4 +77,13 @@ type Interface interface {
 	// For other cases, query the jobs with cursor, not standard pagination. The int64 is next cursor.
 	// The total number is also returned.
 	GetJobs(query *query.Parameter) ([]*job.Stats, int64, error)
+
+	// Get the queue status of all kinds of jobs.
+	GetQueues() ([]*job.QueueStats, error)
+
+	// Get the jobs being executed by the workers.
+	GetRunningJobs() ([]*job.RunningJob, error)
+
+	// Get the p50/p95 execution latency of all kinds of jobs finished in the time window before now.
+	GetLatency(window time.Duration) ([]*job.LatencyStats, error)
 }
@@ -56,6 +56,8 @@ const (
 	GetPeriodicExecutionErrorCode
 	// StatusMismatchErrorCode is code for the error of mismatching status
 	StatusMismatchErrorCode
+	// GetDashboardErrorCode is code for the error of getting dashboard data
+	GetDashboardErrorCode
 )
 
 // baseError ...
@@ -152,6 +154,11 @@ func GetPeriodicExecutionError(err error) error {
 	return New(GetPeriodicExecutionErrorCode, "failed to get periodic executions", err.Error())
 }
 
+// GetDashboardError is error for the case of getting dashboard data failed
+func GetDashboardError(item string, err error) error {
+	return New(GetDashboardErrorCode, fmt.Sprintf("failed to get %s of dashboard", item), err.Error())
+}
+
 // objectNotFound is designed for the case of no object found
 type objectNotFoundError struct {
 	baseError
@@ -92,31 +92,41 @@ func (e *Event) Deserialize(bytes []byte) error {
 
 // Basic agent for usage
 type basicAgent struct {
-	context   context.Context
-	namespace string
-	client    Client
-	ctl       lcm.Controller
-	events    chan *Event
-	tokens    chan bool
-	redisPool *redis.Pool
-	wg        *sync.WaitGroup
+	context context.Context
+	client  Client
+	ctl     lcm.Controller
+	events  chan *Event
+	tokens  chan bool
+	retries retryQueue
+	wg      *sync.WaitGroup
 }
 
 // NewAgent is constructor of basic agent
 func NewAgent(ctx *env.Context, ns string, redisPool *redis.Pool) Agent {
+	return newBasicAgent(ctx, &redisRetryQueue{
+		namespace: ns,
+		pool:      redisPool,
+	})
+}
+
+// NewPGAgent is constructor of basic agent which keeps the events for retrying in PostgreSQL
+func NewPGAgent(ctx *env.Context) Agent {
+	return newBasicAgent(ctx, &pgRetryQueue{})
+}
+
+func newBasicAgent(ctx *env.Context, retries retryQueue) *basicAgent {
 	tks := make(chan bool, maxHandlers)
 	// Put tokens
 	for i := 0; i < maxHandlers; i++ {
 		tks <- true
 	}
 	return &basicAgent{
-		context:   ctx.SystemContext,
-		namespace: ns,
-		client:    NewClient(ctx.SystemContext),
-		events:    make(chan *Event, maxEventChanBuffer),
-		tokens:    tks,
-		redisPool: redisPool,
-		wg:        ctx.WG,
+		context: ctx.SystemContext,
+		client:  NewClient(ctx.SystemContext),
+		events:  make(chan *Event, maxEventChanBuffer),
+		tokens:  tks,
+		retries: retries,
+		wg:      ctx.WG,
 	}
 }
 
@@ -222,24 +232,7 @@ func (ba *basicAgent) pushForRetry(evt *Event) error {
 		return nil
 	}
 
-	conn := ba.redisPool.Get()
-	defer func() {
-		_ = conn.Close()
-	}()
-
-	key := rds.KeyHookEventRetryQueue(ba.namespace)
-	args := make([]interface{}, 0)
-
-	// Use nano time to get more accurate timestamp
-	score := time.Now().UnixNano()
-	args = append(args, key, "NX", score, rawJSON)
-
-	_, err = conn.Do("ZADD", args...)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return ba.retries.push(rawJSON)
 }
 
 func (ba *basicAgent) loopRetry() {
@@ -303,22 +296,11 @@ func (ba *basicAgent) reSend() error {
 }
 
 func (ba *basicAgent) popMinOne() (*Event, error) {
-	conn := ba.redisPool.Get()
-	defer func() {
-		_ = conn.Close()
-	}()
-
-	key := rds.KeyHookEventRetryQueue(ba.namespace)
-	minOne, err := rds.ZPopMin(conn, key)
+	rawEvent, err := ba.retries.popMin()
 	if err != nil {
 		return nil, err
 	}
 
-	rawEvent, ok := minOne.([]byte)
-	if !ok {
-		return nil, errors.New("bad request: non bytes slice for raw event")
-	}
-
 	evt := &Event{}
 	if err := evt.Deserialize(rawEvent); err != nil {
 		return nil, err
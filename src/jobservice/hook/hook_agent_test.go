@@ -149,12 +149,14 @@ func (suite *HookAgentTestSuite) TestRetryAndPopMin() {
 	}
 
 	agent := &basicAgent{
-		context:   ctx,
-		namespace: suite.namespace,
-		client:    NewClient(ctx),
-		events:    make(chan *Event, maxEventChanBuffer),
-		tokens:    tks,
-		redisPool: suite.pool,
+		context: ctx,
+		client:  NewClient(ctx),
+		events:  make(chan *Event, maxEventChanBuffer),
+		tokens:  tks,
+		retries: &redisRetryQueue{
+			namespace: suite.namespace,
+			pool:      suite.pool,
+		},
 	}
 	agent.Attach(suite.lcmCtl)
 
@@ -0,0 +1,104 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hook
+
+import (
+	"time"
+
+	"github.com/astaxie/beego/orm"
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/jobservice/common/rds"
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+)
+
+// retryQueue keeps the hook events failed to send for retrying later
+type retryQueue interface {
+	// Push the raw event to the queue
+	push(rawEvent []byte) error
+	// Pop the earliest event from the queue,
+	// rds.ErrNoElements is returned if the queue is empty
+	popMin() ([]byte, error)
+}
+
+// redisRetryQueue keeps the events in the sorted set of redis
+type redisRetryQueue struct {
+	namespace string
+	pool      *redis.Pool
+}
+
+func (rq *redisRetryQueue) push(rawEvent []byte) error {
+	conn := rq.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	key := rds.KeyHookEventRetryQueue(rq.namespace)
+	args := make([]interface{}, 0)
+
+	// Use nano time to get more accurate timestamp
+	score := time.Now().UnixNano()
+	args = append(args, key, "NX", score, rawEvent)
+
+	_, err := conn.Do("ZADD", args...)
+
+	return err
+}
+
+func (rq *redisRetryQueue) popMin() ([]byte, error) {
+	conn := rq.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	key := rds.KeyHookEventRetryQueue(rq.namespace)
+	minOne, err := rds.ZPopMin(conn, key)
+	if err != nil {
+		return nil, err
+	}
+
+	rawEvent, ok := minOne.([]byte)
+	if !ok {
+		return nil, errors.New("bad request: non bytes slice for raw event")
+	}
+
+	return rawEvent, nil
+}
+
+// pgRetryQueue keeps the events in the job_hook_retry table of PostgreSQL
+type pgRetryQueue struct{}
+
+func (pq *pgRetryQueue) push(rawEvent []byte) error {
+	_, err := dao.GetOrmer().Raw("INSERT INTO job_hook_retry (event, create_time) VALUES (?, ?)",
+		string(rawEvent), time.Now().UnixNano()).Exec()
+
+	return err
+}
+
+func (pq *pgRetryQueue) popMin() ([]byte, error) {
+	var rawEvent string
+	// Skip the locked one to avoid sending the same event by multiple nodes
+	err := dao.GetOrmer().Raw(`DELETE FROM job_hook_retry WHERE id = (
+		SELECT id FROM job_hook_retry ORDER BY create_time, id LIMIT 1 FOR UPDATE SKIP LOCKED)
+		RETURNING event`).QueryRow(&rawEvent)
+	if err != nil {
+		if err == orm.ErrNoRows {
+			return nil, rds.ErrNoElements
+		}
+		return nil, err
+	}
+
+	return []byte(rawEvent), nil
+}
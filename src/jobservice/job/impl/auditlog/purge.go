@@ -0,0 +1,87 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/jobservice/job"
+)
+
+// Purge deletes the audit logs older than the retention period in the configurations
+type Purge struct{}
+
+// MaxFails implements the interface in job/Interface
+func (p *Purge) MaxFails() uint {
+	return 1
+}
+
+// ShouldRetry implements the interface in job/Interface
+func (p *Purge) ShouldRetry() bool {
+	return false
+}
+
+// Validate implements the interface in job/Interface
+func (p *Purge) Validate(params job.Parameters) error {
+	if len(params) > 0 {
+		return fmt.Errorf("the parms should be empty for audit log purge job")
+	}
+	return nil
+}
+
+// Run implements the interface in job/Interface
+func (p *Purge) Run(ctx job.Context, params job.Parameters) error {
+	logger := ctx.GetLogger()
+
+	days, err := retentionDays(ctx)
+	if err != nil {
+		logger.Errorf("Failed to get the retention period of audit logs, error: %v", err)
+		return err
+	}
+	if days == 0 {
+		logger.Info("The audit logs are kept forever as the retention period is 0, skip purging")
+		return nil
+	}
+
+	before := time.Now().AddDate(0, 0, -days)
+	logger.Infof("Purging the audit logs recorded before %s", before.Format(time.RFC3339))
+	count, err := dao.DeleteAuditLogsBefore(before)
+	if err != nil {
+		logger.Errorf("Failed to purge the audit logs, error: %v", err)
+		return err
+	}
+	logger.Infof("%d audit logs are purged", count)
+	return nil
+}
+
+// retentionDays returns the retention period of audit logs in days, the logs are kept forever if it's 0
+func retentionDays(ctx job.Context) (int, error) {
+	v, ok := ctx.Get(common.AuditLogRetentionDays)
+	if !ok {
+		return 0, nil
+	}
+	days, err := strconv.Atoi(fmt.Sprintf("%v", v))
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %v", common.AuditLogRetentionDays, v)
+	}
+	if days < 0 {
+		return 0, fmt.Errorf("%s should be non-negative: %d", common.AuditLogRetentionDays, days)
+	}
+	return days, nil
+}
@@ -123,12 +123,18 @@ func (c *Context) Build(tracker job.Tracker) (job.Context, error) {
 	}
 
 	// Set loggers for job
-	lg, err := createLoggers(tracker.Job().Info.JobID)
+	info := tracker.Job().Info
+	lg, err := createLoggers(info.JobID, info.RequestID)
 	if err != nil {
 		return nil, err
 	}
 	jContext.logger = lg
 
+	// Correlate the job log with the request which triggers the job
+	if len(info.RequestID) > 0 {
+		lg.Infof("Job %s is triggered by the request %s", info.JobID, info.RequestID)
+	}
+
 	return jContext, nil
 }
 
@@ -173,18 +179,20 @@ func (c *Context) Tracker() job.Tracker {
 }
 
 // create loggers based on the configurations.
-func createLoggers(jobID string) (logger.Interface, error) {
+func createLoggers(jobID, requestID string) (logger.Interface, error) {
 	// Init job loggers here
 	lOptions := make([]logger.Option, 0)
 	for _, lc := range config.DefaultConfig.JobLoggerConfigs {
 		// For running job, the depth should be 5
-		if lc.Name == logger.NameFile || lc.Name == logger.NameStdOutput || lc.Name == logger.NameDB {
+		if lc.Name == logger.NameFile || lc.Name == logger.NameStdOutput || lc.Name == logger.NameDB ||
+			lc.Name == logger.NameSyslog || lc.Name == logger.NameHTTP {
 			if lc.Settings == nil {
 				lc.Settings = map[string]interface{}{}
 			}
 			lc.Settings["depth"] = 5
 		}
-		if lc.Name == logger.NameFile || lc.Name == logger.NameDB {
+		if lc.Name == logger.NameFile || lc.Name == logger.NameDB || lc.Name == logger.NameSyslog ||
+			lc.Name == logger.NameHTTP || lc.Name == logger.NameStdOutput {
 			// Need extra param
 			fSettings := map[string]interface{}{}
 			for k, v := range lc.Settings {
@@ -195,9 +203,13 @@ func createLoggers(jobID string) (logger.Interface, error) {
 				// Append file name param
 				fSettings["filename"] = fmt.Sprintf("%s.log", jobID)
 				lOptions = append(lOptions, logger.BackendOption(lc.Name, lc.Level, fSettings))
-			} else { // DB Logger
-				// Append DB key
+			} else { // DB, syslog, HTTP and std output Logger
+				// Append the job ID as key
 				fSettings["key"] = jobID
+				// Attach the request ID to the logs if the backend supports it
+				if len(requestID) > 0 && (lc.Name == logger.NameHTTP || lc.Name == logger.NameStdOutput) {
+					fSettings["request_id"] = requestID
+				}
 				lOptions = append(lOptions, logger.BackendOption(lc.Name, lc.Level, fSettings))
 			}
 		} else {
@@ -16,6 +16,7 @@ package gc
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"time"
 
@@ -23,10 +24,12 @@ import (
 	"github.com/goharbor/harbor/src/common"
 	"github.com/goharbor/harbor/src/common/config"
 	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/common/http/modifier/auth"
 	common_quota "github.com/goharbor/harbor/src/common/quota"
 	"github.com/goharbor/harbor/src/common/registryctl"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/pkg/clients/core"
 	"github.com/goharbor/harbor/src/pkg/types"
 	"github.com/goharbor/harbor/src/registryctl/client"
 	"strconv"
@@ -38,6 +41,20 @@ const (
 	dialWriteTimeout      = 10 * time.Second
 	blobPrefix            = "blobs::*"
 	repoPrefix            = "repository::*"
+
+	// the parameters of GC job
+	paramRedisURL    = "redis_url_reg"
+	paramNonBlocking = "non_blocking"
+	paramTimeWindow  = "time_window"
+	paramDryRun      = "dry_run"
+	// the parameters of deleting the untagged manifests before GC
+	paramDeleteUntagged    = "delete_untagged"
+	paramUntaggedThreshold = "untagged_threshold"
+
+	// defaultTimeWindow is the default hours during which the unreferenced blobs are kept by the non-blocking GC
+	defaultTimeWindow = 2
+	// defaultUntaggedThreshold is the default hours during which the untagged manifests are kept
+	defaultUntaggedThreshold = 24
 )
 
 // GarbageCollector is the struct to run registry's garbage collection
@@ -47,6 +64,12 @@ type GarbageCollector struct {
 	cfgMgr            *config.CfgManager
 	CoreURL           string
 	redisURL          string
+	nonBlocking       bool
+	timeWindow        int64
+	dryRun            bool
+	deleteUntagged    bool
+	untaggedThreshold int64
+	coreClient        core.ImageClient
 }
 
 // MaxFails implements the interface in job/Interface
@@ -61,6 +84,20 @@ func (gc *GarbageCollector) ShouldRetry() bool {
 
 // Validate implements the interface in job/Interface
 func (gc *GarbageCollector) Validate(params job.Parameters) error {
+	for _, name := range []string{paramNonBlocking, paramDryRun, paramDeleteUntagged} {
+		if v, ok := params[name]; ok {
+			if _, ok := v.(bool); !ok {
+				return fmt.Errorf("invalid parameter %s: %v", name, v)
+			}
+		}
+	}
+	for _, name := range []string{paramTimeWindow, paramUntaggedThreshold} {
+		if v, ok := params[name]; ok {
+			if h, err := parseHours(v); err != nil || h < 0 {
+				return fmt.Errorf("invalid parameter %s: %v", name, v)
+			}
+		}
+	}
 	return nil
 }
 
@@ -69,6 +106,19 @@ func (gc *GarbageCollector) Run(ctx job.Context, params job.Parameters) error {
 	if err := gc.init(ctx, params); err != nil {
 		return err
 	}
+	start := time.Now()
+	if gc.deleteUntagged {
+		// the blobs of the deleted manifests can be collected in this run
+		if err := gc.cleanUntagged(); err != nil {
+			return err
+		}
+	}
+	if gc.dryRun {
+		return gc.runDryRun(ctx, start)
+	}
+	if gc.nonBlocking {
+		return gc.runNonBlocking(ctx, start)
+	}
 	readOnlyCur, err := gc.getReadOnly()
 	if err != nil {
 		return err
@@ -83,6 +133,11 @@ func (gc *GarbageCollector) Run(ctx job.Context, params job.Parameters) error {
 		gc.logger.Errorf("failed to start gc as registry controller is unreachable: %v", err)
 		return err
 	}
+	// the registry can't be interrupted once the gc is started, so check the stop command before it
+	if cmd, ok := ctx.OPCommand(); ok && cmd == job.StopCommand {
+		gc.logger.Info("gc job is stopped before the registry gc is started.")
+		return nil
+	}
 	gc.logger.Infof("start to run gc in job.")
 	gcr, err := gc.registryCtlClient.StartGC()
 	if err != nil {
@@ -92,6 +147,10 @@ func (gc *GarbageCollector) Run(ctx job.Context, params job.Parameters) error {
 	if err := gc.cleanCache(); err != nil {
 		return err
 	}
+	// the report is built before the quota ensured as the blobs deleted are removed from projects by then
+	if err := gc.saveRegistryReport(ctx, gcr.Msg, start); err != nil {
+		gc.logger.Warningf("failed to get the blobs deleted by registry: %v", err)
+	}
 	if err := gc.ensureQuota(); err != nil {
 		gc.logger.Warningf("failed to align quota data in gc job, with error: %v", err)
 	}
@@ -114,10 +173,55 @@ func (gc *GarbageCollector) init(ctx job.Context, params job.Parameters) error {
 	secret := os.Getenv("JOBSERVICE_SECRET")
 	configURL := gc.CoreURL + common.CoreConfigPath
 	gc.cfgMgr = config.NewRESTCfgManager(configURL, secret)
-	gc.redisURL = params["redis_url_reg"].(string)
+	gc.coreClient = core.New(gc.CoreURL, http.DefaultClient, auth.NewSecretAuthorizer(secret))
+	gc.redisURL = params[paramRedisURL].(string)
+
+	gc.nonBlocking = false
+	if v, ok := params[paramNonBlocking]; ok {
+		gc.nonBlocking, _ = v.(bool)
+	}
+	gc.dryRun = false
+	if v, ok := params[paramDryRun]; ok {
+		gc.dryRun, _ = v.(bool)
+	}
+	gc.timeWindow = defaultTimeWindow
+	if v, ok := params[paramTimeWindow]; ok {
+		w, err := parseHours(v)
+		if err != nil {
+			return err
+		}
+		gc.timeWindow = w
+	}
+	gc.deleteUntagged = false
+	if v, ok := params[paramDeleteUntagged]; ok {
+		gc.deleteUntagged, _ = v.(bool)
+	}
+	gc.untaggedThreshold = defaultUntaggedThreshold
+	if v, ok := params[paramUntaggedThreshold]; ok {
+		t, err := parseHours(v)
+		if err != nil {
+			return err
+		}
+		gc.untaggedThreshold = t
+	}
 	return nil
 }
 
+// parseHours parses the hours from the job parameter,
+// the numbers in the parameters are decoded as float64 from the JSON
+func parseHours(v interface{}) (int64, error) {
+	switch w := v.(type) {
+	case float64:
+		return int64(w), nil
+	case int64:
+		return w, nil
+	case int:
+		return int64(w), nil
+	default:
+		return 0, fmt.Errorf("unexpected type %T of the hours", v)
+	}
+}
+
 func (gc *GarbageCollector) getReadOnly() (bool, error) {
 
 	if err := gc.cfgMgr.Load(); err != nil {
@@ -137,14 +241,7 @@ func (gc *GarbageCollector) setReadOnly(switcher bool) error {
 // cleanCache is to clean the registry cache for GC.
 // To do this is because the issue https://github.com/docker/distribution/issues/2094
 func (gc *GarbageCollector) cleanCache() error {
-
-	con, err := redis.DialURL(
-		gc.redisURL,
-		redis.DialConnectTimeout(dialConnectionTimeout),
-		redis.DialReadTimeout(dialReadTimeout),
-		redis.DialWriteTimeout(dialWriteTimeout),
-	)
-
+	con, err := gc.dialRedis()
 	if err != nil {
 		gc.logger.Errorf("failed to connect to redis %v", err)
 		return err
@@ -170,6 +267,15 @@ func (gc *GarbageCollector) cleanCache() error {
 	return nil
 }
 
+func (gc *GarbageCollector) dialRedis() (redis.Conn, error) {
+	return redis.DialURL(
+		gc.redisURL,
+		redis.DialConnectTimeout(dialConnectionTimeout),
+		redis.DialReadTimeout(dialReadTimeout),
+		redis.DialWriteTimeout(dialWriteTimeout),
+	)
+}
+
 func delKeys(con redis.Conn, pattern string) error {
 	iter := 0
 	keys := make([]string, 0)
@@ -0,0 +1,35 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gc
+
+import (
+	"testing"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	gc := &GarbageCollector{}
+
+	assert.Nil(t, gc.Validate(job.Parameters{paramRedisURL: "redis://localhost:6379"}))
+	assert.Nil(t, gc.Validate(job.Parameters{paramNonBlocking: true, paramTimeWindow: float64(2)}))
+	assert.NotNil(t, gc.Validate(job.Parameters{paramNonBlocking: "true"}))
+	assert.NotNil(t, gc.Validate(job.Parameters{paramTimeWindow: "2"}))
+	assert.NotNil(t, gc.Validate(job.Parameters{paramTimeWindow: float64(-1)}))
+	assert.Nil(t, gc.Validate(job.Parameters{paramDeleteUntagged: true, paramUntaggedThreshold: float64(24)}))
+	assert.NotNil(t, gc.Validate(job.Parameters{paramDeleteUntagged: 1}))
+	assert.NotNil(t, gc.Validate(job.Parameters{paramUntaggedThreshold: float64(-1)}))
+}
@@ -0,0 +1,177 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/jobservice/job"
+)
+
+// runNonBlocking deletes the blobs which are not referenced by any artifact without setting the system to read-only.
+// The unreferenced blobs are marked as deletion candidates first, then they are swept one by one,
+// the blobs used by the pushing in progress are reset by the core and skipped in the sweeping.
+func (gc *GarbageCollector) runNonBlocking(ctx job.Context, start time.Time) error {
+	if err := gc.registryCtlClient.Health(); err != nil {
+		gc.logger.Errorf("failed to start gc as registry controller is unreachable: %v", err)
+		return err
+	}
+	gc.logger.Infof("start to run non-blocking gc in job, the blobs unreferenced in the last %d hours are kept.", gc.timeWindow)
+
+	candidates, err := gc.mark()
+	if err != nil {
+		gc.logger.Errorf("failed to mark the unreferenced blobs: %v", err)
+		return err
+	}
+	gc.logger.Infof("%d blobs are marked as deletion candidates.", len(candidates))
+
+	// the relationships between the blobs and projects are removed in the sweeping,
+	// so collect them for the report before sweeping
+	projectBlobs, err := getProjectBlobs(candidates)
+	if err != nil {
+		gc.logger.Errorf("failed to get the projects of the unreferenced blobs: %v", err)
+		return err
+	}
+
+	con, err := gc.dialRedis()
+	if err != nil {
+		gc.logger.Errorf("failed to connect to redis %v", err)
+		return err
+	}
+	defer con.Close()
+
+	var deleted []*models.Blob
+	var failed int
+	for _, blob := range candidates {
+		if cmd, ok := ctx.OPCommand(); ok && cmd == job.StopCommand {
+			gc.logger.Infof("gc job is stopped, %d blobs left.", len(candidates)-len(deleted)-failed)
+			return nil
+		}
+
+		ok, err := gc.sweep(con, blob)
+		if err != nil {
+			failed++
+			gc.logger.Errorf("failed to delete blob %s: %v", blob.Digest, err)
+			continue
+		}
+		if ok {
+			deleted = append(deleted, blob)
+		}
+	}
+
+	if err := gc.ensureQuota(); err != nil {
+		gc.logger.Warningf("failed to align quota data in gc job, with error: %v", err)
+	}
+	gc.logger.Infof("GC results: %d blobs deleted, %d blobs failed to be deleted.", len(deleted), failed)
+	gc.saveReport(ctx, deleted, projectBlobs, start)
+	gc.logger.Infof("success to run non-blocking gc in job.")
+	return nil
+}
+
+// mark marks the blobs not referenced by any artifact and not touched in the time window as deletion candidates
+func (gc *GarbageCollector) mark() ([]*models.Blob, error) {
+	before := time.Now().Add(-time.Duration(gc.timeWindow) * time.Hour)
+	blobs, err := dao.ListUnreferencedBlobs(before)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []*models.Blob
+	for _, blob := range blobs {
+		blob.Status = models.BlobStatusDelete
+		n, err := dao.UpdateBlobStatus(blob)
+		if err != nil {
+			gc.logger.Warningf("failed to mark blob %s as deletion candidate: %v, just skip it.", blob.Digest, err)
+			continue
+		}
+		if n == 0 {
+			gc.logger.Debugf("blob %s is updated by others, just skip it.", blob.Digest)
+			continue
+		}
+		candidates = append(candidates, blob)
+	}
+
+	return candidates, nil
+}
+
+// sweep deletes the blob from the registry storage, the registry cache and the database,
+// returns false when the blob is skipped as it's used by the pushing after marked
+func (gc *GarbageCollector) sweep(con redis.Conn, blob *models.Blob) (bool, error) {
+	blob.Status = models.BlobStatusDeleting
+	n, err := dao.UpdateBlobStatus(blob)
+	if err != nil {
+		return false, err
+	}
+	if n == 0 {
+		gc.logger.Debugf("blob %s is used after marked, just skip it.", blob.Digest)
+		return false, nil
+	}
+
+	referenced, err := dao.IsBlobReferenced(blob.Digest)
+	if err != nil {
+		return false, gc.markDeleteFailed(blob, err)
+	}
+	if referenced {
+		gc.logger.Debugf("blob %s is referenced after marked, just skip it.", blob.Digest)
+		blob.Status = models.BlobStatusNone
+		_, err := dao.UpdateBlobStatus(blob)
+		return false, err
+	}
+
+	// the foreign layers are not stored in the registry
+	if !blob.IsForeignLayer() {
+		if err := gc.registryCtlClient.DeleteBlob(blob.Digest); err != nil {
+			return false, gc.markDeleteFailed(blob, err)
+		}
+	}
+
+	if err := cleanBlobCache(con, blob.Digest); err != nil {
+		return false, gc.markDeleteFailed(blob, err)
+	}
+
+	if err := dao.DeleteArtifactAndBlobByDigest(blob.Digest); err != nil {
+		return false, gc.markDeleteFailed(blob, err)
+	}
+	if err := dao.RemoveBlobFromAllProjects(blob.ID); err != nil {
+		return false, gc.markDeleteFailed(blob, err)
+	}
+	if err := dao.DeleteBlob(blob.Digest); err != nil {
+		return false, gc.markDeleteFailed(blob, err)
+	}
+
+	gc.logger.Debugf("blob %s deleted.", blob.Digest)
+	return true, nil
+}
+
+func (gc *GarbageCollector) markDeleteFailed(blob *models.Blob, err error) error {
+	blob.Status = models.BlobStatusDeleteFailed
+	if _, e := dao.UpdateBlobStatus(blob); e != nil {
+		gc.logger.Errorf("failed to mark blob %s as delete failed: %v", blob.Digest, e)
+	}
+	return err
+}
+
+// cleanBlobCache cleans the descriptors of the blob cached by registry,
+// otherwise registry will treat the deleted blob as existing
+func cleanBlobCache(con redis.Conn, digest string) error {
+	if _, err := con.Do("DEL", fmt.Sprintf("blobs::%s", digest)); err != nil {
+		return fmt.Errorf("failed to clean registry cache %v", err)
+	}
+	return delKeys(con, fmt.Sprintf("repository::*::blobs::%s", digest))
+}
@@ -0,0 +1,234 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gc
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/jobservice/job"
+)
+
+const (
+	// the max count of the digests in one query
+	queryBatchSize = 500
+)
+
+// the blobs deleted are printed by the garbage-collect command of registry like:
+// blob eligible for deletion: sha256:1a6fd470b9ce10849be79e99529a88371dff60c60aab424c077007f6979b4812
+var eligibleBlobRe = regexp.MustCompile(`blob eligible for deletion: ([a-z0-9]+:[a-f0-9]+)`)
+
+// buildReport builds the report from the blobs deleted by GC or the ones would be deleted in the dry run,
+// the projectBlobs are the relationships between the blobs and projects before the GC
+func buildReport(blobs []*models.Blob, projectBlobs []*models.ProjectBlob, dryRun bool) *models.GCReport {
+	report := &models.GCReport{
+		DryRun:   dryRun,
+		Projects: []*models.GCProjectSummary{},
+	}
+
+	mp := map[int64]*models.Blob{}
+	for _, blob := range blobs {
+		if blob.IsManifest() {
+			report.ManifestsDeleted++
+		} else {
+			report.BlobsDeleted++
+		}
+		report.BytesFreed += blob.Size
+
+		if dryRun {
+			report.Candidates = append(report.Candidates, &models.GCCandidate{
+				Digest:      blob.Digest,
+				ContentType: blob.ContentType,
+				Size:        blob.Size,
+				Manifest:    blob.IsManifest(),
+			})
+		}
+
+		if blob.ID != 0 {
+			mp[blob.ID] = blob
+		}
+	}
+
+	summaries := map[int64]*models.GCProjectSummary{}
+	for _, pb := range projectBlobs {
+		blob, ok := mp[pb.BlobID]
+		if !ok {
+			continue
+		}
+
+		summary, ok := summaries[pb.ProjectID]
+		if !ok {
+			summary = &models.GCProjectSummary{ProjectID: pb.ProjectID}
+			if project, err := dao.GetProjectByID(pb.ProjectID); err == nil && project != nil {
+				summary.ProjectName = project.Name
+			}
+			summaries[pb.ProjectID] = summary
+			report.Projects = append(report.Projects, summary)
+		}
+
+		if blob.IsManifest() {
+			summary.ManifestsDeleted++
+		} else {
+			summary.BlobsDeleted++
+		}
+		summary.BytesFreed += blob.Size
+	}
+
+	return report
+}
+
+// getProjectBlobs returns the relationships between the blobs and projects
+func getProjectBlobs(blobs []*models.Blob) ([]*models.ProjectBlob, error) {
+	var blobIDs []int64
+	for _, blob := range blobs {
+		if blob.ID != 0 {
+			blobIDs = append(blobIDs, blob.ID)
+		}
+	}
+
+	var projectBlobs []*models.ProjectBlob
+	for start := 0; start < len(blobIDs); start += queryBatchSize {
+		end := start + queryBatchSize
+		if end > len(blobIDs) {
+			end = len(blobIDs)
+		}
+
+		pbs, err := dao.GetProjectBlobsByBlobIDs(blobIDs[start:end]...)
+		if err != nil {
+			return nil, err
+		}
+		projectBlobs = append(projectBlobs, pbs...)
+	}
+
+	return projectBlobs, nil
+}
+
+// getBlobsDeletedByRegistry returns the blobs deleted by the garbage-collect command of registry,
+// the ones not recorded in database are returned with the digest only
+func getBlobsDeletedByRegistry(output string) ([]*models.Blob, error) {
+	var digests []string
+	set := map[string]bool{}
+	for _, match := range eligibleBlobRe.FindAllStringSubmatch(output, -1) {
+		if set[match[1]] {
+			continue
+		}
+		set[match[1]] = true
+		digests = append(digests, match[1])
+	}
+
+	var blobs []*models.Blob
+	found := map[string]bool{}
+	for start := 0; start < len(digests); start += queryBatchSize {
+		end := start + queryBatchSize
+		if end > len(digests) {
+			end = len(digests)
+		}
+
+		bs, err := dao.ListBlobs(&models.BlobQuery{Digests: digests[start:end]})
+		if err != nil {
+			return nil, err
+		}
+		for _, blob := range bs {
+			found[blob.Digest] = true
+			blobs = append(blobs, blob)
+		}
+	}
+
+	for _, digest := range digests {
+		if !found[digest] {
+			blobs = append(blobs, &models.Blob{Digest: digest})
+		}
+	}
+
+	return blobs, nil
+}
+
+// saveReport builds and saves the report of GC, the failure is logged only as it doesn't impact the GC
+func (gc *GarbageCollector) saveReport(ctx job.Context, blobs []*models.Blob, projectBlobs []*models.ProjectBlob, start time.Time) {
+	report := buildReport(blobs, projectBlobs, gc.dryRun)
+
+	report.NonBlocking = gc.nonBlocking
+	report.StartTime = start
+	report.EndTime = time.Now()
+	report.Duration = int64(report.EndTime.Sub(start).Seconds())
+	if tracker := ctx.Tracker(); tracker != nil && tracker.Job() != nil {
+		report.JobUUID = tracker.Job().Info.JobID
+		report.UpstreamJobUUID = tracker.Job().Info.UpstreamJobID
+	}
+
+	if _, err := dao.AddGCReport(report); err != nil {
+		gc.logger.Warningf("failed to save the report of gc: %v", err)
+		return
+	}
+
+	gc.logger.Infof("GC report: %d blobs and %d manifests, %d bytes in total.", report.BlobsDeleted, report.ManifestsDeleted, report.BytesFreed)
+	for _, summary := range report.Projects {
+		gc.logger.Infof("project %s(%d): %d blobs and %d manifests, %d bytes.",
+			summary.ProjectName, summary.ProjectID, summary.BlobsDeleted, summary.ManifestsDeleted, summary.BytesFreed)
+	}
+}
+
+// runDryRun reports the blobs and manifests would be deleted by GC without deleting them,
+// only the blobs recorded in database are estimated
+func (gc *GarbageCollector) runDryRun(ctx job.Context, start time.Time) error {
+	gc.logger.Infof("start to run gc in dry run mode.")
+
+	before := start
+	if gc.nonBlocking {
+		before = start.Add(-time.Duration(gc.timeWindow) * time.Hour)
+	}
+	blobs, err := dao.ListUnreferencedBlobs(before)
+	if err != nil {
+		gc.logger.Errorf("failed to list the unreferenced blobs: %v", err)
+		return err
+	}
+	projectBlobs, err := getProjectBlobs(blobs)
+	if err != nil {
+		gc.logger.Errorf("failed to get the projects of the unreferenced blobs: %v", err)
+		return err
+	}
+
+	for _, blob := range blobs {
+		gc.logger.Infof("%s eligible for deletion: %s, size: %d", blobKind(blob), blob.Digest, blob.Size)
+	}
+	gc.saveReport(ctx, blobs, projectBlobs, start)
+
+	gc.logger.Infof("success to run gc in dry run mode.")
+	return nil
+}
+
+func blobKind(blob *models.Blob) string {
+	if blob.IsManifest() {
+		return "manifest"
+	}
+	return "blob"
+}
+
+// saveRegistryReport saves the report of the blobs deleted by the garbage-collect command of registry
+func (gc *GarbageCollector) saveRegistryReport(ctx job.Context, output string, start time.Time) error {
+	blobs, err := getBlobsDeletedByRegistry(output)
+	if err != nil {
+		return err
+	}
+	projectBlobs, err := getProjectBlobs(blobs)
+	if err != nil {
+		return err
+	}
+
+	gc.saveReport(ctx, blobs, projectBlobs, start)
+	return nil
+}
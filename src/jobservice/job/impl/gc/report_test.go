@@ -0,0 +1,55 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gc
+
+import (
+	"testing"
+
+	"github.com/docker/distribution/manifest/schema2"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildReport(t *testing.T) {
+	blobs := []*models.Blob{
+		{Digest: "sha256:1", ContentType: schema2.MediaTypeManifest, Size: 1},
+		{Digest: "sha256:2", ContentType: schema2.MediaTypeLayer, Size: 10},
+		{Digest: "sha256:3", ContentType: schema2.MediaTypeImageConfig, Size: 100},
+	}
+
+	report := buildReport(blobs, nil, true)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, int64(1), report.ManifestsDeleted)
+	assert.Equal(t, int64(2), report.BlobsDeleted)
+	assert.Equal(t, int64(111), report.BytesFreed)
+	assert.Len(t, report.Candidates, 3)
+	assert.True(t, report.Candidates[0].Manifest)
+	assert.Len(t, report.Projects, 0)
+
+	report = buildReport(blobs, nil, false)
+	assert.Len(t, report.Candidates, 0)
+}
+
+func TestEligibleBlobRe(t *testing.T) {
+	output := `library/hello-world
+library/hello-world: marking manifest sha256:92c7f9c92844bbbb5d0a101b22f7c2a7949e40f8ea90c8b3bc396879d95e899a
+blob eligible for deletion: sha256:1a6fd470b9ce10849be79e99529a88371dff60c60aab424c077007f6979b4812
+blob eligible for deletion: sha256:4ab4c602aa5eed5528a6620ff18a1dc4faef0e1ab3a5eddeddb410714478c67f
+`
+	matches := eligibleBlobRe.FindAllStringSubmatch(output, -1)
+	if assert.Len(t, matches, 2) {
+		assert.Equal(t, "sha256:1a6fd470b9ce10849be79e99529a88371dff60c60aab424c077007f6979b4812", matches[0][1])
+	}
+}
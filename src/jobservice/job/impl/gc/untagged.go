@@ -0,0 +1,115 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gc
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/dao"
+	commonhttp "github.com/goharbor/harbor/src/common/http"
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/utils"
+)
+
+// getUntaggedManifests returns the manifests which are not tagged and not referenced by any
+// manifest list before the time, and the repositories which the manifests are pushed to
+func getUntaggedManifests(before time.Time) ([]*models.Blob, map[string][]string, error) {
+	blobs, err := dao.ListUnreferencedBlobs(before)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	manifests := []*models.Blob{}
+	digests := []string{}
+	for _, blob := range blobs {
+		if blob.IsManifest() {
+			manifests = append(manifests, blob)
+			digests = append(digests, blob.Digest)
+		}
+	}
+
+	repositories := map[string][]string{}
+	for i := 0; i < len(digests); i += queryBatchSize {
+		end := i + queryBatchSize
+		if end > len(digests) {
+			end = len(digests)
+		}
+		rms, err := dao.GetRepositoriesOfManifests(digests[i:end]...)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, rm := range rms {
+			repositories[rm.Digest] = append(repositories[rm.Digest], rm.RepositoryName)
+		}
+	}
+
+	return manifests, repositories, nil
+}
+
+// cleanUntagged deletes the untagged manifests older than the threshold from the repositories they are
+// pushed to. The deletion is done via the API of core which keeps the manifests that are signed or
+// protected by the immutable tag rules. The blobs of the deleted manifests are collected by the following
+// garbage collection. The manifests whose repositories are unknown, e.g. the ones untagged before the
+// repositories of manifests are recorded, are skipped
+func (gc *GarbageCollector) cleanUntagged() error {
+	before := time.Now().Add(-time.Duration(gc.untaggedThreshold) * time.Hour)
+	manifests, repositories, err := getUntaggedManifests(before)
+	if err != nil {
+		gc.logger.Errorf("failed to get the untagged manifests: %v", err)
+		return err
+	}
+	gc.logger.Infof("%d untagged manifests not touched since %s found", len(manifests), before.Format(time.RFC3339))
+	if gc.dryRun {
+		for _, manifest := range manifests {
+			gc.logger.Infof("untagged manifest eligible for deletion: %s", manifest.Digest)
+		}
+		return nil
+	}
+
+	deleted, kept, skipped := 0, 0, 0
+	for _, manifest := range manifests {
+		repos := repositories[manifest.Digest]
+		if len(repos) == 0 {
+			gc.logger.Debugf("the repository of untagged manifest %s is unknown, skip it", manifest.Digest)
+			skipped++
+			continue
+		}
+		for _, repo := range repos {
+			project, repository := utils.ParseRepository(repo)
+			err := gc.coreClient.DeleteManifest(project, repository, manifest.Digest)
+			if err == nil {
+				gc.logger.Infof("untagged manifest %s@%s deleted", repo, manifest.Digest)
+				deleted++
+				continue
+			}
+			if e, ok := err.(*commonhttp.Error); ok {
+				switch e.Code {
+				case http.StatusNotFound:
+					// the manifest has been deleted from this repository
+					continue
+				case http.StatusPreconditionFailed:
+					gc.logger.Infof("untagged manifest %s@%s kept: %s", repo, manifest.Digest, e.Message)
+					kept++
+					continue
+				}
+			}
+			gc.logger.Errorf("failed to delete the untagged manifest %s@%s: %v", repo, manifest.Digest, err)
+		}
+	}
+	gc.logger.Infof("%d untagged manifests deleted, %d kept, %d skipped", deleted, kept, skipped)
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"os"
+	"strconv"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/common/utils/email"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+)
+
+// Max retry of email job
+const maxEmailFails = "JOBSERVICE_EMAIL_JOB_MAX_RETRY"
+
+// EmailJob implements the job interface, which send notification by email.
+// The SMTP settings are read from the configurations of Harbor.
+type EmailJob struct {
+	logger logger.Interface
+}
+
+// MaxFails returns that how many times this job can fail, get this value from ctx.
+func (ej *EmailJob) MaxFails() uint {
+	if maxFails, exist := os.LookupEnv(maxEmailFails); exist {
+		result, err := strconv.ParseUint(maxFails, 10, 32)
+		// Unable to log error message because the logger isn't initialized when calling this function.
+		if err == nil {
+			return uint(result)
+		}
+	}
+
+	// Same as the webhook job, the max retry interval is around 3h
+	return 10
+}
+
+// ShouldRetry ...
+func (ej *EmailJob) ShouldRetry() bool {
+	return true
+}
+
+// Validate implements the interface in job/Interface
+func (ej *EmailJob) Validate(params job.Parameters) error {
+	if params == nil {
+		return errors.New("missing parameters of email job")
+	}
+	to, ok := params["to"].(string)
+	if !ok || len(to) == 0 {
+		return errors.New("missing recipients of email job")
+	}
+	if _, err := mail.ParseAddressList(to); err != nil {
+		return fmt.Errorf("invalid recipients %s: %v", to, err)
+	}
+	if _, ok := params["subject"].(string); !ok {
+		return errors.New("missing subject of email job")
+	}
+	if _, ok := params["message"].(string); !ok {
+		return errors.New("missing message of email job")
+	}
+	return nil
+}
+
+// Run implements the interface in job/Interface
+func (ej *EmailJob) Run(ctx job.Context, params job.Parameters) error {
+	ej.logger = ctx.GetLogger()
+
+	addresses, err := mail.ParseAddressList(params["to"].(string))
+	if err != nil {
+		return err
+	}
+	var to []string
+	for _, address := range addresses {
+		to = append(to, address.Address)
+	}
+
+	addr := net.JoinHostPort(getString(ctx, common.EmailHost), getString(ctx, common.EmailPort))
+	if err = email.Send(addr,
+		getString(ctx, common.EmailIdentity),
+		getString(ctx, common.EmailUsername),
+		getString(ctx, common.EmailPassword),
+		60,
+		getBool(ctx, common.EmailSSL),
+		getBool(ctx, common.EmailInsecure),
+		getString(ctx, common.EmailFrom),
+		to,
+		params["subject"].(string),
+		params["message"].(string)); err != nil {
+		return fmt.Errorf("failed to send email to %v via %s: %v", to, addr, err)
+	}
+
+	if ej.logger != nil {
+		ej.logger.Infof("email sent to %v", to)
+	}
+	return nil
+}
+
+// getString returns the configuration as string, empty string is returned if it isn't set
+func getString(ctx job.Context, key string) string {
+	if v, ok := ctx.Get(key); ok && v != nil {
+		return fmt.Sprint(v)
+	}
+	return ""
+}
+
+// getBool returns the configuration as bool, false is returned if it isn't set or invalid
+func getBool(ctx job.Context, key string) bool {
+	b, _ := strconv.ParseBool(getString(ctx, key))
+	return b
+}
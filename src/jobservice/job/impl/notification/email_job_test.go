@@ -0,0 +1,187 @@
+package notification
+
+import (
+	"context"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/goharbor/harbor/src/common"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer is a local SMTP stand-in which supports neither TLS nor AUTH
+type fakeSMTPServer struct {
+	listener   net.Listener
+	from       string
+	recipients []string
+	data       string
+	done       chan struct{}
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	s := &fakeSMTPServer{
+		listener: l,
+		done:     make(chan struct{}),
+	}
+	go s.serve()
+	return s
+}
+
+func (s *fakeSMTPServer) serve() {
+	defer close(s.done)
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	tp := textproto.NewConn(conn)
+	_ = tp.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			_ = tp.PrintfLine("250 localhost")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			s.from = line[len("MAIL FROM:"):]
+			_ = tp.PrintfLine("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			s.recipients = append(s.recipients, line[len("RCPT TO:"):])
+			_ = tp.PrintfLine("250 OK")
+		case cmd == "DATA":
+			_ = tp.PrintfLine("354 Go ahead")
+			lines, err := tp.ReadDotLines()
+			if err != nil {
+				return
+			}
+			s.data = strings.Join(lines, "\n")
+			_ = tp.PrintfLine("250 OK")
+		case cmd == "QUIT":
+			_ = tp.PrintfLine("221 Bye")
+			return
+		default:
+			_ = tp.PrintfLine("502 Command not implemented")
+		}
+	}
+}
+
+func (s *fakeSMTPServer) port() string {
+	_, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	return port
+}
+
+func (s *fakeSMTPServer) close() {
+	_ = s.listener.Close()
+}
+
+type fakeContext struct {
+	properties map[string]interface{}
+	checkIns   []string
+}
+
+func (f *fakeContext) Build(tracker job.Tracker) (job.Context, error) {
+	return f, nil
+}
+func (f *fakeContext) Get(prop string) (interface{}, bool) {
+	v, ok := f.properties[prop]
+	return v, ok
+}
+func (f *fakeContext) SystemContext() context.Context {
+	return context.TODO()
+}
+func (f *fakeContext) Checkin(status string) error {
+	f.checkIns = append(f.checkIns, status)
+	return nil
+}
+func (f *fakeContext) OPCommand() (job.OPCommand, bool) {
+	return "", false
+}
+func (f *fakeContext) GetLogger() logger.Interface {
+	return nil
+}
+func (f *fakeContext) Tracker() job.Tracker {
+	return nil
+}
+
+func TestEmailJobMaxFails(t *testing.T) {
+	ej := &EmailJob{}
+	assert.Equal(t, uint(10), ej.MaxFails())
+	assert.True(t, ej.ShouldRetry())
+}
+
+func TestEmailJobValidate(t *testing.T) {
+	ej := &EmailJob{}
+	assert.NotNil(t, ej.Validate(nil))
+	assert.NotNil(t, ej.Validate(map[string]interface{}{
+		"to":      "invalid address",
+		"subject": "subject",
+		"message": "message",
+	}))
+	assert.NotNil(t, ej.Validate(map[string]interface{}{
+		"to": "admin@example.com",
+	}))
+	assert.Nil(t, ej.Validate(map[string]interface{}{
+		"to":      "admin@example.com, dev@example.com",
+		"subject": "subject",
+		"message": "message",
+	}))
+}
+
+func TestEmailJobRun(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	defer server.close()
+
+	ctx := &fakeContext{
+		properties: map[string]interface{}{
+			common.EmailHost: "127.0.0.1",
+			common.EmailPort: server.port(),
+			common.EmailFrom: "harbor@example.com",
+			common.EmailSSL:  false,
+		},
+	}
+	params := map[string]interface{}{
+		"to":      "Admin <admin@example.com>, dev@example.com",
+		"subject": "[Harbor] pushImage on library/hello-world",
+		"message": "<p>pushed</p>",
+	}
+	ej := &EmailJob{}
+	require.Nil(t, ej.Run(ctx, params))
+	<-server.done
+
+	assert.Equal(t, "<harbor@example.com>", server.from)
+	assert.Equal(t, []string{"<admin@example.com>", "<dev@example.com>"}, server.recipients)
+	assert.True(t, strings.Contains(server.data, "Subject: [Harbor] pushImage on library/hello-world"))
+	assert.True(t, strings.Contains(server.data, "<p>pushed</p>"))
+}
+
+func TestEmailJobRunWithUnavailableServer(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	port := server.port()
+	server.close()
+	<-server.done
+
+	ctx := &fakeContext{
+		properties: map[string]interface{}{
+			common.EmailHost: "127.0.0.1",
+			common.EmailPort: port,
+		},
+	}
+	params := map[string]interface{}{
+		"to":      "admin@example.com",
+		"subject": "subject",
+		"message": "message",
+	}
+	ej := &EmailJob{}
+	assert.NotNil(t, ej.Run(ctx, params))
+}
@@ -2,17 +2,36 @@ package notification
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
-	commonhttp "github.com/goharbor/harbor/src/common/http"
-	"github.com/goharbor/harbor/src/jobservice/job"
-	"github.com/goharbor/harbor/src/jobservice/logger"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"strconv"
+	"time"
+
+	commonhttp "github.com/goharbor/harbor/src/common/http"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger"
 )
 
-// Max retry has the same meaning as max fails.
-const maxFails = "JOBSERVICE_WEBHOOK_JOB_MAX_RETRY"
+const (
+	// Max retry has the same meaning as max fails.
+	maxFails = "JOBSERVICE_WEBHOOK_JOB_MAX_RETRY"
+	// the max length of response body recorded in the delivery
+	maxResponseExcerpt = 1024
+	signatureHeader    = "X-Harbor-Signature"
+)
+
+// delivery is checked in to core for each attempt of sending the webhook
+type delivery struct {
+	Address      string `json:"address"`
+	StatusCode   int    `json:"status_code"`
+	ResponseBody string `json:"response_body"`
+	Latency      int64  `json:"latency"`
+	Error        string `json:"error,omitempty"`
+}
 
 // WebhookJob implements the job interface, which send notification by http or https.
 type WebhookJob struct {
@@ -74,6 +93,12 @@ func (wj *WebhookJob) init(ctx job.Context, params map[string]interface{}) error
 
 // execute webhook job
 func (wj *WebhookJob) execute(ctx job.Context, params map[string]interface{}) error {
+	// the job may be stopped while waiting for the retry
+	if cmd, ok := ctx.OPCommand(); ok && cmd == job.StopCommand {
+		wj.logger.Info("webhook job is stopped")
+		return nil
+	}
+
 	payload := params["payload"].(string)
 	address := params["address"].(string)
 
@@ -84,16 +109,58 @@ func (wj *WebhookJob) execute(ctx job.Context, params map[string]interface{}) er
 	if v, ok := params["auth_header"]; ok && len(v.(string)) > 0 {
 		req.Header.Set("Authorization", v.(string))
 	}
-	req.Header.Set("Content-Type", "application/json")
+	contentType := "application/json"
+	if v, ok := params["content_type"]; ok && len(v.(string)) > 0 {
+		contentType = v.(string)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if v, ok := params["signature"]; ok && len(v.(string)) > 0 {
+		req.Header.Set(signatureHeader, v.(string))
+	}
 
+	d := &delivery{
+		Address: address,
+	}
+	defer wj.checkIn(d)
+
+	start := time.Now()
 	resp, err := wj.client.Do(req)
+	d.Latency = int64(time.Since(start) / time.Millisecond)
 	if err != nil {
+		d.Error = err.Error()
 		return err
 	}
 	defer resp.Body.Close()
+
+	d.StatusCode = resp.StatusCode
+	excerpt, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseExcerpt))
+	if err != nil {
+		d.Error = fmt.Sprintf("failed to read response body: %v", err)
+	}
+	d.ResponseBody = string(excerpt)
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("webhook job(target: %s) response code is %d", address, resp.StatusCode)
+		err = fmt.Errorf("webhook job(target: %s) response code is %d", address, resp.StatusCode)
+		d.Error = err.Error()
+		return err
 	}
 
 	return nil
 }
+
+// checkIn reports the delivery to core, the failure doesn't affect the result of the job
+func (wj *WebhookJob) checkIn(d *delivery) {
+	data, err := json.Marshal(d)
+	if err != nil {
+		wj.logError("failed to marshal the delivery: %v", err)
+		return
+	}
+	if err = wj.ctx.Checkin(string(data)); err != nil {
+		wj.logError("failed to check in the delivery: %v", err)
+	}
+}
+
+func (wj *WebhookJob) logError(format string, v ...interface{}) {
+	if wj.logger != nil {
+		wj.logger.Errorf(format, v...)
+	}
+}
@@ -1,13 +1,15 @@
 package notification
 
 import (
-	"github.com/goharbor/harbor/src/jobservice/job/impl"
-	"github.com/stretchr/testify/assert"
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMaxFails(t *testing.T) {
@@ -48,6 +50,10 @@ func TestRun(t *testing.T) {
 			assert.Equal(t, "auth_test", r.Header.Get("Authorization"))
 			// test request body
 			assert.Equal(t, string(body), `{"key": "value"}`)
+			// test content type and signature
+			assert.Equal(t, "application/cloudevents+json", r.Header.Get("Content-Type"))
+			assert.Equal(t, "sha256=abc", r.Header.Get("X-Harbor-Signature"))
+			_, _ = w.Write([]byte("received"))
 		}))
 	defer ts.Close()
 	params := map[string]interface{}{
@@ -55,9 +61,20 @@ func TestRun(t *testing.T) {
 		"payload":          `{"key": "value"}`,
 		"address":          ts.URL,
 		"auth_header":      "auth_test",
+		"content_type":     "application/cloudevents+json",
+		"signature":        "sha256=abc",
 	}
 	// test correct webhook response
-	assert.Nil(t, rep.Run(&impl.Context{}, params))
+	ctx := &fakeContext{}
+	assert.Nil(t, rep.Run(ctx, params))
+	// test the delivery is checked in
+	require.Equal(t, 1, len(ctx.checkIns))
+	d := &delivery{}
+	require.Nil(t, json.Unmarshal([]byte(ctx.checkIns[0]), d))
+	assert.Equal(t, ts.URL, d.Address)
+	assert.Equal(t, http.StatusOK, d.StatusCode)
+	assert.Equal(t, "received", d.ResponseBody)
+	assert.Empty(t, d.Error)
 
 	tsWrong := httptest.NewServer(
 		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -71,5 +88,11 @@ func TestRun(t *testing.T) {
 		"auth_header":      "auth_test",
 	}
 	// test incorrect webhook response
-	assert.NotNil(t, rep.Run(&impl.Context{}, paramsWrong))
+	ctx = &fakeContext{}
+	assert.NotNil(t, rep.Run(ctx, paramsWrong))
+	require.Equal(t, 1, len(ctx.checkIns))
+	d = &delivery{}
+	require.Nil(t, json.Unmarshal([]byte(ctx.checkIns[0]), d))
+	assert.Equal(t, http.StatusUnauthorized, d.StatusCode)
+	assert.NotEmpty(t, d.Error)
 }
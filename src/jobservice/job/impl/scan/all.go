@@ -74,6 +74,10 @@ func (sa *All) Run(ctx job.Context, params job.Parameters) error {
 	}
 
 	for _, r := range repos {
+		if cmd, ok := ctx.OPCommand(); ok && cmd == job.StopCommand {
+			logger.Info("Scan all job is stopped")
+			return nil
+		}
 		repoClient, err := utils.NewRepositoryClientForJobservice(r.Name, sa.registryURL, sa.secret, sa.tokenServiceEndpoint)
 		if err != nil {
 			logger.Errorf("Failed to get repo client for repo: %s, error: %v", r.Name, err)
@@ -95,7 +95,11 @@ func (cj *ClairJob) Run(ctx job.Context, params job.Parameters) error {
 	}
 	clairClient := clair.NewClient(cj.clairEndpoint, loggerImpl)
 
-	for _, l := range layers {
+	for i, l := range layers {
+		if cmd, ok := ctx.OPCommand(); ok && cmd == job.StopCommand {
+			logger.Infof("Scan job is stopped, %d layers are not scanned", len(layers)-i)
+			return nil
+		}
 		logger.Infof("Scanning Layer: %s, path: %s", l.Name, l.Path)
 		if err := clairClient.ScanLayer(l); err != nil {
 			logger.Errorf("Failed to scan layer: %s, error: %v", l.Name, err)
@@ -26,12 +26,16 @@ const (
 	ImageScanAllJob = "IMAGE_SCAN_ALL"
 	// ImageGC the name of image garbage collection job in job service
 	ImageGC = "IMAGE_GC"
+	// AuditLogPurge the name of the job which purges the expired audit logs in job service
+	AuditLogPurge = "AUDIT_LOG_PURGE"
 	// Replication : the name of the replication job in job service
 	Replication = "REPLICATION"
 	// ReplicationScheduler : the name of the replication scheduler job in job service
 	ReplicationScheduler = "IMAGE_REPLICATE"
 	// WebhookJob : the name of the webhook job in job service
 	WebhookJob = "WEBHOOK"
+	// EmailJob : the name of the email notification job in job service
+	EmailJob = "EMAIL"
 	// Retention : the name of the retention job
 	Retention = "RETENTION"
 )
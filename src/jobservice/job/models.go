@@ -15,6 +15,10 @@
 package job
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/goharbor/harbor/src/jobservice/common/utils"
 	"github.com/pkg/errors"
 )
@@ -41,6 +45,7 @@ type Metadata struct {
 	ScheduleDelay uint64 `json:"schedule_delay,omitempty"`
 	Cron          string `json:"cron_spec,omitempty"`
 	IsUnique      bool   `json:"unique"`
+	RequestID     string `json:"request_id,omitempty"` // The ID of the request which triggers the job
 }
 
 // Stats keeps the result of job launching.
@@ -68,6 +73,75 @@ type StatsInfo struct {
 	NumericPID    int64      `json:"numeric_policy_id,omitempty"` // The numeric policy ID of the periodic job
 	Parameters    Parameters `json:"parameters,omitempty"`
 	Revision      int64      `json:"revision,omitempty"` // For differentiating the each retry of the same job
+	StartTime     int64      `json:"start_time,omitempty"`
+	RequestID     string     `json:"request_id,omitempty"` // The ID of the request which triggers the job
+}
+
+// QueueStats keeps the queue status of one kind of job.
+type QueueStats struct {
+	JobName  string `json:"job_name"`
+	Pending  int64  `json:"pending"`
+	Running  int64  `json:"running"`
+	Retrying int64  `json:"retrying"`
+	Latency  int64  `json:"latency"` // Seconds the oldest pending job has been waiting
+}
+
+// RunningJob keeps the info of the job being executed by the worker.
+type RunningJob struct {
+	WorkerID  string `json:"worker_id"`
+	JobID     string `json:"job_id"`
+	JobName   string `json:"job_name"`
+	StartedAt int64  `json:"started_at"`
+}
+
+// LatencyStats keeps the execution latency of one kind of job finished in a time window.
+// The latency is in milliseconds.
+type LatencyStats struct {
+	JobName string `json:"job_name"`
+	Count   int64  `json:"count"`
+	Failed  int64  `json:"failed"`
+	Retried int64  `json:"retried"` // The count of the executions retried for the previous failures
+	P50     int64  `json:"p50"`
+	P95     int64  `json:"p95"`
+	Max     int64  `json:"max"`
+}
+
+// LatencySample is the execution latency of the finished job kept for the statistics.
+type LatencySample struct {
+	JobID    string
+	Revision int64
+	Status   Status
+	Latency  int64
+}
+
+// String formats the sample as "<job ID>:<revision>:<status>:<latency>"
+func (ls *LatencySample) String() string {
+	return fmt.Sprintf("%s:%d:%s:%d", ls.JobID, ls.Revision, ls.Status.String(), ls.Latency)
+}
+
+// ParseLatencySample parses the sample from the string formatted by LatencySample.String
+func ParseLatencySample(str string) (*LatencySample, error) {
+	parts := strings.Split(str, ":")
+	l := len(parts)
+	if l < 4 {
+		return nil, errors.Errorf("malformed latency sample: %s", str)
+	}
+
+	revision, err := strconv.ParseInt(parts[l-3], 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse revision of latency sample")
+	}
+	latency, err := strconv.ParseInt(parts[l-1], 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse latency of latency sample")
+	}
+
+	return &LatencySample{
+		JobID:    strings.Join(parts[:l-3], ":"),
+		Revision: revision,
+		Status:   Status(parts[l-2]),
+		Latency:  latency,
+	}, nil
 }
 
 // ActionRequest defines for triggering job action like stop/cancel.
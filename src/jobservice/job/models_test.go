@@ -0,0 +1,41 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLatencySample tests formatting and parsing the latency sample
+func TestLatencySample(t *testing.T) {
+	sample := &LatencySample{
+		JobID:    "fake_job_ID@1571200000",
+		Revision: 1571200001,
+		Status:   SuccessStatus,
+		Latency:  1234,
+	}
+
+	parsed, err := ParseLatencySample(sample.String())
+	require.NoError(t, err)
+	assert.Equal(t, sample, parsed)
+
+	_, err = ParseLatencySample("fake_job_ID:Success:1234")
+	assert.Error(t, err)
+	_, err = ParseLatencySample("fake_job_ID:1571200001:Success:abc")
+	assert.Error(t, err)
+}
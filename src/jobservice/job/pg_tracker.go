@@ -0,0 +1,555 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/astaxie/beego/orm"
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/errs"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/pkg/errors"
+)
+
+const (
+	// the max times of comparing and setting the status when the status is changed concurrently
+	maxCompareAndSetTimes = 3
+)
+
+// pgColumns maps the properties of the job stats to the columns of the job_queue table,
+// the properties are the same with the fields kept in redis.
+var pgColumns = map[string]string{
+	"id":                "job_id",
+	"name":              "job_name",
+	"kind":              "kind",
+	"unique":            "is_unique",
+	"status":            "status",
+	"ref_link":          "ref_link",
+	"enqueue_time":      "enqueue_time",
+	"update_time":       "update_time",
+	"run_at":            "run_at",
+	"check_in":          "check_in",
+	"check_in_at":       "check_in_at",
+	"cron_spec":         "cron_spec",
+	"web_hook_url":      "web_hook_url",
+	"die_at":            "die_at",
+	"upstream_job_id":   "upstream_job_id",
+	"numeric_policy_id": "numeric_policy_id",
+	"parameters":        "parameters",
+	"revision":          "revision",
+	"start_time":        "start_time",
+	"request_id":        "request_id",
+}
+
+// pgStats is the row of the job_queue table
+type pgStats struct {
+	JobID         string `orm:"column(job_id)"`
+	JobName       string `orm:"column(job_name)"`
+	Kind          string `orm:"column(kind)"`
+	IsUnique      bool   `orm:"column(is_unique)"`
+	Status        string `orm:"column(status)"`
+	RefLink       string `orm:"column(ref_link)"`
+	Parameters    string `orm:"column(parameters)"`
+	CronSpec      string `orm:"column(cron_spec)"`
+	WebHookURL    string `orm:"column(web_hook_url)"`
+	UpstreamJobID string `orm:"column(upstream_job_id)"`
+	NumericPID    int64  `orm:"column(numeric_policy_id)"`
+	CheckIn       string `orm:"column(check_in)"`
+	CheckInAt     int64  `orm:"column(check_in_at)"`
+	DieAt         int64  `orm:"column(die_at)"`
+	RequestID     string `orm:"column(request_id)"`
+	RunAt         int64  `orm:"column(run_at)"`
+	EnqueueTime   int64  `orm:"column(enqueue_time)"`
+	UpdateTime    int64  `orm:"column(update_time)"`
+	StartTime     int64  `orm:"column(start_time)"`
+	Revision      int64  `orm:"column(revision)"`
+}
+
+// pgTracker implements Tracker interface based on the job_queue table of PostgreSQL
+type pgTracker struct {
+	context  context.Context
+	jobID    string
+	jobStats *Stats
+	callback HookCallback
+	// the time the job is started to run by this tracker
+	startedAt time.Time
+}
+
+// NewPGTrackerWithID builds a PostgreSQL based tracker with the provided job ID
+func NewPGTrackerWithID(ctx context.Context, jobID string, callback HookCallback) Tracker {
+	return &pgTracker{
+		context:  ctx,
+		jobID:    jobID,
+		callback: callback,
+	}
+}
+
+// NewPGTrackerWithStats builds a PostgreSQL based tracker with the provided job stats
+func NewPGTrackerWithStats(ctx context.Context, stats *Stats, callback HookCallback) Tracker {
+	return &pgTracker{
+		context:  ctx,
+		jobStats: stats,
+		jobID:    stats.Info.JobID,
+		callback: callback,
+	}
+}
+
+// Load the job stats which tracked by this tracker
+func (pt *pgTracker) Load() error {
+	row := &pgStats{}
+	err := dao.GetOrmer().Raw(`SELECT job_id, job_name, kind, is_unique, status, ref_link, parameters, cron_spec,
+		web_hook_url, upstream_job_id, numeric_policy_id, check_in, check_in_at, die_at, request_id, run_at,
+		enqueue_time, update_time, start_time, revision FROM job_queue WHERE job_id = ?`, pt.jobID).QueryRow(row)
+	if err != nil {
+		if err == orm.ErrNoRows {
+			return errs.NoObjectFoundError(pt.jobID)
+		}
+		return err
+	}
+
+	info := &StatsInfo{
+		JobID:         row.JobID,
+		JobName:       row.JobName,
+		JobKind:       row.Kind,
+		IsUnique:      row.IsUnique,
+		Status:        row.Status,
+		RefLink:       row.RefLink,
+		CronSpec:      row.CronSpec,
+		WebHookURL:    row.WebHookURL,
+		UpstreamJobID: row.UpstreamJobID,
+		NumericPID:    row.NumericPID,
+		CheckIn:       row.CheckIn,
+		CheckInAt:     row.CheckInAt,
+		DieAt:         row.DieAt,
+		RequestID:     row.RequestID,
+		RunAt:         row.RunAt,
+		EnqueueTime:   row.EnqueueTime,
+		UpdateTime:    row.UpdateTime,
+		StartTime:     row.StartTime,
+		Revision:      row.Revision,
+	}
+	if len(row.Parameters) > 0 {
+		params := make(Parameters)
+		if err := json.Unmarshal([]byte(row.Parameters), &params); err == nil {
+			info.Parameters = params
+		}
+	}
+
+	pt.jobStats = &Stats{Info: info}
+
+	return nil
+}
+
+// Job returns the job stats which tracked by this tracker
+func (pt *pgTracker) Job() *Stats {
+	return pt.jobStats
+}
+
+// Update the properties of the job stats
+func (pt *pgTracker) Update(fieldAndValues ...interface{}) error {
+	if len(fieldAndValues) == 0 {
+		return errors.New("no properties specified to update")
+	}
+	if len(fieldAndValues)%2 != 0 {
+		return errors.New("properties and values are not paired")
+	}
+
+	// update timestamp
+	values := map[string]interface{}{
+		"update_time": time.Now().Unix(),
+	}
+	for i := 0; i < len(fieldAndValues); i += 2 {
+		field, ok := fieldAndValues[i].(string)
+		if !ok {
+			return errors.Errorf("invalid property: %v", fieldAndValues[i])
+		}
+		column, ok := pgColumns[field]
+		if !ok {
+			return errors.Errorf("unknown property: %s", field)
+		}
+		values[column] = fieldAndValues[i+1]
+	}
+
+	sets := make([]string, 0, len(values))
+	args := make([]interface{}, 0, len(values)+1)
+	for column, value := range values {
+		sets = append(sets, fmt.Sprintf("%s = ?", column))
+		args = append(args, value)
+	}
+	args = append(args, pt.jobID)
+
+	_, err := dao.GetOrmer().Raw(fmt.Sprintf("UPDATE job_queue SET %s WHERE job_id = ?", strings.Join(sets, ", ")), args...).Exec()
+
+	return err
+}
+
+// Status returns the current status of job tracked by this tracker
+func (pt *pgTracker) Status() (Status, error) {
+	var status string
+	if err := dao.GetOrmer().Raw("SELECT status FROM job_queue WHERE job_id = ?", pt.jobID).QueryRow(&status); err != nil {
+		if err == orm.ErrNoRows {
+			return "", errs.NoObjectFoundError(pt.jobID)
+		}
+		return "", err
+	}
+
+	st := Status(status)
+	if err := st.Validate(); err != nil {
+		return "", errors.New("malformed status data returned")
+	}
+
+	return st, nil
+}
+
+// NumericID returns the numeric ID of the periodic job
+func (pt *pgTracker) NumericID() (int64, error) {
+	if pt.jobStats.Info.NumericPID > 0 {
+		return pt.jobStats.Info.NumericPID, nil
+	}
+
+	return -1, errors.Errorf("numeric ID not found for job: %s", pt.jobID)
+}
+
+// PeriodicExecutionDone mark the execution done
+func (pt *pgTracker) PeriodicExecutionDone() error {
+	if utils.IsEmptyStr(pt.jobStats.Info.UpstreamJobID) {
+		return errors.Errorf("%s is not periodic job execution", pt.jobID)
+	}
+
+	_, err := dao.GetOrmer().Raw("UPDATE job_queue SET execution_done = true WHERE job_id = ?", pt.jobID).Exec()
+
+	return err
+}
+
+// CheckIn message
+func (pt *pgTracker) CheckIn(message string) error {
+	if utils.IsEmptyStr(message) {
+		return errors.New("check in error: empty message")
+	}
+
+	now := time.Now().Unix()
+	current := Status(pt.jobStats.Info.Status)
+
+	pt.refresh(current, message)
+	err := pt.fireHookEvent(current, message)
+	err = pt.Update(
+		"check_in", message,
+		"check_in_at", now,
+		"update_time", now,
+	)
+
+	return err
+}
+
+// Expire job stats
+func (pt *pgTracker) Expire() error {
+	return pt.expire(statDataExpireTime)
+}
+
+// Run job
+// Either one is failed, the final return will be marked as failed.
+func (pt *pgTracker) Run() error {
+	err := pt.compareAndSet(RunningStatus)
+	if !errs.IsStatusMismatchError(err) {
+		pt.refresh(RunningStatus)
+		if err == nil {
+			pt.markStarted()
+		}
+		if er := pt.fireHookEvent(RunningStatus); err == nil && er != nil {
+			return er
+		}
+	}
+
+	return err
+}
+
+// Stop job
+// Stop is final status, if failed to do, retry should be enforced.
+// Either one is failed, the final return will be marked as failed.
+func (pt *pgTracker) Stop() error {
+	err := pt.UpdateStatusWithRetry(StoppedStatus)
+	if !errs.IsStatusMismatchError(err) {
+		pt.refresh(StoppedStatus)
+		if er := pt.fireHookEvent(StoppedStatus); err == nil && er != nil {
+			return er
+		}
+	}
+
+	return err
+}
+
+// Fail job
+// Fail is final status, if failed to do, retry should be enforced.
+// Either one is failed, the final return will be marked as failed.
+func (pt *pgTracker) Fail() error {
+	err := pt.UpdateStatusWithRetry(ErrorStatus)
+	if !errs.IsStatusMismatchError(err) {
+		pt.refresh(ErrorStatus)
+		pt.recordLatency(ErrorStatus)
+		if er := pt.fireHookEvent(ErrorStatus); err == nil && er != nil {
+			return er
+		}
+	}
+
+	return err
+}
+
+// Succeed job
+// Succeed is final status, if failed to do, retry should be enforced.
+// Either one is failed, the final return will be marked as failed.
+func (pt *pgTracker) Succeed() error {
+	err := pt.UpdateStatusWithRetry(SuccessStatus)
+	if !errs.IsStatusMismatchError(err) {
+		pt.refresh(SuccessStatus)
+		pt.recordLatency(SuccessStatus)
+
+		// Expire the stat data of the successful job
+		if er := pt.expire(statDataExpireTimeForSuccess); er != nil {
+			// Only logged
+			logger.Errorf("Expire stat data for the success job `%s` failed with error: %s", pt.jobID, er)
+		}
+
+		if er := pt.fireHookEvent(SuccessStatus); err == nil && er != nil {
+			return er
+		}
+	}
+
+	return err
+}
+
+// Save the stats of job tracked by this tracker.
+// The job might have been put into the queue by the worker before saving its stats,
+// so the status and the queue info of the existing job are not overridden.
+func (pt *pgTracker) Save() error {
+	if pt.jobStats == nil {
+		return errors.New("nil job stats to save")
+	}
+
+	// Alliance
+	info := pt.jobStats.Info
+
+	params := ""
+	if len(info.Parameters) > 0 {
+		if bytes, err := json.Marshal(&info.Parameters); err == nil {
+			params = string(bytes)
+		}
+	}
+
+	// If job kind is periodic job, expire time should not be set
+	// If job kind is scheduled job, expire time should be runAt+
+	now := time.Now().Unix()
+	var expireAt int64
+	if info.JobKind != KindPeriodic {
+		expireAt = now + statDataExpireTime
+		if info.JobKind == KindScheduled && info.RunAt > now {
+			expireAt += info.RunAt - now
+		}
+		expireAt += rand.Int63n(15)
+	}
+
+	_, err := dao.GetOrmer().Raw(`INSERT INTO job_queue (job_id, job_name, kind, is_unique, status, ref_link,
+		enqueue_time, run_at, cron_spec, web_hook_url, numeric_policy_id, check_in, check_in_at, die_at,
+		upstream_job_id, request_id, parameters, update_time, revision, expire_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (job_id) DO UPDATE SET ref_link = EXCLUDED.ref_link, cron_spec = EXCLUDED.cron_spec,
+		web_hook_url = EXCLUDED.web_hook_url, numeric_policy_id = EXCLUDED.numeric_policy_id,
+		upstream_job_id = EXCLUDED.upstream_job_id, request_id = EXCLUDED.request_id,
+		parameters = EXCLUDED.parameters, update_time = EXCLUDED.update_time, expire_at = EXCLUDED.expire_at`,
+		info.JobID, info.JobName, info.JobKind, info.IsUnique, info.Status, info.RefLink,
+		info.EnqueueTime, info.RunAt, info.CronSpec, info.WebHookURL, info.NumericPID, info.CheckIn, info.CheckInAt, info.DieAt,
+		info.UpstreamJobID, info.RequestID, params, now, now, expireAt).Exec()
+
+	return err
+}
+
+// UpdateStatusWithRetry updates the status with retry enabled.
+// If update status failed, then retry if permitted.
+// Try best to do
+func (pt *pgTracker) UpdateStatusWithRetry(targetStatus Status) error {
+	err := pt.compareAndSet(targetStatus)
+	if err != nil {
+		// Status mismatching error will be ignored
+		if !errs.IsStatusMismatchError(err) {
+			// The database is probably unavailable, retry in current process
+			logger.Errorf("update job status error: %s, retry later", err)
+			pt.retryUpdateStatus(targetStatus)
+		}
+	}
+
+	return err
+}
+
+// Reset the job status to `pending` and update the revision.
+// Usually for the retry jobs
+func (pt *pgTracker) Reset() error {
+	now := time.Now().Unix()
+	err := pt.Update(
+		"status",
+		PendingStatus.String(),
+		"revision",
+		now,
+	)
+	if err == nil {
+		pt.refresh(PendingStatus)
+		pt.jobStats.Info.Revision = now
+	}
+
+	return err
+}
+
+// Refresh the job stats in mem
+func (pt *pgTracker) refresh(targetStatus Status, checkIn ...string) {
+	now := time.Now().Unix()
+
+	pt.jobStats.Info.Status = targetStatus.String()
+	if len(checkIn) > 0 {
+		pt.jobStats.Info.CheckIn = checkIn[0]
+		pt.jobStats.Info.CheckInAt = now
+	}
+	pt.jobStats.Info.UpdateTime = now
+}
+
+// Keep the start time of the job execution
+func (pt *pgTracker) markStarted() {
+	pt.startedAt = time.Now()
+	pt.jobStats.Info.StartTime = pt.startedAt.Unix()
+
+	if err := pt.Update("start_time", pt.jobStats.Info.StartTime); err != nil {
+		// Only logged
+		logger.Errorf("Update start time of job `%s` failed with error: %s", pt.jobID, err)
+	}
+}
+
+// Record the execution latency of the job for the statistics of the job kind
+func (pt *pgTracker) recordLatency(status Status) {
+	if pt.startedAt.IsZero() {
+		// Not started by this tracker
+		return
+	}
+
+	now := time.Now()
+	latency := int64(now.Sub(pt.startedAt) / time.Millisecond)
+
+	o := dao.GetOrmer()
+	_, err := o.Raw(`INSERT INTO job_latency (job_id, job_name, revision, status, latency, finish_time)
+		VALUES (?, ?, ?, ?, ?, ?)`, pt.jobID, pt.jobStats.Info.JobName, pt.jobStats.Info.Revision,
+		status.String(), latency, now.Unix()).Exec()
+	if err == nil {
+		// Discard the outdated samples
+		_, err = o.Raw("DELETE FROM job_latency WHERE job_name = ? AND finish_time < ?",
+			pt.jobStats.Info.JobName, now.Unix()-latencyDataExpireTime).Exec()
+	}
+
+	if err != nil {
+		// Only logged
+		logger.Errorf("Record latency of job `%s` failed with error: %s", pt.jobID, err)
+	}
+}
+
+// FireHookEvent fires the hook event
+func (pt *pgTracker) fireHookEvent(status Status, checkIn ...string) error {
+	// Check if hook URL is registered
+	if utils.IsEmptyStr(pt.jobStats.Info.WebHookURL) {
+		// Do nothing
+		return nil
+	}
+
+	change := &StatusChange{
+		JobID:    pt.jobID,
+		Status:   status.String(),
+		Metadata: pt.jobStats.Info,
+	}
+
+	if len(checkIn) > 0 {
+		change.CheckIn = checkIn[0]
+	}
+
+	// If callback is registered, then trigger now
+	if pt.callback != nil {
+		return pt.callback(pt.jobStats.Info.WebHookURL, change)
+	}
+
+	return nil
+}
+
+func (pt *pgTracker) retryUpdateStatus(targetStatus Status) {
+	go func() {
+		select {
+		case <-time.After(time.Duration(5)*time.Minute + time.Duration(rand.Int31n(13))*time.Second):
+			// Check the update timestamp
+			if time.Now().Unix()-pt.jobStats.Info.UpdateTime < statDataExpireTime-24*3600 {
+				if err := pt.compareAndSet(targetStatus); err != nil && !errs.IsStatusMismatchError(err) {
+					logger.Errorf("Retry to update job status error: %s", err)
+					pt.retryUpdateStatus(targetStatus)
+				}
+				// Success
+			}
+			return
+		case <-pt.context.Done():
+			return // terminated
+		}
+	}()
+}
+
+// compareAndSet updates the status only when it's not changed by others after checking
+func (pt *pgTracker) compareAndSet(targetStatus Status) error {
+	for i := 0; i < maxCompareAndSetTimes; i++ {
+		st, err := pt.Status()
+		if err != nil {
+			return err
+		}
+
+		diff := st.Compare(targetStatus)
+		if diff > 0 {
+			return errs.StatusMismatchError(st.String(), targetStatus.String())
+		}
+		if diff == 0 {
+			// Desired matches actual
+			return nil
+		}
+
+		res, err := dao.GetOrmer().Raw("UPDATE job_queue SET status = ?, update_time = ? WHERE job_id = ? AND status = ?",
+			targetStatus.String(), time.Now().Unix(), pt.jobID, st.String()).Exec()
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err == nil && n > 0 {
+			return nil
+		}
+		// The status is changed by others, check it again
+	}
+
+	return errors.Errorf("update status of job %s to %s failed: status is changed concurrently", pt.jobID, targetStatus)
+}
+
+func (pt *pgTracker) expire(expireTime int64) error {
+	res, err := dao.GetOrmer().Raw("UPDATE job_queue SET expire_at = ? WHERE job_id = ?", time.Now().Unix()+expireTime, pt.jobID).Exec()
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return errors.Errorf("job stats for expiring %s does not exist", pt.jobID)
+	}
+
+	return nil
+}
@@ -34,8 +34,14 @@ const (
 	statDataExpireTime = 7 * 24 * 3600
 	// 1 hour to discard the job stats of success jobs
 	statDataExpireTimeForSuccess = 3600
+	// Discard the latency samples of the finished jobs out of the max latency window
+	latencyDataExpireTime = int64(MaxLatencyWindow / time.Second)
 )
 
+// MaxLatencyWindow is the max time window of the latency statistics, the latency samples
+// are kept only in this window, so the statistics of the larger windows are not supported
+const MaxLatencyWindow = 24 * time.Hour
+
 // Tracker is designed to track the life cycle of the job described by the stats
 // The status change is linear and then has strict preorder and successor
 // Check should be enforced before switching
@@ -112,6 +118,8 @@ type basicTracker struct {
 	jobID     string
 	jobStats  *Stats
 	callback  HookCallback
+	// the time the job is started to run by this tracker
+	startedAt time.Time
 }
 
 // NewBasicTrackerWithID builds a tracker with the provided job ID
@@ -248,6 +256,9 @@ func (bt *basicTracker) Run() error {
 	err := bt.compareAndSet(RunningStatus)
 	if !errs.IsStatusMismatchError(err) {
 		bt.refresh(RunningStatus)
+		if err == nil {
+			bt.markStarted()
+		}
 		if er := bt.fireHookEvent(RunningStatus); err == nil && er != nil {
 			return er
 		}
@@ -278,6 +289,7 @@ func (bt *basicTracker) Fail() error {
 	err := bt.UpdateStatusWithRetry(ErrorStatus)
 	if !errs.IsStatusMismatchError(err) {
 		bt.refresh(ErrorStatus)
+		bt.recordLatency(ErrorStatus)
 		if er := bt.fireHookEvent(ErrorStatus); err == nil && er != nil {
 			return er
 		}
@@ -293,6 +305,7 @@ func (bt *basicTracker) Succeed() error {
 	err := bt.UpdateStatusWithRetry(SuccessStatus)
 	if !errs.IsStatusMismatchError(err) {
 		bt.refresh(SuccessStatus)
+		bt.recordLatency(SuccessStatus)
 
 		// Expire the stat data of the successful job
 		if er := bt.expire(statDataExpireTimeForSuccess); er != nil {
@@ -352,6 +365,10 @@ func (bt *basicTracker) Save() (err error) {
 		args = append(args, "upstream_job_id", stats.Info.UpstreamJobID)
 	}
 
+	if !utils.IsEmptyStr(stats.Info.RequestID) {
+		args = append(args, "request_id", stats.Info.RequestID)
+	}
+
 	if len(stats.Info.Parameters) > 0 {
 		if bytes, err := json.Marshal(&stats.Info.Parameters); err == nil {
 			args = append(args, "parameters", string(bytes))
@@ -454,6 +471,58 @@ func (bt *basicTracker) refresh(targetStatus Status, checkIn ...string) {
 	bt.jobStats.Info.UpdateTime = now
 }
 
+// Keep the start time of the job execution
+func (bt *basicTracker) markStarted() {
+	bt.startedAt = time.Now()
+	bt.jobStats.Info.StartTime = bt.startedAt.Unix()
+
+	if err := bt.Update("start_time", bt.jobStats.Info.StartTime); err != nil {
+		// Only logged
+		logger.Errorf("Update start time of job `%s` failed with error: %s", bt.jobID, err)
+	}
+}
+
+// Record the execution latency of the job for the statistics of the job kind.
+// The samples are kept in a sorted set scored by the finish time.
+func (bt *basicTracker) recordLatency(status Status) {
+	if bt.startedAt.IsZero() {
+		// Not started by this tracker
+		return
+	}
+
+	conn := bt.pool.Get()
+	defer func() {
+		closeConn(conn)
+	}()
+
+	now := time.Now()
+	latency := int64(now.Sub(bt.startedAt) / time.Millisecond)
+	sample := LatencySample{
+		JobID:    bt.jobID,
+		Revision: bt.jobStats.Info.Revision,
+		Status:   status,
+		Latency:  latency,
+	}
+	key := rds.KeyJobLatency(bt.namespace, bt.jobStats.Info.JobName)
+
+	err := conn.Send("MULTI")
+	if err == nil {
+		err = conn.Send("ZADD", key, now.Unix(), sample.String())
+	}
+	if err == nil {
+		// Discard the outdated samples
+		err = conn.Send("ZREMRANGEBYSCORE", key, "-inf", now.Unix()-latencyDataExpireTime)
+	}
+	if err == nil {
+		_, err = conn.Do("EXEC")
+	}
+
+	if err != nil {
+		// Only logged
+		logger.Errorf("Record latency of job `%s` failed with error: %s", bt.jobID, err)
+	}
+}
+
 // FireHookEvent fires the hook event
 func (bt *basicTracker) fireHookEvent(status Status, checkIn ...string) error {
 	// Check if hook URL is registered
@@ -631,6 +700,12 @@ func (bt *basicTracker) retrieve() error {
 		case "revision":
 			res.Info.Revision = parseInt64(value)
 			break
+		case "start_time":
+			res.Info.StartTime = parseInt64(value)
+			break
+		case "request_id":
+			res.Info.RequestID = value
+			break
 		default:
 			break
 		}
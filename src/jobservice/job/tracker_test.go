@@ -61,11 +61,12 @@ func (suite *TrackerTestSuite) TestTracker() {
 	jobID := utils.MakeIdentifier()
 	mockJobStats := &Stats{
 		Info: &StatsInfo{
-			JobID:    jobID,
-			Status:   SuccessStatus.String(),
-			JobKind:  KindGeneric,
-			JobName:  SampleJob,
-			IsUnique: false,
+			JobID:     jobID,
+			Status:    SuccessStatus.String(),
+			JobKind:   KindGeneric,
+			JobName:   SampleJob,
+			IsUnique:  false,
+			RequestID: "request-id",
 		},
 	}
 
@@ -102,6 +103,7 @@ func (suite *TrackerTestSuite) TestTracker() {
 		"http://hook.url",
 		tracker.Job().Info.WebHookURL,
 	)
+	assert.Equal(suite.T(), "request-id", tracker.Job().Info.RequestID)
 
 	err = tracker.Run()
 	assert.Error(suite.T(), err, "run: non nil error expected but got nil")
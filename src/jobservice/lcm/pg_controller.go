@@ -0,0 +1,71 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lcm
+
+import (
+	"context"
+
+	"github.com/goharbor/harbor/src/jobservice/env"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/pkg/errors"
+)
+
+// pgController is the implementation of Controller based on PostgreSQL
+type pgController struct {
+	context  context.Context
+	callback job.HookCallback
+}
+
+// NewPGController is the constructor of the PostgreSQL based controller
+func NewPGController(ctx *env.Context, callback job.HookCallback) Controller {
+	return &pgController{
+		context:  ctx.SystemContext,
+		callback: callback,
+	}
+}
+
+// Serve ...
+// The failed status updates are retried by the trackers in process, no daemon process is required.
+func (pc *pgController) Serve() error {
+	return nil
+}
+
+// New tracker
+func (pc *pgController) New(stats *job.Stats) (job.Tracker, error) {
+	if stats == nil {
+		return nil, errors.New("nil stats when creating job tracker")
+	}
+
+	if err := stats.Validate(); err != nil {
+		return nil, errors.Errorf("error occurred when creating job tracker: %s", err)
+	}
+
+	pt := job.NewPGTrackerWithStats(pc.context, stats, pc.callback)
+	if err := pt.Save(); err != nil {
+		return nil, err
+	}
+
+	return pt, nil
+}
+
+// Track and attache with the job
+func (pc *pgController) Track(jobID string) (job.Tracker, error) {
+	pt := job.NewPGTrackerWithID(pc.context, jobID, pc.callback)
+	if err := pt.Load(); err != nil {
+		return nil, err
+	}
+
+	return pt, nil
+}
@@ -0,0 +1,290 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultBatchSize is the default max number of the log entries sent in one request
+	defaultBatchSize = 100
+	// defaultFlushInterval is the default interval of sending the buffered log entries
+	defaultFlushInterval = 5 * time.Second
+	// defaultQueueSize is the max number of the batches waiting to be sent, the batches
+	// are dropped when the queue is full to avoid blocking the jobs by the slow log collector
+	defaultQueueSize = 10
+	// httpLogTimeout is the timeout of the request to the log collector
+	httpLogTimeout = 10 * time.Second
+)
+
+// HTTPLogEntry is the log entry sent to the log collector
+type HTTPLogEntry struct {
+	Time      time.Time `json:"time"`
+	Level     string    `json:"level"`
+	Line      string    `json:"line,omitempty"`
+	Message   string    `json:"message"`
+	Key       string    `json:"job_id,omitempty"`
+	RequestID string    `json:"request_id,omitempty"`
+}
+
+// HTTPLogger is an implementation of logger.Interface.
+// It buffers the logs and posts them as a JSON array of HTTPLogEntry to the log collector
+// when the batch is full, the flush interval is reached or the logger is closed.
+// The batches are posted in background, so the logging isn't blocked by the log collector.
+type HTTPLogger struct {
+	backendLogger *log.Logger
+	writer        *httpBatchWriter
+}
+
+// NewHTTPLogger crates a new HTTP logger, the key is attached to each log entry as the job ID
+// together with the ID of the request which triggers the job
+// nil might be returned
+func NewHTTPLogger(level, endpoint, key, requestID string, batchSize int, flushInterval time.Duration, depth int) (*HTTPLogger, error) {
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	if flushInterval <= 0 {
+		flushInterval = defaultFlushInterval
+	}
+
+	w := newHTTPBatchWriter(endpoint, batchSize, defaultQueueSize, flushInterval)
+
+	logLevel := parseLevel(level)
+	backendLogger := log.New(w, &jsonEntryFormatter{key: key, requestID: requestID}, logLevel, depth)
+
+	return &HTTPLogger{
+		backendLogger: backendLogger,
+		writer:        w,
+	}, nil
+}
+
+// Close sends the buffered log entries and stops the flushing loop
+// Implements logger.Closer interface
+func (hl *HTTPLogger) Close() error {
+	return hl.writer.Close()
+}
+
+// Debug ...
+func (hl *HTTPLogger) Debug(v ...interface{}) {
+	hl.backendLogger.Debug(v...)
+}
+
+// Debugf with format
+func (hl *HTTPLogger) Debugf(format string, v ...interface{}) {
+	hl.backendLogger.Debugf(format, v...)
+}
+
+// Info ...
+func (hl *HTTPLogger) Info(v ...interface{}) {
+	hl.backendLogger.Info(v...)
+}
+
+// Infof with format
+func (hl *HTTPLogger) Infof(format string, v ...interface{}) {
+	hl.backendLogger.Infof(format, v...)
+}
+
+// Warning ...
+func (hl *HTTPLogger) Warning(v ...interface{}) {
+	hl.backendLogger.Warning(v...)
+}
+
+// Warningf with format
+func (hl *HTTPLogger) Warningf(format string, v ...interface{}) {
+	hl.backendLogger.Warningf(format, v...)
+}
+
+// Error ...
+func (hl *HTTPLogger) Error(v ...interface{}) {
+	hl.backendLogger.Error(v...)
+}
+
+// Errorf with format
+func (hl *HTTPLogger) Errorf(format string, v ...interface{}) {
+	hl.backendLogger.Errorf(format, v...)
+}
+
+// Fatal error
+func (hl *HTTPLogger) Fatal(v ...interface{}) {
+	hl.backendLogger.Fatal(v...)
+}
+
+// Fatalf error
+func (hl *HTTPLogger) Fatalf(format string, v ...interface{}) {
+	hl.backendLogger.Fatalf(format, v...)
+}
+
+// jsonEntryFormatter formats the log record as the JSON of HTTPLogEntry
+type jsonEntryFormatter struct {
+	key       string
+	requestID string
+}
+
+// Format implements log.Formatter
+func (jf *jsonEntryFormatter) Format(r *log.Record) ([]byte, error) {
+	return json.Marshal(&HTTPLogEntry{
+		Time:      r.Time,
+		Level:     levelName(r.Lvl),
+		Line:      r.Line,
+		Message:   r.Msg,
+		Key:       jf.key,
+		RequestID: jf.requestID,
+	})
+}
+
+// httpBatchWriter keeps the formatted log entries and posts them to the endpoint in batch,
+// the full batches are handed to the background sender through a bounded queue
+type httpBatchWriter struct {
+	endpoint  string
+	batchSize int
+	client    *http.Client
+	entries   []json.RawMessage
+	closed    bool
+	lock      sync.Mutex
+	queue     chan []json.RawMessage
+	done      chan struct{}
+	sent      chan struct{}
+	closeOnce sync.Once
+	// the last error of sending, it's returned and cleared by Close
+	err     error
+	errLock sync.Mutex
+}
+
+func newHTTPBatchWriter(endpoint string, batchSize, queueSize int, flushInterval time.Duration) *httpBatchWriter {
+	w := &httpBatchWriter{
+		endpoint:  endpoint,
+		batchSize: batchSize,
+		client: &http.Client{
+			Timeout: httpLogTimeout,
+		},
+		queue: make(chan []json.RawMessage, queueSize),
+		done:  make(chan struct{}),
+		sent:  make(chan struct{}),
+	}
+	go w.loop(flushInterval)
+	go w.send()
+
+	return w
+}
+
+// Write implements io.Writer, each call writes one entry
+func (hw *httpBatchWriter) Write(p []byte) (int, error) {
+	hw.lock.Lock()
+	defer hw.lock.Unlock()
+
+	if hw.closed {
+		return 0, errors.New("the HTTP log writer is closed")
+	}
+
+	entry := make([]byte, len(p))
+	copy(entry, p)
+	hw.entries = append(hw.entries, entry)
+	if len(hw.entries) >= hw.batchSize {
+		hw.enqueue()
+	}
+
+	return len(p), nil
+}
+
+// Close hands the buffered entries to the sender, waits for all the queued batches
+// being sent and returns the last error of sending if any
+func (hw *httpBatchWriter) Close() error {
+	hw.closeOnce.Do(func() {
+		close(hw.done)
+
+		hw.lock.Lock()
+		defer hw.lock.Unlock()
+
+		hw.closed = true
+		if len(hw.entries) > 0 {
+			// the sender keeps consuming the queue, so it's safe to wait here
+			hw.queue <- hw.entries
+			hw.entries = nil
+		}
+		close(hw.queue)
+	})
+
+	<-hw.sent
+
+	hw.errLock.Lock()
+	defer hw.errLock.Unlock()
+
+	err := hw.err
+	hw.err = nil
+	return err
+}
+
+// enqueue hands the buffered entries to the sender, the entries are dropped when the queue is full.
+// The caller should hold the lock.
+func (hw *httpBatchWriter) enqueue() {
+	if len(hw.entries) == 0 || hw.closed {
+		return
+	}
+
+	entries := hw.entries
+	hw.entries = nil
+
+	select {
+	case hw.queue <- entries:
+	default:
+		log.Warningf("Drop %d job log entries as the queue of sending to %s is full", len(entries), hw.endpoint)
+	}
+}
+
+func (hw *httpBatchWriter) loop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			hw.lock.Lock()
+			hw.enqueue()
+			hw.lock.Unlock()
+		case <-hw.done:
+			return
+		}
+	}
+}
+
+// send posts the queued batches one by one until the queue is closed
+func (hw *httpBatchWriter) send() {
+	defer close(hw.sent)
+
+	for entries := range hw.queue {
+		if err := hw.post(entries); err != nil {
+			log.Errorf("Send job logs to %s error: %s", hw.endpoint, err)
+
+			hw.errLock.Lock()
+			hw.err = err
+			hw.errLock.Unlock()
+		}
+	}
+}
+
+// post sends the entries, the entries are dropped even if the sending failed
+// to avoid buffering too many logs when the log collector is unavailable.
+func (hw *httpBatchWriter) post(entries []json.RawMessage) error {
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	res, err := hw.client.Post(hw.endpoint, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrapf(err, "send %d log entries", len(entries))
+	}
+	_ = res.Body.Close()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return errors.Errorf("send %d log entries: unexpected status code %d", len(entries), res.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package backend
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test HTTP logger
+func TestHTTPLogger(t *testing.T) {
+	var (
+		lock    sync.Mutex
+		batches [][]*HTTPLogEntry
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var entries []*HTTPLogEntry
+		if err := json.NewDecoder(r.Body).Decode(&entries); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		lock.Lock()
+		batches = append(batches, entries)
+		lock.Unlock()
+	}))
+	defer server.Close()
+
+	l, err := NewHTTPLogger("INFO", server.URL, "job-id", "request-id", 2, 0, 4)
+	require.Nil(t, err)
+
+	l.Debug("ignored")
+	l.Info("TestHTTPLogger-1")
+	l.Warningf("%s", "TestHTTPLogger-2")
+	l.Errorf("%s", "TestHTTPLogger-3")
+	require.Nil(t, l.Close())
+
+	lock.Lock()
+	defer lock.Unlock()
+	// The full batch is sent when writing and the remaining one is sent when closing
+	require.Equal(t, 2, len(batches))
+	require.Equal(t, 2, len(batches[0]))
+	require.Equal(t, 1, len(batches[1]))
+
+	assert.Equal(t, "INFO", batches[0][0].Level)
+	assert.Equal(t, "TestHTTPLogger-1", batches[0][0].Message)
+	assert.Equal(t, "job-id", batches[0][0].Key)
+	assert.Equal(t, "request-id", batches[0][0].RequestID)
+	assert.Equal(t, "WARNING", batches[0][1].Level)
+	assert.Equal(t, "ERROR", batches[1][0].Level)
+}
+
+// Test HTTP logger with the unavailable log collector
+func TestHTTPLoggerUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	l, err := NewHTTPLogger("DEBUG", server.URL, "", "", 10, 0, 4)
+	require.Nil(t, err)
+
+	l.Info("TestHTTPLogger")
+	assert.NotNil(t, l.Close())
+	// Nothing left after the failure
+	assert.Nil(t, l.Close())
+}
+
+// Test HTTP logger with the slow log collector
+func TestHTTPLoggerSlowCollector(t *testing.T) {
+	var (
+		received = make(chan int, 10)
+		release  = make(chan struct{})
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var entries []*HTTPLogEntry
+		if err := json.NewDecoder(r.Body).Decode(&entries); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- len(entries)
+		<-release
+	}))
+	defer server.Close()
+
+	w := newHTTPBatchWriter(server.URL, 1, 1, time.Hour)
+	_, err := w.Write([]byte(`{"message":"entry-1"}`))
+	require.Nil(t, err)
+	select {
+	case <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the first batch isn't sent")
+	}
+
+	// the sender is blocked by the collector, the second batch is queued and the others are dropped
+	// without blocking the writing
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		_, err := w.Write([]byte(`{"message":"entry"}`))
+		require.Nil(t, err)
+	}
+	assert.True(t, time.Since(start) < time.Second)
+
+	close(release)
+	require.Nil(t, w.Close())
+	assert.Equal(t, 1, len(received))
+
+	_, err = w.Write([]byte(`{"message":"entry"}`))
+	assert.NotNil(t, err)
+}
@@ -11,6 +11,11 @@ const (
 	StdOut = "std_out"
 	// StdErr represents os.StdErr
 	StdErr = "std_err"
+
+	// componentName is attached to the logs as the component
+	componentName = "jobservice"
+	// fieldJobID is the field of the job ID attached to the logs
+	fieldJobID = "job_id"
 )
 
 // StdOutputLogger is an implementation of logger.Interface.
@@ -19,14 +24,25 @@ type StdOutputLogger struct {
 	backendLogger *log.Logger
 }
 
-// NewStdOutputLogger creates a new std output logger
-func NewStdOutputLogger(level string, output string, depth int) *StdOutputLogger {
+// NewStdOutputLogger creates a new std output logger, the logs are formatted in the format set by the
+// "LOG_FORMAT" env and the key as the job ID and the request ID are attached to the logs if they're provided
+func NewStdOutputLogger(level string, output string, depth int, key, requestID string) *StdOutputLogger {
 	logLevel := parseLevel(level)
 	logStream := os.Stdout
 	if output == StdErr {
 		logStream = os.Stderr
 	}
-	backendLogger := log.New(logStream, log.NewTextFormatter(), logLevel, depth)
+
+	fields := log.Fields{
+		log.FieldComponent: componentName,
+	}
+	if len(key) > 0 {
+		fields[fieldJobID] = key
+	}
+	if len(requestID) > 0 {
+		fields[log.FieldRequestID] = requestID
+	}
+	backendLogger := log.New(logStream, log.NewFormatter(os.Getenv("LOG_FORMAT")), logLevel, depth).WithFields(fields)
 
 	return &StdOutputLogger{
 		backendLogger: backendLogger,
@@ -4,7 +4,7 @@ import "testing"
 
 // Test std logger
 func TestStdLogger(t *testing.T) {
-	l := NewStdOutputLogger("DEBUG", StdErr, 4)
+	l := NewStdOutputLogger("DEBUG", StdErr, 4, "job-id", "request-id")
 	l.Debug("TestStdLogger")
 	l.Debugf("%s", "TestStdLogger")
 	l.Info("TestStdLogger")
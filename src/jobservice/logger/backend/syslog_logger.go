@@ -0,0 +1,244 @@
+package backend
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/pkg/errors"
+)
+
+const (
+	// facilityUser is the syslog facility "user-level messages"
+	facilityUser = 1
+	// nilValue is used for the empty fields of the syslog message
+	nilValue = "-"
+	// defaultSyslogTag is the default APP-NAME of the syslog message
+	defaultSyslogTag = "harbor-jobservice"
+	// syslogDialTimeout is the timeout of connecting the syslog server
+	syslogDialTimeout = 5 * time.Second
+	// syslogRetryInterval is the interval of reconnecting the unavailable syslog server
+	syslogRetryInterval = 30 * time.Second
+)
+
+// SyslogLogger is an implementation of logger.Interface.
+// It sends logs to the syslog server in the RFC5424 format over UDP or TCP.
+type SyslogLogger struct {
+	backendLogger *log.Logger
+	writer        *syslogWriter
+}
+
+// NewSyslogLogger crates a new syslog logger, the key is used as the PROCID of the messages
+// nil might be returned
+func NewSyslogLogger(level, protocol, addr, tag, key string, depth int) (*SyslogLogger, error) {
+	protocol = strings.ToLower(protocol)
+	if protocol != "udp" && protocol != "tcp" {
+		return nil, errors.Errorf("unsupported protocol of syslog: %s", protocol)
+	}
+
+	w := &syslogWriter{
+		protocol: protocol,
+		addr:     addr,
+	}
+	// The unavailable syslog server should not block the job, connect it again when writing
+	if err := w.connect(); err != nil {
+		log.Errorf("Create syslog logger error: %s", err)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || len(hostname) == 0 {
+		hostname = nilValue
+	}
+	if len(tag) == 0 {
+		tag = defaultSyslogTag
+	}
+	procID := key
+	if len(procID) == 0 {
+		procID = fmt.Sprintf("%d", os.Getpid())
+	}
+
+	formatter := &rfc5424Formatter{
+		hostname: hostname,
+		appName:  tag,
+		procID:   procID,
+		framing:  protocol == "tcp",
+	}
+	logLevel := parseLevel(level)
+	backendLogger := log.New(w, formatter, logLevel, depth)
+
+	return &SyslogLogger{
+		backendLogger: backendLogger,
+		writer:        w,
+	}, nil
+}
+
+// Close the connection to the syslog server
+// Implements logger.Closer interface
+func (sl *SyslogLogger) Close() error {
+	return sl.writer.Close()
+}
+
+// Debug ...
+func (sl *SyslogLogger) Debug(v ...interface{}) {
+	sl.backendLogger.Debug(v...)
+}
+
+// Debugf with format
+func (sl *SyslogLogger) Debugf(format string, v ...interface{}) {
+	sl.backendLogger.Debugf(format, v...)
+}
+
+// Info ...
+func (sl *SyslogLogger) Info(v ...interface{}) {
+	sl.backendLogger.Info(v...)
+}
+
+// Infof with format
+func (sl *SyslogLogger) Infof(format string, v ...interface{}) {
+	sl.backendLogger.Infof(format, v...)
+}
+
+// Warning ...
+func (sl *SyslogLogger) Warning(v ...interface{}) {
+	sl.backendLogger.Warning(v...)
+}
+
+// Warningf with format
+func (sl *SyslogLogger) Warningf(format string, v ...interface{}) {
+	sl.backendLogger.Warningf(format, v...)
+}
+
+// Error ...
+func (sl *SyslogLogger) Error(v ...interface{}) {
+	sl.backendLogger.Error(v...)
+}
+
+// Errorf with format
+func (sl *SyslogLogger) Errorf(format string, v ...interface{}) {
+	sl.backendLogger.Errorf(format, v...)
+}
+
+// Fatal error
+func (sl *SyslogLogger) Fatal(v ...interface{}) {
+	sl.backendLogger.Fatal(v...)
+}
+
+// Fatalf error
+func (sl *SyslogLogger) Fatalf(format string, v ...interface{}) {
+	sl.backendLogger.Fatalf(format, v...)
+}
+
+// rfc5424Formatter formats the log record as the RFC5424 syslog message:
+// "<PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG"
+type rfc5424Formatter struct {
+	hostname string
+	appName  string
+	procID   string
+	// prefix the message with its length as the octet counting framing of RFC6587 for TCP
+	framing bool
+}
+
+// Format implements log.Formatter
+func (rf *rfc5424Formatter) Format(r *log.Record) ([]byte, error) {
+	msg := r.Msg
+	if len(r.Line) != 0 {
+		msg = r.Line + " " + msg
+	}
+
+	s := fmt.Sprintf("<%d>1 %s %s %s %s %s %s %s",
+		facilityUser*8+severity(r.Lvl),
+		r.Time.Format(time.RFC3339Nano),
+		rf.hostname,
+		rf.appName,
+		rf.procID,
+		nilValue,
+		nilValue,
+		strings.TrimRight(msg, "\n"),
+	)
+	if rf.framing {
+		s = fmt.Sprintf("%d %s", len(s), s)
+	}
+
+	return []byte(s), nil
+}
+
+// severity returns the syslog severity of the log level
+func severity(lvl log.Level) int {
+	switch lvl {
+	case log.DebugLevel:
+		return 7
+	case log.InfoLevel:
+		return 6
+	case log.WarningLevel:
+		return 4
+	case log.ErrorLevel:
+		return 3
+	default:
+		return 2
+	}
+}
+
+// syslogWriter writes the messages to the syslog server and reconnects it once the writing failed
+type syslogWriter struct {
+	protocol string
+	addr     string
+	conn     net.Conn
+	// do not reconnect the server before it to avoid blocking every writing
+	retryAt time.Time
+	lock    sync.Mutex
+}
+
+func (sw *syslogWriter) connect() error {
+	if time.Now().Before(sw.retryAt) {
+		return errors.Errorf("syslog server %s://%s is unavailable", sw.protocol, sw.addr)
+	}
+
+	conn, err := net.DialTimeout(sw.protocol, sw.addr, syslogDialTimeout)
+	if err != nil {
+		sw.retryAt = time.Now().Add(syslogRetryInterval)
+		return errors.Wrapf(err, "connect to syslog server %s://%s", sw.protocol, sw.addr)
+	}
+	sw.conn = conn
+
+	return nil
+}
+
+// Write implements io.Writer
+func (sw *syslogWriter) Write(p []byte) (int, error) {
+	sw.lock.Lock()
+	defer sw.lock.Unlock()
+
+	if sw.conn != nil {
+		if n, err := sw.conn.Write(p); err == nil {
+			return n, nil
+		}
+		_ = sw.conn.Close()
+		sw.conn = nil
+	}
+
+	// Retry once with a new connection, the message is dropped if the server is still unavailable
+	if err := sw.connect(); err != nil {
+		return 0, err
+	}
+
+	return sw.conn.Write(p)
+}
+
+// Close the connection
+func (sw *syslogWriter) Close() error {
+	sw.lock.Lock()
+	defer sw.lock.Unlock()
+
+	if sw.conn == nil {
+		return nil
+	}
+
+	err := sw.conn.Close()
+	sw.conn = nil
+
+	return err
+}
@@ -0,0 +1,107 @@
+package backend
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test syslog logger creation with unsupported protocol
+func TestSyslogLoggerCreation(t *testing.T) {
+	_, err := NewSyslogLogger("DEBUG", "unix", "/dev/log", "", "", 4)
+	require.NotNil(t, err)
+}
+
+// Test syslog logger over UDP
+func TestSyslogLoggerUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.Nil(t, err)
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	l, err := NewSyslogLogger("INFO", "UDP", conn.LocalAddr().String(), "test", "job-id", 4)
+	require.Nil(t, err)
+	defer func() {
+		_ = l.Close()
+	}()
+
+	l.Debug("ignored")
+	l.Errorf("%s", "TestSyslogLogger")
+
+	buf := make([]byte, 1024)
+	require.Nil(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, _, err := conn.ReadFrom(buf)
+	require.Nil(t, err)
+
+	msg := string(buf[:n])
+	// facility user(1) * 8 + severity error(3)
+	assert.True(t, strings.HasPrefix(msg, "<11>1 "), msg)
+	assert.Contains(t, msg, " test job-id - - ")
+	assert.True(t, strings.HasSuffix(msg, "TestSyslogLogger"), msg)
+}
+
+// Test syslog logger over TCP
+func TestSyslogLoggerTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	defer func() {
+		_ = ln.Close()
+	}()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer func() {
+			_ = conn.Close()
+		}()
+
+		// Read the octet counting framing
+		r := bufio.NewReader(conn)
+		length, err := r.ReadString(' ')
+		if err != nil {
+			return
+		}
+		received <- length
+	}()
+
+	l, err := NewSyslogLogger("DEBUG", "tcp", ln.Addr().String(), "", "", 4)
+	require.Nil(t, err)
+	defer func() {
+		_ = l.Close()
+	}()
+
+	l.Info("TestSyslogLogger")
+
+	select {
+	case length := <-received:
+		assert.Regexp(t, `^[0-9]+ $`, length)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no syslog message received")
+	}
+}
+
+// Test syslog logger with the unavailable server
+func TestSyslogLoggerUnavailable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	addr := ln.Addr().String()
+	require.Nil(t, ln.Close())
+
+	l, err := NewSyslogLogger("DEBUG", "tcp", addr, "", "", 4)
+	require.Nil(t, err)
+
+	// Dropped without blocking
+	start := time.Now()
+	l.Info("TestSyslogLogger")
+	assert.True(t, time.Since(start) < time.Second)
+	assert.Nil(t, l.Close())
+}
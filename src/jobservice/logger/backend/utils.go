@@ -26,3 +26,21 @@ func parseLevel(lvl string) log.Level {
 
 	return level
 }
+
+// levelName returns the name of the log level
+func levelName(lvl log.Level) string {
+	switch lvl {
+	case log.DebugLevel:
+		return "DEBUG"
+	case log.InfoLevel:
+		return "INFO"
+	case log.WarningLevel:
+		return "WARNING"
+	case log.ErrorLevel:
+		return "ERROR"
+	case log.FatalLevel:
+		return "FATAL"
+	default:
+		return "UNKNOWN"
+	}
+}
@@ -182,7 +182,7 @@ func Init(ctx context.Context) error {
 	for _, lc := range config.DefaultConfig.LoggerConfigs {
 		// Inject logger depth here for FILE and STD logger to avoid configuring it in the yaml
 		// For logger of job service itself, the depth should be 6
-		if lc.Name == NameFile || lc.Name == NameStdOutput {
+		if lc.Name == NameFile || lc.Name == NameStdOutput || lc.Name == NameSyslog || lc.Name == NameHTTP {
 			if lc.Settings == nil {
 				lc.Settings = map[string]interface{}{}
 			}
@@ -3,6 +3,7 @@ package logger
 import (
 	"errors"
 	"path"
+	"time"
 
 	"github.com/goharbor/harbor/src/jobservice/logger/backend"
 )
@@ -45,8 +46,8 @@ func FileFactory(options ...OptionItem) (Interface, error) {
 // StdFactory is factory of std output logger.
 func StdFactory(options ...OptionItem) (Interface, error) {
 	var (
-		level, output string
-		depth         int
+		level, output, key, requestID string
+		depth                         int
 	)
 	for _, op := range options {
 		switch op.Field() {
@@ -54,13 +55,17 @@ func StdFactory(options ...OptionItem) (Interface, error) {
 			level = op.String()
 		case "output":
 			output = op.String()
+		case "key":
+			key = op.String()
+		case "request_id":
+			requestID = op.String()
 		case "depth":
 			depth = op.Int()
 		default:
 		}
 	}
 
-	return backend.NewStdOutputLogger(level, output, depth), nil
+	return backend.NewStdOutputLogger(level, output, depth, key, requestID), nil
 }
 
 // DBFactory is factory of file logger
@@ -87,3 +92,71 @@ func DBFactory(options ...OptionItem) (Interface, error) {
 
 	return backend.NewDBLogger(key, level, depth)
 }
+
+// SyslogFactory is factory of syslog logger
+func SyslogFactory(options ...OptionItem) (Interface, error) {
+	var (
+		level, protocol, address, tag, key string
+		depth                              int
+	)
+	for _, op := range options {
+		switch op.Field() {
+		case "level":
+			level = op.String()
+		case "protocol":
+			protocol = op.String()
+		case "address":
+			address = op.String()
+		case "tag":
+			tag = op.String()
+		case "key":
+			key = op.String()
+		case "depth":
+			depth = op.Int()
+		default:
+		}
+	}
+
+	if len(address) == 0 {
+		return nil, errors.New("missing address option of the syslog logger")
+	}
+
+	if len(protocol) == 0 {
+		protocol = "udp"
+	}
+
+	return backend.NewSyslogLogger(level, protocol, address, tag, key, depth)
+}
+
+// HTTPFactory is factory of HTTP logger
+func HTTPFactory(options ...OptionItem) (Interface, error) {
+	var (
+		level, endpoint, key, requestID string
+		batchSize, flushInterval, depth int
+	)
+	for _, op := range options {
+		switch op.Field() {
+		case "level":
+			level = op.String()
+		case "endpoint":
+			endpoint = op.String()
+		case "batch_size":
+			batchSize = op.Int()
+		case "flush_interval":
+			flushInterval = op.Int()
+		case "key":
+			key = op.String()
+		case "request_id":
+			requestID = op.String()
+		case "depth":
+			depth = op.Int()
+		default:
+		}
+	}
+
+	if len(endpoint) == 0 {
+		return nil, errors.New("missing endpoint option of the HTTP logger")
+	}
+
+	return backend.NewHTTPLogger(level, endpoint, key, requestID, batchSize, time.Duration(flushInterval)*time.Second, depth)
+}
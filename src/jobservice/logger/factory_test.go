@@ -72,3 +72,57 @@ func TestDBFactoryErr1(t *testing.T) {
 	_, err := DBFactory(ois...)
 	require.NotNil(t, err)
 }
+
+// TestSyslogFactory
+func TestSyslogFactory(t *testing.T) {
+	ois := make([]OptionItem, 0)
+	ois = append(ois, OptionItem{"level", "DEBUG"})
+	ois = append(ois, OptionItem{"address", "127.0.0.1:514"})
+	ois = append(ois, OptionItem{"key", "key_syslog_logger_unit_text"})
+	ois = append(ois, OptionItem{"depth", 5})
+
+	sl, err := SyslogFactory(ois...)
+	require.Nil(t, err)
+	require.Equal(t, NameSyslog, GetLoggerName(sl))
+
+	if closer, ok := sl.(Closer); ok {
+		_ = closer.Close()
+	}
+}
+
+// TestSyslogFactoryErr
+func TestSyslogFactoryErr(t *testing.T) {
+	ois := make([]OptionItem, 0)
+	ois = append(ois, OptionItem{"level", "DEBUG"})
+
+	_, err := SyslogFactory(ois...)
+	require.NotNil(t, err)
+}
+
+// TestHTTPFactory
+func TestHTTPFactory(t *testing.T) {
+	ois := make([]OptionItem, 0)
+	ois = append(ois, OptionItem{"level", "DEBUG"})
+	ois = append(ois, OptionItem{"endpoint", "http://127.0.0.1:8080/logs"})
+	ois = append(ois, OptionItem{"batch_size", 10})
+	ois = append(ois, OptionItem{"flush_interval", 1})
+	ois = append(ois, OptionItem{"depth", 5})
+
+	hl, err := HTTPFactory(ois...)
+	require.Nil(t, err)
+	require.Equal(t, NameHTTP, GetLoggerName(hl))
+
+	if closer, ok := hl.(Closer); ok {
+		// Nothing to send
+		require.Nil(t, closer.Close())
+	}
+}
+
+// TestHTTPFactoryErr
+func TestHTTPFactoryErr(t *testing.T) {
+	ois := make([]OptionItem, 0)
+	ois = append(ois, OptionItem{"level", "DEBUG"})
+
+	_, err := HTTPFactory(ois...)
+	require.NotNil(t, err)
+}
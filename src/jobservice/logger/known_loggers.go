@@ -13,6 +13,10 @@ const (
 	NameStdOutput = "STD_OUTPUT"
 	// NameDB is the unique name of the DB logger.
 	NameDB = "DB"
+	// NameSyslog is the unique name of the syslog logger.
+	NameSyslog = "SYSLOG"
+	// NameHTTP is the unique name of the HTTP logger.
+	NameHTTP = "HTTP"
 )
 
 // Declaration is used to declare a supported logger.
@@ -36,6 +40,10 @@ var knownLoggers = map[string]*Declaration{
 	NameStdOutput: {StdFactory, nil, nil, true},
 	// DB logger
 	NameDB: {DBFactory, DBSweeperFactory, DBGetterFactory, false},
+	// Syslog logger, the logs are kept by the syslog server
+	NameSyslog: {SyslogFactory, nil, nil, false},
+	// HTTP logger, the logs are posted to the log collector in batch
+	NameHTTP: {HTTPFactory, nil, nil, false},
 }
 
 // IsKnownLogger checks if the logger is supported with name.
@@ -102,6 +110,10 @@ func GetLoggerName(l Interface) string {
 		name = NameStdOutput
 	case *backend.FileLogger:
 		name = NameFile
+	case *backend.SyslogLogger:
+		name = NameSyslog
+	case *backend.HTTPLogger:
+		name = NameHTTP
 	default:
 		name = reflect.TypeOf(l).String()
 	}
@@ -54,7 +54,7 @@ func TestGetLoggerName(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, NameDB, GetLoggerName(l))
 
-	stdLog := backend.NewStdOutputLogger("DEBUG", backend.StdErr, 4)
+	stdLog := backend.NewStdOutputLogger("DEBUG", backend.StdErr, 4, "", "")
 	require.Equal(t, NameStdOutput, GetLoggerName(stdLog))
 
 	fileLog, err := backend.NewFileLogger("DEBUG", path.Join(os.TempDir(), "TestFileLogger.log"), 4)
@@ -0,0 +1,102 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgt
+
+import (
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/jobservice/common/rds"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/pkg/metrics"
+	"github.com/gomodule/redigo/redis"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	pendingJobsDesc = metrics.NewDesc(metrics.SubsystemJobService, "queue_pending_jobs",
+		"The number of the pending jobs in the queue", "job_name")
+	runningJobsDesc = metrics.NewDesc(metrics.SubsystemJobService, "queue_running_jobs",
+		"The number of the running jobs", "job_name")
+	retryingJobsDesc = metrics.NewDesc(metrics.SubsystemJobService, "queue_retrying_jobs",
+		"The number of the failed jobs waiting for retry", "job_name")
+	queueLatencyDesc = metrics.NewDesc(metrics.SubsystemJobService, "queue_latency_seconds",
+		"The seconds the oldest pending job has been waiting", "job_name")
+	hookRetryBacklogDesc = metrics.NewDesc(metrics.SubsystemJobService, "hook_retry_backlog",
+		"The number of the hook events waiting for retry")
+)
+
+// collector reports the queue status of jobs and the hook retry backlog when being scraped
+type collector struct {
+	manager Manager
+	// counts the hook events waiting for retry
+	hookRetryBacklog func() (int64, error)
+}
+
+// NewCollector is constructor of the prometheus collector of the job queues
+func NewCollector(manager Manager, namespace string, pool *redis.Pool) prometheus.Collector {
+	return &collector{
+		manager: manager,
+		hookRetryBacklog: func() (int64, error) {
+			conn := pool.Get()
+			defer func() {
+				_ = conn.Close()
+			}()
+
+			return redis.Int64(conn.Do("ZCARD", rds.KeyHookEventRetryQueue(namespace)))
+		},
+	}
+}
+
+// NewPGCollector is constructor of the prometheus collector of the job queues kept in PostgreSQL
+func NewPGCollector(manager Manager) prometheus.Collector {
+	return &collector{
+		manager: manager,
+		hookRetryBacklog: func() (int64, error) {
+			var count int64
+			err := dao.GetOrmer().Raw("SELECT COUNT(*) FROM job_hook_retry").QueryRow(&count)
+			return count, err
+		},
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pendingJobsDesc
+	ch <- runningJobsDesc
+	ch <- retryingJobsDesc
+	ch <- queueLatencyDesc
+	ch <- hookRetryBacklogDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	queues, err := c.manager.GetQueues()
+	if err != nil {
+		logger.Errorf("Collect metrics of job queues error: %s", err)
+	} else {
+		for _, q := range queues {
+			ch <- prometheus.MustNewConstMetric(pendingJobsDesc, prometheus.GaugeValue, float64(q.Pending), q.JobName)
+			ch <- prometheus.MustNewConstMetric(runningJobsDesc, prometheus.GaugeValue, float64(q.Running), q.JobName)
+			ch <- prometheus.MustNewConstMetric(retryingJobsDesc, prometheus.GaugeValue, float64(q.Retrying), q.JobName)
+			ch <- prometheus.MustNewConstMetric(queueLatencyDesc, prometheus.GaugeValue, float64(q.Latency), q.JobName)
+		}
+	}
+
+	backlog, err := c.hookRetryBacklog()
+	if err != nil {
+		logger.Errorf("Collect metrics of hook retry backlog error: %s", err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(hookRetryBacklogDesc, prometheus.GaugeValue, float64(backlog))
+}
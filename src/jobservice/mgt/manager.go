@@ -16,6 +16,7 @@ package mgt
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/gocraft/work"
 	"github.com/goharbor/harbor/src/jobservice/common/query"
@@ -27,8 +28,10 @@ import (
 	"github.com/goharbor/harbor/src/jobservice/period"
 	"github.com/gomodule/redigo/redis"
 	"github.com/pkg/errors"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Manager defies the related operations to handle the management of job stats.
@@ -86,6 +89,30 @@ type Manager interface {
 	// Returns:
 	//   Non nil error if any issues meet
 	SaveJob(job *job.Stats) error
+
+	// Get the queue status of all kinds of jobs
+	//
+	// Returns:
+	//   The queue status list
+	//   Non nil error if any issues meet
+	GetQueues() ([]*job.QueueStats, error)
+
+	// Get the jobs being executed by the workers
+	//
+	// Returns:
+	//   The running job list
+	//   Non nil error if any issues meet
+	GetRunningJobs() ([]*job.RunningJob, error)
+
+	// Get the execution latency of all kinds of jobs finished in the time window
+	//
+	// Arguments:
+	//   window time.Duration: the time window before now, it can't exceed job.MaxLatencyWindow
+	//
+	// Returns:
+	//   The latency stats list
+	//   Non nil error if any issues meet
+	GetLatency(window time.Duration) ([]*job.LatencyStats, error)
 }
 
 // basicManager is the default implementation of @manager,
@@ -311,6 +338,184 @@ func (bm *basicManager) SaveJob(j *job.Stats) error {
 	return t.Save()
 }
 
+// GetQueues is implementation of Manager.GetQueues
+func (bm *basicManager) GetQueues() ([]*job.QueueStats, error) {
+	queues, err := bm.client.Queues()
+	if err != nil {
+		return nil, err
+	}
+
+	conn := bm.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	retrying, err := countRetryJobs(conn, bm.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*job.QueueStats, 0, len(queues))
+	for _, q := range queues {
+		// The lock keeps the count of the running jobs
+		running, err := redis.Int64(conn.Do("GET", rds.RedisKeyJobsLock(bm.namespace, q.JobName)))
+		if err != nil && err != redis.ErrNil {
+			return nil, err
+		}
+
+		res = append(res, &job.QueueStats{
+			JobName:  q.JobName,
+			Pending:  q.Count,
+			Running:  running,
+			Retrying: retrying[q.JobName],
+			Latency:  q.Latency,
+		})
+	}
+
+	return res, nil
+}
+
+// GetRunningJobs is implementation of Manager.GetRunningJobs
+func (bm *basicManager) GetRunningJobs() ([]*job.RunningJob, error) {
+	observations, err := bm.client.WorkerObservations()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*job.RunningJob, 0)
+	for _, o := range observations {
+		if !o.IsBusy {
+			continue
+		}
+
+		jID := o.JobID
+		// The periodic execution has its own ID format
+		args := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(o.ArgsJSON), &args); err == nil {
+			if epoch, ok := args[period.PeriodicExecutionMark]; ok {
+				jID = fmt.Sprintf("%s@%s", o.JobID, epoch)
+			}
+		}
+
+		res = append(res, &job.RunningJob{
+			WorkerID:  o.WorkerID,
+			JobID:     jID,
+			JobName:   o.JobName,
+			StartedAt: o.StartedAt,
+		})
+	}
+
+	return res, nil
+}
+
+// GetLatency is implementation of Manager.GetLatency
+func (bm *basicManager) GetLatency(window time.Duration) ([]*job.LatencyStats, error) {
+	if window <= 0 {
+		return nil, errs.BadRequestError("non-positive time window")
+	}
+	if window > job.MaxLatencyWindow {
+		return nil, errs.BadRequestError(fmt.Sprintf("time window exceeds the max value %s", job.MaxLatencyWindow))
+	}
+
+	conn := bm.pool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	jobNames, err := redis.Strings(conn.Do("SMEMBERS", rds.RedisKeyKnownJobs(bm.namespace)))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(jobNames)
+
+	from := time.Now().Add(-window).Unix()
+	res := make([]*job.LatencyStats, 0, len(jobNames))
+	for _, name := range jobNames {
+		values, err := redis.Strings(conn.Do("ZRANGEBYSCORE", rds.KeyJobLatency(bm.namespace, name), from, "+inf"))
+		if err != nil {
+			return nil, err
+		}
+
+		samples := make([]*job.LatencySample, 0, len(values))
+		for _, v := range values {
+			sample, err := job.ParseLatencySample(v)
+			if err != nil {
+				// Just log it
+				logger.Errorf("mgt.basicManager: parse latency sample error: %s", err)
+				continue
+			}
+			samples = append(samples, sample)
+		}
+
+		stats := latencyStats(samples)
+		stats.JobName = name
+		res = append(res, stats)
+	}
+
+	return res, nil
+}
+
+// countRetryJobs counts the jobs waiting for retrying by the job name
+func countRetryJobs(conn redis.Conn, namespace string) (map[string]int64, error) {
+	values, err := redis.Strings(conn.Do("ZRANGE", rds.RedisKeyRetry(namespace), 0, -1))
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64)
+	for _, v := range values {
+		j := &work.Job{}
+		if err := json.Unmarshal([]byte(v), j); err != nil {
+			continue
+		}
+		counts[j.Name]++
+	}
+
+	return counts, nil
+}
+
+// latencyStats calculates the percentiles of the latency samples
+func latencyStats(samples []*job.LatencySample) *job.LatencyStats {
+	stats := &job.LatencyStats{}
+	if len(samples) == 0 {
+		return stats
+	}
+
+	latencies := make([]int64, 0, len(samples))
+	executed := make(map[string]bool)
+	for _, s := range samples {
+		latencies = append(latencies, s.Latency)
+		if s.Status == job.ErrorStatus {
+			stats.Failed++
+		}
+		// The same job appears more than once when it's retried
+		if executed[s.JobID] {
+			stats.Retried++
+		}
+		executed[s.JobID] = true
+	}
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+
+	stats.Count = int64(len(latencies))
+	stats.P50 = percentile(latencies, 50)
+	stats.P95 = percentile(latencies, 95)
+	stats.Max = latencies[len(latencies)-1]
+
+	return stats
+}
+
+// percentile returns the nearest-rank percentile of the sorted values
+func percentile(sorted []int64, p int) int64 {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
+
 // queryExecutions queries periodic executions by status
 func queryExecutions(conn redis.Conn, dataKey string, q *query.Parameter) ([]string, int64, error) {
 	total, err := redis.Int64(conn.Do("ZCOUNT", dataKey, 0, "+inf"))
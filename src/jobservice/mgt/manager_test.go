@@ -16,6 +16,7 @@ package mgt
 
 import (
 	"context"
+	"fmt"
 	"github.com/gocraft/work"
 	"github.com/goharbor/harbor/src/jobservice/common/query"
 	"github.com/goharbor/harbor/src/jobservice/job"
@@ -182,3 +183,45 @@ func (suite *BasicManagerTestSuite) TestSaveJob() {
 	err := suite.manager.SaveJob(newJob)
 	require.NoError(suite.T(), err)
 }
+
+// TestGetLatency tests getting latency of the jobs
+func (suite *BasicManagerTestSuite) TestGetLatency() {
+	_, err := suite.manager.GetLatency(time.Hour)
+	require.NoError(suite.T(), err)
+
+	_, err = suite.manager.GetLatency(0)
+	assert.Error(suite.T(), err)
+
+	_, err = suite.manager.GetLatency(job.MaxLatencyWindow + time.Second)
+	assert.Error(suite.T(), err)
+}
+
+// TestLatencyStats tests calculating the percentiles of the latency
+func TestLatencyStats(t *testing.T) {
+	stats := latencyStats(nil)
+	assert.Equal(t, int64(0), stats.Count)
+
+	samples := make([]*job.LatencySample, 0)
+	for i := 1; i <= 20; i++ {
+		samples = append(samples, &job.LatencySample{
+			JobID:   fmt.Sprintf("job-%d", i),
+			Status:  job.SuccessStatus,
+			Latency: int64(i * 10),
+		})
+	}
+	// A retried execution of the failed job
+	samples[0].Status = job.ErrorStatus
+	samples = append(samples, &job.LatencySample{
+		JobID:   "job-1",
+		Status:  job.SuccessStatus,
+		Latency: 5,
+	})
+
+	stats = latencyStats(samples)
+	assert.Equal(t, int64(21), stats.Count)
+	assert.Equal(t, int64(1), stats.Failed)
+	assert.Equal(t, int64(1), stats.Retried)
+	assert.Equal(t, int64(100), stats.P50)
+	assert.Equal(t, int64(190), stats.P95)
+	assert.Equal(t, int64(200), stats.Max)
+}
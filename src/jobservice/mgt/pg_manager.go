@@ -0,0 +1,295 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgt
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/jobservice/common/query"
+	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/errs"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/pkg/errors"
+)
+
+// pgManager is the implementation of @manager based on PostgreSQL
+type pgManager struct {
+	// system context
+	ctx context.Context
+}
+
+// NewPGManager news a PostgreSQL based manager
+func NewPGManager(ctx context.Context) Manager {
+	return &pgManager{
+		ctx: ctx,
+	}
+}
+
+// GetJobs is implementation of Manager.GetJobs
+// The cursor is the offset of the jobs to keep the same behavior with the redis based manager,
+// 0 is returned as the next cursor when all the jobs are fetched.
+func (pm *pgManager) GetJobs(q *query.Parameter) ([]*job.Stats, int64, error) {
+	cursor, count := int64(0), query.DefaultPageSize
+	if q != nil {
+		if q.PageSize > 0 {
+			count = q.PageSize
+		}
+
+		if cur, ok := q.Extras.Get(query.ExtraParamKeyCursor); ok {
+			cursor = cur.(int64)
+		}
+	}
+
+	var jobIDs []string
+	if _, err := dao.GetOrmer().Raw("SELECT job_id FROM job_queue ORDER BY id LIMIT ? OFFSET ?",
+		count, cursor).QueryRows(&jobIDs); err != nil {
+		return nil, 0, err
+	}
+
+	var nextCur int64
+	if uint(len(jobIDs)) == count {
+		nextCur = cursor + int64(len(jobIDs))
+	}
+
+	return pm.load(jobIDs), nextCur, nil
+}
+
+// GetPeriodicExecution is implementation of Manager.GetPeriodicExecution
+func (pm *pgManager) GetPeriodicExecution(pID string, q *query.Parameter) ([]*job.Stats, int64, error) {
+	if utils.IsEmptyStr(pID) {
+		return nil, 0, errors.New("nil periodic job ID")
+	}
+
+	tracker := job.NewPGTrackerWithID(pm.ctx, pID, nil)
+	if err := tracker.Load(); err != nil {
+		return nil, 0, err
+	}
+
+	if tracker.Job().Info.JobKind != job.KindPeriodic {
+		return nil, 0, errors.Errorf("only periodic job has executions: %s kind is received", tracker.Job().Info.JobKind)
+	}
+
+	where := "WHERE upstream_job_id = ?"
+	// Query executions by "non stopped"
+	if q != nil {
+		if nonStoppedOnly, ok := q.Extras.Get(query.ExtraParamKeyNonStoppedOnly); ok {
+			if v, yes := nonStoppedOnly.(bool); yes && v {
+				where += " AND NOT execution_done"
+			}
+		}
+	}
+
+	return pm.page("SELECT job_id FROM job_queue "+where+" ORDER BY run_at DESC",
+		"SELECT COUNT(*) FROM job_queue "+where, q, pID)
+}
+
+// GetScheduledJobs is implementation of Manager.GetScheduledJobs
+func (pm *pgManager) GetScheduledJobs(q *query.Parameter) ([]*job.Stats, int64, error) {
+	where := "WHERE queued AND status = ?"
+
+	return pm.page("SELECT job_id FROM job_queue "+where+" ORDER BY run_at",
+		"SELECT COUNT(*) FROM job_queue "+where, q, job.ScheduledStatus.String())
+}
+
+// GetJob is implementation of Manager.GetJob
+func (pm *pgManager) GetJob(jobID string) (*job.Stats, error) {
+	if utils.IsEmptyStr(jobID) {
+		return nil, errs.BadRequestError("empty job ID")
+	}
+
+	t := job.NewPGTrackerWithID(pm.ctx, jobID, nil)
+	if err := t.Load(); err != nil {
+		return nil, err
+	}
+
+	return t.Job(), nil
+}
+
+// SaveJob is implementation of Manager.SaveJob
+func (pm *pgManager) SaveJob(j *job.Stats) error {
+	if j == nil {
+		return errs.BadRequestError("nil saving job stats")
+	}
+
+	t := job.NewPGTrackerWithStats(pm.ctx, j, nil)
+	return t.Save()
+}
+
+// GetQueues is implementation of Manager.GetQueues
+// The failed jobs waiting for retrying are kept in the queue with the fails,
+// and the running jobs are the ones claimed by the workers.
+func (pm *pgManager) GetQueues() ([]*job.QueueStats, error) {
+	type queue struct {
+		JobName  string `orm:"column(job_name)"`
+		Pending  int64  `orm:"column(pending)"`
+		Running  int64  `orm:"column(running)"`
+		Retrying int64  `orm:"column(retrying)"`
+		Oldest   int64  `orm:"column(oldest)"`
+	}
+
+	now := time.Now().Unix()
+	var queues []*queue
+	if _, err := dao.GetOrmer().Raw(`SELECT job_name,
+		SUM(CASE WHEN queued AND fails = 0 AND run_at <= ? THEN 1 ELSE 0 END) AS pending,
+		SUM(CASE WHEN worker_id IS NOT NULL THEN 1 ELSE 0 END) AS running,
+		SUM(CASE WHEN queued AND fails > 0 THEN 1 ELSE 0 END) AS retrying,
+		MIN(CASE WHEN queued AND fails = 0 AND run_at <= ? THEN run_at ELSE NULL END) AS oldest
+		FROM job_queue WHERE queued OR worker_id IS NOT NULL GROUP BY job_name ORDER BY job_name`,
+		now, now).QueryRows(&queues); err != nil {
+		return nil, err
+	}
+
+	res := make([]*job.QueueStats, 0, len(queues))
+	for _, q := range queues {
+		var latency int64
+		if q.Oldest > 0 {
+			latency = now - q.Oldest
+		}
+
+		res = append(res, &job.QueueStats{
+			JobName:  q.JobName,
+			Pending:  q.Pending,
+			Running:  q.Running,
+			Retrying: q.Retrying,
+			Latency:  latency,
+		})
+	}
+
+	return res, nil
+}
+
+// GetRunningJobs is implementation of Manager.GetRunningJobs
+func (pm *pgManager) GetRunningJobs() ([]*job.RunningJob, error) {
+	var jobs []*struct {
+		WorkerID  string `orm:"column(worker_id)"`
+		JobID     string `orm:"column(job_id)"`
+		JobName   string `orm:"column(job_name)"`
+		StartTime int64  `orm:"column(start_time)"`
+	}
+	if _, err := dao.GetOrmer().Raw(`SELECT worker_id, job_id, job_name, start_time FROM job_queue
+		WHERE worker_id IS NOT NULL ORDER BY worker_id`).QueryRows(&jobs); err != nil {
+		return nil, err
+	}
+
+	res := make([]*job.RunningJob, 0, len(jobs))
+	for _, j := range jobs {
+		res = append(res, &job.RunningJob{
+			WorkerID:  j.WorkerID,
+			JobID:     j.JobID,
+			JobName:   j.JobName,
+			StartedAt: j.StartTime,
+		})
+	}
+
+	return res, nil
+}
+
+// GetLatency is implementation of Manager.GetLatency
+func (pm *pgManager) GetLatency(window time.Duration) ([]*job.LatencyStats, error) {
+	if window <= 0 {
+		return nil, errs.BadRequestError("non-positive time window")
+	}
+
+	var rows []*struct {
+		JobID    string `orm:"column(job_id)"`
+		JobName  string `orm:"column(job_name)"`
+		Revision int64  `orm:"column(revision)"`
+		Status   string `orm:"column(status)"`
+		Latency  int64  `orm:"column(latency)"`
+	}
+	from := time.Now().Add(-window).Unix()
+	if _, err := dao.GetOrmer().Raw(`SELECT job_id, job_name, revision, status, latency FROM job_latency
+		WHERE finish_time >= ?`, from).QueryRows(&rows); err != nil {
+		return nil, err
+	}
+
+	samples := make(map[string][]*job.LatencySample)
+	for _, r := range rows {
+		samples[r.JobName] = append(samples[r.JobName], &job.LatencySample{
+			JobID:    r.JobID,
+			Revision: r.Revision,
+			Status:   job.Status(r.Status),
+			Latency:  r.Latency,
+		})
+	}
+
+	jobNames := make([]string, 0, len(samples))
+	for name := range samples {
+		jobNames = append(jobNames, name)
+	}
+	sort.Strings(jobNames)
+
+	res := make([]*job.LatencyStats, 0, len(jobNames))
+	for _, name := range jobNames {
+		stats := latencyStats(samples[name])
+		stats.JobName = name
+		res = append(res, stats)
+	}
+
+	return res, nil
+}
+
+// page queries the IDs of the jobs by pagination and loads the stats of them
+func (pm *pgManager) page(sql, countSQL string, q *query.Parameter, args ...interface{}) ([]*job.Stats, int64, error) {
+	var pageNumber, pageSize uint = 1, query.DefaultPageSize
+	if q != nil {
+		if q.PageNumber > 0 {
+			pageNumber = q.PageNumber
+		}
+		if q.PageSize > 0 {
+			pageSize = q.PageSize
+		}
+	}
+
+	o := dao.GetOrmer()
+
+	var total int64
+	if err := o.Raw(countSQL, args...).QueryRow(&total); err != nil {
+		return nil, 0, err
+	}
+
+	// No items
+	if total == 0 || (int64)((pageNumber-1)*pageSize) >= total {
+		return []*job.Stats{}, total, nil
+	}
+
+	var jobIDs []string
+	args = append(args, pageSize, (pageNumber-1)*pageSize)
+	if _, err := o.Raw(sql+" LIMIT ? OFFSET ?", args...).QueryRows(&jobIDs); err != nil {
+		return nil, 0, err
+	}
+
+	return pm.load(jobIDs), total, nil
+}
+
+// load the stats of the jobs, the ones failed to load are skipped
+func (pm *pgManager) load(jobIDs []string) []*job.Stats {
+	results := make([]*job.Stats, 0, len(jobIDs))
+	for _, jID := range jobIDs {
+		t := job.NewPGTrackerWithID(pm.ctx, jID, nil)
+		if err := t.Load(); err != nil {
+			logger.Errorf("retrieve stats data of job %s error: %s", jID, err)
+			continue
+		}
+
+		results = append(results, t.Job())
+	}
+
+	return results
+}
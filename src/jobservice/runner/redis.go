@@ -26,9 +26,14 @@ import (
 	"github.com/goharbor/harbor/src/jobservice/lcm"
 	"github.com/goharbor/harbor/src/jobservice/logger"
 	"github.com/goharbor/harbor/src/jobservice/period"
+	"github.com/goharbor/harbor/src/pkg/metrics"
 	"github.com/pkg/errors"
 )
 
+// finishedJobs counts the finished jobs of each kind by the final status
+var finishedJobs = metrics.NewCounterVec(metrics.SubsystemJobService, "jobs_total",
+	"The total number of the finished jobs", "job_name", "status")
+
 // RedisJob is a job wrapper to wrap the job.Interface to the style which can be recognized by the redis worker.
 type RedisJob struct {
 	job     interface{}    // the real job implementation
@@ -130,6 +135,7 @@ func (rj *RedisJob) Run(j *work.Job) (err error) {
 			if er := tracker.Fail(); er != nil {
 				logger.Errorf("Mark job status to failure error: %s", err)
 			}
+			finishedJobs.WithLabelValues(j.Name, job.ErrorStatus.String()).Inc()
 
 			return
 		}
@@ -142,6 +148,7 @@ func (rj *RedisJob) Run(j *work.Job) (err error) {
 				logger.Infof("Job %s:%s is stopped", tracker.Job().Info.JobName, tracker.Job().Info.JobID)
 				// Stopped job, no exit message printing.
 				markStopped = bp(true)
+				finishedJobs.WithLabelValues(j.Name, job.StoppedStatus.String()).Inc()
 				return
 			}
 		}
@@ -150,6 +157,7 @@ func (rj *RedisJob) Run(j *work.Job) (err error) {
 		if er := tracker.Succeed(); er != nil {
 			logger.Errorf("Mark job status to success error: %s", er)
 		}
+		finishedJobs.WithLabelValues(j.Name, job.SuccessStatus.String()).Inc()
 	}()
 
 	// Defer to handle runtime error
@@ -33,6 +33,7 @@ import (
 	"github.com/goharbor/harbor/src/jobservice/env"
 	"github.com/goharbor/harbor/src/jobservice/hook"
 	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/job/impl/auditlog"
 	"github.com/goharbor/harbor/src/jobservice/job/impl/gc"
 	"github.com/goharbor/harbor/src/jobservice/job/impl/notification"
 	"github.com/goharbor/harbor/src/jobservice/job/impl/replication"
@@ -44,6 +45,8 @@ import (
 	"github.com/goharbor/harbor/src/jobservice/migration"
 	"github.com/goharbor/harbor/src/jobservice/worker"
 	"github.com/goharbor/harbor/src/jobservice/worker/cworker"
+	"github.com/goharbor/harbor/src/jobservice/worker/pworker"
+	"github.com/goharbor/harbor/src/pkg/metrics"
 	"github.com/goharbor/harbor/src/pkg/retention"
 	"github.com/goharbor/harbor/src/pkg/scheduler"
 )
@@ -94,72 +97,21 @@ func (bs *Bootstrap) LoadAndRun(ctx context.Context, cancel context.CancelFunc)
 		backendWorker worker.Interface
 		manager       mgt.Manager
 	)
-	if cfg.PoolConfig.Backend == config.JobServicePoolBackendRedis {
-		// Number of workers
-		workerNum := cfg.PoolConfig.WorkerCount
-		// Add {} to namespace to void slot issue
-		namespace := fmt.Sprintf("{%s}", cfg.PoolConfig.RedisPoolCfg.Namespace)
-		// Get redis connection pool
-		redisPool := bs.getRedisPool(cfg.PoolConfig.RedisPoolCfg)
-
-		// Do data migration if necessary
-		rdbMigrator := migration.New(redisPool, namespace)
-		rdbMigrator.Register(migration.PolicyMigratorFactory)
-		if err := rdbMigrator.Migrate(); err != nil {
-			// Just logged, should not block the starting process
-			logger.Error(err)
+	switch cfg.PoolConfig.Backend {
+	case config.JobServicePoolBackendRedis:
+		backendWorker, manager, err = bs.loadAndRunRedisBackend(rootContext, cfg)
+	case config.JobServicePoolBackendPostgreSQL:
+		// The database is connected when initializing the job context
+		if rootContext.JobContext == nil {
+			return errors.Errorf("job context is required by the worker backend '%s'", cfg.PoolConfig.Backend)
 		}
-
-		// Create stats manager
-		manager = mgt.NewManager(ctx, namespace, redisPool)
-		// Create hook agent, it's a singleton object
-		hookAgent := hook.NewAgent(rootContext, namespace, redisPool)
-		hookCallback := func(URL string, change *job.StatusChange) error {
-			msg := fmt.Sprintf("status change: job=%s, status=%s", change.JobID, change.Status)
-			if !utils.IsEmptyStr(change.CheckIn) {
-				msg = fmt.Sprintf("%s, check_in=%s", msg, change.CheckIn)
-			}
-
-			evt := &hook.Event{
-				URL:       URL,
-				Timestamp: time.Now().Unix(),
-				Data:      change,
-				Message:   msg,
-			}
-
-			return hookAgent.Trigger(evt)
-		}
-
-		// Create job life cycle management controller
-		lcmCtl := lcm.NewController(rootContext, namespace, redisPool, hookCallback)
-
-		// Start the backend worker
-		backendWorker, err = bs.loadAndRunRedisWorkerPool(
-			rootContext,
-			namespace,
-			workerNum,
-			redisPool,
-			lcmCtl,
-		)
-		if err != nil {
-			return errors.Errorf("load and run worker error: %s", err)
-		}
-
-		// Run daemon process of life cycle controller
-		// Ignore returned error
-		if err = lcmCtl.Serve(); err != nil {
-			return errors.Errorf("start life cycle controller error: %s", err)
-		}
-
-		// Start agent
-		// Non blocking call
-		hookAgent.Attach(lcmCtl)
-		if err = hookAgent.Serve(); err != nil {
-			return errors.Errorf("start hook agent error: %s", err)
-		}
-	} else {
+		backendWorker, manager, err = bs.loadAndRunPGBackend(rootContext, cfg)
+	default:
 		return errors.Errorf("worker backend '%s' is not supported", cfg.PoolConfig.Backend)
 	}
+	if err != nil {
+		return err
+	}
 
 	// Initialize controller
 	ctl := core.NewController(backendWorker, manager)
@@ -209,6 +161,103 @@ func (bs *Bootstrap) LoadAndRun(ctx context.Context, cancel context.CancelFunc)
 	return
 }
 
+// Load and run the components of the redis backend
+func (bs *Bootstrap) loadAndRunRedisBackend(ctx *env.Context, cfg *config.Configuration) (worker.Interface, mgt.Manager, error) {
+	// Number of workers
+	workerNum := cfg.PoolConfig.WorkerCount
+	// Add {} to namespace to void slot issue
+	namespace := fmt.Sprintf("{%s}", cfg.PoolConfig.RedisPoolCfg.Namespace)
+	// Get redis connection pool
+	redisPool := bs.getRedisPool(cfg.PoolConfig.RedisPoolCfg)
+
+	// Do data migration if necessary
+	rdbMigrator := migration.New(redisPool, namespace)
+	rdbMigrator.Register(migration.PolicyMigratorFactory)
+	if err := rdbMigrator.Migrate(); err != nil {
+		// Just logged, should not block the starting process
+		logger.Error(err)
+	}
+
+	// Create stats manager
+	manager := mgt.NewManager(ctx.SystemContext, namespace, redisPool)
+	// Expose the queue status of jobs as metrics
+	metrics.Register(mgt.NewCollector(manager, namespace, redisPool))
+	// Create hook agent, it's a singleton object
+	hookAgent := hook.NewAgent(ctx, namespace, redisPool)
+
+	// Create job life cycle management controller
+	lcmCtl := lcm.NewController(ctx, namespace, redisPool, hookCallback(hookAgent))
+
+	// Start the backend worker
+	backendWorker, err := bs.loadAndRunRedisWorkerPool(
+		ctx,
+		namespace,
+		workerNum,
+		redisPool,
+		lcmCtl,
+		cfg.PoolConfig.JobOptions,
+	)
+	if err != nil {
+		return nil, nil, errors.Errorf("load and run worker error: %s", err)
+	}
+
+	if err := bs.serve(lcmCtl, hookAgent); err != nil {
+		return nil, nil, err
+	}
+
+	return backendWorker, manager, nil
+}
+
+// Load and run the components of the PostgreSQL backend
+func (bs *Bootstrap) loadAndRunPGBackend(ctx *env.Context, cfg *config.Configuration) (worker.Interface, mgt.Manager, error) {
+	var pollInterval time.Duration
+	if cfg.PoolConfig.PostgreSQLPoolCfg != nil {
+		pollInterval = time.Duration(cfg.PoolConfig.PostgreSQLPoolCfg.PollIntervalSecond) * time.Second
+	}
+
+	// Create stats manager
+	manager := mgt.NewPGManager(ctx.SystemContext)
+	// Expose the queue status of jobs as metrics
+	metrics.Register(mgt.NewPGCollector(manager))
+	// Create hook agent, it's a singleton object
+	hookAgent := hook.NewPGAgent(ctx)
+
+	// Create job life cycle management controller
+	lcmCtl := lcm.NewPGController(ctx, hookCallback(hookAgent))
+
+	// Start the backend worker
+	pgWorker := pworker.NewWorker(ctx, cfg.PoolConfig.WorkerCount, pollInterval, lcmCtl, cfg.PoolConfig.JobOptions)
+	if err := pgWorker.RegisterJobs(knownJobs()); err != nil {
+		return nil, nil, errors.Errorf("load and run worker error: %s", err)
+	}
+	if err := pgWorker.Start(); err != nil {
+		return nil, nil, errors.Errorf("load and run worker error: %s", err)
+	}
+
+	if err := bs.serve(lcmCtl, hookAgent); err != nil {
+		return nil, nil, err
+	}
+
+	return pgWorker, manager, nil
+}
+
+// Run the daemon process of life cycle controller and the hook agent
+func (bs *Bootstrap) serve(lcmCtl lcm.Controller, hookAgent hook.Agent) error {
+	// Ignore returned error
+	if err := lcmCtl.Serve(); err != nil {
+		return errors.Errorf("start life cycle controller error: %s", err)
+	}
+
+	// Start agent
+	// Non blocking call
+	hookAgent.Attach(lcmCtl)
+	if err := hookAgent.Serve(); err != nil {
+		return errors.Errorf("start hook agent error: %s", err)
+	}
+
+	return nil
+}
+
 // Load and run the API server.
 func (bs *Bootstrap) createAPIServer(ctx context.Context, cfg *config.Configuration, ctl core.Interface) *api.Server {
 	// Initialized API server
@@ -234,23 +283,11 @@ func (bs *Bootstrap) loadAndRunRedisWorkerPool(
 	workers uint,
 	redisPool *redis.Pool,
 	lcmCtl lcm.Controller,
+	jobOptions map[string]*config.JobOptions,
 ) (worker.Interface, error) {
-	redisWorker := cworker.NewWorker(ctx, ns, workers, redisPool, lcmCtl)
+	redisWorker := cworker.NewWorker(ctx, ns, workers, redisPool, lcmCtl, jobOptions)
 	// Register jobs here
-	if err := redisWorker.RegisterJobs(
-		map[string]interface{}{
-			// Only for debugging and testing purpose
-			job.SampleJob: (*sample.Job)(nil),
-			// Functional jobs
-			job.ImageScanJob:           (*scan.ClairJob)(nil),
-			job.ImageScanAllJob:        (*scan.All)(nil),
-			job.ImageGC:                (*gc.GarbageCollector)(nil),
-			job.Replication:            (*replication.Replication)(nil),
-			job.ReplicationScheduler:   (*replication.Scheduler)(nil),
-			job.Retention:              (*retention.Job)(nil),
-			scheduler.JobNameScheduler: (*scheduler.PeriodicJob)(nil),
-			job.WebhookJob:             (*notification.WebhookJob)(nil),
-		}); err != nil {
+	if err := redisWorker.RegisterJobs(knownJobs()); err != nil {
 		// exit
 		return nil, err
 	}
@@ -262,6 +299,44 @@ func (bs *Bootstrap) loadAndRunRedisWorkerPool(
 	return redisWorker, nil
 }
 
+// hookCallback triggers the hook event of the status change with the hook agent
+func hookCallback(hookAgent hook.Agent) job.HookCallback {
+	return func(URL string, change *job.StatusChange) error {
+		msg := fmt.Sprintf("status change: job=%s, status=%s", change.JobID, change.Status)
+		if !utils.IsEmptyStr(change.CheckIn) {
+			msg = fmt.Sprintf("%s, check_in=%s", msg, change.CheckIn)
+		}
+
+		evt := &hook.Event{
+			URL:       URL,
+			Timestamp: time.Now().Unix(),
+			Data:      change,
+			Message:   msg,
+		}
+
+		return hookAgent.Trigger(evt)
+	}
+}
+
+// knownJobs returns the jobs registered to the worker, key is the job name
+func knownJobs() map[string]interface{} {
+	return map[string]interface{}{
+		// Only for debugging and testing purpose
+		job.SampleJob: (*sample.Job)(nil),
+		// Functional jobs
+		job.ImageScanJob:           (*scan.ClairJob)(nil),
+		job.ImageScanAllJob:        (*scan.All)(nil),
+		job.ImageGC:                (*gc.GarbageCollector)(nil),
+		job.AuditLogPurge:          (*auditlog.Purge)(nil),
+		job.Replication:            (*replication.Replication)(nil),
+		job.ReplicationScheduler:   (*replication.Scheduler)(nil),
+		job.Retention:              (*retention.Job)(nil),
+		scheduler.JobNameScheduler: (*scheduler.PeriodicJob)(nil),
+		job.WebhookJob:             (*notification.WebhookJob)(nil),
+		job.EmailJob:               (*notification.EmailJob)(nil),
+	}
+}
+
 // Get a redis connection pool
 func (bs *Bootstrap) getRedisPool(redisPoolConfig *config.RedisPoolConfig) *redis.Pool {
 	return &redis.Pool{
@@ -21,7 +21,9 @@ import (
 	"time"
 
 	"github.com/gocraft/work"
+	"github.com/goharbor/harbor/src/jobservice/common/rds"
 	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/config"
 	"github.com/goharbor/harbor/src/jobservice/env"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/lcm"
@@ -42,6 +44,7 @@ const (
 	workerPoolStatusDead         = "Dead"
 	pingRedisMaxTimes            = 10
 	defaultWorkerCount      uint = 10
+	defaultJobPriority      uint = 1
 )
 
 // basicWorker is the worker implementation based on gocraft/work powered by redis.
@@ -58,6 +61,10 @@ type basicWorker struct {
 	// key is name of known job
 	// value is the type of known job
 	knownJobs *sync.Map
+
+	// key is name of job
+	// value is the priority and concurrency limit of the job
+	jobOptions map[string]*config.JobOptions
 }
 
 // workerContext ...
@@ -76,22 +83,27 @@ func (rpc *workerContext) logJob(job *work.Job, next work.NextMiddlewareFunc) er
 }
 
 // NewWorker is constructor of worker
-func NewWorker(ctx *env.Context, namespace string, workerCount uint, redisPool *redis.Pool, ctl lcm.Controller) worker.Interface {
+func NewWorker(ctx *env.Context, namespace string, workerCount uint, redisPool *redis.Pool, ctl lcm.Controller, jobOptions map[string]*config.JobOptions) worker.Interface {
 	wc := defaultWorkerCount
 	if workerCount > 0 {
 		wc = workerCount
 	}
 
+	if jobOptions == nil {
+		jobOptions = make(map[string]*config.JobOptions)
+	}
+
 	return &basicWorker{
-		namespace: namespace,
-		redisPool: redisPool,
-		pool:      work.NewWorkerPool(workerContext{}, wc, namespace, redisPool),
-		enqueuer:  work.NewEnqueuer(namespace, redisPool),
-		client:    work.NewClient(namespace, redisPool),
-		scheduler: period.NewScheduler(ctx.SystemContext, namespace, redisPool, ctl),
-		ctl:       ctl,
-		context:   ctx,
-		knownJobs: new(sync.Map),
+		namespace:  namespace,
+		redisPool:  redisPool,
+		pool:       work.NewWorkerPool(workerContext{}, wc, namespace, redisPool),
+		enqueuer:   work.NewEnqueuer(namespace, redisPool),
+		client:     work.NewClient(namespace, redisPool),
+		scheduler:  period.NewScheduler(ctx.SystemContext, namespace, redisPool, ctl),
+		ctl:        ctl,
+		context:    ctx,
+		knownJobs:  new(sync.Map),
+		jobOptions: jobOptions,
 	}
 }
 
@@ -304,11 +316,64 @@ func (w *basicWorker) Stats() (*worker.Stats, error) {
 		return nil, errors.New("failed to get stats of worker pools")
 	}
 
+	jobs, err := w.jobStats()
+	if err != nil {
+		return nil, err
+	}
+
 	return &worker.Stats{
 		Pools: stats,
+		Jobs:  jobs,
 	}, nil
 }
 
+// jobStats returns the options and the queue status of the known jobs
+func (w *basicWorker) jobStats() ([]*worker.JobStatsData, error) {
+	queues, err := w.client.Queues()
+	if err != nil {
+		return nil, err
+	}
+
+	conn := w.redisPool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	jobs := make([]*worker.JobStatsData, 0, len(queues))
+	for _, q := range queues {
+		// the lock is the count of the running jobs kept by the backend worker pool
+		running, err := redis.Int64(conn.Do("GET", rds.RedisKeyJobsLock(w.namespace, q.JobName)))
+		if err != nil && err != redis.ErrNil {
+			return nil, err
+		}
+
+		priority, maxConcurrency := w.getJobOptions(q.JobName)
+		jobs = append(jobs, &worker.JobStatsData{
+			JobName:        q.JobName,
+			Priority:       priority,
+			MaxConcurrency: maxConcurrency,
+			Pending:        q.Count,
+			Running:        running,
+			Latency:        q.Latency,
+		})
+	}
+
+	return jobs, nil
+}
+
+// getJobOptions returns the priority and concurrency limit of the job
+func (w *basicWorker) getJobOptions(name string) (priority uint, maxConcurrency uint) {
+	priority = defaultJobPriority
+	if opts, ok := w.jobOptions[name]; ok {
+		if opts.Priority > 0 {
+			priority = opts.Priority
+		}
+		maxConcurrency = opts.MaxConcurrency
+	}
+
+	return
+}
+
 // StopJob will stop the job
 func (w *basicWorker) StopJob(jobID string) error {
 	if utils.IsEmptyStr(jobID) {
@@ -345,8 +410,69 @@ func (w *basicWorker) StopJob(jobID string) error {
 }
 
 // RetryJob retry the job
+// Only the failed or stopped job can be retried, it's put back to the queue with the same ID
+// and a new revision, so the status changes of the previous run are ignored by the hook receivers.
 func (w *basicWorker) RetryJob(jobID string) error {
-	return errors.New("not implemented")
+	if utils.IsEmptyStr(jobID) {
+		return errors.New("empty job ID to retry")
+	}
+
+	t, err := w.ctl.Track(jobID)
+	if err != nil {
+		return err
+	}
+
+	info := t.Job().Info
+	status := job.Status(info.Status)
+	if status != job.ErrorStatus && status != job.StoppedStatus {
+		return errors.Errorf("mismatch job status for retrying job: %s, job status %s is not %s or %s", jobID, status, job.ErrorStatus, job.StoppedStatus)
+	}
+
+	if info.JobKind == job.KindPeriodic {
+		return errors.Errorf("periodic job %s can not be retried, retry its executions instead", jobID)
+	}
+
+	j := &work.Job{
+		Name:       info.JobName,
+		ID:         jobID,
+		EnqueuedAt: time.Now().Unix(),
+		Args:       info.Parameters,
+	}
+	// The execution of periodic job uses the ID of its policy
+	if !utils.IsEmptyStr(info.UpstreamJobID) {
+		j.ID = info.UpstreamJobID
+		j.Args = make(job.Parameters)
+		for k, v := range info.Parameters {
+			j.Args[k] = v
+		}
+		j.Args[period.PeriodicExecutionMark] = fmt.Sprintf("%d", info.RunAt)
+	}
+
+	rawJSON, err := utils.SerializeJob(j)
+	if err != nil {
+		return err
+	}
+
+	// Reset the status before putting the job back to the queue,
+	// otherwise the runner may reject it as it's still in the final status
+	if err := t.Reset(); err != nil {
+		return err
+	}
+
+	conn := w.redisPool.Get()
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	if _, err := conn.Do("LPUSH", rds.RedisKeyJobs(w.namespace, info.JobName), rawJSON); err != nil {
+		// Put the status back as the job will not be run
+		if er := t.Update("status", status.String()); er != nil {
+			logger.Errorf("Restore the status of job %s error: %s", jobID, er)
+		}
+		return err
+	}
+
+	return nil
 }
 
 // IsKnownJob ...
@@ -401,12 +527,15 @@ func (w *basicWorker) registerJob(name string, j interface{}) (err error) {
 	redisJob := runner.NewRedisJob(j, w.context, w.ctl)
 	// Get more info from j
 	theJ := runner.Wrap(j)
+	priority, maxConcurrency := w.getJobOptions(name)
 	// Put into the pool
 	w.pool.JobWithOptions(
 		name,
 		work.JobOptions{
-			MaxFails: theJ.MaxFails(),
-			SkipDead: true,
+			MaxFails:       theJ.MaxFails(),
+			SkipDead:       true,
+			Priority:       priority,
+			MaxConcurrency: maxConcurrency,
 		},
 		// Use generic handler to handle as we do not accept context with this way.
 		func(job *work.Job) error {
@@ -416,7 +545,7 @@ func (w *basicWorker) registerJob(name string, j interface{}) (err error) {
 	// Keep the name of registered jobs as known jobs for future validation
 	w.knownJobs.Store(name, j)
 
-	logger.Infof("Register job %s with name %s", reflect.TypeOf(j).String(), name)
+	logger.Infof("Register job %s with name %s, priority: %d, max concurrency: %d", reflect.TypeOf(j).String(), name, priority, maxConcurrency)
 
 	return nil
 }
@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/config"
 	"github.com/goharbor/harbor/src/jobservice/env"
 	"github.com/goharbor/harbor/src/jobservice/job"
 	"github.com/goharbor/harbor/src/jobservice/lcm"
@@ -71,7 +72,12 @@ func (suite *CWorkerTestSuite) SetupSuite() {
 		func(hookURL string, change *job.StatusChange) error { return nil },
 	)
 
-	suite.cWorker = NewWorker(envCtx, suite.namespace, 5, suite.pool, suite.lcmCtl)
+	suite.cWorker = NewWorker(envCtx, suite.namespace, 5, suite.pool, suite.lcmCtl, map[string]*config.JobOptions{
+		"fake_job": {
+			Priority:       100,
+			MaxConcurrency: 2,
+		},
+	})
 	err := suite.cWorker.RegisterJobs(map[string]interface{}{
 		"fake_job":          (*fakeJob)(nil),
 		"fake_long_run_job": (*fakeLongRunJob)(nil),
@@ -175,6 +181,17 @@ func (suite *CWorkerTestSuite) TestWorkerStats() {
 	stats, err := suite.cWorker.Stats()
 	require.NoError(suite.T(), err, "worker stats: nil error expected but got %s", err)
 	assert.Equal(suite.T(), 1, len(stats.Pools), "expected 1 pool but got 0")
+
+	for _, j := range stats.Jobs {
+		switch j.JobName {
+		case "fake_job":
+			assert.Equal(suite.T(), uint(100), j.Priority, "expected priority 100 but got %d", j.Priority)
+			assert.Equal(suite.T(), uint(2), j.MaxConcurrency, "expected max concurrency 2 but got %d", j.MaxConcurrency)
+		case "fake_long_run_job":
+			assert.Equal(suite.T(), uint(1), j.Priority, "expected default priority 1 but got %d", j.Priority)
+			assert.Equal(suite.T(), uint(0), j.MaxConcurrency, "expected no max concurrency but got %d", j.MaxConcurrency)
+		}
+	}
 }
 
 // TestStopJob test stop job
@@ -219,6 +236,45 @@ LOOP:
 	require.NoError(suite.T(), err, "stop job: nil error expected but got %s", err)
 }
 
+// TestRetryJob ...
+func (suite *CWorkerTestSuite) TestRetryJob() {
+	params := make(map[string]interface{})
+	params["name"] = "testing:v1"
+
+	scheduledJob, err := suite.cWorker.Schedule("fake_job", params, 120, false, "")
+	require.NoError(suite.T(), err, "schedule job: nil error expected but got %s", err)
+	t, err := suite.lcmCtl.New(scheduledJob)
+	require.NoError(suite.T(), err, "new job stats: nil error expected but got %s", err)
+
+	err = suite.cWorker.RetryJob(scheduledJob.Info.JobID)
+	require.Error(suite.T(), err, "retry scheduled job: non nil error expected but got nil")
+
+	err = suite.cWorker.StopJob(scheduledJob.Info.JobID)
+	require.NoError(suite.T(), err, "stop job: nil error expected but got %s", err)
+
+	err = suite.cWorker.RetryJob(scheduledJob.Info.JobID)
+	require.NoError(suite.T(), err, "retry stopped job: nil error expected but got %s", err)
+
+	tk := time.NewTicker(500 * time.Millisecond)
+	defer tk.Stop()
+	timeout := time.After(30 * time.Second)
+
+LOOP:
+	for {
+		select {
+		case <-tk.C:
+			latest, err := t.Status()
+			require.NoError(suite.T(), err, "get latest status: nil error expected but got %s", err)
+			if latest == job.SuccessStatus {
+				break LOOP
+			}
+		case <-timeout:
+			require.NoError(suite.T(), errors.New("check success status of retried job time out"))
+			break LOOP
+		}
+	}
+}
+
 type fakeJob struct{}
 
 func (j *fakeJob) MaxFails() uint {
@@ -16,7 +16,8 @@ package worker
 
 // Stats represents the healthy and status of all the running worker pools.
 type Stats struct {
-	Pools []*StatsData `json:"worker_pools"`
+	Pools []*StatsData    `json:"worker_pools"`
+	Jobs  []*JobStatsData `json:"jobs,omitempty"`
 }
 
 // StatsData represents the healthy and status of the worker worker.
@@ -28,3 +29,13 @@ type StatsData struct {
 	Concurrency  uint     `json:"concurrency"`
 	Status       string   `json:"status"`
 }
+
+// JobStatsData represents the options and queue status of one kind of job
+type JobStatsData struct {
+	JobName        string `json:"job_name"`
+	Priority       uint   `json:"priority"`
+	MaxConcurrency uint   `json:"max_concurrency"`
+	Pending        int64  `json:"pending"`
+	Running        int64  `json:"running"`
+	Latency        int64  `json:"latency"`
+}
@@ -0,0 +1,998 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pworker
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/astaxie/beego/orm"
+	"github.com/gocraft/work"
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/config"
+	"github.com/goharbor/harbor/src/jobservice/env"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/lcm"
+	"github.com/goharbor/harbor/src/jobservice/logger"
+	"github.com/goharbor/harbor/src/jobservice/period"
+	"github.com/goharbor/harbor/src/jobservice/runner"
+	"github.com/goharbor/harbor/src/jobservice/worker"
+	"github.com/pkg/errors"
+	"github.com/robfig/cron"
+)
+
+const (
+	workerPoolStatusHealthy      = "Healthy"
+	defaultWorkerCount      uint = 10
+	defaultJobPriority      uint = 1
+	defaultPollInterval          = 2 * time.Second
+	periodicEnqueueInterval      = 2 * time.Minute
+	periodicEnqueueHorizon       = 4 * time.Minute
+	reapInterval                 = 10 * time.Minute
+	pingDatabaseMaxTimes         = 10
+	// the lease of the claimed jobs, it's renewed by the heartbeat of the node running the jobs,
+	// the jobs whose lease expires are taken as interrupted and put back to the queue by any node
+	jobLease          = 2 * time.Minute
+	heartbeatInterval = 20 * time.Second
+	// the key of the advisory lock which serializes the claiming of all the nodes
+	claimLockKey = int64(0x6a6f62)
+	// the runner sets the fails of the job to this number to cancel the retry
+	noRetry = int64(10000000000)
+)
+
+// basicWorker is the worker implementation based on the job_queue table of PostgreSQL.
+// The workers poll the queued jobs and claim them under an advisory lock shared by all the
+// jobservice nodes, so multiple nodes can share the same queue and its concurrency limits.
+type basicWorker struct {
+	context      *env.Context
+	nodeID       string
+	workerCount  uint
+	pollInterval time.Duration
+	ctl          lcm.Controller
+	startedAt    int64
+
+	// key is name of known job
+	// value is the type of known job
+	knownJobs *sync.Map
+
+	// key is name of job
+	// value is the priority and concurrency limit of the job
+	jobOptions map[string]*config.JobOptions
+}
+
+// queuedJob is the job claimed from the queue
+type queuedJob struct {
+	JobID         string `orm:"column(job_id)"`
+	JobName       string `orm:"column(job_name)"`
+	UpstreamJobID string `orm:"column(upstream_job_id)"`
+	Parameters    string `orm:"column(parameters)"`
+	Fails         int64  `orm:"column(fails)"`
+	FailedAt      int64  `orm:"column(failed_at)"`
+	LastErr       string `orm:"column(last_err)"`
+	RunAt         int64  `orm:"column(run_at)"`
+	EnqueueTime   int64  `orm:"column(enqueue_time)"`
+}
+
+// NewWorker is constructor of worker
+func NewWorker(ctx *env.Context, workerCount uint, pollInterval time.Duration, ctl lcm.Controller, jobOptions map[string]*config.JobOptions) worker.Interface {
+	wc := defaultWorkerCount
+	if workerCount > 0 {
+		wc = workerCount
+	}
+
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+
+	if jobOptions == nil {
+		jobOptions = make(map[string]*config.JobOptions)
+	}
+
+	return &basicWorker{
+		context:      ctx,
+		workerCount:  wc,
+		pollInterval: pollInterval,
+		ctl:          ctl,
+		knownJobs:    new(sync.Map),
+		jobOptions:   jobOptions,
+	}
+}
+
+// Start to serve
+// Unblock action
+func (w *basicWorker) Start() error {
+	if w.context == nil || w.context.SystemContext == nil {
+		// report and exit
+		return errors.New("missing context")
+	}
+
+	if w.ctl == nil {
+		return errors.New("missing job life cycle controller")
+	}
+
+	nodeID, ok := w.context.SystemContext.Value(utils.NodeID).(string)
+	if !ok || utils.IsEmptyStr(nodeID) {
+		return errors.New("missing node ID in the system context")
+	}
+	w.nodeID = nodeID
+
+	// Test the database connection
+	if err := w.ping(); err != nil {
+		return err
+	}
+
+	// The jobs claimed by this node before restarting are interrupted, put them back to the queue
+	if err := w.requeueInterruptedJobs(); err != nil {
+		return err
+	}
+
+	w.startedAt = time.Now().Unix()
+
+	for i := uint(0); i < w.workerCount; i++ {
+		w.context.WG.Add(1)
+		go w.loop(fmt.Sprintf("%s-%d", w.nodeID, i))
+	}
+
+	w.context.WG.Add(1)
+	go w.loopPeriodicEnqueue()
+
+	w.context.WG.Add(1)
+	go w.loopReap()
+
+	w.context.WG.Add(1)
+	go w.loopHeartbeat()
+
+	logger.Infof("PostgreSQL worker is started with %d workers", w.workerCount)
+
+	return nil
+}
+
+// RegisterJobs is used to register multiple jobs to worker.
+func (w *basicWorker) RegisterJobs(jobs map[string]interface{}) error {
+	if jobs == nil || len(jobs) == 0 {
+		// Do nothing
+		return nil
+	}
+
+	for name, j := range jobs {
+		if err := w.registerJob(name, j); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Enqueue job
+func (w *basicWorker) Enqueue(jobName string, params job.Parameters, isUnique bool, webHook string) (*job.Stats, error) {
+	res := generateResult(utils.MakeIdentifier(), jobName, job.KindGeneric, isUnique, params, webHook)
+	res.Info.RunAt = res.Info.EnqueueTime
+
+	if err := w.enqueue(res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Schedule job
+func (w *basicWorker) Schedule(jobName string, params job.Parameters, runAfterSeconds uint64, isUnique bool, webHook string) (*job.Stats, error) {
+	res := generateResult(utils.MakeIdentifier(), jobName, job.KindScheduled, isUnique, params, webHook)
+	res.Info.RunAt = res.Info.EnqueueTime + int64(runAfterSeconds)
+	res.Info.Status = job.ScheduledStatus.String()
+
+	if err := w.enqueue(res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// PeriodicallyEnqueue job
+func (w *basicWorker) PeriodicallyEnqueue(jobName string, params job.Parameters, cronSetting string, isUnique bool, webHook string) (*job.Stats, error) {
+	p := &period.Policy{
+		ID:            utils.MakeIdentifier(),
+		JobName:       jobName,
+		CronSpec:      cronSetting,
+		JobParameters: params,
+		WebHookURL:    webHook,
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	res := generateResult(p.ID, jobName, job.KindPeriodic, isUnique, params, webHook)
+	res.Info.Status = job.ScheduledStatus.String()
+	res.Info.CronSpec = cronSetting
+
+	// The periodic job is kept as the policy of the executions, it's not put into the queue
+	paramsJSON, err := marshalParameters(params)
+	if err != nil {
+		return nil, err
+	}
+
+	o := dao.GetOrmer()
+	if err := o.Raw(`INSERT INTO job_queue (job_id, job_name, kind, is_unique, status, ref_link, parameters,
+		cron_spec, web_hook_url, queued, enqueue_time, update_time, revision)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, false, ?, ?, ?) RETURNING id`,
+		p.ID, jobName, job.KindPeriodic, isUnique, res.Info.Status, res.Info.RefLink, paramsJSON,
+		cronSetting, webHook, res.Info.EnqueueTime, res.Info.UpdateTime, res.Info.UpdateTime).QueryRow(&res.Info.NumericPID); err != nil {
+		return nil, err
+	}
+
+	if _, err := o.Raw("UPDATE job_queue SET numeric_policy_id = ? WHERE job_id = ?", res.Info.NumericPID, p.ID).Exec(); err != nil {
+		return nil, err
+	}
+
+	// Do the 1st round of enqueuing
+	w.scheduleNextJobs(p)
+
+	return res, nil
+}
+
+// Stats of worker
+func (w *basicWorker) Stats() (*worker.Stats, error) {
+	if err := dao.GetOrmer().Raw("SELECT 1").QueryRow(new(int)); err != nil {
+		return nil, errors.Wrap(err, "failed to get stats of worker pools")
+	}
+
+	jobNames := make([]string, 0)
+	w.knownJobs.Range(func(name interface{}, _ interface{}) bool {
+		jobNames = append(jobNames, name.(string))
+		return true
+	})
+
+	jobs, err := w.jobStats(jobNames)
+	if err != nil {
+		return nil, err
+	}
+
+	return &worker.Stats{
+		Pools: []*worker.StatsData{
+			{
+				WorkerPoolID: w.nodeID,
+				StartedAt:    w.startedAt,
+				HeartbeatAt:  time.Now().Unix(),
+				JobNames:     jobNames,
+				Concurrency:  w.workerCount,
+				Status:       workerPoolStatusHealthy,
+			},
+		},
+		Jobs: jobs,
+	}, nil
+}
+
+// jobStats returns the options and the queue status of the known jobs
+func (w *basicWorker) jobStats(jobNames []string) ([]*worker.JobStatsData, error) {
+	var queues []*struct {
+		JobName string `orm:"column(job_name)"`
+		Pending int64  `orm:"column(pending)"`
+		Running int64  `orm:"column(running)"`
+		Oldest  int64  `orm:"column(oldest)"`
+	}
+
+	now := time.Now().Unix()
+	if _, err := dao.GetOrmer().Raw(`SELECT job_name,
+		SUM(CASE WHEN queued AND run_at <= ? THEN 1 ELSE 0 END) AS pending,
+		SUM(CASE WHEN worker_id IS NOT NULL THEN 1 ELSE 0 END) AS running,
+		MIN(CASE WHEN queued AND run_at <= ? THEN run_at ELSE NULL END) AS oldest
+		FROM job_queue WHERE queued OR worker_id IS NOT NULL GROUP BY job_name`,
+		now, now).QueryRows(&queues); err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*worker.JobStatsData, 0, len(jobNames))
+	for _, name := range jobNames {
+		priority, maxConcurrency := w.getJobOptions(name)
+		data := &worker.JobStatsData{
+			JobName:        name,
+			Priority:       priority,
+			MaxConcurrency: maxConcurrency,
+		}
+		for _, q := range queues {
+			if q.JobName == name {
+				data.Pending = q.Pending
+				data.Running = q.Running
+				if q.Oldest > 0 {
+					data.Latency = now - q.Oldest
+				}
+			}
+		}
+		jobs = append(jobs, data)
+	}
+
+	return jobs, nil
+}
+
+// getJobOptions returns the priority and concurrency limit of the job
+func (w *basicWorker) getJobOptions(name string) (priority uint, maxConcurrency uint) {
+	priority = defaultJobPriority
+	if opts, ok := w.jobOptions[name]; ok {
+		if opts.Priority > 0 {
+			priority = opts.Priority
+		}
+		maxConcurrency = opts.MaxConcurrency
+	}
+
+	return
+}
+
+// StopJob will stop the job
+func (w *basicWorker) StopJob(jobID string) error {
+	if utils.IsEmptyStr(jobID) {
+		return errors.New("empty job ID to stop")
+	}
+
+	t, err := w.ctl.Track(jobID)
+	if err != nil {
+		return err
+	}
+
+	if job.RunningStatus.Compare(job.Status(t.Job().Info.Status)) < 0 {
+		// Job has been in the final states
+		return errors.Errorf("mismatch job status for stopping job: %s, job status %s is behind %s", jobID, t.Job().Info.Status, job.RunningStatus)
+	}
+
+	switch t.Job().Info.JobKind {
+	case job.KindGeneric, job.KindScheduled:
+		// Remove the job from the queue if it is not running yet, otherwise, stop it.
+		if _, err := dao.GetOrmer().Raw("UPDATE job_queue SET queued = false, unique_key = NULL WHERE job_id = ?", jobID).Exec(); err != nil {
+			logger.Errorf("Remove job %s from the queue error: %s", jobID, err)
+		}
+		// Anyway, mark job stopped
+		return t.Stop()
+	case job.KindPeriodic:
+		return w.unSchedule(t)
+	default:
+		return errors.Errorf("job kind %s is not supported", t.Job().Info.JobKind)
+	}
+}
+
+// RetryJob retry the job
+// Only the failed or stopped job can be retried, it's put back to the queue with the fails cleared
+// and a new revision, so the status changes of the previous run are ignored by the hook receivers.
+func (w *basicWorker) RetryJob(jobID string) error {
+	if utils.IsEmptyStr(jobID) {
+		return errors.New("empty job ID to retry")
+	}
+
+	t, err := w.ctl.Track(jobID)
+	if err != nil {
+		return err
+	}
+
+	info := t.Job().Info
+	status := job.Status(info.Status)
+	if status != job.ErrorStatus && status != job.StoppedStatus {
+		return errors.Errorf("mismatch job status for retrying job: %s, job status %s is not %s or %s", jobID, status, job.ErrorStatus, job.StoppedStatus)
+	}
+
+	if info.JobKind == job.KindPeriodic {
+		return errors.Errorf("periodic job %s can not be retried, retry its executions instead", jobID)
+	}
+
+	var uniqueKey interface{}
+	if info.IsUnique {
+		paramsJSON, err := marshalParameters(info.Parameters)
+		if err != nil {
+			return err
+		}
+		uniqueKey = makeUniqueKey(info.JobName, paramsJSON)
+	}
+
+	// Reset the status before putting the job back to the queue,
+	// otherwise the runner may reject it as it's still in the final status
+	if err := t.Reset(); err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+	res, err := dao.GetOrmer().Raw(`UPDATE job_queue SET queued = true, unique_key = ?, fails = 0, failed_at = 0,
+		last_err = NULL, run_at = ?, update_time = ? WHERE job_id = ? AND NOT queued AND worker_id IS NULL`,
+		uniqueKey, now, now, jobID).Exec()
+	if err == nil {
+		var n int64
+		if n, err = res.RowsAffected(); err == nil && n == 0 {
+			err = errors.Errorf("job %s is still in the queue", jobID)
+		}
+	}
+
+	if err != nil {
+		// Put the status back as the job will not be run
+		if er := t.Update("status", status.String()); er != nil {
+			logger.Errorf("Restore the status of job %s error: %s", jobID, er)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// IsKnownJob ...
+func (w *basicWorker) IsKnownJob(name string) (interface{}, bool) {
+	return w.knownJobs.Load(name)
+}
+
+// ValidateJobParameters ...
+func (w *basicWorker) ValidateJobParameters(jobType interface{}, params job.Parameters) error {
+	if jobType == nil {
+		return errors.New("nil job type")
+	}
+
+	theJ := runner.Wrap(jobType)
+	return theJ.Validate(params)
+}
+
+// RegisterJob is used to register the job to the worker.
+// j is the type of job
+func (w *basicWorker) registerJob(name string, j interface{}) (err error) {
+	if utils.IsEmptyStr(name) || j == nil {
+		return errors.New("job can not be registered with empty name or nil interface")
+	}
+
+	// j must be job.Interface
+	if _, ok := j.(job.Interface); !ok {
+		return errors.Errorf("job must implement the job.Interface: %s", reflect.TypeOf(j).String())
+	}
+
+	// 1:1 constraint
+	if jInList, ok := w.knownJobs.Load(name); ok {
+		return fmt.Errorf("job name %s has been already registered with %s", name, reflect.TypeOf(jInList).String())
+	}
+
+	// Same job implementation can be only registered with one name
+	w.knownJobs.Range(func(jName interface{}, jInList interface{}) bool {
+		jobImpl := reflect.TypeOf(j).String()
+		if reflect.TypeOf(jInList).String() == jobImpl {
+			err = errors.Errorf("job %s has been already registered with name %s", jobImpl, jName)
+			return false
+		}
+
+		return true
+	})
+
+	// Something happened in the range
+	if err != nil {
+		return
+	}
+
+	// Keep the name of registered jobs as known jobs for claiming and future validation
+	w.knownJobs.Store(name, j)
+
+	priority, maxConcurrency := w.getJobOptions(name)
+	logger.Infof("Register job %s with name %s, priority: %d, max concurrency: %d", reflect.TypeOf(j).String(), name, priority, maxConcurrency)
+
+	return nil
+}
+
+// enqueue puts the job into the queue, the unique job is rejected
+// if the same one is already in the queue
+func (w *basicWorker) enqueue(stats *job.Stats) error {
+	info := stats.Info
+
+	paramsJSON, err := marshalParameters(info.Parameters)
+	if err != nil {
+		return err
+	}
+
+	var uniqueKey interface{}
+	if info.IsUnique {
+		uniqueKey = makeUniqueKey(info.JobName, paramsJSON)
+	}
+
+	priority, _ := w.getJobOptions(info.JobName)
+	res, err := dao.GetOrmer().Raw(`INSERT INTO job_queue (job_id, job_name, kind, is_unique, unique_key, status,
+		ref_link, parameters, web_hook_url, priority, queued, run_at, enqueue_time, update_time, revision)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, true, ?, ?, ?, ?) ON CONFLICT DO NOTHING`,
+		info.JobID, info.JobName, info.JobKind, info.IsUnique, uniqueKey, info.Status,
+		info.RefLink, paramsJSON, info.WebHookURL, priority, info.RunAt, info.EnqueueTime, info.UpdateTime, info.UpdateTime).Exec()
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.Errorf("job '%s' can not be enqueued: the same unique job is already in the queue", info.JobName)
+	}
+
+	return nil
+}
+
+// loop claims the queued jobs and runs them one by one until the system context is done
+func (w *basicWorker) loop(workerID string) {
+	defer func() {
+		logger.Debugf("Worker %s is stopped", workerID)
+		w.context.WG.Done()
+	}()
+
+	for {
+		select {
+		case <-w.context.SystemContext.Done():
+			return
+		default:
+		}
+
+		qj, err := w.claim(workerID)
+		if err != nil {
+			logger.Errorf("Worker %s claims job error: %s", workerID, err)
+		}
+
+		if qj == nil {
+			// Nothing to do, wait for a while or be terminated
+			select {
+			case <-time.After(w.pollInterval):
+			case <-w.context.SystemContext.Done():
+				return
+			}
+			continue
+		}
+
+		w.run(qj, workerID)
+	}
+}
+
+// claim takes the queued job with the highest priority, nil is returned if no job can be run now.
+// The running jobs are counted and the job is claimed in the same transaction holding the advisory
+// lock, so the concurrency limit is respected by all the nodes.
+func (w *basicWorker) claim(workerID string) (*queuedJob, error) {
+	var qj *queuedJob
+	err := dao.WithTransaction(func(o orm.Ormer) error {
+		// The lock is released when the transaction ends
+		if _, err := o.Raw("SELECT pg_advisory_xact_lock(?)", claimLockKey).Exec(); err != nil {
+			return err
+		}
+
+		jobNames, err := w.claimableJobs(o)
+		if err != nil {
+			return err
+		}
+		if len(jobNames) == 0 {
+			return nil
+		}
+
+		now := time.Now().Unix()
+		args := []interface{}{w.nodeID, workerID, now, now + int64(jobLease/time.Second), now}
+		for _, name := range jobNames {
+			args = append(args, name)
+		}
+
+		claimed := &queuedJob{}
+		// The unique key only restricts the jobs in the queue
+		err = o.Raw(fmt.Sprintf(`UPDATE job_queue SET queued = false, unique_key = NULL,
+			pool_id = ?, worker_id = ?, update_time = ?, lease_expire_at = ? WHERE id = (
+			SELECT id FROM job_queue WHERE queued AND run_at <= ? AND job_name IN (%s)
+			ORDER BY priority DESC, run_at, id LIMIT 1 FOR UPDATE SKIP LOCKED)
+			RETURNING job_id, job_name, upstream_job_id, parameters, fails, failed_at, last_err, run_at, enqueue_time`,
+			placeholders(len(jobNames))), args...).QueryRow(claimed)
+		if err != nil {
+			if err == orm.ErrNoRows {
+				return nil
+			}
+			return err
+		}
+
+		qj = claimed
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return qj, nil
+}
+
+// claimableJobs returns the names of the known jobs not reaching the concurrency limit
+func (w *basicWorker) claimableJobs(o orm.Ormer) ([]string, error) {
+	var running []*struct {
+		JobName string `orm:"column(job_name)"`
+		Count   uint   `orm:"column(count)"`
+	}
+	if _, err := o.Raw(`SELECT job_name, COUNT(*) AS count FROM job_queue
+		WHERE worker_id IS NOT NULL GROUP BY job_name`).QueryRows(&running); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]uint, len(running))
+	for _, r := range running {
+		counts[r.JobName] = r.Count
+	}
+
+	jobNames := make([]string, 0)
+	w.knownJobs.Range(func(name interface{}, _ interface{}) bool {
+		_, maxConcurrency := w.getJobOptions(name.(string))
+		if maxConcurrency == 0 || counts[name.(string)] < maxConcurrency {
+			jobNames = append(jobNames, name.(string))
+		}
+		return true
+	})
+
+	return jobNames, nil
+}
+
+// run the claimed job and put it back to the queue if it should be retried,
+// the job is released only when it's still claimed by the worker
+func (w *basicWorker) run(qj *queuedJob, workerID string) {
+	j, ok := w.knownJobs.Load(qj.JobName)
+	if !ok {
+		// Should not happen as only the known jobs are claimed
+		logger.Errorf("Job %s with unknown name %s is claimed", qj.JobID, qj.JobName)
+		return
+	}
+
+	wj := qj.toWorkJob()
+	jobInfo, _ := utils.SerializeJob(&work.Job{Name: wj.Name, ID: wj.ID, EnqueuedAt: wj.EnqueuedAt, Fails: wj.Fails})
+	logger.Infof("Job incoming: %s", jobInfo)
+
+	// The runner of redis job is reused as the queued job is converted to the job of the redis worker
+	err := runner.NewRedisJob(j, w.context, w.ctl).Run(wj)
+
+	now := time.Now().Unix()
+	if err == nil {
+		if _, er := dao.GetOrmer().Raw(`UPDATE job_queue SET pool_id = NULL, worker_id = NULL, update_time = ?
+			WHERE job_id = ? AND worker_id = ?`, now, qj.JobID, workerID).Exec(); er != nil {
+			logger.Errorf("Release job %s error: %s", qj.JobID, er)
+		}
+		return
+	}
+
+	// The runner sets the fails to a big number to cancel the retry
+	fails := wj.Fails
+	if fails < noRetry {
+		fails++
+	}
+	queued := fails < int64(runner.Wrap(j).MaxFails())
+	runAt := now + backoff(fails)
+
+	if _, er := dao.GetOrmer().Raw(`UPDATE job_queue SET queued = ?, pool_id = NULL, worker_id = NULL, fails = ?,
+		failed_at = ?, last_err = ?, run_at = ?, update_time = ? WHERE job_id = ? AND worker_id = ?`,
+		queued, fails, now, err.Error(), runAt, now, qj.JobID, workerID).Exec(); er != nil {
+		logger.Errorf("Release failed job %s error: %s", qj.JobID, er)
+	}
+}
+
+// requeueInterruptedJobs puts the jobs claimed by this node before restarting back to the queue
+func (w *basicWorker) requeueInterruptedJobs() error {
+	return w.requeue("pool_id = ?", w.nodeID)
+}
+
+// requeueExpiredJobs puts the jobs whose lease expires back to the queue, they are claimed
+// by the nodes which are gone or can't renew the lease, e.g. restarted with a new node ID
+func (w *basicWorker) requeueExpiredJobs() error {
+	return w.requeue("worker_id IS NOT NULL AND lease_expire_at < ?", time.Now().Unix())
+}
+
+// requeue puts the claimed jobs matching the condition back to the queue,
+// the running ones are reset to pending to be run again
+func (w *basicWorker) requeue(condition string, args ...interface{}) error {
+	params := []interface{}{job.RunningStatus.String(), job.PendingStatus.String(), time.Now().Unix()}
+	params = append(params, args...)
+	res, err := dao.GetOrmer().Raw(`UPDATE job_queue SET queued = true, pool_id = NULL, worker_id = NULL,
+		status = CASE WHEN status = ? THEN ? ELSE status END, update_time = ? WHERE `+condition, params...).Exec()
+	if err != nil {
+		return errors.Wrap(err, "requeue interrupted jobs")
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n > 0 {
+		logger.Infof("%d interrupted jobs are put back to the queue", n)
+	}
+
+	return nil
+}
+
+// loopHeartbeat renews the lease of the jobs run by this node and puts the jobs
+// whose lease expires back to the queue periodically
+func (w *basicWorker) loopHeartbeat() {
+	defer func() {
+		w.context.WG.Done()
+	}()
+
+	for {
+		select {
+		case <-time.After(heartbeatInterval):
+			if _, err := dao.GetOrmer().Raw(`UPDATE job_queue SET lease_expire_at = ?
+				WHERE pool_id = ? AND worker_id IS NOT NULL`,
+				time.Now().Add(jobLease).Unix(), w.nodeID).Exec(); err != nil {
+				logger.Errorf("Renew the lease of jobs error: %s", err)
+			}
+			if err := w.requeueExpiredJobs(); err != nil {
+				logger.Errorf("Requeue the jobs with expired lease error: %s", err)
+			}
+		case <-w.context.SystemContext.Done():
+			return
+		}
+	}
+}
+
+// loopPeriodicEnqueue schedules the executions of the periodic jobs in the horizon periodically
+func (w *basicWorker) loopPeriodicEnqueue() {
+	defer func() {
+		logger.Info("Periodic enqueuer is stopped")
+		w.context.WG.Done()
+	}()
+
+	logger.Info("Periodic enqueuer is started")
+
+	for {
+		w.enqueuePeriodicJobs()
+
+		select {
+		case <-time.After(periodicEnqueueInterval + time.Duration(rand.Intn(5))*time.Second):
+		case <-w.context.SystemContext.Done():
+			return
+		}
+	}
+}
+
+// enqueuePeriodicJobs schedules the executions of all the periodic jobs
+func (w *basicWorker) enqueuePeriodicJobs() {
+	var policies []*struct {
+		JobID      string `orm:"column(job_id)"`
+		JobName    string `orm:"column(job_name)"`
+		CronSpec   string `orm:"column(cron_spec)"`
+		Parameters string `orm:"column(parameters)"`
+		WebHookURL string `orm:"column(web_hook_url)"`
+	}
+	if _, err := dao.GetOrmer().Raw(`SELECT job_id, job_name, cron_spec, parameters, web_hook_url FROM job_queue
+		WHERE kind = ? AND status <> ?`, job.KindPeriodic, job.StoppedStatus.String()).QueryRows(&policies); err != nil {
+		logger.Errorf("Load periodic jobs error: %s", err)
+		return
+	}
+
+	for _, p := range policies {
+		params := make(job.Parameters)
+		if len(p.Parameters) > 0 {
+			if err := json.Unmarshal([]byte(p.Parameters), &params); err != nil {
+				logger.Errorf("Invalid parameters of periodic job %s: %s", p.JobID, err)
+				continue
+			}
+		}
+
+		w.scheduleNextJobs(&period.Policy{
+			ID:            p.JobID,
+			JobName:       p.JobName,
+			CronSpec:      p.CronSpec,
+			JobParameters: params,
+			WebHookURL:    p.WebHookURL,
+		})
+	}
+}
+
+// scheduleNextJobs schedules job for next time slots based on the policy.
+// The ID of execution is generated from the policy ID and the time slot,
+// so the executions scheduled by multiple nodes are not duplicated.
+func (w *basicWorker) scheduleNextJobs(p *period.Policy) {
+	nowTime := time.Unix(time.Now().Unix(), 0)
+	horizon := nowTime.Add(periodicEnqueueHorizon)
+
+	schedule, err := cron.Parse(p.CronSpec)
+	if err != nil {
+		// The cron spec should be already checked at upper layers.
+		// Just in cases, if error occurred, ignore it
+		logger.Errorf("Invalid corn spec in periodic policy %s %s: %s", p.JobName, p.ID, err)
+		return
+	}
+
+	for t := schedule.Next(nowTime); t.Before(horizon); t = schedule.Next(t) {
+		epoch := t.Unix()
+		eID := fmt.Sprintf("%s@%d", p.ID, epoch)
+
+		execution := generateResult(eID, p.JobName, job.KindScheduled, false, p.JobParameters, p.WebHookURL)
+		execution.Info.Status = job.ScheduledStatus.String()
+		execution.Info.CronSpec = p.CronSpec
+		execution.Info.UpstreamJobID = p.ID
+		execution.Info.RunAt = epoch
+
+		paramsJSON, err := marshalParameters(p.JobParameters)
+		if err != nil {
+			logger.Errorf("Marshal parameters of periodic job %s error: %s", p.ID, err)
+			return
+		}
+
+		priority, _ := w.getJobOptions(p.JobName)
+		res, err := dao.GetOrmer().Raw(`INSERT INTO job_queue (job_id, job_name, kind, status, ref_link, parameters,
+			cron_spec, web_hook_url, upstream_job_id, priority, queued, run_at, enqueue_time, update_time, revision)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, true, ?, ?, ?, ?) ON CONFLICT (job_id) DO NOTHING`,
+			eID, p.JobName, job.KindScheduled, execution.Info.Status, execution.Info.RefLink, paramsJSON,
+			p.CronSpec, p.WebHookURL, p.ID, priority, epoch, execution.Info.EnqueueTime,
+			execution.Info.UpdateTime, execution.Info.UpdateTime).Exec()
+		if err != nil {
+			logger.Errorf("Put the execution of the periodic job '%s' to the queue error: %s", p.ID, err)
+			return
+		}
+
+		if n, err := res.RowsAffected(); err == nil && n > 0 {
+			// Save the stats to set the expiration of the new execution
+			if _, err := w.ctl.New(execution); err != nil {
+				logger.Errorf("Save stats data of job execution '%s' error: %s", eID, err)
+			}
+			logger.Debugf("Scheduled execution for periodic job %s:%s at %d", p.JobName, p.ID, epoch)
+		}
+	}
+}
+
+// unSchedule stops the periodic job and its executions not finished yet
+func (w *basicWorker) unSchedule(t job.Tracker) error {
+	policyID := t.Job().Info.JobID
+
+	// Expire periodic job stats
+	if err := t.Expire(); err != nil {
+		logger.Error(err)
+	}
+
+	// Switch the job stats to stopped
+	// Should not block the next clear action
+	err := t.Stop()
+
+	// Remove the executions not running yet from the queue and stop the ones not finished.
+	// This is a try best action, its failure will not cause the unschedule action failed.
+	o := dao.GetOrmer()
+	if _, er := o.Raw("UPDATE job_queue SET queued = false WHERE upstream_job_id = ? AND queued", policyID).Exec(); er != nil {
+		logger.Errorf("Remove executions of periodic job %s from the queue error: %s", policyID, er)
+	}
+
+	var eIDs []string
+	if _, er := o.Raw("SELECT job_id FROM job_queue WHERE upstream_job_id = ? AND status IN (?, ?, ?)", policyID,
+		job.PendingStatus.String(), job.ScheduledStatus.String(), job.RunningStatus.String()).QueryRows(&eIDs); er != nil {
+		logger.Errorf("Get executions for periodic job %s error: %s", policyID, er)
+	}
+	for _, eID := range eIDs {
+		eTracker, er := w.ctl.Track(eID)
+		if er != nil {
+			logger.Errorf("Track execution %s error: %s", eID, er)
+			continue
+		}
+		if er := eTracker.Stop(); er != nil {
+			logger.Errorf("Stop execution %s error: %s", eID, er)
+		}
+	}
+
+	return err
+}
+
+// loopReap deletes the expired job stats periodically
+func (w *basicWorker) loopReap() {
+	defer func() {
+		w.context.WG.Done()
+	}()
+
+	for {
+		select {
+		case <-time.After(reapInterval):
+			res, err := dao.GetOrmer().Raw(`DELETE FROM job_queue WHERE expire_at > 0 AND expire_at < ?
+				AND NOT queued AND worker_id IS NULL`, time.Now().Unix()).Exec()
+			if err != nil {
+				logger.Errorf("Delete expired job stats error: %s", err)
+				continue
+			}
+			if n, err := res.RowsAffected(); err == nil && n > 0 {
+				logger.Debugf("%d expired job stats are deleted", n)
+			}
+		case <-w.context.SystemContext.Done():
+			return
+		}
+	}
+}
+
+// Ping the database
+func (w *basicWorker) ping() error {
+	var err error
+	for count := 1; count <= pingDatabaseMaxTimes; count++ {
+		if err = dao.GetOrmer().Raw("SELECT 1").QueryRow(new(int)); err == nil {
+			return nil
+		}
+
+		time.Sleep(time.Duration(count+4) * time.Second)
+	}
+
+	return fmt.Errorf("connect to database timeout: %s", err.Error())
+}
+
+// toWorkJob converts the queued job to the job of the redis worker for the runner.
+// The execution of periodic job uses the ID of the policy and is marked with the time slot.
+func (qj *queuedJob) toWorkJob() *work.Job {
+	args := make(map[string]interface{})
+	if len(qj.Parameters) > 0 {
+		if err := json.Unmarshal([]byte(qj.Parameters), &args); err != nil {
+			logger.Errorf("Invalid parameters of job %s: %s", qj.JobID, err)
+		}
+	}
+
+	jID := qj.JobID
+	if !utils.IsEmptyStr(qj.UpstreamJobID) {
+		jID = qj.UpstreamJobID
+		// The run_at is changed when the execution is retried, so take the time slot from the execution ID
+		args[period.PeriodicExecutionMark] = strings.TrimPrefix(qj.JobID, qj.UpstreamJobID+"@")
+	}
+
+	return &work.Job{
+		Name:       qj.JobName,
+		ID:         jID,
+		EnqueuedAt: qj.EnqueueTime,
+		Args:       args,
+		Fails:      qj.Fails,
+		FailedAt:   qj.FailedAt,
+		LastErr:    qj.LastErr,
+	}
+}
+
+// backoff returns the seconds to wait before retrying the failed job, the same with the redis worker
+func backoff(fails int64) int64 {
+	if fails > 100 {
+		fails = 100
+	}
+
+	return int64(math.Pow(float64(fails), 4)) + 15 + (rand.Int63n(30) * (fails + 1))
+}
+
+func marshalParameters(params job.Parameters) (string, error) {
+	if len(params) == 0 {
+		return "", nil
+	}
+
+	bytes, err := json.Marshal(&params)
+	if err != nil {
+		return "", err
+	}
+
+	return string(bytes), nil
+}
+
+// makeUniqueKey makes the key to check the uniqueness of the queued job with the same name and parameters
+func makeUniqueKey(jobName, paramsJSON string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(jobName+paramsJSON)))
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// generate the job stats data
+func generateResult(
+	jobID string,
+	jobName string,
+	jobKind string,
+	isUnique bool,
+	jobParameters job.Parameters,
+	webHook string,
+) *job.Stats {
+	now := time.Now().Unix()
+
+	return &job.Stats{
+		Info: &job.StatsInfo{
+			JobID:       jobID,
+			JobName:     jobName,
+			JobKind:     jobKind,
+			IsUnique:    isUnique,
+			Status:      job.PendingStatus.String(),
+			EnqueueTime: now,
+			UpdateTime:  now,
+			RefLink:     fmt.Sprintf("/api/v1/jobs/%s", jobID),
+			Parameters:  jobParameters,
+			WebHookURL:  webHook,
+		},
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pworker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/jobservice/common/utils"
+	"github.com/goharbor/harbor/src/jobservice/config"
+	"github.com/goharbor/harbor/src/jobservice/env"
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/jobservice/lcm"
+	"github.com/goharbor/harbor/src/jobservice/period"
+	"github.com/goharbor/harbor/src/jobservice/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// PWorkerTestSuite tests functions of the PostgreSQL worker
+type PWorkerTestSuite struct {
+	suite.Suite
+
+	pWorker worker.Interface
+	lcmCtl  lcm.Controller
+
+	cancel  context.CancelFunc
+	context *env.Context
+}
+
+// SetupSuite prepares test suite
+func (suite *PWorkerTestSuite) SetupSuite() {
+	dao.PrepareTestForPostgresSQL()
+
+	// Append node ID
+	vCtx := context.WithValue(context.Background(), utils.NodeID, utils.GenerateNodeID())
+	// Create the root context
+	ctx, cancel := context.WithCancel(vCtx)
+	suite.cancel = cancel
+
+	envCtx := &env.Context{
+		SystemContext: ctx,
+		WG:            new(sync.WaitGroup),
+		ErrorChan:     make(chan error, 1),
+	}
+	suite.context = envCtx
+
+	suite.lcmCtl = lcm.NewPGController(
+		envCtx,
+		func(hookURL string, change *job.StatusChange) error { return nil },
+	)
+
+	suite.pWorker = NewWorker(envCtx, 2, 100*time.Millisecond, suite.lcmCtl, map[string]*config.JobOptions{
+		"fake_job": {
+			Priority:       100,
+			MaxConcurrency: 1,
+		},
+	})
+	err := suite.pWorker.RegisterJobs(map[string]interface{}{
+		"fake_job": (*fakeJob)(nil),
+	})
+	require.NoError(suite.T(), err, "register jobs: nil error expected but got %s", err)
+
+	err = suite.pWorker.Start()
+	require.NoError(suite.T(), err, "start PostgreSQL worker: nil error expected but got %s", err)
+}
+
+// TearDownSuite clears the test suite
+func (suite *PWorkerTestSuite) TearDownSuite() {
+	suite.cancel()
+
+	suite.context.WG.Wait()
+
+	_, _ = dao.GetOrmer().Raw("DELETE FROM job_queue WHERE job_name = ?", "fake_job").Exec()
+	_, _ = dao.GetOrmer().Raw("DELETE FROM job_latency WHERE job_name = ?", "fake_job").Exec()
+}
+
+// TestPWorkerTestSuite is entry fo go test
+func TestPWorkerTestSuite(t *testing.T) {
+	suite.Run(t, new(PWorkerTestSuite))
+}
+
+// TestEnqueueUniqueJob ...
+func (suite *PWorkerTestSuite) TestEnqueueUniqueJob() {
+	params := job.Parameters{"name": "testing:v1"}
+
+	res, err := suite.pWorker.Enqueue("fake_job", params, true, "")
+	require.NoError(suite.T(), err, "enqueue unique job: nil error expected but got %s", err)
+
+	_, err = suite.pWorker.Enqueue("fake_job", params, true, "")
+	assert.Error(suite.T(), err, "enqueue duplicated unique job: non nil error expected but got nil")
+
+	suite.waitStatus(res.Info.JobID, job.SuccessStatus)
+}
+
+// TestStopScheduledJob ...
+func (suite *PWorkerTestSuite) TestStopScheduledJob() {
+	res, err := suite.pWorker.Schedule("fake_job", job.Parameters{"name": "testing:v2"}, 3600, false, "")
+	require.NoError(suite.T(), err, "schedule job: nil error expected but got %s", err)
+	assert.Equal(suite.T(), job.ScheduledStatus.String(), res.Info.Status)
+
+	err = suite.pWorker.StopJob(res.Info.JobID)
+	require.NoError(suite.T(), err, "stop scheduled job: nil error expected but got %s", err)
+
+	suite.waitStatus(res.Info.JobID, job.StoppedStatus)
+
+	var queued bool
+	err = dao.GetOrmer().Raw("SELECT queued FROM job_queue WHERE job_id = ?", res.Info.JobID).QueryRow(&queued)
+	require.NoError(suite.T(), err)
+	assert.False(suite.T(), queued, "expect the stopped job to be removed from the queue")
+}
+
+// TestRetryStoppedJob ...
+func (suite *PWorkerTestSuite) TestRetryStoppedJob() {
+	res, err := suite.pWorker.Schedule("fake_job", job.Parameters{"name": "testing:v2"}, 3600, false, "")
+	require.NoError(suite.T(), err, "schedule job: nil error expected but got %s", err)
+
+	err = suite.pWorker.RetryJob(res.Info.JobID)
+	assert.Error(suite.T(), err, "retry scheduled job: non nil error expected but got nil")
+
+	err = suite.pWorker.StopJob(res.Info.JobID)
+	require.NoError(suite.T(), err, "stop scheduled job: nil error expected but got %s", err)
+	suite.waitStatus(res.Info.JobID, job.StoppedStatus)
+
+	err = suite.pWorker.RetryJob(res.Info.JobID)
+	require.NoError(suite.T(), err, "retry stopped job: nil error expected but got %s", err)
+	suite.waitStatus(res.Info.JobID, job.SuccessStatus)
+}
+
+// TestPeriodicallyEnqueue ...
+func (suite *PWorkerTestSuite) TestPeriodicallyEnqueue() {
+	res, err := suite.pWorker.PeriodicallyEnqueue("fake_job", job.Parameters{"name": "testing:v1"}, "0 * * * * *", false, "")
+	require.NoError(suite.T(), err, "periodically enqueue job: nil error expected but got %s", err)
+	assert.True(suite.T(), res.Info.NumericPID > 0, "expect numeric ID of periodic job but got %d", res.Info.NumericPID)
+
+	var count int64
+	err = dao.GetOrmer().Raw("SELECT COUNT(*) FROM job_queue WHERE upstream_job_id = ?", res.Info.JobID).QueryRow(&count)
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), count >= 3, "expect executions in the horizon to be scheduled but got %d", count)
+
+	err = suite.pWorker.StopJob(res.Info.JobID)
+	require.NoError(suite.T(), err, "stop periodic job: nil error expected but got %s", err)
+
+	suite.waitStatus(res.Info.JobID, job.StoppedStatus)
+}
+
+// TestToWorkJob ...
+func (suite *PWorkerTestSuite) TestToWorkJob() {
+	qj := &queuedJob{
+		JobID:         "fake_policy@1500",
+		JobName:       "fake_job",
+		UpstreamJobID: "fake_policy",
+		Parameters:    `{"name":"testing:v1"}`,
+		RunAt:         1500,
+	}
+
+	wj := qj.toWorkJob()
+	assert.Equal(suite.T(), "fake_policy", wj.ID)
+	assert.Equal(suite.T(), "testing:v1", wj.Args["name"])
+	assert.Equal(suite.T(), "1500", wj.Args[period.PeriodicExecutionMark])
+}
+
+// TestRequeueExpiredJobs ...
+func (suite *PWorkerTestSuite) TestRequeueExpiredJobs() {
+	now := time.Now().Unix()
+	_, err := dao.GetOrmer().Raw(`INSERT INTO job_queue (job_id, job_name, kind, status, parameters, queued,
+		pool_id, worker_id, lease_expire_at, enqueue_time, update_time)
+		VALUES (?, ?, ?, ?, ?, false, ?, ?, ?, ?, ?)`, "fake_job_gone", "fake_job_gone", job.KindGeneric,
+		job.RunningStatus.String(), "{}", "gone", "gone", now-1, now, now).Exec()
+	require.NoError(suite.T(), err)
+	defer func() {
+		_, _ = dao.GetOrmer().Raw("DELETE FROM job_queue WHERE job_id = ?", "fake_job_gone").Exec()
+	}()
+
+	err = suite.pWorker.(*basicWorker).requeueExpiredJobs()
+	require.NoError(suite.T(), err, "requeue expired jobs: nil error expected but got %s", err)
+
+	var requeued []*struct {
+		Queued bool   `orm:"column(queued)"`
+		Status string `orm:"column(status)"`
+	}
+	_, err = dao.GetOrmer().Raw("SELECT queued, status FROM job_queue WHERE job_id = ?", "fake_job_gone").QueryRows(&requeued)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), 1, len(requeued))
+	assert.True(suite.T(), requeued[0].Queued, "expect the job with expired lease to be put back to the queue")
+	assert.Equal(suite.T(), job.PendingStatus.String(), requeued[0].Status)
+}
+
+func (suite *PWorkerTestSuite) waitStatus(jobID string, status job.Status) {
+	tk := time.NewTicker(200 * time.Millisecond)
+	defer tk.Stop()
+	timeout := time.After(15 * time.Second)
+
+	for {
+		select {
+		case <-tk.C:
+			t, err := suite.lcmCtl.Track(jobID)
+			require.NoError(suite.T(), err, "track job: nil error expected but got %s", err)
+			if t.Job().Info.Status == status.String() {
+				return
+			}
+		case <-timeout:
+			require.Fail(suite.T(), "wait job status timeout", "expect status %s of job %s", status, jobID)
+			return
+		}
+	}
+}
+
+type fakeJob struct{}
+
+func (j *fakeJob) MaxFails() uint {
+	return 3
+}
+
+func (j *fakeJob) ShouldRetry() bool {
+	return false
+}
+
+func (j *fakeJob) Validate(params job.Parameters) error {
+	if p, ok := params["name"]; ok {
+		if p == "testing:v1" || p == "testing:v2" {
+			return nil
+		}
+	}
+
+	return errors.New("validate: testing error")
+}
+
+func (j *fakeJob) Run(ctx job.Context, params job.Parameters) error {
+	ctx.OPCommand()
+	_ = ctx.Checkin("done")
+
+	return nil
+}
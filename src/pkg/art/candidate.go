@@ -81,6 +81,8 @@ type Candidate struct {
 	PulledTime int64
 	// Created time in seconds
 	CreationTime int64
+	// Size in bytes
+	Size int64
 	// Labels attached with the candidate
 	Labels []string
 }
@@ -19,6 +19,7 @@ import (
 
 	"github.com/goharbor/harbor/src/pkg/art"
 	"github.com/goharbor/harbor/src/pkg/art/selectors/doublestar"
+	"github.com/goharbor/harbor/src/pkg/art/selectors/label"
 	"github.com/pkg/errors"
 )
 
@@ -34,7 +35,7 @@ func init() {
 	}, doublestar.New)
 
 	// Register label selector
-	// Register(label.Kind, []string{label.With, label.Without}, label.New)
+	Register(label.Kind, []string{label.With, label.Without}, label.New)
 }
 
 // index for keeping the mapping between selector meta and its implementation
@@ -38,6 +38,8 @@ type ImageClient interface {
 	ListAllImages(project, repository string) ([]*models.TagResp, error)
 	DeleteImage(project, repository, tag string) error
 	DeleteImageRepository(project, repository string) error
+	DeleteManifest(project, repository, digest string) error
+	ListUntaggedManifests(project, repository string) ([]*models.Blob, error)
 }
 
 // ChartClient defines the methods that a chart client should implement
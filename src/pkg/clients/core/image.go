@@ -38,3 +38,17 @@ func (c *client) DeleteImageRepository(project, repository string) error {
 	url := c.buildURL(fmt.Sprintf("/api/repositories/%s/%s", project, repository))
 	return c.httpclient.Delete(url)
 }
+
+func (c *client) ListUntaggedManifests(project, repository string) ([]*models.Blob, error) {
+	url := c.buildURL(fmt.Sprintf("/api/repositories/%s/%s/manifests", project, repository))
+	var manifests []*models.Blob
+	if err := c.httpclient.Get(url, &manifests); err != nil {
+		return nil, err
+	}
+	return manifests, nil
+}
+
+func (c *client) DeleteManifest(project, repository, digest string) error {
+	url := c.buildURL(fmt.Sprintf("/api/repositories/%s/%s/manifests/%s", project, repository, digest))
+	return c.httpclient.Delete(url)
+}
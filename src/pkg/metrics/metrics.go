@@ -0,0 +1,170 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	// Namespace is the common prefix of the names of all Harbor metrics
+	Namespace = "harbor"
+
+	// Path is the path under which the metrics are exposed by the services
+	Path = "/metrics"
+
+	// SubsystemCore is the subsystem of the metrics of core
+	SubsystemCore = "core"
+	// SubsystemJobService is the subsystem of the metrics of jobservice
+	SubsystemJobService = "jobservice"
+	// SubsystemRegistryCtl is the subsystem of the metrics of registryctl
+	SubsystemRegistryCtl = "registryctl"
+)
+
+// Handler returns the handler which exposes the registered metrics in the Prometheus format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// NewCounterVec creates a counter vector named "harbor_<subsystem>_<name>" and registers it
+func NewCounterVec(subsystem, name, help string, labels ...string) *prometheus.CounterVec {
+	c := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	}, labels)
+	return register(c).(*prometheus.CounterVec)
+}
+
+// NewHistogramVec creates a histogram vector named "harbor_<subsystem>_<name>" with the default buckets and registers it
+func NewHistogramVec(subsystem, name, help string, labels ...string) *prometheus.HistogramVec {
+	h := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	}, labels)
+	return register(h).(*prometheus.HistogramVec)
+}
+
+// NewDesc creates the description of the metric named "harbor_<subsystem>_<name>",
+// it is used by the collectors which read the values when being scraped
+func NewDesc(subsystem, name, help string, labels ...string) *prometheus.Desc {
+	return prometheus.NewDesc(prometheus.BuildFQName(Namespace, subsystem, name), help, labels, nil)
+}
+
+// Register registers the collector, registering the same collector more than once is ignored
+func Register(c prometheus.Collector) {
+	register(c)
+}
+
+// register returns the existing one if an equal collector has been registered
+func register(c prometheus.Collector) prometheus.Collector {
+	if err := prometheus.Register(c); err != nil {
+		if e, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			return e.ExistingCollector
+		}
+		panic(err)
+	}
+	return c
+}
+
+// HTTPMetrics records the count and the duration of the HTTP requests handled by a service
+type HTTPMetrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// NewHTTPMetrics creates the "harbor_<subsystem>_http_requests_total" and
+// "harbor_<subsystem>_http_request_duration_seconds" metrics of the service
+func NewHTTPMetrics(subsystem string) *HTTPMetrics {
+	return &HTTPMetrics{
+		requests: NewCounterVec(subsystem, "http_requests_total",
+			"The total number of the HTTP requests", "route", "method", "code"),
+		duration: NewHistogramVec(subsystem, "http_request_duration_seconds",
+			"The duration of the HTTP requests", "route", "method"),
+	}
+}
+
+// Observe records a request handled by the route
+func (h *HTTPMetrics) Observe(route, method string, code int, elapsed time.Duration) {
+	h.requests.WithLabelValues(route, method, strconv.Itoa(code)).Inc()
+	h.duration.WithLabelValues(route, method).Observe(elapsed.Seconds())
+}
+
+// Middleware is the middleware of the gorilla mux router which observes the requests
+// with the path template of the matched route
+func (h *HTTPMetrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		route := "unknown"
+		if r := mux.CurrentRoute(req); r != nil {
+			if tpl, err := r.GetPathTemplate(); err == nil {
+				route = tpl
+			}
+		}
+
+		start := time.Now()
+		rec := NewStatusRecorder(w)
+		next.ServeHTTP(rec, req)
+		h.Observe(route, req.Method, rec.Status(), time.Since(start))
+	})
+}
+
+// StatusRecorder keeps the status code written to the response
+type StatusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+// NewStatusRecorder returns the recorder wrapping the response writer
+func NewStatusRecorder(w http.ResponseWriter) *StatusRecorder {
+	return &StatusRecorder{ResponseWriter: w, status: http.StatusOK}
+}
+
+// Status returns the status code written, it's http.StatusOK if the status code isn't written explicitly
+func (s *StatusRecorder) Status() int {
+	return s.status
+}
+
+// WriteHeader ...
+func (s *StatusRecorder) WriteHeader(code int) {
+	s.status = code
+	s.ResponseWriter.WriteHeader(code)
+}
+
+// Flush ...
+func (s *StatusRecorder) Flush() {
+	if f, ok := s.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack ...
+func (s *StatusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := s.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+	return nil, nil, errors.New("the response writer doesn't support hijacking")
+}
@@ -0,0 +1,57 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCounterVec(t *testing.T) {
+	c1 := NewCounterVec("test", "counter_total", "testing counter", "label")
+	c2 := NewCounterVec("test", "counter_total", "testing counter", "label")
+	assert.Equal(t, c1, c2)
+}
+
+func TestHTTPMetrics(t *testing.T) {
+	h := NewHTTPMetrics("test")
+
+	r := mux.NewRouter()
+	r.HandleFunc("/api/items/{id}", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}).Methods(http.MethodGet)
+	r.Handle(Path, Handler()).Methods(http.MethodGet)
+	r.Use(h.Middleware)
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/items/1", nil))
+		require.Equal(t, http.StatusNotFound, w.Code)
+	}
+	m := &dto.Metric{}
+	require.Nil(t, h.requests.WithLabelValues("/api/items/{id}", http.MethodGet, "404").Write(m))
+	assert.Equal(t, float64(2), m.GetCounter().GetValue())
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, Path, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "harbor_test_http_requests_total")
+}
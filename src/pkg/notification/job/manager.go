@@ -17,4 +17,13 @@ type Manager interface {
 
 	// ListJobsGroupByEventType lists last triggered jobs group by event type
 	ListJobsGroupByEventType(policyID int64) ([]*models.NotificationJob, error)
+
+	// Get the notification job with specified ID, nil is returned if it doesn't exist
+	Get(id int64) (*models.NotificationJob, error)
+
+	// CreateDelivery records a delivery attempt of the notification job
+	CreateDelivery(delivery *models.NotificationDelivery) (int64, error)
+
+	// ListDeliveries lists the delivery attempts of the notification job
+	ListDeliveries(jobID int64) ([]*models.NotificationDelivery, error)
 }
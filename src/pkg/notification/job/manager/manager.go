@@ -53,3 +53,18 @@ func (d *DefaultManager) Update(job *models.NotificationJob, props ...string) er
 func (d *DefaultManager) ListJobsGroupByEventType(policyID int64) ([]*models.NotificationJob, error) {
 	return notification.GetLastTriggerJobsGroupByEventType(policyID)
 }
+
+// Get ...
+func (d *DefaultManager) Get(id int64) (*models.NotificationJob, error) {
+	return notification.GetNotificationJob(id)
+}
+
+// CreateDelivery ...
+func (d *DefaultManager) CreateDelivery(delivery *models.NotificationDelivery) (int64, error) {
+	return notification.AddNotificationDelivery(delivery)
+}
+
+// ListDeliveries ...
+func (d *DefaultManager) ListDeliveries(jobID int64) ([]*models.NotificationDelivery, error) {
+	return notification.GetNotificationDeliveries(jobID)
+}
@@ -12,5 +12,13 @@ const (
 	EventTypeScanningFailed    = "scanningFailed"
 	EventTypeTestEndpoint      = "testEndpoint"
 
-	NotifyTypeHTTP = "http"
+	EventTypeQuotaExceed           = "quotaExceed"
+	EventTypeQuotaWarning          = "quotaWarning"
+	EventTypeReplicationCompleted  = "replicationCompleted"
+	EventTypeReplicationFailed     = "replicationFailed"
+	EventTypeRetentionCompleted    = "retentionCompleted"
+	EventTypeImmutableTagViolation = "immutableTagViolation"
+
+	NotifyTypeHTTP  = "http"
+	NotifyTypeEmail = "email"
 )
@@ -43,9 +43,12 @@ func Init() {
 		model.EventTypePushImage, model.EventTypePullImage, model.EventTypeDeleteImage,
 		model.EventTypeUploadChart, model.EventTypeDeleteChart, model.EventTypeDownloadChart,
 		model.EventTypeScanningCompleted, model.EventTypeScanningFailed,
+		model.EventTypeQuotaExceed, model.EventTypeQuotaWarning,
+		model.EventTypeReplicationCompleted, model.EventTypeReplicationFailed,
+		model.EventTypeRetentionCompleted, model.EventTypeImmutableTagViolation,
 	)
 
-	initSupportedNotifyType(model.NotifyTypeHTTP)
+	initSupportedNotifyType(model.NotifyTypeHTTP, model.NotifyTypeEmail)
 
 	log.Info("notification initialization completed")
 }
@@ -3,13 +3,18 @@ package manager
 import (
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/goharbor/harbor/src/common/dao/notification"
 	commonhttp "github.com/goharbor/harbor/src/common/http"
 	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/common/utils/email"
 	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/goharbor/harbor/src/core/config"
 	notifierModel "github.com/goharbor/harbor/src/core/notifier/model"
 	"github.com/goharbor/harbor/src/pkg/notification/model"
 )
@@ -33,6 +38,9 @@ func (m *DefaultManager) Create(policy *models.NotificationPolicy) (int64, error
 	if err != nil {
 		return 0, err
 	}
+	if err = encryptSecret(policy); err != nil {
+		return 0, err
+	}
 	return notification.AddNotificationPolicy(policy)
 }
 
@@ -49,6 +57,9 @@ func (m *DefaultManager) List(projectID int64) ([]*models.NotificationPolicy, er
 		if err != nil {
 			return nil, err
 		}
+		if err = decryptSecret(policy); err != nil {
+			return nil, err
+		}
 		policies = append(policies, policy)
 	}
 
@@ -64,8 +75,13 @@ func (m *DefaultManager) Get(id int64) (*models.NotificationPolicy, error) {
 	if policy == nil {
 		return nil, nil
 	}
-	err = policy.ConvertFromDBModel()
-	return policy, err
+	if err = policy.ConvertFromDBModel(); err != nil {
+		return nil, err
+	}
+	if err = decryptSecret(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
 }
 
 // GetByNameAndProjectID notification policy by the name and projectID
@@ -74,8 +90,13 @@ func (m *DefaultManager) GetByNameAndProjectID(name string, projectID int64) (*m
 	if err != nil {
 		return nil, err
 	}
-	err = policy.ConvertFromDBModel()
-	return policy, err
+	if err = policy.ConvertFromDBModel(); err != nil {
+		return nil, err
+	}
+	if err = decryptSecret(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
 }
 
 // Update the specified notification policy
@@ -85,6 +106,9 @@ func (m *DefaultManager) Update(policy *models.NotificationPolicy) error {
 	if err != nil {
 		return err
 	}
+	if err = encryptSecret(policy); err != nil {
+		return err
+	}
 	return notification.UpdateNotificationPolicy(policy)
 }
 
@@ -93,6 +117,33 @@ func (m *DefaultManager) Delete(policyID int64) error {
 	return notification.DeleteNotificationPolicy(policyID)
 }
 
+// encryptSecret encrypts the secret of the policy before persisting it
+func encryptSecret(policy *models.NotificationPolicy) error {
+	policy.SecretDB = ""
+	if len(policy.Secret) == 0 {
+		return nil
+	}
+	key, err := config.SecretKey()
+	if err != nil {
+		return err
+	}
+	policy.SecretDB, err = utils.ReversibleEncrypt(policy.Secret, key)
+	return err
+}
+
+// decryptSecret decrypts the persisted secret of the policy
+func decryptSecret(policy *models.NotificationPolicy) error {
+	if len(policy.SecretDB) == 0 {
+		return nil
+	}
+	key, err := config.SecretKey()
+	if err != nil {
+		return err
+	}
+	policy.Secret, err = utils.ReversibleDecrypt(policy.SecretDB, key)
+	return err
+}
+
 // Test the specified notification policy, just test for network connection without request body
 func (m *DefaultManager) Test(policy *models.NotificationPolicy) error {
 	p, err := json.Marshal(notifierModel.Payload{
@@ -106,6 +157,8 @@ func (m *DefaultManager) Test(policy *models.NotificationPolicy) error {
 		switch target.Type {
 		case "http":
 			return m.policyHTTPTest(target.Address, target.SkipCertVerify, p)
+		case model.NotifyTypeEmail:
+			return m.policyEmailTest()
 		default:
 			return fmt.Errorf("invalid policy target type: %s", target.Type)
 		}
@@ -135,6 +188,23 @@ func (m *DefaultManager) policyHTTPTest(address string, skipCertVerify bool, p [
 	return nil
 }
 
+// policyEmailTest checks the connection and authentication with the configured email server
+func (m *DefaultManager) policyEmailTest() error {
+	settings, err := config.Email()
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(settings.Host, strconv.Itoa(settings.Port))
+	if err = email.Ping(addr, settings.Identity, settings.Username, settings.Password,
+		60, settings.SSL, settings.Insecure); err != nil {
+		return err
+	}
+	log.Debugf("policy test success with email server %s", addr)
+
+	return nil
+}
+
 // GetRelatedPolices get policies including event type in project
 func (m *DefaultManager) GetRelatedPolices(projectID int64, eventType string) ([]*models.NotificationPolicy, error) {
 	policies, err := m.List(projectID)
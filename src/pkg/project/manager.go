@@ -29,6 +29,8 @@ type Manager interface {
 	List(...*models.ProjectQueryParam) ([]*models.Project, error)
 	// Get the project specified by the ID or name
 	Get(interface{}) (*models.Project, error)
+	// GetMetadata returns the metadata of the project specified by the ID
+	GetMetadata(projectID int64, name ...string) (map[string]string, error)
 }
 
 // New returns a default implementation of Manager
@@ -59,3 +61,16 @@ func (m *manager) Get(idOrName interface{}) (*models.Project, error) {
 	}
 	return nil, fmt.Errorf("invalid parameter: %v, should be ID(int64) or name(string)", idOrName)
 }
+
+// GetMetadata returns the metadata of the project specified by the ID
+func (m *manager) GetMetadata(projectID int64, name ...string) (map[string]string, error) {
+	metas, err := dao.GetProjectMetadata(projectID, name...)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]string, len(metas))
+	for _, meta := range metas {
+		result[meta.Name] = meta.Value
+	}
+	return result, nil
+}
@@ -0,0 +1,94 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"time"
+
+	"github.com/astaxie/beego/orm"
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/goharbor/harbor/src/common/models"
+)
+
+func init() {
+	orm.RegisterModel(new(CacheEntry))
+}
+
+// CreateEntry creates a cache entry
+func CreateEntry(e *CacheEntry) (int64, error) {
+	return dao.GetOrmer().Insert(e)
+}
+
+// GetEntry returns the cache entry of the reference in repository of the project,
+// nil is returned if not found
+func GetEntry(projectID int64, repository, reference string) (*CacheEntry, error) {
+	e := &CacheEntry{}
+	err := dao.GetOrmer().QueryTable(new(CacheEntry)).
+		Filter("project_id", projectID).
+		Filter("repository", repository).
+		Filter("reference", reference).One(e)
+	if err != nil {
+		if err == orm.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return e, nil
+}
+
+// UpdateEntry updates the cache entry with the given properties
+func UpdateEntry(e *CacheEntry, props ...string) error {
+	_, err := dao.GetOrmer().Update(e, props...)
+	return err
+}
+
+// IncreaseHits increases the hit count of the cache entry
+func IncreaseHits(id int64) error {
+	_, err := dao.GetOrmer().QueryTable(new(CacheEntry)).Filter("id", id).Update(orm.Params{
+		"hits": orm.ColValue(orm.ColAdd, 1),
+	})
+	return err
+}
+
+// RenewEntry increases the hit count of the cache entry and renews its expire time
+func RenewEntry(id int64, expireTime time.Time) error {
+	_, err := dao.GetOrmer().QueryTable(new(CacheEntry)).Filter("id", id).Update(orm.Params{
+		"hits":        orm.ColValue(orm.ColAdd, 1),
+		"expire_time": expireTime,
+		"update_time": time.Now(),
+	})
+	return err
+}
+
+// DeleteEntriesOfProject deletes all the cache entries of the project
+func DeleteEntriesOfProject(projectID int64) error {
+	_, err := dao.GetOrmer().QueryTable(new(CacheEntry)).Filter("project_id", projectID).Delete()
+	return err
+}
+
+// GetSummary returns the statistics of the cache entries of the project
+func GetSummary(projectID int64) (*models.ProxyCacheSummary, error) {
+	summary := &models.ProxyCacheSummary{}
+	// the entries referenced by digest are the manifests of manifest list, not counted as tags
+	sql := `SELECT
+  COALESCE(SUM(CASE WHEN reference LIKE 'sha256:%' THEN 0 ELSE 1 END), 0) AS cached_tags,
+  COALESCE(SUM(hits), 0) AS hits,
+  COALESCE(SUM(misses), 0) AS misses
+FROM proxy_cache_entry WHERE project_id = ?`
+	if err := dao.GetOrmer().Raw(sql, projectID).QueryRow(summary); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
@@ -0,0 +1,109 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/dao"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// CacheEntryTestSuite is test suite of testing proxy cache entry DAO.
+type CacheEntryTestSuite struct {
+	suite.Suite
+}
+
+// TestCacheEntry is the entry of CacheEntryTestSuite.
+func TestCacheEntry(t *testing.T) {
+	suite.Run(t, &CacheEntryTestSuite{})
+}
+
+// SetupSuite prepares env for test suite.
+func (suite *CacheEntryTestSuite) SetupSuite() {
+	dao.PrepareTestForPostgresSQL()
+}
+
+// TearDownTest clears env for test case.
+func (suite *CacheEntryTestSuite) TearDownTest() {
+	require.NoError(suite.T(), DeleteEntriesOfProject(1000))
+}
+
+// TestEntry tests create, get and update cache entry.
+func (suite *CacheEntryTestSuite) TestEntry() {
+	e := &CacheEntry{
+		ProjectID:  1000,
+		Repository: "proxy/library/hello-world",
+		Reference:  "latest",
+		Digest:     "sha256:1",
+		Misses:     1,
+		ExpireTime: time.Now().Add(time.Hour),
+	}
+	id, err := CreateEntry(e)
+	require.NoError(suite.T(), err)
+	suite.True(id > 0)
+
+	got, err := GetEntry(1000, "proxy/library/hello-world", "latest")
+	require.NoError(suite.T(), err)
+	require.NotNil(suite.T(), got)
+	suite.Equal("sha256:1", got.Digest)
+	suite.False(got.Expired(time.Now()))
+
+	require.NoError(suite.T(), IncreaseHits(id))
+	got.Digest = "sha256:2"
+	got.Misses++
+	require.NoError(suite.T(), UpdateEntry(got, "digest", "misses"))
+
+	got, err = GetEntry(1000, "proxy/library/hello-world", "latest")
+	require.NoError(suite.T(), err)
+	suite.Equal("sha256:2", got.Digest)
+	suite.Equal(int64(1), got.Hits)
+	suite.Equal(int64(2), got.Misses)
+
+	expireTime := time.Now().Add(2 * time.Hour)
+	require.NoError(suite.T(), RenewEntry(id, expireTime))
+	got, err = GetEntry(1000, "proxy/library/hello-world", "latest")
+	require.NoError(suite.T(), err)
+	suite.Equal(int64(2), got.Hits)
+	suite.Equal(int64(2), got.Misses)
+	suite.Equal(expireTime.Unix(), got.ExpireTime.Unix())
+
+	got, err = GetEntry(1000, "proxy/library/hello-world", "not-found")
+	require.NoError(suite.T(), err)
+	suite.Nil(got)
+}
+
+// TestSummary tests the statistics of the cache entries.
+func (suite *CacheEntryTestSuite) TestSummary() {
+	for _, reference := range []string{"latest", "1.0", "sha256:1"} {
+		_, err := CreateEntry(&CacheEntry{
+			ProjectID:  1000,
+			Repository: "proxy/library/hello-world",
+			Reference:  reference,
+			Hits:       2,
+			Misses:     1,
+			ExpireTime: time.Now(),
+		})
+		require.NoError(suite.T(), err)
+	}
+
+	summary, err := GetSummary(1000)
+	require.NoError(suite.T(), err)
+	suite.Equal(int64(2), summary.CachedTags)
+	suite.Equal(int64(6), summary.Hits)
+	suite.Equal(int64(3), summary.Misses)
+}
@@ -0,0 +1,49 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dao
+
+import "time"
+
+// CacheEntry records the tag cached in proxy cache project.
+// Identified by the `project_id`, `repository` and `reference`.
+type CacheEntry struct {
+	ID           int64     `orm:"pk;auto;column(id)" json:"id"`
+	ProjectID    int64     `orm:"column(project_id)" json:"project_id"`
+	Repository   string    `orm:"column(repository)" json:"repository"`
+	Reference    string    `orm:"column(reference)" json:"reference"`
+	Digest       string    `orm:"column(digest)" json:"digest"`
+	Hits         int64     `orm:"column(hits)" json:"hits"`
+	Misses       int64     `orm:"column(misses)" json:"misses"`
+	ExpireTime   time.Time `orm:"column(expire_time);type(datetime)" json:"expire_time"`
+	CreationTime time.Time `orm:"column(creation_time);auto_now_add;type(datetime)" json:"creation_time"`
+	UpdateTime   time.Time `orm:"column(update_time);auto_now;type(datetime)" json:"update_time"`
+}
+
+// TableName for CacheEntry
+func (c *CacheEntry) TableName() string {
+	return "proxy_cache_entry"
+}
+
+// TableUnique for CacheEntry
+func (c *CacheEntry) TableUnique() [][]string {
+	return [][]string{
+		{"project_id", "repository", "reference"},
+	}
+}
+
+// Expired returns true if the cache entry is expired at the given time
+func (c *CacheEntry) Expired(now time.Time) bool {
+	return !c.ExpireTime.After(now)
+}
@@ -0,0 +1,94 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"time"
+
+	"github.com/goharbor/harbor/src/common/models"
+	"github.com/goharbor/harbor/src/pkg/proxy/dao"
+)
+
+// Mgr is the global proxy cache manager
+var Mgr = NewDefaultManager()
+
+// Manager manages the cache entries of the proxy cache projects
+type Manager interface {
+	// Get returns the cache entry of the reference in the repository, nil is returned if it isn't cached
+	Get(projectID int64, repository, reference string) (*dao.CacheEntry, error)
+	// Hit records that the cache entry is served from the local registry
+	Hit(entry *dao.CacheEntry) error
+	// Miss records that the reference is fetched from the upstream registry
+	// and cached with the expiry
+	Miss(projectID int64, repository, reference, digest string, expiry time.Duration) error
+	// Renew records that the expired cache entry is revalidated with the upstream registry
+	// and served from the local registry, it's counted as a hit and the expiry is renewed
+	Renew(entry *dao.CacheEntry, expiry time.Duration) error
+	// Summary returns the statistics of the proxy cache project
+	Summary(projectID int64) (*models.ProxyCacheSummary, error)
+	// DeleteByProject deletes all the cache entries of the project
+	DeleteByProject(projectID int64) error
+}
+
+// NewDefaultManager returns an instance of the default manager
+func NewDefaultManager() Manager {
+	return &manager{}
+}
+
+type manager struct{}
+
+func (m *manager) Get(projectID int64, repository, reference string) (*dao.CacheEntry, error) {
+	return dao.GetEntry(projectID, repository, reference)
+}
+
+func (m *manager) Hit(entry *dao.CacheEntry) error {
+	return dao.IncreaseHits(entry.ID)
+}
+
+func (m *manager) Miss(projectID int64, repository, reference, digest string, expiry time.Duration) error {
+	entry, err := dao.GetEntry(projectID, repository, reference)
+	if err != nil {
+		return err
+	}
+
+	if entry == nil {
+		_, err = dao.CreateEntry(&dao.CacheEntry{
+			ProjectID:  projectID,
+			Repository: repository,
+			Reference:  reference,
+			Digest:     digest,
+			Misses:     1,
+			ExpireTime: time.Now().Add(expiry),
+		})
+		return err
+	}
+
+	entry.Digest = digest
+	entry.Misses++
+	entry.ExpireTime = time.Now().Add(expiry)
+	return dao.UpdateEntry(entry, "digest", "misses", "expire_time", "update_time")
+}
+
+func (m *manager) Renew(entry *dao.CacheEntry, expiry time.Duration) error {
+	return dao.RenewEntry(entry.ID, time.Now().Add(expiry))
+}
+
+func (m *manager) Summary(projectID int64) (*models.ProxyCacheSummary, error) {
+	return dao.GetSummary(projectID)
+}
+
+func (m *manager) DeleteByProject(projectID int64) error {
+	return dao.DeleteEntriesOfProject(projectID)
+}
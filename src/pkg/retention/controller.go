@@ -29,6 +29,8 @@ import (
 type APIController interface {
 	GetRetention(id int64) (*policy.Metadata, error)
 
+	GetSystemRetention() (*policy.Metadata, error)
+
 	CreateRetention(p *policy.Metadata) (int64, error)
 
 	UpdateRetention(p *policy.Metadata) error
@@ -50,6 +52,8 @@ type APIController interface {
 	GetTotalOfRetentionExecTasks(executionID int64) (int64, error)
 
 	GetRetentionExecTaskLog(taskID int64) ([]byte, error)
+
+	GetRetentionExecReport(executionID int64) ([]*ReportItem, error)
 }
 
 // DefaultAPIController ...
@@ -77,6 +81,18 @@ func (r *DefaultAPIController) GetRetention(id int64) (*policy.Metadata, error)
 	return r.manager.GetPolicy(id)
 }
 
+// GetSystemRetention Get the system level Retention, nil is returned if no system level retention exists
+func (r *DefaultAPIController) GetSystemRetention() (*policy.Metadata, error) {
+	policies, err := r.manager.ListPolicies(policy.ScopeLevelSystem)
+	if err != nil {
+		return nil, err
+	}
+	if len(policies) == 0 {
+		return nil, nil
+	}
+	return policies[0], nil
+}
+
 // CreateRetention Create Retention
 func (r *DefaultAPIController) CreateRetention(p *policy.Metadata) (int64, error) {
 	if p.Trigger.Kind == policy.TriggerKindSchedule {
@@ -210,6 +226,8 @@ func (r *DefaultAPIController) OperateRetentionExec(eid int64, action string) er
 	switch action {
 	case "stop":
 		return r.launcher.Stop(eid)
+	case "retry":
+		return r.launcher.Retry(eid)
 	default:
 		return fmt.Errorf("not support action %s", action)
 	}
@@ -252,6 +270,11 @@ func (r *DefaultAPIController) GetRetentionExecTaskLog(taskID int64) ([]byte, er
 	return r.manager.GetTaskLog(taskID)
 }
 
+// GetRetentionExecReport Get Retention Execution Report
+func (r *DefaultAPIController) GetRetentionExecReport(executionID int64) ([]*ReportItem, error) {
+	return r.manager.ListReportItems(executionID)
+}
+
 // NewAPIController ...
 func NewAPIController(retentionMgr Manager, projectManager project.Manager, repositoryMgr repository.Manager, scheduler scheduler.Scheduler, retentionLauncher Launcher) APIController {
 	return &DefaultAPIController{
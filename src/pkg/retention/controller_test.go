@@ -188,6 +188,9 @@ func (s *ControllerTestSuite) TestExecution() {
 	err = m.OperateRetentionExec(id, "stop")
 	s.Require().Nil(err)
 
+	err = m.OperateRetentionExec(id, "retry")
+	s.Require().Nil(err)
+
 	es, err := m.ListRetentionExecs(policyID, nil)
 	s.Require().Nil(err)
 	s.Require().EqualValues(1, len(es))
@@ -216,6 +219,10 @@ func (f *fakeLauncher) Stop(executionID int64) error {
 	return nil
 }
 
+func (f *fakeLauncher) Retry(executionID int64) error {
+	return nil
+}
+
 func (f *fakeLauncher) Launch(policy *policy.Metadata, executionID int64, isDryRun bool) (int64, error) {
 	return 0, nil
 }
@@ -19,6 +19,7 @@ func init() {
 		new(RetentionPolicy),
 		new(RetentionExecution),
 		new(RetentionTask),
+		new(RetentionReportItem),
 	)
 }
 
@@ -51,6 +52,7 @@ type RetentionExecution struct {
 type RetentionTask struct {
 	ID             int64     `orm:"pk;auto;column(id)"`
 	ExecutionID    int64     `orm:"column(execution_id)"`
+	Project        string    `orm:"column(project)"`
 	Repository     string    `orm:"column(repository)"`
 	JobID          string    `orm:"column(job_id)"`
 	Status         string    `orm:"column(status)"`
@@ -61,3 +63,17 @@ type RetentionTask struct {
 	Total          int       `orm:"column(total)"`
 	Retained       int       `orm:"column(retained)"`
 }
+
+// RetentionReportItem records how one candidate is handled in the retention task
+type RetentionReportItem struct {
+	ID           int64     `orm:"pk;auto;column(id)"`
+	ExecutionID  int64     `orm:"column(execution_id)"`
+	TaskID       int64     `orm:"column(task_id)"`
+	Repository   string    `orm:"column(repository)"`
+	Tag          string    `orm:"column(tag)"`
+	Digest       string    `orm:"column(digest)"`
+	Action       string    `orm:"column(action)"`
+	Rules        string    `orm:"column(rules)"`
+	Reason       string    `orm:"column(reason)"`
+	CreationTime time.Time `orm:"column(creation_time);auto_now_add"`
+}
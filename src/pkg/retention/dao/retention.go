@@ -29,6 +29,9 @@ func UpdatePolicy(p *models.RetentionPolicy, cols ...string) error {
 // DeletePolicyAndExec Delete Policy and Exec
 func DeletePolicyAndExec(id int64) error {
 	o := dao.GetOrmer()
+	if _, err := o.Raw("delete from retention_report_item where execution_id in (select id from retention_execution where policy_id = ?) ", id).Exec(); err != nil {
+		return err
+	}
 	if _, err := o.Raw("delete from retention_task where execution_id in (select id from retention_execution where policy_id = ?) ", id).Exec(); err != nil {
 		return nil
 	}
@@ -55,6 +58,19 @@ func GetPolicy(id int64) (*models.RetentionPolicy, error) {
 	return p, nil
 }
 
+// ListPolicies lists the policies with the specified scope level
+func ListPolicies(scopeLevel string) ([]*models.RetentionPolicy, error) {
+	qs := dao.GetOrmer().QueryTable(new(models.RetentionPolicy))
+	if len(scopeLevel) > 0 {
+		qs = qs.Filter("ScopeLevel", scopeLevel)
+	}
+	var policies []*models.RetentionPolicy
+	if _, err := qs.OrderBy("ID").All(&policies); err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
 // CreateExecution Create Execution
 func CreateExecution(e *models.RetentionExecution) (int64, error) {
 	o := dao.GetOrmer()
@@ -68,9 +84,35 @@ func UpdateExecution(e *models.RetentionExecution, cols ...string) error {
 	return err
 }
 
+// MarkExecutionNotified records the final status whose event is published for the execution,
+// returns false when the event of the status has been published already
+func MarkExecutionNotified(id int64, status string) (bool, error) {
+	// use raw sql to check and update the status in a single statement
+	r, err := dao.GetOrmer().Raw(`update retention_execution set notified_status = ?
+		where id = ? and notified_status is distinct from ?`, status, id, status).Exec()
+	if err != nil {
+		return false, err
+	}
+	n, err := r.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// ResetExecutionNotified clears the notified status when the tasks of the execution re-run
+func ResetExecutionNotified(id int64) error {
+	_, err := dao.GetOrmer().Raw(`update retention_execution set notified_status = null
+		where id = ? and notified_status is not null`, id).Exec()
+	return err
+}
+
 // DeleteExecution Delete Execution
 func DeleteExecution(id int64) error {
 	o := dao.GetOrmer()
+	if _, err := o.Raw("delete from retention_report_item where execution_id = ?", id).Exec(); err != nil {
+		return err
+	}
 	_, err := o.Delete(&models.RetentionTask{
 		ExecutionID: id,
 	})
@@ -279,6 +321,9 @@ func ListTask(query ...*q.TaskQuery) ([]*models.RetentionTask, error) {
 		if q.ExecutionID > 0 {
 			qs = qs.Filter("ExecutionID", q.ExecutionID)
 		}
+		if len(q.Project) > 0 {
+			qs = qs.Filter("Project", q.Project)
+		}
 		if len(q.Status) > 0 {
 			qs = qs.Filter("Status", q.Status)
 		}
@@ -301,6 +346,28 @@ func GetTotalOfTasks(executionID int64) (int64, error) {
 	return qs.Count()
 }
 
+// SaveReportItems replaces the report items of the task with the provided ones
+func SaveReportItems(taskID int64, items []*models.RetentionReportItem) error {
+	o := dao.GetOrmer()
+	if _, err := o.Raw("delete from retention_report_item where task_id = ?", taskID).Exec(); err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	_, err := o.InsertMulti(100, items)
+	return err
+}
+
+// ListReportItems lists the report items of the execution
+func ListReportItems(executionID int64) ([]*models.RetentionReportItem, error) {
+	qs := dao.GetOrmer().QueryTable(&models.RetentionReportItem{})
+	qs = qs.Filter("ExecutionID", executionID).OrderBy("TaskID", "ID")
+	items := []*models.RetentionReportItem{}
+	_, err := qs.All(&items)
+	return items, err
+}
+
 // IsFinalStatus checks whether the status is a final status
 func IsFinalStatus(status string) bool {
 	if status == job.StoppedStatus.String() || status == job.SuccessStatus.String() ||
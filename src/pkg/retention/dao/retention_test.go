@@ -80,6 +80,13 @@ func TestPolicy(t *testing.T) {
 	assert.EqualValues(t, "project", p1.ScopeLevel)
 	assert.True(t, p1.ID > 0)
 
+	policies, err := ListPolicies("project")
+	assert.Nil(t, err)
+	assert.True(t, len(policies) > 0)
+	policies, err = ListPolicies("system")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(policies))
+
 	p1.ScopeLevel = "test"
 	err = UpdatePolicy(p1)
 	assert.Nil(t, err)
@@ -167,6 +174,17 @@ func TestExecution(t *testing.T) {
 	es, err := ListExecutions(policyID, nil)
 	assert.Nil(t, err)
 	assert.EqualValues(t, 1, len(es))
+
+	notified, err := MarkExecutionNotified(id, models.ExecutionStatusSucceed)
+	assert.Nil(t, err)
+	assert.True(t, notified)
+	notified, err = MarkExecutionNotified(id, models.ExecutionStatusSucceed)
+	assert.Nil(t, err)
+	assert.False(t, notified)
+	assert.Nil(t, ResetExecutionNotified(id))
+	notified, err = MarkExecutionNotified(id, models.ExecutionStatusSucceed)
+	assert.Nil(t, err)
+	assert.True(t, notified)
 }
 
 func TestTask(t *testing.T) {
@@ -40,6 +40,17 @@ type Client interface {
 	//    error            : common error if any errors occurred
 	GetCandidates(repo *art.Repository) ([]*art.Candidate, error)
 
+	// Get the untagged manifest candidates under the repository,
+	// the tag of the returned candidates is empty
+	//
+	//  Arguments:
+	//    repo *art.Repository : repository info
+	//
+	//  Returns:
+	//    []*art.Candidate : candidates returned
+	//    error            : common error if any errors occurred
+	GetUntaggedCandidates(repo *art.Repository) ([]*art.Candidate, error)
+
 	// Delete the given repository
 	//
 	//  Arguments:
@@ -114,6 +125,7 @@ func (bc *basicClient) GetCandidates(repository *art.Repository) ([]*art.Candida
 				CreationTime: image.Created.Unix(),
 				PulledTime:   image.PullTime.Unix(),
 				PushedTime:   image.PushTime.Unix(),
+				Size:         image.Size,
 			}
 			candidates = append(candidates, candidate)
 		}
@@ -147,6 +159,34 @@ func (bc *basicClient) GetCandidates(repository *art.Repository) ([]*art.Candida
 	return candidates, nil
 }
 
+// GetUntaggedCandidates gets the untagged manifest candidates under the repository
+func (bc *basicClient) GetUntaggedCandidates(repository *art.Repository) ([]*art.Candidate, error) {
+	if repository == nil {
+		return nil, errors.New("repository is nil")
+	}
+	if repository.Kind != art.Image {
+		return nil, fmt.Errorf("unsupported repository kind: %s", repository.Kind)
+	}
+	manifests, err := bc.coreClient.ListUntaggedManifests(repository.Namespace, repository.Name)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]*art.Candidate, 0)
+	for _, manifest := range manifests {
+		candidates = append(candidates, &art.Candidate{
+			Kind:         art.Image,
+			Namespace:    repository.Namespace,
+			Repository:   repository.Name,
+			Digest:       manifest.Digest,
+			Labels:       []string{},
+			CreationTime: manifest.CreationTime.Unix(),
+			PushedTime:   manifest.CreationTime.Unix(),
+			Size:         manifest.Size,
+		})
+	}
+	return candidates, nil
+}
+
 // DeleteRepository deletes the specified repository
 func (bc *basicClient) DeleteRepository(repo *art.Repository) error {
 	if repo == nil {
@@ -171,6 +211,10 @@ func (bc *basicClient) Delete(candidate *art.Candidate) error {
 	}
 	switch candidate.Kind {
 	case art.Image:
+		// the untagged manifest is deleted by digest
+		if len(candidate.Tag) == 0 {
+			return bc.coreClient.DeleteManifest(candidate.Namespace, candidate.Repository, candidate.Digest)
+		}
 		return bc.coreClient.DeleteImage(candidate.Namespace, candidate.Repository, candidate.Tag)
 	/*
 		case art.Chart:
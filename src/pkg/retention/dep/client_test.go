@@ -40,6 +40,10 @@ func (f *fakeCoreClient) ListAllImages(project, repository string) ([]*models.Ta
 	return []*models.TagResp{image}, nil
 }
 
+func (f *fakeCoreClient) ListUntaggedManifests(project, repository string) ([]*models.Blob, error) {
+	return []*models.Blob{{Digest: "sha256:untagged", Size: 10}}, nil
+}
+
 func (f *fakeCoreClient) ListAllCharts(project, repository string) ([]*chartserver.ChartVersion, error) {
 	metadata := &chart.Metadata{
 		Name: "1.0",
@@ -65,6 +69,15 @@ func (f *fakeJobserviceClient) PostAction(uuid, action string) error {
 func (f *fakeJobserviceClient) GetExecutions(uuid string) ([]job.Stats, error) {
 	return nil, nil
 }
+func (f *fakeJobserviceClient) GetQueues() ([]*job.QueueStats, error) {
+	return nil, nil
+}
+func (f *fakeJobserviceClient) GetRunningJobs() ([]*job.RunningJob, error) {
+	return nil, nil
+}
+func (f *fakeJobserviceClient) GetLatency(window int64) ([]*job.LatencyStats, error) {
+	return nil, nil
+}
 
 type clientTestSuite struct {
 	suite.Suite
@@ -105,6 +118,33 @@ func (c *clientTestSuite) TestGetCandidates() {
 	*/
 }
 
+func (c *clientTestSuite) TestGetUntaggedCandidates() {
+	client := &basicClient{}
+	client.coreClient = &fakeCoreClient{}
+	// nil repository
+	_, err := client.GetUntaggedCandidates(nil)
+	require.NotNil(c.T(), err)
+
+	repository := &art.Repository{
+		Kind:      art.Image,
+		Namespace: "library",
+		Name:      "hello-world",
+	}
+	candidates, err := client.GetUntaggedCandidates(repository)
+	require.Nil(c.T(), err)
+	require.Equal(c.T(), 1, len(candidates))
+	assert.Equal(c.T(), "library", candidates[0].Namespace)
+	assert.Equal(c.T(), "hello-world", candidates[0].Repository)
+	assert.Equal(c.T(), "", candidates[0].Tag)
+	assert.Equal(c.T(), "sha256:untagged", candidates[0].Digest)
+	assert.Equal(c.T(), int64(10), candidates[0].Size)
+
+	// unsupported type
+	repository.Kind = art.Chart
+	_, err = client.GetUntaggedCandidates(repository)
+	require.NotNil(c.T(), err)
+}
+
 func (c *clientTestSuite) TestDelete() {
 	client := &basicClient{}
 	client.coreClient = &fakeCoreClient{}
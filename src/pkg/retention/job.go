@@ -27,6 +27,8 @@ import (
 	"github.com/goharbor/harbor/src/pkg/retention/dep"
 	"github.com/goharbor/harbor/src/pkg/retention/policy"
 	"github.com/goharbor/harbor/src/pkg/retention/policy/lwp"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/untagged"
 	"github.com/olekukonko/tablewriter"
 	"github.com/pkg/errors"
 )
@@ -87,12 +89,26 @@ func (pj *Job) Run(ctx job.Context, params job.Parameters) error {
 		return logError(myLogger, err)
 	}
 
+	// The untagged manifests are retrieved only when there are rules for them and they are
+	// processed by these rules separately, so the tag based rules never remove them
+	taggedMeta, untaggedMeta := splitRules(liteMeta)
+	untaggedCandidates := make([]*art.Candidate, 0)
+	if len(untaggedMeta.Rules) > 0 {
+		if untaggedCandidates, err = dep.DefaultClient.GetUntaggedCandidates(repo); err != nil {
+			return logError(myLogger, err)
+		}
+	}
+
 	// Log stage: load candidates
-	myLogger.Infof("Load %d candidates from repository %s", len(allCandidates), repoPath)
+	myLogger.Infof("Load %d candidates and %d untagged manifests from repository %s", len(allCandidates), len(untaggedCandidates), repoPath)
 
-	// Build the processor
-	builder := policy.NewBuilder(allCandidates)
-	processor, err := builder.Build(liteMeta, isDryRun)
+	// Build the processors, the rules matching the candidates are recorded for the report
+	recorder := rule.NewRecorder()
+	processor, err := policy.NewBuilder(allCandidates, recorder).Build(taggedMeta, isDryRun)
+	if err != nil {
+		return logError(myLogger, err)
+	}
+	untaggedProcessor, err := policy.NewBuilder(untaggedCandidates, recorder).Build(untaggedMeta, isDryRun)
 	if err != nil {
 		return logError(myLogger, err)
 	}
@@ -108,15 +124,39 @@ func (pj *Job) Run(ctx job.Context, params job.Parameters) error {
 	if err != nil {
 		return logError(myLogger, err)
 	}
+	untaggedResults, err := untaggedProcessor.Process(untaggedCandidates)
+	if err != nil {
+		return logError(myLogger, err)
+	}
+	results = append(results, untaggedResults...)
+	allCandidates = append(allCandidates, untaggedCandidates...)
 
 	// Log stage: results with table view
 	logResults(myLogger, allCandidates, results)
 
+	// Build the report of the candidates
+	report := buildReport(recorder, allCandidates, results)
+
 	// Save retain and total num in DB
-	return saveRetainNum(ctx, results, allCandidates)
+	return saveRetainNum(ctx, results, allCandidates, report)
+}
+
+// splitRules splits the rules of the policy into the ones for the tagged candidates
+// and the ones for the untagged manifests, both keep the algorithm of the policy
+func splitRules(liteMeta *lwp.Metadata) (*lwp.Metadata, *lwp.Metadata) {
+	tagged := &lwp.Metadata{Algorithm: liteMeta.Algorithm}
+	untaggedMeta := &lwp.Metadata{Algorithm: liteMeta.Algorithm}
+	for _, r := range liteMeta.Rules {
+		if r.Template == untagged.TemplateID {
+			untaggedMeta.Rules = append(untaggedMeta.Rules, r)
+		} else {
+			tagged.Rules = append(tagged.Rules, r)
+		}
+	}
+	return tagged, untaggedMeta
 }
 
-func saveRetainNum(ctx job.Context, retained []*art.Result, allCandidates []*art.Candidate) error {
+func saveRetainNum(ctx job.Context, retained []*art.Result, allCandidates []*art.Candidate, report []*ReportItem) error {
 	var delNum int
 	for _, r := range retained {
 		if r.Error == nil {
@@ -124,11 +164,13 @@ func saveRetainNum(ctx job.Context, retained []*art.Result, allCandidates []*art
 		}
 	}
 	retainObj := struct {
-		Total    int `json:"total"`
-		Retained int `json:"retained"`
+		Total      int           `json:"total"`
+		Retained   int           `json:"retained"`
+		Candidates []*ReportItem `json:"candidates,omitempty"`
 	}{
-		Total:    len(allCandidates),
-		Retained: len(allCandidates) - delNum,
+		Total:      len(allCandidates),
+		Retained:   len(allCandidates) - delNum,
+		Candidates: report,
 	}
 	c, err := json.Marshal(retainObj)
 	if err != nil {
@@ -194,6 +236,49 @@ func logResults(logger logger.Interface, all []*art.Candidate, results []*art.Re
 	}
 }
 
+// buildReport builds the report items which record how each candidate is handled
+// with the rules recorded when processing them
+func buildReport(recorder *rule.Recorder, all []*art.Candidate, results []*art.Result) []*ReportItem {
+	removed := make(map[string]*art.Result, len(results))
+	for _, r := range results {
+		if r.Target != nil {
+			removed[r.Target.Hash()] = r
+		}
+	}
+
+	items := make([]*ReportItem, 0, len(all))
+	for _, c := range all {
+		rules := recorder.Matched(c)
+		item := &ReportItem{
+			Repository: fmt.Sprintf("%s/%s", c.Namespace, c.Repository),
+			Tag:        c.Tag,
+			Digest:     c.Digest,
+			Rules:      strings.Join(rules, ","),
+		}
+		r, exist := removed[c.Hash()]
+		switch {
+		case exist && r.Error != nil:
+			item.Action = ReportActionError
+			item.Reason = fmt.Sprintf("failed to remove: %v", r.Error)
+		case exist && len(rules) == 0:
+			item.Action = ReportActionRemoved
+			item.Reason = "not retained by any rule"
+		case exist:
+			item.Action = ReportActionRemoved
+			item.Reason = "not retained by all the rules"
+		case len(rules) == 0:
+			item.Action = ReportActionRetained
+			item.Reason = "no rule applied"
+		default:
+			item.Action = ReportActionRetained
+			item.Reason = "retained by the rules"
+		}
+		items = append(items, item)
+	}
+
+	return items
+}
+
 func arn(art *art.Candidate) string {
 	return fmt.Sprintf("%s/%s:%s", art.Namespace, art.Repository, art.Tag)
 }
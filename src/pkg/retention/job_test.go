@@ -30,6 +30,8 @@ import (
 	"github.com/goharbor/harbor/src/pkg/retention/policy/lwp"
 	"github.com/goharbor/harbor/src/pkg/retention/policy/rule"
 	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/latestps"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/untagged"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
@@ -109,6 +111,99 @@ func (suite *JobTestSuite) TestRunSuccess() {
 	require.NoError(suite.T(), err)
 }
 
+func (suite *JobTestSuite) TestSplitRules() {
+	meta := &lwp.Metadata{
+		Algorithm: policy.AlgorithmAND,
+		Rules: []*rule.Metadata{
+			{ID: 1, Template: latestps.TemplateID},
+			{ID: 2, Template: untagged.TemplateID},
+		},
+	}
+
+	tagged, untaggedMeta := splitRules(meta)
+	assert.Equal(suite.T(), policy.AlgorithmAND, tagged.Algorithm)
+	require.Equal(suite.T(), 1, len(tagged.Rules))
+	assert.Equal(suite.T(), 1, tagged.Rules[0].ID)
+	assert.Equal(suite.T(), policy.AlgorithmAND, untaggedMeta.Algorithm)
+	require.Equal(suite.T(), 1, len(untaggedMeta.Rules))
+	assert.Equal(suite.T(), 2, untaggedMeta.Rules[0].ID)
+}
+
+func (suite *JobTestSuite) TestBuildReportOfUntagged() {
+	all, err := dep.DefaultClient.GetUntaggedCandidates(nil)
+	require.Nil(suite.T(), err)
+
+	meta := &lwp.Metadata{
+		Algorithm: policy.AlgorithmOR,
+		Rules: []*rule.Metadata{
+			{
+				ID:         1,
+				Action:     action.Retain,
+				Template:   untagged.TemplateID,
+				Parameters: rule.Parameters{untagged.ParameterN: 60},
+				TagSelectors: []*rule.Selector{{
+					Kind:       doublestar.Kind,
+					Decoration: doublestar.Matches,
+					Pattern:    "**",
+				}},
+			},
+		},
+	}
+
+	recorder := rule.NewRecorder()
+	p, err := policy.NewBuilder(all, recorder).Build(meta, true)
+	require.Nil(suite.T(), err)
+	results, err := p.Process(all)
+	require.Nil(suite.T(), err)
+
+	items := buildReport(recorder, all, results)
+	require.Equal(suite.T(), 1, len(items))
+	assert.Equal(suite.T(), "untagged", items[0].Digest)
+	assert.Equal(suite.T(), ReportActionRetained, items[0].Action)
+	assert.Equal(suite.T(), "nDaysSinceUntaggedPush(60)", items[0].Rules)
+}
+
+func (suite *JobTestSuite) TestBuildReport() {
+	all, err := dep.DefaultClient.GetCandidates(nil)
+	require.Nil(suite.T(), err)
+
+	ruleParams := make(rule.Parameters)
+	ruleParams[latestps.ParameterK] = 1
+	meta := &lwp.Metadata{
+		Algorithm: policy.AlgorithmOR,
+		Rules: []*rule.Metadata{
+			{
+				ID:         1,
+				Action:     action.Retain,
+				Template:   latestps.TemplateID,
+				Parameters: ruleParams,
+				TagSelectors: []*rule.Selector{{
+					Kind:       doublestar.Kind,
+					Decoration: doublestar.Matches,
+					Pattern:    "**",
+				}},
+			},
+		},
+	}
+	recorder := rule.NewRecorder()
+	p, err := policy.NewBuilder(all, recorder).Build(meta, true)
+	require.Nil(suite.T(), err)
+	results, err := p.Process(all)
+	require.Nil(suite.T(), err)
+
+	items := buildReport(recorder, all, results)
+	require.Equal(suite.T(), 2, len(items))
+
+	assert.Equal(suite.T(), "library/harbor", items[0].Repository)
+	assert.Equal(suite.T(), "latest", items[0].Tag)
+	assert.Equal(suite.T(), ReportActionRemoved, items[0].Action)
+	assert.Equal(suite.T(), "", items[0].Rules)
+
+	assert.Equal(suite.T(), "dev", items[1].Tag)
+	assert.Equal(suite.T(), ReportActionRetained, items[1].Action)
+	assert.Equal(suite.T(), "latestPushedK(1)", items[1].Rules)
+}
+
 type fakeRetentionClient struct{}
 
 // GetCandidates ...
@@ -139,6 +234,20 @@ func (frc *fakeRetentionClient) GetCandidates(repo *art.Repository) ([]*art.Cand
 	}, nil
 }
 
+// GetUntaggedCandidates ...
+func (frc *fakeRetentionClient) GetUntaggedCandidates(repo *art.Repository) ([]*art.Candidate, error) {
+	return []*art.Candidate{
+		{
+			Namespace:    "library",
+			Repository:   "harbor",
+			Kind:         "image",
+			Digest:       "untagged",
+			PushedTime:   time.Now().Unix() - 30*24*3600,
+			CreationTime: time.Now().Unix() - 30*24*3600,
+		},
+	}, nil
+}
+
 // Delete ...
 func (frc *fakeRetentionClient) Delete(candidate *art.Candidate) error {
 	return nil
@@ -16,6 +16,7 @@ package retention
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/goharbor/harbor/src/jobservice/job"
@@ -67,6 +68,14 @@ type Launcher interface {
 	//  Returns:
 	//   error : common error if any errors occurred
 	Stop(executionID int64) error
+	// Retry the failed or stopped jobs for one execution
+	//
+	//  Arguments:
+	//   executionID int64 : the execution ID
+	//
+	//  Returns:
+	//   error : common error if any errors occurred
+	Retry(executionID int64) error
 }
 
 // NewLauncher returns an instance of Launcher
@@ -115,12 +124,17 @@ func (l *launcher) Launch(ply *policy.Metadata, executionID int64, isDryRun bool
 	level := scope.Level
 	var allProjects []*art.Candidate
 	var err error
-	if level == "system" {
+	if level == policy.ScopeLevelSystem {
 		// get projects
 		allProjects, err = getProjects(l.projectMgr)
 		if err != nil {
 			return 0, launcherError(err)
 		}
+		// exclude the projects which opt out or override the system level policy
+		allProjects, err = filterProjects(l.projectMgr, scope, allProjects)
+		if err != nil {
+			return 0, launcherError(err)
+		}
 	}
 
 	for _, rule := range ply.Rules {
@@ -130,7 +144,7 @@ func (l *launcher) Launch(ply *policy.Metadata, executionID int64, isDryRun bool
 		}
 		projectCandidates := allProjects
 		switch level {
-		case "system":
+		case policy.ScopeLevelSystem:
 			// filter projects according to the project selectors
 			for _, projectSelector := range rule.ScopeSelectors["project"] {
 				selector, err := index.Get(projectSelector.Kind, projectSelector.Decoration,
@@ -143,7 +157,7 @@ func (l *launcher) Launch(ply *policy.Metadata, executionID int64, isDryRun bool
 					return 0, launcherError(err)
 				}
 			}
-		case "project":
+		case policy.ScopeLevelProject:
 			projectCandidates = append(projectCandidates, &art.Candidate{
 				NamespaceID: scope.Reference,
 			})
@@ -247,6 +261,7 @@ func (l *launcher) createTasks(executionID int64, jobDatas []*jobData) error {
 	for _, jobData := range jobDatas {
 		taskID, err := l.retentionMgr.CreateTask(&Task{
 			ExecutionID: executionID,
+			Project:     jobData.Repository.Namespace,
 			Repository:  jobData.Repository.Name,
 			StartTime:   now.Truncate(time.Second),
 		})
@@ -316,6 +331,46 @@ func (l *launcher) Stop(executionID int64) error {
 	return nil
 }
 
+func (l *launcher) Retry(executionID int64) error {
+	if executionID <= 0 {
+		return launcherError(fmt.Errorf("invalid execution ID: %d", executionID))
+	}
+	tasks, err := l.retentionMgr.ListTasks(&q.TaskQuery{
+		ExecutionID: executionID,
+	})
+	if err != nil {
+		return err
+	}
+	retried := 0
+	for _, task := range tasks {
+		if task.Status != job.ErrorStatus.String() && task.Status != job.StoppedStatus.String() {
+			continue
+		}
+		// set the status to pending before sending the retry request, the status changes
+		// of the retried job carry a new revision so they can override the status set here
+		pending := &Task{
+			ID:         task.ID,
+			Status:     job.PendingStatus.String(),
+			StatusCode: job.PendingStatus.Code(),
+		}
+		if err = l.retentionMgr.UpdateTask(pending, "Status", "StatusCode"); err != nil {
+			return err
+		}
+		if err = l.jobserviceClient.PostAction(task.JobID, cjob.JobActionRetry); err != nil {
+			log.Errorf("failed to retry task %d, job ID: %s : %v", task.ID, task.JobID, err)
+			if e := l.retentionMgr.UpdateTask(task, "Status", "StatusCode"); e != nil {
+				log.Errorf("failed to restore the status of task %d, job ID: %s : %v", task.ID, task.JobID, e)
+			}
+			continue
+		}
+		retried++
+	}
+	if retried == 0 {
+		return launcherError(fmt.Errorf("no failed or stopped task of execution %d is retried", executionID))
+	}
+	return nil
+}
+
 func launcherError(err error) error {
 	return errors.Wrap(err, "launcher")
 }
@@ -335,6 +390,43 @@ func getProjects(projectMgr project.Manager) ([]*art.Candidate, error) {
 	return candidates, nil
 }
 
+// filterProjects filters the projects for the system level policy: the projects opting out the
+// system level policy or having their own retention policies are excluded, and only the ones
+// having all the project metadata declared in the scope are kept
+func filterProjects(projectMgr project.Manager, scope *policy.Scope, projects []*art.Candidate) ([]*art.Candidate, error) {
+	var candidates []*art.Candidate
+	for _, pro := range projects {
+		metas, err := projectMgr.GetMetadata(pro.NamespaceID)
+		if err != nil {
+			return nil, err
+		}
+		if _, exist := metas[cmodels.ProMetaRetentionID]; exist {
+			log.Debugf("project %s has its own retention policy, skip it", pro.Namespace)
+			continue
+		}
+		if (&cmodels.Project{Metadata: metas}).RetentionOptOut() {
+			log.Debugf("project %s opts out the system level retention policy, skip it", pro.Namespace)
+			continue
+		}
+		if !matchMetadata(metas, scope.ProjectMetadata) {
+			continue
+		}
+		candidates = append(candidates, pro)
+	}
+	return candidates, nil
+}
+
+// matchMetadata returns true if the metadata contains all the expected key/value pairs
+func matchMetadata(metas, expected map[string]string) bool {
+	for key, value := range expected {
+		v, exist := metas[key]
+		if !exist || !strings.EqualFold(v, value) {
+			return false
+		}
+	}
+	return true
+}
+
 func getRepositories(projectMgr project.Manager, repositoryMgr repository.Manager,
 	projectID int64, chartServerEnabled bool) ([]*art.Candidate, error) {
 	var candidates []*art.Candidate
@@ -61,6 +61,14 @@ func (f *fakeProjectManager) Get(idOrName interface{}) (*models.Project, error)
 	}
 	return nil, fmt.Errorf("invalid parameter: %v, should be ID(int64) or name(string)", idOrName)
 }
+func (f *fakeProjectManager) GetMetadata(projectID int64, name ...string) (map[string]string, error) {
+	for _, pro := range f.projects {
+		if pro.ProjectID == projectID {
+			return pro.Metadata, nil
+		}
+	}
+	return nil, nil
+}
 
 type fakeRepositoryManager struct {
 	imageRepositories []*models.RepoRecord
@@ -96,6 +104,9 @@ func (f *fakeRetentionManager) DeletePolicyAndExec(ID int64) error {
 func (f *fakeRetentionManager) GetPolicy(ID int64) (*policy.Metadata, error) {
 	return nil, nil
 }
+func (f *fakeRetentionManager) ListPolicies(scopeLevel string) ([]*policy.Metadata, error) {
+	return nil, nil
+}
 func (f *fakeRetentionManager) CreateExecution(execution *Execution) (int64, error) {
 	return 0, nil
 }
@@ -105,6 +116,12 @@ func (f *fakeRetentionManager) UpdateExecution(execution *Execution) error {
 func (f *fakeRetentionManager) GetExecution(eid int64) (*Execution, error) {
 	return nil, nil
 }
+func (f *fakeRetentionManager) MarkExecutionNotified(eid int64, status string) (bool, error) {
+	return true, nil
+}
+func (f *fakeRetentionManager) ResetExecutionNotified(eid int64) error {
+	return nil
+}
 func (f *fakeRetentionManager) DeleteExecution(eid int64) error {
 	return nil
 }
@@ -132,6 +149,12 @@ func (f *fakeRetentionManager) UpdateTaskStatus(int64, string, int64) error {
 func (f *fakeRetentionManager) GetTaskLog(taskID int64) ([]byte, error) {
 	return nil, nil
 }
+func (f *fakeRetentionManager) SaveReportItems(taskID int64, items []*ReportItem) error {
+	return nil
+}
+func (f *fakeRetentionManager) ListReportItems(executionID int64) ([]*ReportItem, error) {
+	return nil, nil
+}
 func (f *fakeRetentionManager) ListExecutions(policyID int64, query *q.Query) ([]*Execution, error) {
 	return nil, nil
 }
@@ -192,6 +215,59 @@ func (l *launchTestSuite) TestGetProjects() {
 	assert.Equal(l.T(), "library", projects[0].Namespace)
 }
 
+func (l *launchTestSuite) TestFilterProjects() {
+	projectMgr := &fakeProjectManager{
+		projects: []*models.Project{
+			{
+				ProjectID: 1,
+				Name:      "library",
+				Metadata: map[string]string{
+					models.ProMetaPublic: "true",
+				},
+			},
+			{
+				ProjectID: 2,
+				Name:      "override",
+				Metadata: map[string]string{
+					models.ProMetaPublic:      "true",
+					models.ProMetaRetentionID: "1",
+				},
+			},
+			{
+				ProjectID: 3,
+				Name:      "optout",
+				Metadata: map[string]string{
+					models.ProMetaPublic:          "true",
+					models.ProMetaRetentionOptOut: "true",
+				},
+			},
+			{
+				ProjectID: 4,
+				Name:      "private",
+			},
+		}}
+	projects, err := getProjects(projectMgr)
+	require.Nil(l.T(), err)
+
+	// the projects overriding or opting out the system level policy are excluded
+	candidates, err := filterProjects(projectMgr, &policy.Scope{Level: policy.ScopeLevelSystem}, projects)
+	require.Nil(l.T(), err)
+	require.Equal(l.T(), 2, len(candidates))
+	assert.Equal(l.T(), "library", candidates[0].Namespace)
+	assert.Equal(l.T(), "private", candidates[1].Namespace)
+
+	// filtered by the project metadata
+	candidates, err = filterProjects(projectMgr, &policy.Scope{
+		Level: policy.ScopeLevelSystem,
+		ProjectMetadata: map[string]string{
+			models.ProMetaPublic: "true",
+		},
+	}, projects)
+	require.Nil(l.T(), err)
+	require.Equal(l.T(), 1, len(candidates))
+	assert.Equal(l.T(), "library", candidates[0].Namespace)
+}
+
 func (l *launchTestSuite) TestGetRepositories() {
 	repositories, err := getRepositories(l.projectMgr, l.repositoryMgr, 1, true)
 	require.Nil(l.T(), err)
@@ -296,6 +372,23 @@ func (l *launchTestSuite) TestStop() {
 	require.Nil(t, err)
 }
 
+func (l *launchTestSuite) TestRetry() {
+	t := l.T()
+	launcher := &launcher{
+		projectMgr:       l.projectMgr,
+		repositoryMgr:    l.repositoryMgr,
+		retentionMgr:     l.retentionMgr,
+		jobserviceClient: l.jobserviceClient,
+	}
+	// invalid execution ID
+	err := launcher.Retry(0)
+	require.NotNil(t, err)
+
+	// no failed or stopped task
+	err = launcher.Retry(1)
+	require.NotNil(t, err)
+}
+
 func TestLaunchTestSuite(t *testing.T) {
 	suite.Run(t, new(launchTestSuite))
 }
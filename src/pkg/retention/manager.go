@@ -41,12 +41,19 @@ type Manager interface {
 	DeletePolicyAndExec(ID int64) error
 	// Get the specified policy
 	GetPolicy(ID int64) (*policy.Metadata, error)
+	// List the policies with the specified scope level
+	ListPolicies(scopeLevel string) ([]*policy.Metadata, error)
 	// Create a new retention execution
 	CreateExecution(execution *Execution) (int64, error)
 	// Delete a new retention execution
 	DeleteExecution(int64) error
 	// Get the specified execution
 	GetExecution(eid int64) (*Execution, error)
+	// Mark the final status of the execution as notified, returns false
+	// when the event of the status has been published already
+	MarkExecutionNotified(eid int64, status string) (bool, error)
+	// Clear the notified status when the tasks of the execution re-run
+	ResetExecutionNotified(eid int64) error
 	// List executions
 	ListExecutions(policyID int64, query *q.Query) ([]*Execution, error)
 	// GetTotalOfRetentionExecs Count Retention Executions
@@ -67,6 +74,10 @@ type Manager interface {
 	GetTask(taskID int64) (*Task, error)
 	// Get the log of the specified task
 	GetTaskLog(taskID int64) ([]byte, error)
+	// Save the report items of the specified task, the existing ones are replaced
+	SaveReportItems(taskID int64, items []*ReportItem) error
+	// List the report items of the specified execution
+	ListReportItems(executionID int64) ([]*ReportItem, error)
 }
 
 // DefaultManager ...
@@ -115,11 +126,32 @@ func (d *DefaultManager) GetPolicy(id int64) (*policy.Metadata, error) {
 		}
 		return nil, err
 	}
+	return toMetadata(p1)
+}
+
+// ListPolicies List Policies
+func (d *DefaultManager) ListPolicies(scopeLevel string) ([]*policy.Metadata, error) {
+	ps, err := dao.ListPolicies(scopeLevel)
+	if err != nil {
+		return nil, err
+	}
+	var policies []*policy.Metadata
+	for _, p1 := range ps {
+		p, err := toMetadata(p1)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, p)
+	}
+	return policies, nil
+}
+
+func toMetadata(p1 *models.RetentionPolicy) (*policy.Metadata, error) {
 	p := &policy.Metadata{}
-	if err = json.Unmarshal([]byte(p1.Data), p); err != nil {
+	if err := json.Unmarshal([]byte(p1.Data), p); err != nil {
 		return nil, err
 	}
-	p.ID = id
+	p.ID = p1.ID
 	if p.Trigger.Settings != nil {
 		if _, ok := p.Trigger.References[policy.TriggerReferencesJobid]; ok {
 			p.Trigger.References[policy.TriggerReferencesJobid] = int64(p.Trigger.References[policy.TriggerReferencesJobid].(float64))
@@ -143,6 +175,16 @@ func (d *DefaultManager) DeleteExecution(eid int64) error {
 	return dao.DeleteExecution(eid)
 }
 
+// MarkExecutionNotified ...
+func (d *DefaultManager) MarkExecutionNotified(eid int64, status string) (bool, error) {
+	return dao.MarkExecutionNotified(eid, status)
+}
+
+// ResetExecutionNotified ...
+func (d *DefaultManager) ResetExecutionNotified(eid int64) error {
+	return dao.ResetExecutionNotified(eid)
+}
+
 // ListExecutions List Executions
 func (d *DefaultManager) ListExecutions(policyID int64, query *q.Query) ([]*Execution, error) {
 	execs, err := dao.ListExecutions(policyID, query)
@@ -196,6 +238,7 @@ func (d *DefaultManager) CreateTask(task *Task) (int64, error) {
 	}
 	t := &models.RetentionTask{
 		ExecutionID:    task.ExecutionID,
+		Project:        task.Project,
 		Repository:     task.Repository,
 		JobID:          task.JobID,
 		Status:         task.Status,
@@ -223,6 +266,7 @@ func (d *DefaultManager) ListTasks(query ...*q.TaskQuery) ([]*Task, error) {
 		tasks = append(tasks, &Task{
 			ID:             t.ID,
 			ExecutionID:    t.ExecutionID,
+			Project:        t.Project,
 			Repository:     t.Repository,
 			JobID:          t.JobID,
 			Status:         t.Status,
@@ -253,6 +297,7 @@ func (d *DefaultManager) UpdateTask(task *Task, cols ...string) error {
 	return dao.UpdateTask(&models.RetentionTask{
 		ID:             task.ID,
 		ExecutionID:    task.ExecutionID,
+		Project:        task.Project,
 		Repository:     task.Repository,
 		JobID:          task.JobID,
 		Status:         task.Status,
@@ -286,6 +331,7 @@ func (d *DefaultManager) GetTask(taskID int64) (*Task, error) {
 	return &Task{
 		ID:             task.ID,
 		ExecutionID:    task.ExecutionID,
+		Project:        task.Project,
 		Repository:     task.Repository,
 		JobID:          task.JobID,
 		Status:         task.Status,
@@ -310,6 +356,54 @@ func (d *DefaultManager) GetTaskLog(taskID int64) ([]byte, error) {
 	return cjob.GlobalClient.GetJobLog(task.JobID)
 }
 
+// SaveReportItems saves the report items of the task
+func (d *DefaultManager) SaveReportItems(taskID int64, items []*ReportItem) error {
+	if taskID <= 0 {
+		return fmt.Errorf("invalid task ID: %d", taskID)
+	}
+	task, err := dao.GetTask(taskID)
+	if err != nil {
+		return err
+	}
+	var ris []*models.RetentionReportItem
+	for _, item := range items {
+		ris = append(ris, &models.RetentionReportItem{
+			ExecutionID: task.ExecutionID,
+			TaskID:      taskID,
+			Repository:  item.Repository,
+			Tag:         item.Tag,
+			Digest:      item.Digest,
+			Action:      item.Action,
+			Rules:       item.Rules,
+			Reason:      item.Reason,
+		})
+	}
+	return dao.SaveReportItems(taskID, ris)
+}
+
+// ListReportItems lists the report items of the execution
+func (d *DefaultManager) ListReportItems(executionID int64) ([]*ReportItem, error) {
+	ris, err := dao.ListReportItems(executionID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*ReportItem, 0)
+	for _, ri := range ris {
+		items = append(items, &ReportItem{
+			ID:          ri.ID,
+			ExecutionID: ri.ExecutionID,
+			TaskID:      ri.TaskID,
+			Repository:  ri.Repository,
+			Tag:         ri.Tag,
+			Digest:      ri.Digest,
+			Action:      ri.Action,
+			Rules:       ri.Rules,
+			Reason:      ri.Reason,
+		})
+	}
+	return items, nil
+}
+
 // NewManager ...
 func NewManager() Manager {
 	return &DefaultManager{}
@@ -28,6 +28,10 @@ const (
 
 	ExecutionTriggerManual   string = "Manual"
 	ExecutionTriggerSchedule string = "Schedule"
+
+	ReportActionRetained string = "retained"
+	ReportActionRemoved  string = "removed"
+	ReportActionError    string = "error"
 )
 
 // Execution of retention
@@ -45,6 +49,7 @@ type Execution struct {
 type Task struct {
 	ID             int64     `json:"id"`
 	ExecutionID    int64     `json:"execution_id"`
+	Project        string    `json:"project"`
 	Repository     string    `json:"repository"`
 	JobID          string    `json:"job_id"`
 	Status         string    `json:"status"`
@@ -56,6 +61,21 @@ type Task struct {
 	Retained       int       `json:"retained"`
 }
 
+// ReportItem records how one candidate is handled in the retention execution
+type ReportItem struct {
+	ID          int64  `json:"-"`
+	ExecutionID int64  `json:"execution_id"`
+	TaskID      int64  `json:"task_id"`
+	Repository  string `json:"repository"`
+	Tag         string `json:"tag"`
+	Digest      string `json:"digest"`
+	// "retained", "removed" or "error"
+	Action string `json:"action"`
+	// the rules retaining the candidate, separated by comma
+	Rules  string `json:"rules"`
+	Reason string `json:"reason"`
+}
+
 // History of retention
 type History struct {
 	ID          int64 `json:"id,omitempty"`
@@ -104,6 +104,11 @@ func (frc *fakeRetentionClient) GetCandidates(repo *art.Repository) ([]*art.Cand
 	return nil, errors.New("not implemented")
 }
 
+// GetUntaggedCandidates ...
+func (frc *fakeRetentionClient) GetUntaggedCandidates(repo *art.Repository) ([]*art.Candidate, error) {
+	return nil, nil
+}
+
 // Delete ...
 func (frc *fakeRetentionClient) Delete(candidate *art.Candidate) error {
 	return nil
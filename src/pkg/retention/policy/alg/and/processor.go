@@ -0,0 +1,171 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package and
+
+import (
+	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/goharbor/harbor/src/pkg/art"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/action"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/alg"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule"
+	"github.com/pkg/errors"
+)
+
+// processor to handle the rules with AND mapping ways
+type processor struct {
+	// keep evaluator and its related selectors
+	// attentions here, the order of the rules is kept
+	evaluators []*evaluatorItem
+	// action performer
+	performers map[string]action.Performer
+}
+
+type evaluatorItem struct {
+	evaluator rule.Evaluator
+	selectors []art.Selector
+}
+
+// New processor
+func New(parameters []*alg.Parameter) alg.Processor {
+	p := &processor{
+		performers: make(map[string]action.Performer),
+	}
+
+	if len(parameters) > 0 {
+		for _, param := range parameters {
+			if param.Evaluator != nil {
+				if len(param.Selectors) > 0 {
+					p.evaluators = append(p.evaluators, &evaluatorItem{
+						evaluator: param.Evaluator,
+						selectors: param.Selectors,
+					})
+				}
+
+				if param.Performer != nil {
+					p.performers[param.Evaluator.Action()] = param.Performer
+				}
+			}
+		}
+	}
+
+	return p
+}
+
+// Process the candidates with the rules, only the candidates matched by
+// all the rules with the same action are passed to the action performer
+func (p *processor) Process(artifacts []*art.Candidate) ([]*art.Result, error) {
+	if len(artifacts) == 0 {
+		log.Debug("no artifacts to retention")
+		return make([]*art.Result, 0), nil
+	}
+
+	var (
+		// the processed candidates of each action
+		processedCandidates = make(map[string]cHash)
+		// the number of the rules matching the candidate of each action
+		hits = make(map[string]map[string]int)
+		// the number of the rules of each action
+		rules = make(map[string]int)
+	)
+
+	for _, item := range p.evaluators {
+		var (
+			processed []*art.Candidate
+			err       error
+		)
+
+		// pass array copy to the selector
+		processed = append(processed, artifacts...)
+
+		// selecting artifacts one by one
+		// `&&` mappings
+		for _, s := range item.selectors {
+			if processed, err = s.Select(processed); err != nil {
+				return nil, errors.Wrap(err, "artifact processing error")
+			}
+		}
+
+		if processed, err = item.evaluator.Process(processed); err != nil {
+			return nil, errors.Wrap(err, "artifact processing error")
+		}
+
+		act := item.evaluator.Action()
+		rules[act]++
+		if _, ok := processedCandidates[act]; !ok {
+			processedCandidates[act] = make(cHash)
+			hits[act] = make(map[string]int)
+		}
+
+		// remove duplicated ones in the result of one rule
+		matched := make(map[string]bool)
+		for _, c := range processed {
+			h := c.Hash()
+			if matched[h] {
+				continue
+			}
+			matched[h] = true
+			processedCandidates[act][h] = c
+			hits[act][h]++
+		}
+	}
+
+	results := make([]*art.Result, 0)
+	// Perform actions
+	for act, hash := range processedCandidates {
+		var attachedErr error
+
+		// keep the intersection only
+		for h := range hash {
+			if hits[act][h] < rules[act] {
+				delete(hash, h)
+			}
+		}
+
+		cl := hash.toList()
+
+		if pf, ok := p.performers[act]; ok {
+			if theRes, err := pf.Perform(cl); err != nil {
+				attachedErr = err
+			} else {
+				results = append(results, theRes...)
+			}
+		} else {
+			attachedErr = errors.Errorf("no performer added for action %s in AND processor", act)
+		}
+
+		if attachedErr != nil {
+			for _, c := range cl {
+				results = append(results, &art.Result{
+					Target: c,
+					Error:  attachedErr,
+				})
+			}
+		}
+	}
+
+	return results, nil
+}
+
+type cHash map[string]*art.Candidate
+
+func (ch cHash) toList() []*art.Candidate {
+	l := make([]*art.Candidate, 0)
+
+	for _, v := range ch {
+		l = append(l, v)
+	}
+
+	return l
+}
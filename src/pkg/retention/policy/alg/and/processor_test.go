@@ -0,0 +1,171 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package and
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/pkg/art"
+	"github.com/goharbor/harbor/src/pkg/art/selectors/doublestar"
+	"github.com/goharbor/harbor/src/pkg/art/selectors/label"
+	"github.com/goharbor/harbor/src/pkg/retention/dep"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/action"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/alg"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/always"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/daysps"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/sizelt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// ProcessorTestSuite is suite for testing processor
+type ProcessorTestSuite struct {
+	suite.Suite
+
+	all []*art.Candidate
+
+	oldClient dep.Client
+}
+
+// TestProcessor is entrance for ProcessorTestSuite
+func TestProcessor(t *testing.T) {
+	suite.Run(t, new(ProcessorTestSuite))
+}
+
+// SetupSuite ...
+func (suite *ProcessorTestSuite) SetupSuite() {
+	suite.all = []*art.Candidate{
+		{
+			Namespace:  "library",
+			Repository: "harbor",
+			Kind:       "image",
+			Tag:        "latest",
+			Digest:     "latest",
+			PushedTime: time.Now().Unix(),
+			Labels:     []string{"L1", "L2"},
+			Size:       10 * 1024 * 1024,
+		},
+		{
+			Namespace:  "library",
+			Repository: "harbor",
+			Kind:       "image",
+			Tag:        "dev",
+			Digest:     "dev",
+			PushedTime: time.Now().Add(-60 * 24 * time.Hour).Unix(),
+			Labels:     []string{"L3"},
+			Size:       500 * 1024 * 1024,
+		},
+	}
+
+	suite.oldClient = dep.DefaultClient
+	dep.DefaultClient = &fakeRetentionClient{}
+}
+
+// TearDownSuite ...
+func (suite *ProcessorTestSuite) TearDownSuite() {
+	dep.DefaultClient = suite.oldClient
+}
+
+// TestProcess tests process method
+func (suite *ProcessorTestSuite) TestProcess() {
+	perf := action.NewRetainAction(suite.all, false)
+
+	params := make([]*alg.Parameter, 0)
+	daysParams := make(map[string]rule.Parameter)
+	daysParams[daysps.ParameterN] = 30
+	params = append(params, &alg.Parameter{
+		Evaluator: daysps.New(daysParams),
+		Selectors: []art.Selector{
+			doublestar.New(doublestar.Matches, "**"),
+		},
+		Performer: perf,
+	})
+
+	sizeParams := make(map[string]rule.Parameter)
+	sizeParams[sizelt.ParameterN] = 100
+	params = append(params, &alg.Parameter{
+		Evaluator: sizelt.New(sizeParams),
+		Selectors: []art.Selector{
+			doublestar.New(doublestar.Matches, "**"),
+		},
+		Performer: perf,
+	})
+
+	p := New(params)
+
+	results, err := p.Process(suite.all)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), 1, len(results))
+	assert.Nil(suite.T(), results[0].Error)
+	assert.Equal(suite.T(), "dev", results[0].Target.Tag)
+}
+
+// TestProcessNoIntersection tests process method with rules retaining different candidates
+func (suite *ProcessorTestSuite) TestProcessNoIntersection() {
+	perf := action.NewRetainAction(suite.all, false)
+
+	params := make([]*alg.Parameter, 0)
+	params = append(params, &alg.Parameter{
+		Evaluator: always.New(make(map[string]rule.Parameter)),
+		Selectors: []art.Selector{
+			doublestar.New(doublestar.Matches, "**"),
+			label.New(label.With, "L1"),
+		},
+		Performer: perf,
+	})
+	params = append(params, &alg.Parameter{
+		Evaluator: always.New(make(map[string]rule.Parameter)),
+		Selectors: []art.Selector{
+			doublestar.New(doublestar.Matches, "**"),
+			label.New(label.With, "L3"),
+		},
+		Performer: perf,
+	})
+
+	p := New(params)
+
+	results, err := p.Process(suite.all)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, len(results))
+	for _, r := range results {
+		assert.Nil(suite.T(), r.Error)
+	}
+}
+
+type fakeRetentionClient struct{}
+
+// GetCandidates ...
+func (frc *fakeRetentionClient) GetCandidates(repo *art.Repository) ([]*art.Candidate, error) {
+	return nil, errors.New("not implemented")
+}
+
+// GetUntaggedCandidates ...
+func (frc *fakeRetentionClient) GetUntaggedCandidates(repo *art.Repository) ([]*art.Candidate, error) {
+	return nil, nil
+}
+
+// Delete ...
+func (frc *fakeRetentionClient) Delete(candidate *art.Candidate) error {
+	return nil
+}
+
+// DeleteRepository ...
+func (frc *fakeRetentionClient) DeleteRepository(repo *art.Repository) error {
+	panic("implement me")
+}
@@ -18,6 +18,7 @@ import (
 	"sync"
 
 	"github.com/goharbor/harbor/src/pkg/retention/policy/alg"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/alg/and"
 	"github.com/goharbor/harbor/src/pkg/retention/policy/alg/or"
 	"github.com/pkg/errors"
 )
@@ -25,6 +26,8 @@ import (
 const (
 	// AlgorithmOR for || algorithm
 	AlgorithmOR = "or"
+	// AlgorithmAND for && algorithm
+	AlgorithmAND = "and"
 )
 
 // index for keeping the mapping between algorithm and its processor
@@ -33,6 +36,8 @@ var index sync.Map
 func init() {
 	// Register or
 	Register(AlgorithmOR, or.New)
+	// Register and
+	Register(AlgorithmAND, and.New)
 }
 
 // Register processor with the algorithm
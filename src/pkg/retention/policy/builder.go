@@ -24,10 +24,12 @@ import (
 	index2 "github.com/goharbor/harbor/src/pkg/art/selectors/index"
 
 	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/index"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/untagged"
 
 	"github.com/goharbor/harbor/src/pkg/art"
 	"github.com/goharbor/harbor/src/pkg/retention/policy/alg"
 	"github.com/goharbor/harbor/src/pkg/retention/policy/lwp"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule"
 	"github.com/pkg/errors"
 )
 
@@ -46,15 +48,18 @@ type Builder interface {
 }
 
 // NewBuilder news a basic builder
-func NewBuilder(all []*art.Candidate) Builder {
+// recorder can be nil, otherwise the rules matching the candidates are recorded into it
+func NewBuilder(all []*art.Candidate, recorder *rule.Recorder) Builder {
 	return &basicBuilder{
 		allCandidates: all,
+		recorder:      recorder,
 	}
 }
 
 // basicBuilder is default implementation of Builder interface
 type basicBuilder struct {
 	allCandidates []*art.Candidate
+	recorder      *rule.Recorder
 }
 
 // Build policy processor from the raw policy
@@ -70,6 +75,9 @@ func (bb *basicBuilder) Build(policy *lwp.Metadata, isDryRun bool) (alg.Processo
 		if err != nil {
 			return nil, err
 		}
+		if bb.recorder != nil {
+			evaluator = bb.recorder.Wrap(r, evaluator)
+		}
 
 		perf, err := index4.Get(r.Action, bb.allCandidates, isDryRun)
 		if err != nil {
@@ -77,13 +85,18 @@ func (bb *basicBuilder) Build(policy *lwp.Metadata, isDryRun bool) (alg.Processo
 		}
 
 		sl := make([]art.Selector, 0)
-		for _, s := range r.TagSelectors {
-			sel, err := index2.Get(s.Kind, s.Decoration, s.Pattern)
-			if err != nil {
-				return nil, errors.Wrap(err, "get selector by metadata")
+		if r.Template == untagged.TemplateID {
+			// the untagged manifests have no tag to match the tag selectors
+			sl = append(sl, untagged.NewSelector())
+		} else {
+			for _, s := range r.TagSelectors {
+				sel, err := index2.Get(s.Kind, s.Decoration, s.Pattern)
+				if err != nil {
+					return nil, errors.Wrap(err, "get selector by metadata")
+				}
+
+				sl = append(sl, sel)
 			}
-
-			sl = append(sl, sel)
 		}
 
 		params = append(params, &alg.Parameter{
@@ -112,7 +112,8 @@ func (suite *TestBuilderSuite) TearDownSuite() {
 
 // TestBuild tests the Build function
 func (suite *TestBuilderSuite) TestBuild() {
-	b := &basicBuilder{suite.all}
+	recorder := rule.NewRecorder()
+	b := NewBuilder(suite.all, recorder)
 
 	params := make(rule.Parameters)
 	params[latestps.ParameterK] = 10
@@ -159,6 +160,14 @@ func (suite *TestBuilderSuite) TestBuild() {
 
 		return
 	})
+
+	for _, c := range suite.all {
+		if c.Tag == "latest" {
+			assert.Equal(suite.T(), []string{"latestPushedK(10)"}, recorder.Matched(c))
+		} else {
+			assert.Empty(suite.T(), recorder.Matched(c))
+		}
+	}
 }
 
 type fakeRetentionClient struct{}
@@ -172,6 +181,11 @@ func (frc *fakeRetentionClient) GetCandidates(repo *art.Repository) ([]*art.Cand
 	return nil, errors.New("not implemented")
 }
 
+// GetUntaggedCandidates ...
+func (frc *fakeRetentionClient) GetUntaggedCandidates(repo *art.Repository) ([]*art.Candidate, error) {
+	return nil, nil
+}
+
 // Delete ...
 func (frc *fakeRetentionClient) Delete(candidate *art.Candidate) error {
 	return nil
@@ -23,6 +23,8 @@ import (
 const (
 	// AlgorithmOR for OR algorithm
 	AlgorithmOR = "or"
+	// AlgorithmAND for AND algorithm
+	AlgorithmAND = "and"
 
 	// TriggerKindSchedule Schedule
 	TriggerKindSchedule = "Schedule"
@@ -34,6 +36,8 @@ const (
 
 	// ScopeLevelProject project
 	ScopeLevelProject = "project"
+	// ScopeLevelSystem system
+	ScopeLevelSystem = "system"
 )
 
 // Metadata of policy
@@ -43,7 +47,7 @@ type Metadata struct {
 
 	// Algorithm applied to the rules
 	// "OR" / "AND"
-	Algorithm string `json:"algorithm" valid:"Required;Match(or)"`
+	Algorithm string `json:"algorithm" valid:"Required;Match(/^(or|and)$/)"`
 
 	// Rule collection
 	Rules []rule.Metadata `json:"rules"`
@@ -65,6 +69,17 @@ func (m *Metadata) Valid(v *validation.Validation) {
 		_ = v.SetError("Scope", "Can not be empty")
 		return
 	}
+	switch m.Scope.Level {
+	case ScopeLevelProject:
+		if m.Scope.Reference <= 0 {
+			_ = v.SetError("Scope.Reference", "Can not be empty")
+			return
+		}
+	case ScopeLevelSystem:
+	default:
+		_ = v.SetError("Scope.Level", "Must be project or system")
+		return
+	}
 	if m.Trigger != nil && m.Trigger.Kind == TriggerKindSchedule {
 		if m.Trigger.Settings == nil {
 			_ = v.SetError("Trigger.Settings", "Can not be empty")
@@ -106,9 +121,13 @@ type Trigger struct {
 type Scope struct {
 	// Scope level declaration
 	// 'system', 'project' and 'repository'
-	Level string `json:"level" valid:"Required;Match(/^(project)$/)"`
+	Level string `json:"level" valid:"Required;Match(/^(project|system)$/)"`
 
 	// The reference identity for the specified level
 	// 0 for 'system', project ID for 'project' and repo ID for 'repository'
-	Reference int64 `json:"ref" valid:"Required"`
+	Reference int64 `json:"ref"`
+
+	// The project metadata the projects must have to be applied by the 'system' level policy
+	// e.g: '[public]="true"'
+	ProjectMetadata map[string]string `json:"project_metadata,omitempty"`
 }
@@ -181,3 +181,115 @@ func TestParamValid(t *testing.T) {
 	require.True(t, v.HasErrors())
 	require.EqualValues(t, "Parameters", v.Errors[0].Field)
 }
+
+func TestScope(t *testing.T) {
+	p := &Metadata{
+		Algorithm: "and",
+		Rules: []rule.Metadata{
+			{
+				ID:       1,
+				Priority: 1,
+				Action:   "retain",
+				Template: "latestPushedK",
+				Parameters: rule.Parameters{
+					"latestPushedK": 10,
+				},
+				TagSelectors: []*rule.Selector{
+					{
+						Kind:       "doublestar",
+						Decoration: "matches",
+						Pattern:    "**",
+					},
+				},
+				ScopeSelectors: map[string][]*rule.Selector{
+					"project": {
+						{
+							Kind:       "doublestar",
+							Decoration: "nsMatches",
+							Pattern:    "**",
+						},
+					},
+				},
+			},
+		},
+		Trigger: &Trigger{
+			Kind: "Schedule",
+			Settings: map[string]interface{}{
+				"cron": "* 22 11 * * *",
+			},
+		},
+		Scope: &Scope{
+			Level: "system",
+		},
+	}
+	v := &validation.Validation{}
+	ok, err := v.Valid(p)
+	require.Nil(t, err)
+	require.True(t, ok)
+
+	p.Scope.Level = "project"
+	v = &validation.Validation{}
+	ok, err = v.Valid(p)
+	require.Nil(t, err)
+	require.False(t, ok)
+	require.EqualValues(t, "Scope.Reference", v.Errors[0].Field)
+
+	p.Scope.Level = "repository"
+	v = &validation.Validation{}
+	ok, err = v.Valid(p)
+	require.Nil(t, err)
+	require.False(t, ok)
+	require.EqualValues(t, "Scope.Level", v.Errors[0].Field)
+}
+
+func TestLabelSelector(t *testing.T) {
+	p := &Metadata{
+		Algorithm: "and",
+		Rules: []rule.Metadata{
+			{
+				ID:       1,
+				Priority: 1,
+				Action:   "retain",
+				Template: "latestPushedK",
+				Parameters: rule.Parameters{
+					"latestPushedK": 10,
+				},
+				TagSelectors: []*rule.Selector{
+					{
+						Kind:       "doublestar",
+						Decoration: "matches",
+						Pattern:    "**",
+					},
+					{
+						Kind:       "label",
+						Decoration: "withLabels",
+						Pattern:    "release",
+					},
+				},
+				ScopeSelectors: map[string][]*rule.Selector{
+					"repository": {
+						{
+							Kind:       "doublestar",
+							Decoration: "matches",
+							Pattern:    "**",
+						},
+					},
+				},
+			},
+		},
+		Trigger: &Trigger{
+			Kind: "Schedule",
+			Settings: map[string]interface{}{
+				"cron": "* 22 11 * * *",
+			},
+		},
+		Scope: &Scope{
+			Level:     "project",
+			Reference: 1,
+		},
+	}
+	v := &validation.Validation{}
+	ok, err := v.Valid(p)
+	require.Nil(t, err)
+	require.True(t, ok)
+}
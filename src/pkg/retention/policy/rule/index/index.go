@@ -26,6 +26,10 @@ import (
 	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/latestk"
 	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/latestpl"
 	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/latestps"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/scannedps"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/severity"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/sizelt"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule/untagged"
 	"github.com/pkg/errors"
 )
 
@@ -163,6 +167,62 @@ func init() {
 			},
 		},
 	}, daysps.New, daysps.Valid)
+
+	// Register severity
+	Register(&Metadata{
+		TemplateID: severity.TemplateID,
+		Action:     action.Retain,
+		Parameters: []*IndexedParam{
+			{
+				Name:     severity.ParameterSeverity,
+				Type:     "string",
+				Unit:     "severity",
+				Required: true,
+			},
+		},
+	}, severity.New, severity.Valid)
+
+	// Register scannedps
+	Register(&Metadata{
+		TemplateID: scannedps.TemplateID,
+		Action:     action.Retain,
+		Parameters: []*IndexedParam{
+			{
+				Name:     scannedps.ParameterN,
+				Type:     "int",
+				Unit:     "days",
+				Required: true,
+			},
+		},
+	}, scannedps.New, scannedps.Valid)
+
+	// Register sizelt
+	Register(&Metadata{
+		TemplateID: sizelt.TemplateID,
+		Action:     action.Retain,
+		Parameters: []*IndexedParam{
+			{
+				Name:     sizelt.ParameterN,
+				Type:     "int",
+				Unit:     "MB",
+				Required: true,
+			},
+		},
+	}, sizelt.New, sizelt.Valid)
+
+	// Register untagged
+	Register(&Metadata{
+		TemplateID: untagged.TemplateID,
+		Action:     action.Retain,
+		Parameters: []*IndexedParam{
+			{
+				Name:     untagged.ParameterN,
+				Type:     "int",
+				Unit:     "days",
+				Required: true,
+			},
+		},
+	}, untagged.New, untagged.Valid)
 }
 
 // Register the rule evaluator with the corresponding rule template
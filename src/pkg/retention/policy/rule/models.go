@@ -66,7 +66,7 @@ func (m *Metadata) Valid(v *validation.Validation) {
 type Selector struct {
 	// Kind of the selector
 	// "doublestar" or "label"
-	Kind string `json:"kind" valid:"Required;Match(doublestar)"`
+	Kind string `json:"kind" valid:"Required;Match(/^(doublestar|label)$/)"`
 
 	// Decorated the selector
 	// for "doublestar" : "matching" and "excluding"
@@ -0,0 +1,112 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rule
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/goharbor/harbor/src/pkg/art"
+)
+
+// Recorder records the rules matching each candidate when the rule evaluators
+// are run by the processors, so the matching needn't be evaluated again
+type Recorder struct {
+	lock sync.Mutex
+	// the order of the rules which match the candidate, indexed by the hash of the candidate
+	matched map[string][]int
+	// the names of the rules in order
+	names []string
+}
+
+// NewRecorder news a recorder
+func NewRecorder() *Recorder {
+	return &Recorder{
+		matched: make(map[string][]int),
+	}
+}
+
+// Wrap the evaluator of the rule to record the candidates it matches, the matched
+// rules of the candidate are sorted in the order of wrapping
+func (r *Recorder) Wrap(m *Metadata, evaluator Evaluator) Evaluator {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	order := len(r.names)
+	r.names = append(r.names, name(m))
+	return &recordingEvaluator{
+		Evaluator: evaluator,
+		order:     order,
+		recorder:  r,
+	}
+}
+
+// Matched returns the names of the rules matching the candidate in order,
+// e.g: "latestPushedK(10)"
+func (r *Recorder) Matched(candidate *art.Candidate) []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	orders := append([]int{}, r.matched[candidate.Hash()]...)
+	sort.Ints(orders)
+	names := make([]string, 0, len(orders))
+	for _, o := range orders {
+		names = append(names, r.names[o])
+	}
+	return names
+}
+
+func (r *Recorder) record(order int, candidates []*art.Candidate) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	// remove duplicated ones in the result of one rule
+	recorded := make(map[string]bool)
+	for _, c := range candidates {
+		h := c.Hash()
+		if recorded[h] {
+			continue
+		}
+		recorded[h] = true
+		r.matched[h] = append(r.matched[h], order)
+	}
+}
+
+// recordingEvaluator records the candidates matched by the wrapped evaluator
+type recordingEvaluator struct {
+	Evaluator
+	order    int
+	recorder *Recorder
+}
+
+// Process the candidates with the wrapped evaluator and record the matched ones
+func (re *recordingEvaluator) Process(artifacts []*art.Candidate) ([]*art.Candidate, error) {
+	processed, err := re.Evaluator.Process(artifacts)
+	if err != nil {
+		return nil, err
+	}
+
+	re.recorder.record(re.order, processed)
+	return processed, nil
+}
+
+// name returns the readable name of the rule, e.g: "latestPushedK(10)"
+func name(m *Metadata) string {
+	if v, ok := m.Parameters[m.Template]; ok {
+		return fmt.Sprintf("%s(%v)", m.Template, v)
+	}
+	return m.Template
+}
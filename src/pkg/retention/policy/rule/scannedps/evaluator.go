@@ -0,0 +1,107 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scannedps
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/goharbor/harbor/src/common/utils"
+	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/goharbor/harbor/src/pkg/art"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/action"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule"
+	"github.com/goharbor/harbor/src/pkg/scan/report"
+)
+
+const (
+	// TemplateID of the rule
+	TemplateID = "scannedOrNDaysSinceLastPush"
+
+	// ParameterN is the name of the metadata parameter for the N value
+	ParameterN = TemplateID
+
+	// DefaultN is the default number of days that an unscanned artifact must have
+	// been pushed within to retain the tag or artifact.
+	DefaultN = 30
+)
+
+// reportMgr is used to get the scan reports of the artifacts
+var reportMgr = report.NewManager()
+
+type evaluator struct {
+	n   int
+	mgr report.Manager
+}
+
+// Process retains the scanned artifacts and the unscanned ones pushed within the last N days,
+// which means the unscanned artifacts older than N days are evicted.
+func (e *evaluator) Process(artifacts []*art.Candidate) (result []*art.Candidate, err error) {
+	minPushTime := time.Now().UTC().Add(time.Duration(-1*24*e.n) * time.Hour).Unix()
+	for _, a := range artifacts {
+		if a.PushedTime >= minPushTime {
+			result = append(result, a)
+			continue
+		}
+
+		_, scanned, err := report.LatestSeverity(e.mgr, a.Digest)
+		if err != nil {
+			return nil, err
+		}
+		if scanned {
+			result = append(result, a)
+		}
+	}
+
+	return
+}
+
+func (e *evaluator) Action() string {
+	return action.Retain
+}
+
+// New constructs a new 'Scanned Or Days Since Last Push' evaluator
+func New(params rule.Parameters) rule.Evaluator {
+	if params != nil {
+		if p, ok := params[ParameterN]; ok {
+			if v, ok := utils.ParseJSONInt(p); ok && v >= 0 {
+				return &evaluator{n: int(v), mgr: reportMgr}
+			}
+		}
+	}
+
+	log.Warningf("default parameter %d used for rule %s", DefaultN, TemplateID)
+
+	return &evaluator{n: DefaultN, mgr: reportMgr}
+}
+
+// Valid ...
+func Valid(params rule.Parameters) error {
+	if params != nil {
+		if p, ok := params[ParameterN]; ok {
+			if v, ok := utils.ParseJSONInt(p); ok {
+				if v < 0 {
+					return fmt.Errorf("%s is less than zero", ParameterN)
+				}
+				if v > 20190904 {
+					return fmt.Errorf("%s is too large", ParameterN)
+				}
+			} else {
+				return fmt.Errorf("%s type error", ParameterN)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scannedps
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/pkg/art"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule"
+	"github.com/goharbor/harbor/src/pkg/scan/dao/scan"
+	"github.com/goharbor/harbor/src/pkg/scan/report"
+	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type fakeReportManager struct {
+	report.Manager
+	scanned map[string]bool
+}
+
+func (f *fakeReportManager) GetBy(digest string, registrationUUID string, mimeTypes []string) ([]*scan.Report, error) {
+	if !f.scanned[digest] {
+		return nil, nil
+	}
+	return []*scan.Report{
+		{
+			MimeType: v1.MimeTypeNativeReport,
+			Status:   job.SuccessStatus.String(),
+			Report:   `{"severity":"Low"}`,
+			EndTime:  time.Now(),
+		},
+	}, nil
+}
+
+type EvaluatorTestSuite struct {
+	suite.Suite
+}
+
+func (e *EvaluatorTestSuite) TestNew() {
+	tests := []struct {
+		Name      string
+		args      rule.Parameters
+		expectedN int
+	}{
+		{Name: "Valid", args: map[string]rule.Parameter{ParameterN: float64(5)}, expectedN: 5},
+		{Name: "Default If Negative", args: map[string]rule.Parameter{ParameterN: float64(-1)}, expectedN: DefaultN},
+		{Name: "Default If Not Set", args: map[string]rule.Parameter{}, expectedN: DefaultN},
+		{Name: "Default If Wrong Type", args: map[string]rule.Parameter{ParameterN: "foo"}, expectedN: DefaultN},
+	}
+
+	for _, tt := range tests {
+		e.T().Run(tt.Name, func(t *testing.T) {
+			e := New(tt.args).(*evaluator)
+
+			require.Equal(t, tt.expectedN, e.n)
+		})
+	}
+}
+
+func (e *EvaluatorTestSuite) TestProcess() {
+	now := time.Now().UTC()
+	mgr := &fakeReportManager{
+		scanned: map[string]bool{
+			"scanned-old": true,
+			"scanned-new": true,
+		},
+	}
+	data := []*art.Candidate{
+		{Digest: "scanned-old", PushedTime: daysAgo(now, 10)},
+		{Digest: "scanned-new", PushedTime: daysAgo(now, 1)},
+		{Digest: "unscanned-old", PushedTime: daysAgo(now, 10)},
+		{Digest: "unscanned-new", PushedTime: daysAgo(now, 1)},
+	}
+
+	sut := &evaluator{n: 5, mgr: mgr}
+	result, err := sut.Process(data)
+	require.NoError(e.T(), err)
+
+	var digests []string
+	for _, v := range result {
+		digests = append(digests, v.Digest)
+	}
+	assert.Equal(e.T(), []string{"scanned-old", "scanned-new", "unscanned-new"}, digests)
+}
+
+func (e *EvaluatorTestSuite) TestValid() {
+	tests := []struct {
+		Name      string
+		args      rule.Parameters
+		expectedN error
+	}{
+		{Name: "Valid", args: map[string]rule.Parameter{ParameterN: 5}, expectedN: nil},
+		{Name: "Negative", args: map[string]rule.Parameter{ParameterN: -1}, expectedN: errors.New("scannedOrNDaysSinceLastPush is less than zero")},
+		{Name: "Big", args: map[string]rule.Parameter{ParameterN: 21000000}, expectedN: errors.New("scannedOrNDaysSinceLastPush is too large")},
+	}
+
+	for _, tt := range tests {
+		e.T().Run(tt.Name, func(t *testing.T) {
+			err := Valid(tt.args)
+
+			require.Equal(t, tt.expectedN, err)
+		})
+	}
+}
+
+func TestEvaluatorSuite(t *testing.T) {
+	suite.Run(t, &EvaluatorTestSuite{})
+}
+
+func daysAgo(from time.Time, n int) int64 {
+	return from.Add(time.Duration(-1*24*n) * time.Hour).Unix()
+}
@@ -0,0 +1,99 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package severity
+
+import (
+	"fmt"
+
+	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/goharbor/harbor/src/pkg/art"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/action"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule"
+	"github.com/goharbor/harbor/src/pkg/scan/report"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+)
+
+const (
+	// TemplateID of the rule
+	TemplateID = "severityLowerThan"
+
+	// ParameterSeverity is the name of the metadata parameter for the severity value
+	ParameterSeverity = TemplateID
+
+	// DefaultSeverity is the default severity that the vulnerabilities of the retained
+	// artifacts must be lower than.
+	DefaultSeverity = vuln.Critical
+)
+
+// reportMgr is used to get the scan reports of the artifacts
+var reportMgr = report.NewManager()
+
+type evaluator struct {
+	severity vuln.Severity
+	mgr      report.Manager
+}
+
+// Process retains the scanned artifacts whose overall severity of the latest scan report
+// is lower than the given one, the unscanned artifacts are not retained.
+func (e *evaluator) Process(artifacts []*art.Candidate) (result []*art.Candidate, err error) {
+	for _, a := range artifacts {
+		sev, scanned, err := report.LatestSeverity(e.mgr, a.Digest)
+		if err != nil {
+			return nil, err
+		}
+		if scanned && sev.Code() < e.severity.Code() {
+			result = append(result, a)
+		}
+	}
+
+	return
+}
+
+func (e *evaluator) Action() string {
+	return action.Retain
+}
+
+// New constructs a new 'Severity Lower Than' evaluator
+func New(params rule.Parameters) rule.Evaluator {
+	if params != nil {
+		if p, ok := params[ParameterSeverity]; ok {
+			if v, ok := p.(string); ok {
+				if sev, ok := vuln.ParseSeverity(v); ok {
+					return &evaluator{severity: sev, mgr: reportMgr}
+				}
+			}
+		}
+	}
+
+	log.Warningf("default parameter %s used for rule %s", DefaultSeverity, TemplateID)
+
+	return &evaluator{severity: DefaultSeverity, mgr: reportMgr}
+}
+
+// Valid ...
+func Valid(params rule.Parameters) error {
+	if params != nil {
+		if p, ok := params[ParameterSeverity]; ok {
+			v, ok := p.(string)
+			if !ok {
+				return fmt.Errorf("%s type error", ParameterSeverity)
+			}
+			if _, ok := vuln.ParseSeverity(v); !ok {
+				return fmt.Errorf("%s is not a valid severity", ParameterSeverity)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package severity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/pkg/art"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule"
+	"github.com/goharbor/harbor/src/pkg/scan/dao/scan"
+	"github.com/goharbor/harbor/src/pkg/scan/report"
+	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type fakeReportManager struct {
+	report.Manager
+	severities map[string]vuln.Severity
+}
+
+func (f *fakeReportManager) GetBy(digest string, registrationUUID string, mimeTypes []string) ([]*scan.Report, error) {
+	sev, ok := f.severities[digest]
+	if !ok {
+		return nil, nil
+	}
+	return []*scan.Report{
+		{
+			MimeType: v1.MimeTypeNativeReport,
+			Status:   job.SuccessStatus.String(),
+			Report:   `{"severity":"` + string(sev) + `"}`,
+			EndTime:  time.Now(),
+		},
+	}, nil
+}
+
+type EvaluatorTestSuite struct {
+	suite.Suite
+}
+
+func (e *EvaluatorTestSuite) TestNew() {
+	tests := []struct {
+		Name             string
+		args             rule.Parameters
+		expectedSeverity vuln.Severity
+	}{
+		{Name: "Valid", args: map[string]rule.Parameter{ParameterSeverity: "high"}, expectedSeverity: vuln.High},
+		{Name: "Default If Invalid", args: map[string]rule.Parameter{ParameterSeverity: "foo"}, expectedSeverity: DefaultSeverity},
+		{Name: "Default If Not Set", args: map[string]rule.Parameter{}, expectedSeverity: DefaultSeverity},
+		{Name: "Default If Wrong Type", args: map[string]rule.Parameter{ParameterSeverity: 1}, expectedSeverity: DefaultSeverity},
+	}
+
+	for _, tt := range tests {
+		e.T().Run(tt.Name, func(t *testing.T) {
+			e := New(tt.args).(*evaluator)
+
+			require.Equal(t, tt.expectedSeverity, e.severity)
+		})
+	}
+}
+
+func (e *EvaluatorTestSuite) TestProcess() {
+	mgr := &fakeReportManager{
+		severities: map[string]vuln.Severity{
+			"none":     "",
+			"low":      vuln.Low,
+			"high":     vuln.High,
+			"critical": vuln.Critical,
+		},
+	}
+	data := []*art.Candidate{
+		{Digest: "none"},
+		{Digest: "low"},
+		{Digest: "high"},
+		{Digest: "critical"},
+		{Digest: "unscanned"},
+	}
+
+	tests := []struct {
+		severity vuln.Severity
+		expected []string
+	}{
+		{severity: vuln.Critical, expected: []string{"none", "low", "high"}},
+		{severity: vuln.Medium, expected: []string{"none", "low"}},
+		{severity: vuln.Unknown, expected: []string{"none"}},
+	}
+
+	for _, tt := range tests {
+		e.T().Run(string(tt.severity), func(t *testing.T) {
+			sut := &evaluator{severity: tt.severity, mgr: mgr}
+
+			result, err := sut.Process(data)
+			require.NoError(t, err)
+
+			var digests []string
+			for _, v := range result {
+				digests = append(digests, v.Digest)
+			}
+			assert.Equal(t, tt.expected, digests)
+		})
+	}
+}
+
+func (e *EvaluatorTestSuite) TestValid() {
+	tests := []struct {
+		Name     string
+		args     rule.Parameters
+		expected error
+	}{
+		{Name: "Valid", args: map[string]rule.Parameter{ParameterSeverity: "Critical"}, expected: nil},
+		{Name: "Invalid", args: map[string]rule.Parameter{ParameterSeverity: "foo"}, expected: errors.New("severityLowerThan is not a valid severity")},
+		{Name: "Wrong Type", args: map[string]rule.Parameter{ParameterSeverity: 1}, expected: errors.New("severityLowerThan type error")},
+	}
+
+	for _, tt := range tests {
+		e.T().Run(tt.Name, func(t *testing.T) {
+			err := Valid(tt.args)
+
+			require.Equal(t, tt.expected, err)
+		})
+	}
+}
+
+func TestEvaluatorSuite(t *testing.T) {
+	suite.Run(t, &EvaluatorTestSuite{})
+}
@@ -0,0 +1,108 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package untagged
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/pkg/art"
+	"github.com/goharbor/harbor/src/pkg/retention/policy/rule"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type EvaluatorTestSuite struct {
+	suite.Suite
+}
+
+func (e *EvaluatorTestSuite) TestNew() {
+	tests := []struct {
+		Name      string
+		args      rule.Parameters
+		expectedN int
+	}{
+		{Name: "Valid", args: map[string]rule.Parameter{ParameterN: float64(5)}, expectedN: 5},
+		{Name: "Default If Negative", args: map[string]rule.Parameter{ParameterN: float64(-1)}, expectedN: DefaultN},
+		{Name: "Default If Not Set", args: map[string]rule.Parameter{}, expectedN: DefaultN},
+		{Name: "Default If Wrong Type", args: map[string]rule.Parameter{ParameterN: "foo"}, expectedN: DefaultN},
+	}
+
+	for _, tt := range tests {
+		e.T().Run(tt.Name, func(t *testing.T) {
+			e := New(tt.args).(*evaluator)
+
+			require.Equal(t, tt.expectedN, e.n)
+		})
+	}
+}
+
+func (e *EvaluatorTestSuite) TestProcess() {
+	now := time.Now().UTC()
+	data := []*art.Candidate{
+		{Digest: "d1", PushedTime: now.Add(-1 * time.Hour).Unix()},
+		{Digest: "d2", PushedTime: now.Add(-3 * 24 * time.Hour).Unix()},
+		{Digest: "d3", PushedTime: now.Add(-10 * 24 * time.Hour).Unix()},
+		{Digest: "d4", Tag: "latest", PushedTime: now.Add(-1 * time.Hour).Unix()},
+	}
+
+	sut := New(map[string]rule.Parameter{ParameterN: float64(5)})
+	result, err := sut.Process(data)
+	require.NoError(e.T(), err)
+	require.Len(e.T(), result, 2)
+	assert.Equal(e.T(), "d1", result[0].Digest)
+	assert.Equal(e.T(), "d2", result[1].Digest)
+
+	sut = New(map[string]rule.Parameter{ParameterN: float64(0)})
+	result, err = sut.Process(data)
+	require.NoError(e.T(), err)
+	assert.Len(e.T(), result, 0)
+}
+
+func (e *EvaluatorTestSuite) TestValid() {
+	tests := []struct {
+		Name      string
+		args      rule.Parameters
+		expectedK error
+	}{
+		{Name: "Valid", args: map[string]rule.Parameter{ParameterN: 5}, expectedK: nil},
+		{Name: "Negative", args: map[string]rule.Parameter{ParameterN: -1}, expectedK: errors.New("nDaysSinceUntaggedPush is less than zero")},
+		{Name: "Big", args: map[string]rule.Parameter{ParameterN: 21000000}, expectedK: errors.New("nDaysSinceUntaggedPush is too large")},
+	}
+
+	for _, tt := range tests {
+		e.T().Run(tt.Name, func(t *testing.T) {
+			err := Valid(tt.args)
+
+			require.Equal(t, tt.expectedK, err)
+		})
+	}
+}
+
+func (e *EvaluatorTestSuite) TestSelector() {
+	selected, err := NewSelector().Select([]*art.Candidate{
+		{Digest: "d1"},
+		{Digest: "d2", Tag: "latest"},
+	})
+	require.NoError(e.T(), err)
+	require.Len(e.T(), selected, 1)
+	assert.Equal(e.T(), "d1", selected[0].Digest)
+}
+
+func TestEvaluatorSuite(t *testing.T) {
+	suite.Run(t, &EvaluatorTestSuite{})
+}
@@ -23,6 +23,7 @@ type Query struct {
 // TaskQuery parameters
 type TaskQuery struct {
 	ExecutionID int64
+	Project     string
 	Status      string
 	PageNumber  int64
 	PageSize    int64
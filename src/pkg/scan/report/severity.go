@@ -0,0 +1,64 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"github.com/goharbor/harbor/src/jobservice/job"
+	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+	"github.com/pkg/errors"
+)
+
+// LatestSeverity gets the overall severity from the latest successful native report of the given digest.
+// The returned severity is empty if no vulnerabilities found, and the returned bool is false
+// if the artifact is not scanned successfully yet.
+func LatestSeverity(mgr Manager, digest string) (vuln.Severity, bool, error) {
+	if mgr == nil {
+		return "", false, errors.New("nil report manager")
+	}
+
+	reports, err := mgr.GetBy(digest, "", []string{v1.MimeTypeNativeReport})
+	if err != nil {
+		return "", false, errors.Wrap(err, "latest severity")
+	}
+
+	var latest *vuln.Report
+	var endTime int64
+	for _, r := range reports {
+		if r.Status != job.SuccessStatus.String() || len(r.Report) == 0 {
+			continue
+		}
+		if latest != nil && r.EndTime.Unix() < endTime {
+			continue
+		}
+
+		data, err := ResolveData(r.MimeType, []byte(r.Report))
+		if err != nil {
+			return "", false, errors.Wrap(err, "latest severity")
+		}
+		rp, ok := data.(*vuln.Report)
+		if !ok {
+			continue
+		}
+		latest = rp
+		endTime = r.EndTime.Unix()
+	}
+
+	if latest == nil {
+		return "", false, nil
+	}
+
+	return latest.Severity, true, nil
+}
@@ -0,0 +1,74 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goharbor/harbor/src/jobservice/job"
+	"github.com/goharbor/harbor/src/pkg/scan/dao/scan"
+	v1 "github.com/goharbor/harbor/src/pkg/scan/rest/v1"
+	"github.com/goharbor/harbor/src/pkg/scan/vuln"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeManager struct {
+	Manager
+	reports []*scan.Report
+}
+
+func (f *fakeManager) GetBy(digest string, registrationUUID string, mimeTypes []string) ([]*scan.Report, error) {
+	return f.reports, nil
+}
+
+// TestLatestSeverity tests the latest severity getter
+func TestLatestSeverity(t *testing.T) {
+	now := time.Now()
+	mgr := &fakeManager{}
+
+	// not scanned
+	_, scanned, err := LatestSeverity(mgr, "sha256:abc")
+	require.NoError(t, err)
+	assert.False(t, scanned)
+
+	mgr.reports = []*scan.Report{
+		{
+			MimeType: v1.MimeTypeNativeReport,
+			Status:   job.SuccessStatus.String(),
+			Report:   `{"severity":"High"}`,
+			EndTime:  now.Add(-time.Hour),
+		},
+		{
+			MimeType: v1.MimeTypeNativeReport,
+			Status:   job.SuccessStatus.String(),
+			Report:   `{"severity":"Low"}`,
+			EndTime:  now,
+		},
+		{
+			MimeType: v1.MimeTypeNativeReport,
+			Status:   job.ErrorStatus.String(),
+			EndTime:  now.Add(time.Hour),
+		},
+	}
+	sev, scanned, err := LatestSeverity(mgr, "sha256:abc")
+	require.NoError(t, err)
+	assert.True(t, scanned)
+	assert.Equal(t, vuln.Low, sev)
+
+	_, _, err = LatestSeverity(nil, "sha256:abc")
+	assert.Error(t, err)
+}
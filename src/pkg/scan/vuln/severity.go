@@ -14,6 +14,8 @@
 
 package vuln
 
+import "strings"
+
 const (
 	// Unknown - either a security problem that has not been assigned to a priority yet or
 	// a priority that the scanner did not recognize.
@@ -37,3 +39,35 @@ const (
 
 // Severity is a standard scale for measuring the severity of a vulnerability.
 type Severity string
+
+// Code returns the int code of the severity for comparing, the more severe the larger.
+// 0 is returned for the empty or unrecognized severity.
+func (s Severity) Code() int {
+	switch s {
+	case Unknown:
+		return 1
+	case Negligible:
+		return 2
+	case Low:
+		return 3
+	case Medium:
+		return 4
+	case High:
+		return 5
+	case Critical:
+		return 6
+	default:
+		return 0
+	}
+}
+
+// ParseSeverity parses the severity from the string ignoring the case
+func ParseSeverity(s string) (Severity, bool) {
+	for _, sev := range []Severity{Unknown, Negligible, Low, Medium, High, Critical} {
+		if strings.EqualFold(s, string(sev)) {
+			return sev, true
+		}
+	}
+
+	return "", false
+}
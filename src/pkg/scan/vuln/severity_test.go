@@ -0,0 +1,41 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vuln
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSeverityCode tests the ordering of severities
+func TestSeverityCode(t *testing.T) {
+	assert.True(t, Severity("").Code() < Unknown.Code())
+	assert.True(t, Unknown.Code() < Negligible.Code())
+	assert.True(t, Negligible.Code() < Low.Code())
+	assert.True(t, Low.Code() < Medium.Code())
+	assert.True(t, Medium.Code() < High.Code())
+	assert.True(t, High.Code() < Critical.Code())
+}
+
+// TestParseSeverity tests parsing severity from string
+func TestParseSeverity(t *testing.T) {
+	sev, ok := ParseSeverity("critical")
+	assert.True(t, ok)
+	assert.Equal(t, Critical, sev)
+
+	_, ok = ParseSeverity("fatal")
+	assert.False(t, ok)
+}
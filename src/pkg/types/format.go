@@ -20,7 +20,8 @@ import (
 
 var (
 	resourceValueFormats = map[ResourceName]func(int64) string{
-		ResourceStorage: byteCountToDisplaySize,
+		ResourceStorage:      byteCountToDisplaySize,
+		ResourceChartStorage: byteCountToDisplaySize,
 	}
 )
 
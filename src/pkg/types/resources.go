@@ -27,6 +27,10 @@ const (
 	ResourceCount ResourceName = "count"
 	// ResourceStorage storage size, in bytes
 	ResourceStorage ResourceName = "storage"
+	// ResourceRepositoryCount repository count, in number
+	ResourceRepositoryCount ResourceName = "repository_count"
+	// ResourceChartStorage storage size of chart packages, in bytes
+	ResourceChartStorage ResourceName = "chart_storage"
 )
 
 // ResourceName is the name identifying various resources in a ResourceList.
@@ -30,12 +30,18 @@ export class TagRetentionService {
         "always": "RULE_NAME_5",
         "nDaysSinceLastPull": "RULE_NAME_6",
         "nDaysSinceLastPush": "RULE_NAME_7",
+        "severityLowerThan": "RULE_NAME_8",
+        "scannedOrNDaysSinceLastPush": "RULE_NAME_9",
+        "sizeNotLargerThanNMB": "RULE_NAME_10",
         "the images from the last # days": "RULE_TEMPLATE_1",
         "the most recent active # images": "RULE_TEMPLATE_2",
         "the most recently pushed # images": "RULE_TEMPLATE_3",
         "the most recently pulled # images": "RULE_TEMPLATE_4",
         "pulled within the last # days": "RULE_TEMPLATE_6",
         "pushed within the last # days": "RULE_TEMPLATE_7",
+        "scanned with vulnerability severity lower than #": "RULE_TEMPLATE_8",
+        "scanned or pushed within the last # days": "RULE_TEMPLATE_9",
+        "not larger than # MB": "RULE_TEMPLATE_10",
         "repoMatches": "MAT",
         "repoExcludes": "EXC",
         "matches": "MAT",
@@ -44,11 +50,14 @@ export class TagRetentionService {
         "withoutLabels": "WITHOUT",
         "COUNT": "UNIT_COUNT",
         "DAYS": "UNIT_DAY",
+        "MB": "UNIT_MB",
         "none": "NONE",
         "nothing": "NONE",
         "Parameters nDaysSinceLastPull is too large": "DAYS_LARGE",
         "Parameters nDaysSinceLastPush is too large": "DAYS_LARGE",
         "Parameters latestPushedK is too large": "COUNT_LARGE",
+        "Parameters scannedOrNDaysSinceLastPush is too large": "DAYS_LARGE",
+        "Parameters sizeNotLargerThanNMB is too large": "SIZE_LARGE",
         "Parameters latestPulledN is too large": "COUNT_LARGE"
     };
 
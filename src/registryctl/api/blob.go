@@ -0,0 +1,101 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"sync"
+
+	"github.com/docker/distribution/configuration"
+	storagedriver "github.com/docker/distribution/registry/storage/driver"
+	"github.com/docker/distribution/registry/storage/driver/factory"
+	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/gorilla/mux"
+	"github.com/opencontainers/go-digest"
+
+	// the storage drivers which support deleting blobs from registryctl
+	_ "github.com/docker/distribution/registry/storage/driver/filesystem"
+	_ "github.com/docker/distribution/registry/storage/driver/s3-aws"
+)
+
+const (
+	// the root path of the registry storage, see the path spec of docker distribution
+	storagePathRoot = "/docker/registry/v2"
+)
+
+var (
+	driver     storagedriver.StorageDriver
+	driverErr  error
+	driverOnce sync.Once
+)
+
+// storageDriver returns the storage driver created from the storage section of the registry config
+func storageDriver() (storagedriver.StorageDriver, error) {
+	driverOnce.Do(func() {
+		fp, err := os.Open(regConf)
+		if err != nil {
+			driverErr = err
+			return
+		}
+		defer fp.Close()
+
+		cfg, err := configuration.Parse(fp)
+		if err != nil {
+			driverErr = fmt.Errorf("failed to parse registry config %s: %v", regConf, err)
+			return
+		}
+
+		driver, driverErr = factory.Create(cfg.Storage.Type(), cfg.Storage.Parameters())
+	})
+
+	return driver, driverErr
+}
+
+// blobDataPath returns the path of the directory which contains the data of the blob
+func blobDataPath(dgst digest.Digest) string {
+	hex := dgst.Hex()
+	return path.Join(storagePathRoot, "blobs", dgst.Algorithm().String(), hex[:2], hex)
+}
+
+// DeleteBlob deletes the data of the blob from the storage of registry,
+// the registry cache of the blob should be cleaned by the caller
+func DeleteBlob(w http.ResponseWriter, r *http.Request) {
+	dgst, err := digest.Parse(mux.Vars(r)["reference"])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid digest: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	d, err := storageDriver()
+	if err != nil {
+		log.Errorf("failed to get the storage driver of registry: %v", err)
+		http.Error(w, fmt.Sprintf("storage driver not supported to delete blobs: %v", err), http.StatusNotImplemented)
+		return
+	}
+
+	if err := d.Delete(r.Context(), blobDataPath(dgst)); err != nil {
+		if _, ok := err.(storagedriver.PathNotFoundError); !ok {
+			log.Errorf("failed to delete blob %s: %v", dgst, err)
+			handleInternalServerError(w)
+			return
+		}
+		log.Debugf("blob %s not found in storage, skip it", dgst)
+	}
+
+	log.Debugf("blob %s deleted", dgst)
+}
@@ -0,0 +1,69 @@
+// Copyright Project Harbor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/docker/distribution/registry/storage/driver/filesystem"
+	"github.com/gorilla/mux"
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobDataPath(t *testing.T) {
+	assert.Equal(t, "/docker/registry/v2/blobs/sha256/9e/9e14cd7a6b2d4f1e0a2e1e8fdc79b51f5d0f0f0b3e3a4b8d9b7b0a4a9b1c2d3e",
+		blobDataPath("sha256:9e14cd7a6b2d4f1e0a2e1e8fdc79b51f5d0f0f0b3e3a4b8d9b7b0a4a9b1c2d3e"))
+}
+
+func TestDeleteBlob(t *testing.T) {
+	root, err := ioutil.TempDir("", "registryctl")
+	require.Nil(t, err)
+	defer os.RemoveAll(root)
+
+	// use the filesystem driver rooted at the temp dir instead of the one from the registry config
+	driverOnce.Do(func() {})
+	driver = filesystem.New(filesystem.DriverParameters{RootDirectory: root, MaxThreads: 100})
+
+	dgst := "sha256:9e14cd7a6b2d4f1e0a2e1e8fdc79b51f5d0f0f0b3e3a4b8d9b7b0a4a9b1c2d3e"
+	p := blobDataPath(digest.Digest(dgst)) + "/data"
+	require.Nil(t, driver.PutContent(context.Background(), p, []byte("blob")))
+
+	cases := []struct {
+		reference string
+		code      int
+	}{
+		{"invalid", http.StatusBadRequest},
+		{dgst, http.StatusOK},
+		// deleting the blob which not exists is ok
+		{dgst, http.StatusOK},
+	}
+	for _, c := range cases {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "", nil)
+		req = mux.SetURLVars(req, map[string]string{"reference": c.reference})
+		DeleteBlob(w, req)
+		assert.Equal(t, c.code, w.Code)
+	}
+
+	_, err = driver.Stat(context.Background(), p)
+	assert.NotNil(t, err)
+}
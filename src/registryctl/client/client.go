@@ -34,6 +34,8 @@ type Client interface {
 	Health() error
 	// StartGC enable the gc of registry server
 	StartGC() (*api.GCResult, error)
+	// DeleteBlob deletes the specified blob from the storage of registry server
+	DeleteBlob(reference string) error
 }
 
 type client struct {
@@ -100,3 +102,25 @@ func (c *client) StartGC() (*api.GCResult, error) {
 
 	return gcr, nil
 }
+
+// DeleteBlob ...
+func (c *client) DeleteBlob(reference string) error {
+	url := c.baseURL + "/api/registry/blob/" + reference
+
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		data, _ := ioutil.ReadAll(resp.Body)
+		log.Errorf("Failed to delete blob %s: %d %s", reference, resp.StatusCode, string(data))
+		return fmt.Errorf("Failed to delete blob %s: %d", reference, resp.StatusCode)
+	}
+
+	return nil
+}
@@ -49,3 +49,8 @@ func TesStartGC(t *testing.T) {
 	assert.Equal(t, gcr.Msg, "hello-world")
 	assert.Equal(t, gcr.Status, true)
 }
+
+func TestDeleteBlob(t *testing.T) {
+	err := c.DeleteBlob("sha256:9e14cd7a6b2d4f1e0a2e1e8fdc79b51f5d0f0f0b3e3a4b8d9b7b0a4a9b1c2d3e")
+	assert.Nil(t, err)
+}
@@ -19,6 +19,7 @@ import (
 	"os"
 
 	"github.com/goharbor/harbor/src/common/utils/log"
+	"github.com/goharbor/harbor/src/pkg/metrics"
 	"github.com/goharbor/harbor/src/registryctl/auth"
 	gorilla_handlers "github.com/gorilla/handlers"
 )
@@ -32,6 +33,7 @@ func NewHandlerChain() http.Handler {
 	}
 	insecureAPIs := map[string]bool{
 		"/api/health": true,
+		metrics.Path:  true,
 	}
 	h = newAuthHandler(auth.NewSecretHandler(secrets), h, insecureAPIs)
 	h = gorilla_handlers.LoggingHandler(os.Stdout, h)
@@ -66,4 +66,9 @@ func TestNewAuthHandler(t *testing.T) {
 	r := httptest.NewRequest("GET", "http://localhost/api/health", nil)
 	handler.ServeHTTP(w, r)
 
+	// no auth is required by the metrics
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest("GET", "http://localhost/metrics", nil)
+	handler.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
 }
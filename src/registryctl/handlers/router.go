@@ -17,13 +17,19 @@ package handlers
 import (
 	"net/http"
 
+	"github.com/goharbor/harbor/src/pkg/metrics"
 	"github.com/goharbor/harbor/src/registryctl/api"
 	"github.com/gorilla/mux"
 )
 
+var httpMetrics = metrics.NewHTTPMetrics(metrics.SubsystemRegistryCtl)
+
 func newRouter() http.Handler {
 	r := mux.NewRouter()
 	r.HandleFunc("/api/registry/gc", api.StartGC).Methods("POST")
+	r.HandleFunc("/api/registry/blob/{reference}", api.DeleteBlob).Methods("DELETE")
 	r.HandleFunc("/api/health", api.Health).Methods("GET")
+	r.Handle(metrics.Path, metrics.Handler()).Methods("GET")
+	r.Use(httpMetrics.Middleware)
 	return r
 }
@@ -69,6 +69,7 @@ func (s *RegistryCtl) Start() {
 }
 
 func main() {
+	log.SetComponent("registryctl")
 
 	configPath := flag.String("c", "", "Specify the yaml config file path")
 	flag.Parse()
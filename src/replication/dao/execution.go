@@ -224,6 +224,31 @@ func UpdateExecution(execution *models.Execution, props ...string) (int64, error
 	return o.Update(execution, props...)
 }
 
+// FinishExecution persists the final status and the statistics of the execution,
+// the execution is updated only when it is still in progress in database, so only
+// one of the concurrent callers gets true
+func FinishExecution(execution *models.Execution) (bool, error) {
+	if execution.ID == 0 {
+		return false, fmt.Errorf("execution ID is empty")
+	}
+	if !executionFinished(execution.Status) {
+		return false, fmt.Errorf("the status %s of execution %d isn't final", execution.Status, execution.ID)
+	}
+	// use raw sql rather than the ORM as the sql generated by ORM isn't a "single" statement
+	sql := `update replication_execution set status = ?, total = ?, failed = ?, succeed = ?,
+		in_progress = ?, stopped = ?, end_time = ? where id = ? and status = ?`
+	r, err := dao.GetOrmer().Raw(sql, execution.Status, execution.Total, execution.Failed, execution.Succeed,
+		execution.InProgress, execution.Stopped, execution.EndTime, execution.ID, models.ExecutionStatusInProgress).Exec()
+	if err != nil {
+		return false, err
+	}
+	n, err := r.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
 // AddTask ...
 func AddTask(task *models.Task) (int64, error) {
 	o := dao.GetOrmer()
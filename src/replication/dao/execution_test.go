@@ -292,3 +292,40 @@ func TestExecutionFill2(t *testing.T) {
 	assert.Equal(t, 1, exes[0].Failed)
 	assert.Equal(t, 0, exes[0].Succeed)
 }
+
+func TestFinishExecution(t *testing.T) {
+	executionID, err := AddExecution(&models.Execution{
+		PolicyID:  11210,
+		Status:    models.ExecutionStatusInProgress,
+		Total:     1,
+		Trigger:   "Manual",
+		StartTime: time.Now(),
+	})
+	require.Nil(t, err)
+	defer DeleteAllExecutions(11210)
+
+	execution := &models.Execution{
+		ID:      executionID,
+		Status:  models.ExecutionStatusSucceed,
+		Total:   1,
+		Succeed: 1,
+		EndTime: time.Now(),
+	}
+	finished, err := FinishExecution(execution)
+	require.Nil(t, err)
+	assert.True(t, finished)
+
+	// the execution has been finished by the previous call
+	finished, err = FinishExecution(execution)
+	require.Nil(t, err)
+	assert.False(t, finished)
+
+	exe, err := GetExecution(executionID)
+	require.Nil(t, err)
+	assert.Equal(t, models.ExecutionStatusSucceed, exe.Status)
+	assert.Equal(t, 1, exe.Succeed)
+
+	// the status isn't final
+	_, err = FinishExecution(&models.Execution{ID: executionID, Status: models.ExecutionStatusInProgress})
+	assert.NotNil(t, err)
+}
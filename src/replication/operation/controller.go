@@ -35,8 +35,13 @@ type Controller interface {
 	// trigger is used to specify what this replication is triggered by
 	StartReplication(policy *model.Policy, resource *model.Resource, trigger model.TriggerType) (int64, error)
 	StopReplication(int64) error
+	// RetryReplication retries the failed and stopped tasks of the execution
+	RetryReplication(int64) error
 	ListExecutions(...*models.ExecutionQuery) (int64, []*models.Execution, error)
 	GetExecution(int64) (*models.Execution, error)
+	// FinishExecution persists the final status of the execution calculated from its tasks,
+	// returns true only for the caller that moves the execution out of "InProgress"
+	FinishExecution(int64) (bool, error)
 	ListTasks(...*models.TaskQuery) (int64, []*models.Task, error)
 	GetTask(int64) (*models.Task, error)
 	UpdateTaskStatus(id int64, status string, statusRevision int64, statusCondition ...string) error
@@ -200,6 +205,61 @@ func (c *controller) StopReplication(executionID int64) error {
 	return nil
 }
 
+func (c *controller) RetryReplication(executionID int64) error {
+	execution, err := c.executionMgr.Get(executionID)
+	if err != nil {
+		return err
+	}
+	if execution == nil {
+		return fmt.Errorf("the execution %d not found", executionID)
+	}
+	_, tasks, err := c.ListTasks(&models.TaskQuery{
+		ExecutionID: executionID,
+	})
+	if err != nil {
+		return err
+	}
+	retried := 0
+	for _, task := range tasks {
+		if task.Status != models.TaskStatusFailed && task.Status != models.TaskStatusStopped {
+			continue
+		}
+		// set the status to pending before sending the retry request, the status changes of the retried
+		// job carry a new revision so they can override the status set here
+		if err = c.executionMgr.UpdateTask(&models.Task{
+			ID:     task.ID,
+			Status: models.TaskStatusPending,
+		}, models.TaskPropsName.Status); err != nil {
+			return err
+		}
+		if err = c.scheduler.Retry(task.JobID); err != nil {
+			log.Errorf("failed to retry the task %d(job ID: %s): %v", task.ID, task.JobID, err)
+			if e := c.executionMgr.UpdateTask(&models.Task{
+				ID:     task.ID,
+				Status: task.Status,
+			}, models.TaskPropsName.Status); e != nil {
+				log.Errorf("failed to restore the status of the task %d(job ID: %s): %v", task.ID, task.JobID, e)
+			}
+			continue
+		}
+		retried++
+		log.Debugf("the retry request for task %d(job ID: %s) sent", task.ID, task.JobID)
+	}
+	if retried == 0 {
+		return fmt.Errorf("no failed or stopped task of the execution %d is retried", executionID)
+	}
+	// the status of execution is calculated by its tasks when it isn't in final status
+	if execution.Status != models.ExecutionStatusInProgress {
+		if err = c.executionMgr.Update(&models.Execution{
+			ID:     executionID,
+			Status: models.ExecutionStatusInProgress,
+		}, models.ExecutionPropsName.Status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func isTaskInFinalStatus(task *models.Task) bool {
 	if task == nil {
 		return false
@@ -226,6 +286,20 @@ func (c *controller) ListExecutions(query ...*models.ExecutionQuery) (int64, []*
 func (c *controller) GetExecution(executionID int64) (*models.Execution, error) {
 	return c.executionMgr.Get(executionID)
 }
+func (c *controller) FinishExecution(executionID int64) (bool, error) {
+	execution, err := c.executionMgr.Get(executionID)
+	if err != nil {
+		return false, err
+	}
+	if execution == nil {
+		return false, fmt.Errorf("the execution %d not found", executionID)
+	}
+	// the status is calculated from the tasks when the execution is in progress
+	if execution.Status == models.ExecutionStatusInProgress {
+		return false, nil
+	}
+	return c.executionMgr.Finish(execution)
+}
 func (c *controller) ListTasks(query ...*models.TaskQuery) (int64, []*models.Task, error) {
 	return c.executionMgr.ListTasks(query...)
 }
@@ -50,6 +50,9 @@ func (f *fakedExecutionManager) Get(int64) (*models.Execution, error) {
 func (f *fakedExecutionManager) Update(*models.Execution, ...string) error {
 	return nil
 }
+func (f *fakedExecutionManager) Finish(*models.Execution) (bool, error) {
+	return true, nil
+}
 func (f *fakedExecutionManager) Remove(int64) error {
 	return nil
 }
@@ -112,6 +115,9 @@ func (f *fakedScheduler) Schedule(items []*scheduler.ScheduleItem) ([]*scheduler
 func (f *fakedScheduler) Stop(id string) error {
 	return nil
 }
+func (f *fakedScheduler) Retry(id string) error {
+	return nil
+}
 
 func fakedAdapterFactory(*model.Registry) (adapter.Adapter, error) {
 	return &fakedAdapter{}, nil
@@ -305,6 +311,12 @@ func TestStopReplication(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestRetryReplication(t *testing.T) {
+	// the task isn't failed or stopped
+	err := ctl.RetryReplication(1)
+	require.NotNil(t, err)
+}
+
 func TestListExecutions(t *testing.T) {
 	n, executions, err := ctl.ListExecutions()
 	require.Nil(t, err)
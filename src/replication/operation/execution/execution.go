@@ -33,6 +33,9 @@ type Manager interface {
 	// Update the data of the specified execution, the "props" are the
 	// properties of execution that need to be updated
 	Update(execution *models.Execution, props ...string) error
+	// Finish persists the final status of the execution which is still in progress,
+	// returns false if the execution has been finished by others
+	Finish(execution *models.Execution) (bool, error)
 	// Remove the execution specified by the ID
 	Remove(int64) error
 	// Remove all executions of one policy specified by the policy ID
@@ -104,6 +107,11 @@ func (dm *DefaultManager) Update(execution *models.Execution, props ...string) e
 	return nil
 }
 
+// Finish ...
+func (dm *DefaultManager) Finish(execution *models.Execution) (bool, error) {
+	return dao.FinishExecution(execution)
+}
+
 // Remove the execution specified by the ID
 func (dm *DefaultManager) Remove(id int64) error {
 	return dao.DeleteExecution(id)
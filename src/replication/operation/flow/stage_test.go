@@ -139,6 +139,9 @@ func (f *fakedScheduler) Schedule(items []*scheduler.ScheduleItem) ([]*scheduler
 func (f *fakedScheduler) Stop(id string) error {
 	return nil
 }
+func (f *fakedScheduler) Retry(id string) error {
+	return nil
+}
 
 type fakedExecutionManager struct {
 	taskID int64
@@ -156,6 +159,9 @@ func (f *fakedExecutionManager) Get(int64) (*models.Execution, error) {
 func (f *fakedExecutionManager) Update(*models.Execution, ...string) error {
 	return nil
 }
+func (f *fakedExecutionManager) Finish(*models.Execution) (bool, error) {
+	return true, nil
+}
 func (f *fakedExecutionManager) Remove(int64) error {
 	return nil
 }
@@ -34,12 +34,18 @@ func (f *fakedOperationController) StartReplication(*model.Policy, *model.Resour
 func (f *fakedOperationController) StopReplication(int64) error {
 	return nil
 }
+func (f *fakedOperationController) RetryReplication(int64) error {
+	return nil
+}
 func (f *fakedOperationController) ListExecutions(...*models.ExecutionQuery) (int64, []*models.Execution, error) {
 	return 0, nil, nil
 }
 func (f *fakedOperationController) GetExecution(int64) (*models.Execution, error) {
 	return nil, nil
 }
+func (f *fakedOperationController) FinishExecution(int64) (bool, error) {
+	return true, nil
+}
 func (f *fakedOperationController) ListTasks(...*models.TaskQuery) (int64, []*models.Task, error) {
 	return 0, nil, nil
 }
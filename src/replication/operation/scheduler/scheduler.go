@@ -61,6 +61,8 @@ type Scheduler interface {
 	Schedule([]*ScheduleItem) ([]*ScheduleResult, error)
 	// Stop the job specified by ID
 	Stop(id string) error
+	// Retry the failed or stopped job specified by ID
+	Retry(id string) error
 }
 
 // Preprocess the resources and returns the item list that can be scheduled
@@ -139,3 +141,8 @@ func (d *defaultScheduler) Stop(id string) error {
 	return nil
 
 }
+
+// Retry the transfer job
+func (d *defaultScheduler) Retry(id string) error {
+	return d.client.PostAction(id, string(job.RetryCommand))
+}
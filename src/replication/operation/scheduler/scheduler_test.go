@@ -28,6 +28,15 @@ func (client TestClient) PostAction(uuid, action string) error {
 func (client TestClient) GetExecutions(uuid string) ([]job.Stats, error) {
 	return nil, nil
 }
+func (client TestClient) GetQueues() ([]*job.QueueStats, error) {
+	return nil, nil
+}
+func (client TestClient) GetRunningJobs() ([]*job.RunningJob, error) {
+	return nil, nil
+}
+func (client TestClient) GetLatency(window int64) ([]*job.LatencyStats, error) {
+	return nil, nil
+}
 
 func TestPreprocess(t *testing.T) {
 	items, err := generateData()
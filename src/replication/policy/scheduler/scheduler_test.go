@@ -56,6 +56,15 @@ func (f *fakedJobserviceClient) GetExecutions(uuid string) ([]job.Stats, error)
 	f.stopped = true
 	return nil, nil
 }
+func (f *fakedJobserviceClient) GetQueues() ([]*job.QueueStats, error) {
+	return nil, nil
+}
+func (f *fakedJobserviceClient) GetRunningJobs() ([]*job.RunningJob, error) {
+	return nil, nil
+}
+func (f *fakedJobserviceClient) GetLatency(window int64) ([]*job.LatencyStats, error) {
+	return nil, nil
+}
 
 type fakedScheduleJobDAO struct {
 	idCounter int64
@@ -21,11 +21,13 @@ import (
 	"testing"
 
 	"github.com/docker/distribution"
+	"github.com/docker/distribution/manifest/manifestlist"
 	"github.com/docker/distribution/manifest/schema2"
 	"github.com/goharbor/harbor/src/common/utils/log"
 	pkg_registry "github.com/goharbor/harbor/src/common/utils/registry"
 	"github.com/goharbor/harbor/src/replication/model"
 	trans "github.com/goharbor/harbor/src/replication/transfer"
+	"github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -94,6 +96,116 @@ func (f *fakeRegistry) PushBlob(repository, digest string, size int64, blob io.R
 	return nil
 }
 
+type fakeManifestListRegistry struct {
+	fakeRegistry
+	pushed []string
+}
+
+func (f *fakeManifestListRegistry) PullManifest(repository, reference string, accepttedMediaTypes []string) (distribution.Manifest, string, error) {
+	if reference != "list" {
+		return f.fakeRegistry.PullManifest(repository, reference, accepttedMediaTypes)
+	}
+	manifest := `{
+		"schemaVersion": 2,
+		"mediaType": "application/vnd.docker.distribution.manifest.list.v2+json",
+		"manifests": [
+			{
+				"mediaType": "application/vnd.docker.distribution.manifest.v2+json",
+				"size": 7143,
+				"digest": "sha256:c6b2b2c507a0944348e0303114d8d93aaaa081732b86451d9bce1f432a537bc7",
+				"platform": {
+					"architecture": "amd64",
+					"os": "linux"
+				}
+			},
+			{
+				"mediaType": "application/vnd.docker.distribution.manifest.v2+json",
+				"size": 7682,
+				"digest": "sha256:5b0bcabd1ed22e9fb1310cf6c2dec7cdef19f0ad69efa1f392e94a4333501270",
+				"platform": {
+					"architecture": "arm64",
+					"os": "linux",
+					"variant": "v8"
+				}
+			}
+		]
+	}`
+	mani, _, err := pkg_registry.UnMarshal(manifestlist.MediaTypeManifestList, []byte(manifest))
+	if err != nil {
+		return nil, "", err
+	}
+	return mani, "sha256:e1c4b2b2c507a0944348e0303114d8d93aaaa081732b86451d9bce1f432a537bc7", nil
+}
+
+func (f *fakeManifestListRegistry) PushManifest(repository, reference, mediaType string, payload []byte) error {
+	f.pushed = append(f.pushed, mediaType)
+	return nil
+}
+
+type fakeOCIIndexRegistry struct {
+	fakeRegistry
+	accepted []string
+	pushed   []string
+	blobs    []string
+}
+
+func (f *fakeOCIIndexRegistry) PullManifest(repository, reference string, accepttedMediaTypes []string) (distribution.Manifest, string, error) {
+	f.accepted = accepttedMediaTypes
+	if reference == "index" {
+		manifest := `{
+			"schemaVersion": 2,
+			"mediaType": "application/vnd.oci.image.index.v1+json",
+			"manifests": [
+				{
+					"mediaType": "application/vnd.oci.image.manifest.v1+json",
+					"size": 7143,
+					"digest": "sha256:c6b2b2c507a0944348e0303114d8d93aaaa081732b86451d9bce1f432a537bc7",
+					"platform": {
+						"architecture": "amd64",
+						"os": "linux"
+					}
+				}
+			]
+		}`
+		mani, _, err := pkg_registry.UnMarshal(v1.MediaTypeImageIndex, []byte(manifest))
+		if err != nil {
+			return nil, "", err
+		}
+		return mani, "sha256:e1c4b2b2c507a0944348e0303114d8d93aaaa081732b86451d9bce1f432a537bc7", nil
+	}
+	manifest := `{
+		"schemaVersion": 2,
+		"mediaType": "application/vnd.oci.image.manifest.v1+json",
+		"config": {
+			"mediaType": "application/vnd.oci.image.config.v1+json",
+			"size": 7023,
+			"digest": "sha256:b5b2b2c507a0944348e0303114d8d93aaaa081732b86451d9bce1f432a537bc7"
+		},
+		"layers": [
+			{
+				"mediaType": "application/vnd.oci.image.layer.v1.tar+gzip",
+				"size": 32654,
+				"digest": "sha256:e692418e4cbaf90ca69d05a66403747baa33ee08806650b51fab815ad7fc331f"
+			}
+		]
+	}`
+	mani, _, err := pkg_registry.UnMarshal(v1.MediaTypeImageManifest, []byte(manifest))
+	if err != nil {
+		return nil, "", err
+	}
+	return mani, "sha256:c6b2b2c507a0944348e0303114d8d93aaaa081732b86451d9bce1f432a537bc7", nil
+}
+
+func (f *fakeOCIIndexRegistry) PushManifest(repository, reference, mediaType string, payload []byte) error {
+	f.pushed = append(f.pushed, mediaType)
+	return nil
+}
+
+func (f *fakeOCIIndexRegistry) PushBlob(repository, digest string, size int64, blob io.Reader) error {
+	f.blobs = append(f.blobs, digest)
+	return nil
+}
+
 func TestFactory(t *testing.T) {
 	tr, err := factory(nil, nil)
 	require.Nil(t, err)
@@ -140,6 +252,49 @@ func TestCopy(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestCopyManifestList(t *testing.T) {
+	stopFunc := func() bool { return false }
+	dst := &fakeManifestListRegistry{}
+	tr := &transfer{
+		logger:    log.DefaultLogger(),
+		isStopped: stopFunc,
+		src:       &fakeManifestListRegistry{},
+		dst:       dst,
+	}
+
+	err := tr.copyImage("source", "list", "destination", "list", true)
+	require.Nil(t, err)
+	// the referenced manifests are pushed before the manifest list
+	require.Len(t, dst.pushed, 3)
+	assert.Equal(t, schema2.MediaTypeManifest, dst.pushed[0])
+	assert.Equal(t, schema2.MediaTypeManifest, dst.pushed[1])
+	assert.Equal(t, manifestlist.MediaTypeManifestList, dst.pushed[2])
+}
+
+func TestCopyOCIIndex(t *testing.T) {
+	stopFunc := func() bool { return false }
+	src := &fakeOCIIndexRegistry{}
+	dst := &fakeOCIIndexRegistry{}
+	tr := &transfer{
+		logger:    log.DefaultLogger(),
+		isStopped: stopFunc,
+		src:       src,
+		dst:       dst,
+	}
+
+	err := tr.copyImage("source", "index", "destination", "index", true)
+	require.Nil(t, err)
+	assert.Contains(t, src.accepted, v1.MediaTypeImageManifest)
+	// the OCI image manifest is copied as a manifest rather than a blob
+	require.Len(t, dst.pushed, 2)
+	assert.Equal(t, v1.MediaTypeImageManifest, dst.pushed[0])
+	assert.Equal(t, v1.MediaTypeImageIndex, dst.pushed[1])
+	assert.Equal(t, []string{
+		"sha256:b5b2b2c507a0944348e0303114d8d93aaaa081732b86451d9bce1f432a537bc7",
+		"sha256:e692418e4cbaf90ca69d05a66403747baa33ee08806650b51fab815ad7fc331f",
+	}, dst.blobs)
+}
+
 func TestDelete(t *testing.T) {
 	stopFunc := func() bool { return false }
 	tr := &transfer{
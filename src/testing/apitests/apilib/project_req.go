@@ -31,4 +31,8 @@ type ProjectReq struct {
 	CountLimit *int64 `json:"count_limit,omitempty"`
 	// The storage quota of the project
 	StorageLimit *int64 `json:"storage_limit,omitempty"`
+	// The repository count quota of the project
+	RepositoryCountLimit *int64 `json:"repository_count_limit,omitempty"`
+	// The chart storage quota of the project
+	ChartStorageLimit *int64 `json:"chart_storage_limit,omitempty"`
 }
@@ -7,6 +7,7 @@ package testing
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"strings"
 
 	hlog "github.com/goharbor/harbor/src/common/utils/log"
@@ -46,6 +47,10 @@ var MockChartRepoHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.
 			w.Write(HelmChartContent)
 			return
 		}
+		if r.Method == http.MethodHead {
+			w.Header().Set("Content-Length", strconv.Itoa(len(HelmChartContent)))
+			return
+		}
 	case "/api/repo1/charts", "/api/library/charts":
 		if r.Method == http.MethodGet {
 			w.Write(ChartListContent)
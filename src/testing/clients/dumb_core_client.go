@@ -38,6 +38,16 @@ func (d *DumbCoreClient) DeleteImageRepository(project, repository string) error
 	return nil
 }
 
+// DeleteManifest ...
+func (d *DumbCoreClient) DeleteManifest(project, repository, digest string) error {
+	return nil
+}
+
+// ListUntaggedManifests ...
+func (d *DumbCoreClient) ListUntaggedManifests(project, repository string) ([]*models.Blob, error) {
+	return nil, nil
+}
+
 // ListAllCharts ...
 func (d *DumbCoreClient) ListAllCharts(project, repository string) ([]*chartserver.ChartVersion, error) {
 	return nil, nil
@@ -48,6 +48,21 @@ func (mjc *MockJobClient) GetExecutions(uuid string) ([]job.Stats, error) {
 	return nil, nil
 }
 
+// GetQueues ...
+func (mjc *MockJobClient) GetQueues() ([]*job.QueueStats, error) {
+	return nil, nil
+}
+
+// GetRunningJobs ...
+func (mjc *MockJobClient) GetRunningJobs() ([]*job.RunningJob, error) {
+	return nil, nil
+}
+
+// GetLatency ...
+func (mjc *MockJobClient) GetLatency(window int64) ([]*job.LatencyStats, error) {
+	return nil, nil
+}
+
 func (mjc *MockJobClient) validUUID(uuid string) bool {
 	for _, u := range mjc.JobUUID {
 		if uuid == u {